@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"billing-engine/accrual"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AccrualHandler struct {
+	accrualService accrual.AccrualServiceInterface
+	middleware     middlewares.GoMiddlewareInterface
+}
+
+// NewAccrualHandler creates a new interest accrual handler instance
+func NewAccrualHandler(e *echo.Echo, accrualService accrual.AccrualServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &AccrualHandler{
+		accrualService: accrualService,
+		middleware:     middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/accruals/run", handler.AccrueInterest)
+	v1.GET("/loans/:loan_id/accruals", handler.GetLoanAccruals)
+}
+
+func (h *AccrualHandler) AccrueInterest(c echo.Context) error {
+	var req models.InterestAccrualRunRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	asOf := req.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	response, err := h.accrualService.AccrueInterest(c.Request().Context(), asOf)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.InterestAccrualRunSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *AccrualHandler) GetLoanAccruals(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	response, err := h.accrualService.GetLoanAccruals(c.Request().Context(), loanID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.LoanAccrualsSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mocks "billing-engine/accrual/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestAccrualHandler_AccrueInterest_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewAccrualServiceInterface(t)
+	handler := &AccrualHandler{accrualService: mockService, middleware: new(MockMiddleware)}
+
+	asOf := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+	expectedResponse := &models.InterestAccrualRunResponse{
+		AsOf:           asOf,
+		Method:         models.AccrualMethodStraightLine,
+		ProcessedLoans: 2,
+		AccruedLoans:   1,
+		AccruedDays:    3,
+	}
+
+	mockService.On("AccrueInterest", mock.Anything, asOf).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/accruals/run", strings.NewReader(`{"as_of":"2026-01-04T00:00:00Z"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.AccrueInterest(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.InterestAccrualRunSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 3, response.Data.AccruedDays)
+}
+
+func TestAccrualHandler_AccrueInterest_InvalidBody(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewAccrualServiceInterface(t)
+	handler := &AccrualHandler{accrualService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/accruals/run", strings.NewReader(`{"as_of":"yesterday"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.AccrueInterest(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "AccrueInterest", mock.Anything, mock.Anything)
+}
+
+func TestAccrualHandler_GetLoanAccruals_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewAccrualServiceInterface(t)
+	handler := &AccrualHandler{accrualService: mockService, middleware: new(MockMiddleware)}
+
+	expectedResponse := &models.LoanAccrualsResponse{
+		LoanID:                 "loan_123",
+		Currency:               models.CurrencyIDR,
+		InterestAmount:         300000.00,
+		AccruedInterestAmount:  30000.00,
+		UnearnedInterestAmount: 270000.00,
+	}
+
+	mockService.On("GetLoanAccruals", mock.Anything, "loan_123").Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans/loan_123/accruals", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.GetLoanAccruals(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.LoanAccrualsSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, 30000.00, response.Data.AccruedInterestAmount)
+	assert.Equal(t, 270000.00, response.Data.UnearnedInterestAmount)
+}
+
+func TestAccrualHandler_GetLoanAccruals_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewAccrualServiceInterface(t)
+	handler := &AccrualHandler{accrualService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("GetLoanAccruals", mock.Anything, "loan_999").Return(nil, errors.New("loan not found"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans/loan_999/accruals", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_999")
+
+	// Execute
+	err := handler.GetLoanAccruals(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,26 @@
+package accrual
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// AccrualMySQLRepositoryInterface defines the interface for interest accrual repository
+type AccrualMySQLRepositoryInterface interface {
+	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetAccruableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetAccrualPositions(ctx context.Context, loanIDs []string) ([]*models.AccrualPosition, error)
+	GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	GetAccrualsByLoanID(ctx context.Context, loanID string) ([]*models.InterestAccrual, error)
+	CreateAccruals(ctx context.Context, accruals []*models.InterestAccrual) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AccrualServiceInterface defines the interface for interest accrual service
+type AccrualServiceInterface interface {
+	AccrueInterest(ctx context.Context, asOf time.Time) (*models.InterestAccrualRunResponse, error)
+	GetAccruableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	AccrueLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) error
+	GetLoanAccruals(ctx context.Context, loanID string) (*models.LoanAccrualsResponse, error)
+}
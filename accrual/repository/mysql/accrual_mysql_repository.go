@@ -0,0 +1,108 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"billing-engine/accrual"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type accrualMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewAccrualMySQLRepository creates a new interest accrual repository instance
+func NewAccrualMySQLRepository(db *gorm.DB) accrual.AccrualMySQLRepositoryInterface {
+	return &accrualMySQLRepository{db: db}
+}
+
+func (r *accrualMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
+
+// GetAccruableLoanSummaries returns the disbursed loans that carry interest, and the paid loans
+// whose interest is not fully accrued yet
+func (r *accrualMySQLRepository) GetAccruableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND interest_amount > 0 AND deleted_at IS NULL", afterID).
+		Where("status IN ? OR (status = ? AND interest_amount > (SELECT COALESCE(SUM(ia.amount), 0) FROM interest_accruals ia WHERE ia.loan_id = loan_summaries.loan_id AND ia.status = ?))",
+			[]string{models.StatusPending, models.StatusDelinquent}, models.StatusPaid, models.AccrualStatusAccrued).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+// GetAccrualPositions sums the accrued and suspended interest of the loans; loans without
+// accruals are left out
+func (r *accrualMySQLRepository) GetAccrualPositions(ctx context.Context, loanIDs []string) ([]*models.AccrualPosition, error) {
+	var positions []*models.AccrualPosition
+	if len(loanIDs) == 0 {
+		return positions, nil
+	}
+	err := transaction.DB(ctx, r.db).
+		Model(&models.InterestAccrual{}).
+		Select("loan_id, "+
+			"COALESCE(SUM(CASE WHEN status = ? THEN amount ELSE 0 END), 0) AS accrued_amount, "+
+			"COALESCE(SUM(CASE WHEN status = ? THEN amount ELSE 0 END), 0) AS suspended_amount, "+
+			"MAX(accrual_date) AS last_accrual_date",
+			models.AccrualStatusAccrued, models.AccrualStatusSuspended).
+		Where("loan_id IN ?", loanIDs).
+		Group("loan_id").
+		Scan(&positions).Error
+	if err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+func (r *accrualMySQLRepository) GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND deleted_at IS NULL", loanID).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *accrualMySQLRepository) GetAccrualsByLoanID(ctx context.Context, loanID string) ([]*models.InterestAccrual, error) {
+	var accruals []*models.InterestAccrual
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ?", loanID).
+		Order("accrual_date ASC").
+		Find(&accruals).Error
+	if err != nil {
+		return nil, err
+	}
+	return accruals, nil
+}
+
+// CreateAccruals stores the accrual rows. The unique loan and date index keeps a day from being
+// accrued twice when runs overlap.
+func (r *accrualMySQLRepository) CreateAccruals(ctx context.Context, accruals []*models.InterestAccrual) error {
+	return transaction.DB(ctx, r.db).Create(accruals).Error
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *accrualMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"billing-engine/accrual"
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+// accrualBatchSize is the number of loans loaded per page by the accrual run
+const accrualBatchSize = 500
+
+type accrualService struct {
+	accrualRepo           accrual.AccrualMySQLRepositoryInterface
+	ledgerService         ledger.LedgerServiceInterface
+	method                string
+	suspendCollectibility int // collectibility grade from which a loan is non-performing and stops accruing
+}
+
+// NewAccrualService creates a new interest accrual service instance
+func NewAccrualService(accrualRepo accrual.AccrualMySQLRepositoryInterface, ledgerService ledger.LedgerServiceInterface, method string, suspendCollectibility int) accrual.AccrualServiceInterface {
+	return &accrualService{
+		accrualRepo:           accrualRepo,
+		ledgerService:         ledgerService,
+		method:                method,
+		suspendCollectibility: suspendCollectibility,
+	}
+}
+
+// AccrueInterest accrues the interest of every accruable loan up to and including the day of asOf.
+// Days missed since a loan's last accrual are back-filled in the same run.
+func (s *accrualService) AccrueInterest(ctx context.Context, asOf time.Time) (*models.InterestAccrualRunResponse, error) {
+	accrualDate := startOfDay(asOf)
+	result := &models.InterestAccrualRunResponse{AsOf: accrualDate, Method: s.method}
+
+	var afterID uint
+	for {
+		loanSummaries, err := s.accrualRepo.GetAccruableLoanSummaries(ctx, afterID, accrualBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accruable loans: %v", err)
+		}
+		if len(loanSummaries) == 0 {
+			break
+		}
+
+		loanIDs := make([]string, 0, len(loanSummaries))
+		for _, loanSummary := range loanSummaries {
+			loanIDs = append(loanIDs, loanSummary.LoanID)
+		}
+		positions, err := s.accrualRepo.GetAccrualPositions(ctx, loanIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accrual positions: %v", err)
+		}
+		positionByLoan := make(map[string]*models.AccrualPosition, len(positions))
+		for _, position := range positions {
+			positionByLoan[position.LoanID] = position
+		}
+
+		for _, loanSummary := range loanSummaries {
+			afterID = loanSummary.ID
+			result.ProcessedLoans++
+
+			// A single bad loan must not stop the rest of the portfolio from accruing
+			accruedDays, suspendedDays, err := s.accrueLoan(ctx, loanSummary, positionByLoan[loanSummary.LoanID], accrualDate)
+			if err != nil {
+				log.Printf("interest accrual failed for loan %s: %v", loanSummary.LoanID, err)
+				result.FailedLoans++
+				continue
+			}
+			if accruedDays > 0 {
+				result.AccruedLoans++
+			}
+			if suspendedDays > 0 {
+				result.SuspendedLoans++
+			}
+			result.AccruedDays += accruedDays
+			result.SuspendedDays += suspendedDays
+		}
+	}
+
+	return result, nil
+}
+
+// GetAccruableLoans returns the loans the accrual run accrues, in ID order after afterID
+func (s *accrualService) GetAccruableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	loanSummaries, err := s.accrualRepo.GetAccruableLoanSummaries(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accruable loans: %v", err)
+	}
+	return loanSummaries, nil
+}
+
+// AccrueLoan accrues the interest of one loan up to and including the day of asOf
+func (s *accrualService) AccrueLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) error {
+	positions, err := s.accrualRepo.GetAccrualPositions(ctx, []string{loanSummary.LoanID})
+	if err != nil {
+		return fmt.Errorf("failed to get accrual positions: %v", err)
+	}
+	var position *models.AccrualPosition
+	if len(positions) > 0 {
+		position = positions[0]
+	}
+
+	_, _, err = s.accrueLoan(ctx, loanSummary, position, startOfDay(asOf))
+	return err
+}
+
+// accrueLoan accrues the loan for every day from the day after its last accrual up to accrualDate
+// and returns the number of accrued and suspended days. Each day accrues the interest earned by its
+// end that has not been accrued yet, so interest suspended while the loan was non-performing is
+// caught up once it performs again.
+func (s *accrualService) accrueLoan(ctx context.Context, loanSummary *models.LoanSummary, position *models.AccrualPosition, accrualDate time.Time) (int, int, error) {
+	from := startOfDay(loanSummary.LoanStartDate).AddDate(0, 0, 1)
+	accrued := decimal.Zero
+	if position != nil {
+		accrued = decimal.NewFromFloat(position.AccruedAmount)
+		if position.LastAccrualDate != nil && !startOfDay(*position.LastAccrualDate).Before(from) {
+			from = startOfDay(*position.LastAccrualDate).AddDate(0, 0, 1)
+		}
+	}
+	if from.After(accrualDate) {
+		return 0, 0, nil
+	}
+
+	schedules, err := s.accrualRepo.GetPaymentSchedulesByLoanID(ctx, loanSummary.LoanID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get payment schedules: %v", err)
+	}
+	curve, err := newInterestCurve(s.method, loanSummary, schedules)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// A paid loan has earned all of its interest, whatever is left is accrued at once
+	if loanSummary.Status == models.StatusPaid {
+		interest := decimal.NewFromFloat(loanSummary.InterestAmount)
+		curve = func(time.Time) decimal.Decimal { return interest }
+	}
+	suspended := loanSummary.Status != models.StatusPaid && loanSummary.Collectibility >= s.suspendCollectibility
+
+	var accruals []*models.InterestAccrual
+	for day := from; !day.After(accrualDate); day = day.AddDate(0, 0, 1) {
+		earned := currency.Round(curve(day), loanSummary.Currency)
+		if suspended {
+			amount := earned.Sub(currency.Round(curve(day.AddDate(0, 0, -1)), loanSummary.Currency))
+			if amount.IsPositive() {
+				accruals = append(accruals, s.newAccrual(loanSummary, day, amount, models.AccrualStatusSuspended))
+			}
+			continue
+		}
+
+		amount := earned.Sub(accrued)
+		if !amount.IsPositive() {
+			continue
+		}
+		accruals = append(accruals, s.newAccrual(loanSummary, day, amount, models.AccrualStatusAccrued))
+		accrued = earned
+	}
+	if len(accruals) == 0 {
+		return 0, 0, nil
+	}
+
+	err = s.accrualRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.accrualRepo.CreateAccruals(ctx, accruals); err != nil {
+			return fmt.Errorf("failed to create accruals: %v", err)
+		}
+		for _, interestAccrual := range accruals {
+			if interestAccrual.Status != models.AccrualStatusAccrued {
+				continue
+			}
+			if err := s.ledgerService.PostInterestAccrual(ctx, loanSummary, interestAccrual.Amount, interestAccrual.AccrualDate); err != nil {
+				return fmt.Errorf("failed to post interest accrual: %v", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if suspended {
+		return 0, len(accruals), nil
+	}
+	return len(accruals), 0, nil
+}
+
+func (s *accrualService) GetLoanAccruals(ctx context.Context, loanID string) (*models.LoanAccrualsResponse, error) {
+	loanSummary, err := s.accrualRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	accruals, err := s.accrualRepo.GetAccrualsByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accruals: %v", err)
+	}
+
+	response := &models.LoanAccrualsResponse{
+		LoanID:         loanID,
+		Currency:       currency.Normalize(loanSummary.Currency),
+		InterestAmount: loanSummary.InterestAmount,
+		Accruals:       make([]models.InterestAccrualResponse, 0, len(accruals)),
+	}
+	accrued, suspended := decimal.Zero, decimal.Zero
+	for _, interestAccrual := range accruals {
+		if interestAccrual.Status == models.AccrualStatusAccrued {
+			accrued = accrued.Add(decimal.NewFromFloat(interestAccrual.Amount))
+		} else {
+			suspended = suspended.Add(decimal.NewFromFloat(interestAccrual.Amount))
+		}
+		accrualDate := interestAccrual.AccrualDate
+		response.LastAccrualDate = &accrualDate
+		response.Accruals = append(response.Accruals, models.InterestAccrualResponse{
+			AccrualDate: interestAccrual.AccrualDate,
+			Method:      interestAccrual.Method,
+			Amount:      interestAccrual.Amount,
+			Status:      interestAccrual.Status,
+		})
+	}
+	response.AccruedInterestAmount = accrued.InexactFloat64()
+	response.SuspendedInterestAmount = suspended.InexactFloat64()
+	response.UnearnedInterestAmount = decimal.Max(decimal.NewFromFloat(loanSummary.InterestAmount).Sub(accrued), decimal.Zero).InexactFloat64()
+
+	return response, nil
+}
+
+// newAccrual builds the accrual row of the loan for one day
+func (s *accrualService) newAccrual(loanSummary *models.LoanSummary, day time.Time, amount decimal.Decimal, status string) *models.InterestAccrual {
+	return &models.InterestAccrual{
+		LoanID:      loanSummary.LoanID,
+		AccrualDate: day,
+		Method:      s.method,
+		Amount:      amount.InexactFloat64(),
+		Currency:    currency.Normalize(loanSummary.Currency),
+		Status:      status,
+		CreatedBy:   "system",
+	}
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	mocks "billing-engine/accrual/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// accruingLoan is a loan earning 300000 of interest over the 30 days of January 2026, 10000 a day
+func accruingLoan() (*models.LoanSummary, []*models.PaymentSchedule) {
+	loanSummary := &models.LoanSummary{
+		ID:              1,
+		LoanID:          "loan_123",
+		Currency:        models.CurrencyIDR,
+		PrincipalAmount: 3000000.00,
+		InterestAmount:  300000.00,
+		Collectibility:  models.CollectibilityCurrent,
+		Status:          models.StatusPending,
+		LoanStartDate:   date(2026, 1, 1),
+	}
+	schedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 3300000.00, InstallmentDueDate: date(2026, 1, 31), Status: models.StatusPending},
+	}
+	return loanSummary, schedules
+}
+
+func withinTransaction(mockRepo *mocks.AccrualMySQLRepositoryInterface, ctx context.Context) {
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+func TestParseAccrualMethod(t *testing.T) {
+	method, err := ParseAccrualMethod(" effective_interest ")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccrualMethodEffectiveInterest, method)
+
+	_, err = ParseAccrualMethod("DAILY")
+	assert.Error(t, err)
+}
+
+func TestStraightLineCurve(t *testing.T) {
+	curve := straightLineCurve(date(2026, 1, 1), date(2026, 1, 31), decimal.NewFromInt(300000))
+
+	assert.True(t, curve(date(2026, 1, 1)).IsZero())
+	assert.Equal(t, "150000", curve(date(2026, 1, 16)).String())
+	assert.Equal(t, "300000", curve(date(2026, 1, 31)).String())
+	assert.Equal(t, "300000", curve(date(2026, 3, 1)).String()) // nothing more after the term
+}
+
+func TestEffectiveInterestCurve_EarnsMoreEarly(t *testing.T) {
+	dueDates := []time.Time{date(2026, 2, 1), date(2026, 3, 1)}
+	curve, err := effectiveInterestCurve(date(2026, 1, 1), dueDates, decimal.NewFromInt(1000000), decimal.NewFromInt(100000))
+
+	assert.NoError(t, err)
+	assert.True(t, curve(date(2026, 1, 1)).IsZero())
+	// The first month earns interest on the full principal, the second only on what is left
+	firstPeriod := curve(date(2026, 2, 1)).InexactFloat64()
+	assert.InDelta(t, 65965.00, firstPeriod, 1.00)
+	assert.InDelta(t, firstPeriod*15/31, curve(date(2026, 1, 16)).InexactFloat64(), 0.01)
+	assert.Equal(t, "100000", curve(date(2026, 3, 1)).String())
+}
+
+func TestAccrualService_AccrueInterest_BackfillsMissedDays(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(1), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.MatchedBy(func(accruals []*models.InterestAccrual) bool {
+		return len(accruals) == 3 && accruals[0].AccrualDate.Equal(date(2026, 1, 2)) && accruals[2].AccrualDate.Equal(date(2026, 1, 4)) &&
+			accruals[0].Amount == 10000.00 && accruals[2].Amount == 10000.00 &&
+			accruals[0].Status == models.AccrualStatusAccrued && accruals[0].Method == models.AccrualMethodStraightLine
+	})).Return(nil)
+	mockLedger.On("PostInterestAccrual", ctx, loanSummary, 10000.00, mock.AnythingOfType("time.Time")).Return(nil).Times(3)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, time.Date(2026, 1, 4, 23, 0, 0, 0, time.Local))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, date(2026, 1, 4), response.AsOf)
+	assert.Equal(t, 1, response.ProcessedLoans)
+	assert.Equal(t, 1, response.AccruedLoans)
+	assert.Equal(t, 3, response.AccruedDays)
+	assert.Equal(t, 0, response.FailedLoans)
+}
+
+func TestAccrualService_AccrueInterest_ResumesAfterLastAccrual(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+	lastAccrualDate := date(2026, 1, 3)
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(1), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{
+		{LoanID: "loan_123", AccruedAmount: 20000.00, LastAccrualDate: &lastAccrualDate},
+	}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.MatchedBy(func(accruals []*models.InterestAccrual) bool {
+		return len(accruals) == 1 && accruals[0].AccrualDate.Equal(date(2026, 1, 4)) && accruals[0].Amount == 10000.00
+	})).Return(nil)
+	mockLedger.On("PostInterestAccrual", ctx, loanSummary, 10000.00, date(2026, 1, 4)).Return(nil)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 4))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.AccruedDays)
+}
+
+func TestAccrualService_AccrueInterest_AlreadyAccrued(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, _ := accruingLoan()
+	lastAccrualDate := date(2026, 1, 4)
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(1), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{
+		{LoanID: "loan_123", AccruedAmount: 30000.00, LastAccrualDate: &lastAccrualDate},
+	}, nil)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 4))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.ProcessedLoans)
+	assert.Equal(t, 0, response.AccruedLoans)
+	mockRepo.AssertNotCalled(t, "CreateAccruals", mock.Anything, mock.Anything)
+}
+
+func TestAccrualService_AccrueInterest_SuspendsNonPerformingLoan(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+	loanSummary.Collectibility = models.CollectibilitySubstandard
+	loanSummary.Status = models.StatusDelinquent
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(1), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.MatchedBy(func(accruals []*models.InterestAccrual) bool {
+		return len(accruals) == 2 && accruals[0].Status == models.AccrualStatusSuspended &&
+			accruals[1].Status == models.AccrualStatusSuspended && accruals[1].Amount == 10000.00
+	})).Return(nil)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 3))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.SuspendedLoans)
+	assert.Equal(t, 2, response.SuspendedDays)
+	assert.Equal(t, 0, response.AccruedDays)
+	mockLedger.AssertNotCalled(t, "PostInterestAccrual", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAccrualService_AccrueInterest_CatchesUpAfterCure(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+	lastAccrualDate := date(2026, 1, 3)
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(1), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{
+		{LoanID: "loan_123", AccruedAmount: 10000.00, SuspendedAmount: 10000.00, LastAccrualDate: &lastAccrualDate},
+	}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.MatchedBy(func(accruals []*models.InterestAccrual) bool {
+		return len(accruals) == 1 && accruals[0].Amount == 20000.00 // today plus the suspended day
+	})).Return(nil)
+	mockLedger.On("PostInterestAccrual", ctx, loanSummary, 20000.00, date(2026, 1, 4)).Return(nil)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 4))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.AccruedDays)
+}
+
+func TestAccrualService_AccrueInterest_PaidLoanAccruesRemainder(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+	loanSummary.Status = models.StatusPaid
+	lastAccrualDate := date(2026, 1, 10)
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(1), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{
+		{LoanID: "loan_123", AccruedAmount: 100000.00, LastAccrualDate: &lastAccrualDate},
+	}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.MatchedBy(func(accruals []*models.InterestAccrual) bool {
+		return len(accruals) == 1 && accruals[0].Amount == 200000.00
+	})).Return(nil)
+	mockLedger.On("PostInterestAccrual", ctx, loanSummary, 200000.00, date(2026, 1, 11)).Return(nil)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 12))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.AccruedDays)
+}
+
+func TestAccrualService_AccrueInterest_FailedLoanDoesNotStopRun(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+	brokenLoan := &models.LoanSummary{ID: 2, LoanID: "loan_456", InterestAmount: 1000.00, Status: models.StatusPending, LoanStartDate: date(2026, 1, 1)}
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return([]*models.LoanSummary{loanSummary, brokenLoan}, nil)
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(2), accrualBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123", "loan_456"}).Return([]*models.AccrualPosition{}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_456").Return(nil, errors.New("database error"))
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.Anything).Return(nil)
+	mockLedger.On("PostInterestAccrual", ctx, loanSummary, 10000.00, date(2026, 1, 2)).Return(nil)
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 2))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.ProcessedLoans)
+	assert.Equal(t, 1, response.AccruedLoans)
+	assert.Equal(t, 1, response.FailedLoans)
+}
+
+func TestAccrualService_AccrueInterest_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetAccruableLoanSummaries", ctx, uint(0), accrualBatchSize).Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.AccrueInterest(ctx, date(2026, 1, 2))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to get accruable loans")
+}
+
+func TestAccrualService_GetLoanAccruals(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, _ := accruingLoan()
+	accruals := []*models.InterestAccrual{
+		{LoanID: "loan_123", AccrualDate: date(2026, 1, 2), Method: models.AccrualMethodStraightLine, Amount: 10000.00, Status: models.AccrualStatusAccrued},
+		{LoanID: "loan_123", AccrualDate: date(2026, 1, 3), Method: models.AccrualMethodStraightLine, Amount: 10000.00, Status: models.AccrualStatusSuspended},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetAccrualsByLoanID", ctx, "loan_123").Return(accruals, nil)
+
+	// Execute
+	response, err := service.GetLoanAccruals(ctx, "loan_123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10000.00, response.AccruedInterestAmount)
+	assert.Equal(t, 10000.00, response.SuspendedInterestAmount)
+	assert.Equal(t, 290000.00, response.UnearnedInterestAmount)
+	assert.Equal(t, date(2026, 1, 3), *response.LastAccrualDate)
+	assert.Len(t, response.Accruals, 2)
+}
+
+func TestAccrualService_GetLoanAccruals_LoanNotFound(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_999").Return(nil, nil)
+
+	// Execute
+	response, err := service.GetLoanAccruals(ctx, "loan_999")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "loan not found", err.Error())
+}
+
+func TestAccrualService_AccrueLoan(t *testing.T) {
+	mockRepo := mocks.NewAccrualMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewAccrualService(mockRepo, mockLedger, models.AccrualMethodStraightLine, models.CollectibilitySubstandard)
+	ctx := context.Background()
+
+	loanSummary, schedules := accruingLoan()
+	lastAccrualDate := date(2026, 1, 2)
+
+	// Mock repository calls
+	mockRepo.On("GetAccrualPositions", ctx, []string{"loan_123"}).Return([]*models.AccrualPosition{
+		{LoanID: "loan_123", AccruedAmount: 10000.00, LastAccrualDate: &lastAccrualDate},
+	}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	withinTransaction(mockRepo, ctx)
+	mockRepo.On("CreateAccruals", ctx, mock.MatchedBy(func(accruals []*models.InterestAccrual) bool {
+		return len(accruals) == 1 && accruals[0].AccrualDate.Equal(date(2026, 1, 3))
+	})).Return(nil)
+	mockLedger.On("PostInterestAccrual", ctx, loanSummary, 10000.00, date(2026, 1, 3)).Return(nil)
+
+	// Execute
+	err := service.AccrueLoan(ctx, loanSummary, time.Date(2026, 1, 3, 18, 0, 0, 0, time.Local))
+
+	// Assert
+	assert.NoError(t, err)
+}
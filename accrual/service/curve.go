@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/utils/rate"
+
+	"github.com/shopspring/decimal"
+)
+
+// interestCurve returns the interest a loan has earned by the end of the given day, before rounding
+type interestCurve func(day time.Time) decimal.Decimal
+
+// ParseAccrualMethod parses the configured interest accrual method
+func ParseAccrualMethod(value string) (string, error) {
+	method := strings.ToUpper(strings.TrimSpace(value))
+	switch method {
+	case models.AccrualMethodStraightLine, models.AccrualMethodEffectiveInterest:
+		return method, nil
+	default:
+		return "", fmt.Errorf("invalid accrual method %q", value)
+	}
+}
+
+// newInterestCurve builds the interest curve of the loan for the method. The loan earns interest
+// from its start date until the due date of its last installment.
+func newInterestCurve(method string, loanSummary *models.LoanSummary, schedules []*models.PaymentSchedule) (interestCurve, error) {
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("loan has no installments")
+	}
+
+	start := startOfDay(loanSummary.LoanStartDate)
+	dueDates := make([]time.Time, 0, len(schedules))
+	for _, schedule := range schedules {
+		dueDates = append(dueDates, startOfDay(schedule.InstallmentDueDate))
+	}
+	interest := decimal.NewFromFloat(loanSummary.InterestAmount)
+
+	if method == models.AccrualMethodEffectiveInterest {
+		return effectiveInterestCurve(start, dueDates, decimal.NewFromFloat(loanSummary.PrincipalAmount), interest)
+	}
+	return straightLineCurve(start, dueDates[len(dueDates)-1], interest), nil
+}
+
+// straightLineCurve spreads the interest evenly over every day of the loan term
+func straightLineCurve(start, end time.Time, interest decimal.Decimal) interestCurve {
+	termDays := daysBetween(start, end)
+	return func(day time.Time) decimal.Decimal {
+		elapsed := daysBetween(start, day)
+		if termDays <= 0 || elapsed >= termDays {
+			return interest
+		}
+		if elapsed <= 0 {
+			return decimal.Zero
+		}
+		return interest.Mul(decimal.NewFromInt(int64(elapsed))).Div(decimal.NewFromInt(int64(termDays)))
+	}
+}
+
+// effectiveInterestCurve earns each installment period the interest on the balance still owed at
+// the loan's internal rate of return, so more interest is earned early on when more is owed. The
+// interest of a period is spread evenly over its days.
+func effectiveInterestCurve(start time.Time, dueDates []time.Time, principal, interest decimal.Decimal) (interestCurve, error) {
+	periods := len(dueDates)
+	payment := principal.Add(interest).Div(decimal.NewFromInt(int64(periods)))
+	payments := make([]float64, periods)
+	for i := range payments {
+		payments[i] = payment.InexactFloat64()
+	}
+	periodicRate, err := rate.PeriodicIRR(principal.InexactFloat64(), payments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve effective interest rate: %v", err)
+	}
+
+	// earned[k] is the interest earned by the due date of installment k, earned[0] at the start
+	earned := make([]decimal.Decimal, periods+1)
+	balance := principal
+	for k := 1; k <= periods; k++ {
+		periodInterest := balance.Mul(decimal.NewFromFloat(periodicRate))
+		balance = balance.Add(periodInterest).Sub(payment)
+		earned[k] = earned[k-1].Add(periodInterest)
+	}
+	earned[periods] = interest // the rate is solved numerically, the last period absorbs what is left
+
+	return func(day time.Time) decimal.Decimal {
+		if day.Before(start) {
+			return decimal.Zero
+		}
+		periodStart := start
+		for k, dueDate := range dueDates {
+			if day.Before(dueDate) {
+				periodDays := daysBetween(periodStart, dueDate)
+				if periodDays <= 0 {
+					return earned[k+1]
+				}
+				elapsed := decimal.NewFromInt(int64(daysBetween(periodStart, day)))
+				return earned[k].Add(earned[k+1].Sub(earned[k]).Mul(elapsed).Div(decimal.NewFromInt(int64(periodDays))))
+			}
+			periodStart = dueDate
+		}
+		return interest
+	}, nil
+}
+
+// startOfDay returns midnight local time of the day t falls on
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	return int(math.Round(startOfDay(b).Sub(startOfDay(a)).Hours() / 24))
+}
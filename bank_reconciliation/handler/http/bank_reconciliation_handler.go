@@ -0,0 +1,170 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"billing-engine/bank_reconciliation"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxStatementSize caps the size of an uploaded statement file
+const maxStatementSize = 10 << 20
+
+type BankReconciliationHandler struct {
+	bankReconciliationService bank_reconciliation.BankReconciliationServiceInterface
+	middleware                middlewares.GoMiddlewareInterface
+}
+
+// NewBankReconciliationHandler creates a new bank statement reconciliation handler instance
+func NewBankReconciliationHandler(e *echo.Echo, bankReconciliationService bank_reconciliation.BankReconciliationServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &BankReconciliationHandler{
+		bankReconciliationService: bankReconciliationService,
+		middleware:                middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/bank-statements", handler.ImportStatement)
+	v1.GET("/bank-statements/unmatched-lines", handler.ListUnmatchedLines)
+	v1.POST("/bank-statements/lines/:line_id/assign", handler.AssignLine)
+}
+
+// ImportStatement imports a statement uploaded as the multipart field "file"
+func (h *BankReconciliationHandler) ImportStatement(c echo.Context) error {
+	var req models.BankStatementImportRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Statement file is required",
+		})
+	}
+	if fileHeader.Size > maxStatementSize {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Statement file is too large",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Statement file is unreadable",
+		})
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Statement file is unreadable",
+		})
+	}
+	req.FileName = fileHeader.Filename
+	req.Content = content
+
+	response, err := h.bankReconciliationService.ImportStatement(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.BankStatementImportSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *BankReconciliationHandler) ListUnmatchedLines(c echo.Context) error {
+	var req models.BankStatementLineListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.bankReconciliationService.ListUnmatchedLines(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.BankStatementLineListSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *BankReconciliationHandler) AssignLine(c echo.Context) error {
+	lineID, err := strconv.ParseUint(c.Param("line_id"), 10, 64)
+	if err != nil || lineID == 0 {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid line ID",
+		})
+	}
+
+	var req models.BankStatementLineAssignRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.bankReconciliationService.AssignLine(c.Request().Context(), uint(lineID), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.BankStatementLineSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,223 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mocks "billing-engine/bank_reconciliation/_mock"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func newStatementUpload(t *testing.T, format string, fileName string, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	assert.NoError(t, writer.WriteField("format", format))
+	assert.NoError(t, writer.WriteField("account_number", "1234567890"))
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		assert.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestBankReconciliationHandler_ImportStatement_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	content := "value_date,amount,description\n2026-01-04,110000.00,TRF DARI BUDI\n"
+	mockService.On("ImportStatement", mock.Anything, &models.BankStatementImportRequest{
+		Format:        "csv",
+		AccountNumber: "1234567890",
+		FileName:      "statement.csv",
+		Content:       []byte(content),
+	}).Return(&models.BankStatementImportResponse{StatementID: 5, CreditLines: 1, UnmatchedLines: 1}, nil)
+
+	// Create request
+	body, contentType := newStatementUpload(t, "csv", "statement.csv", content)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/bank-statements", body)
+	httpReq.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ImportStatement(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"statement_id":5`)
+}
+
+func TestBankReconciliationHandler_ImportStatement_MissingFile(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	body, contentType := newStatementUpload(t, "mt940", "", "")
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/bank-statements", body)
+	httpReq.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ImportStatement(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Statement file is required")
+	mockService.AssertNotCalled(t, "ImportStatement", mock.Anything, mock.Anything)
+}
+
+func TestBankReconciliationHandler_ImportStatement_InvalidFormat(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	body, contentType := newStatementUpload(t, "bai2", "statement.bai", "01,")
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/bank-statements", body)
+	httpReq.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ImportStatement(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "ImportStatement", mock.Anything, mock.Anything)
+}
+
+func TestBankReconciliationHandler_ListUnmatchedLines_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ListUnmatchedLines", mock.Anything, &models.BankStatementLineListRequest{Limit: 20}).Return(&models.BankStatementLineListResponse{
+		Lines: []models.BankStatementLineResponse{{ID: 11, Status: models.StatementLineStatusUnmatched, Reason: "no loan reference found"}},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/bank-statements/unmatched-lines?limit=20", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ListUnmatchedLines(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no loan reference found")
+}
+
+func TestBankReconciliationHandler_AssignLine_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("AssignLine", mock.Anything, uint(11), &models.BankStatementLineAssignRequest{LoanID: "loan_123"}).Return(&models.BankStatementLineResponse{
+		ID:     11,
+		Status: models.StatementLineStatusAssigned,
+		LoanID: "loan_123",
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/bank-statements/lines/11/assign", strings.NewReader(`{"loan_id":"loan_123"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("line_id")
+	c.SetParamValues("11")
+
+	// Execute
+	err := handler.AssignLine(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"ASSIGNED"`)
+}
+
+func TestBankReconciliationHandler_AssignLine_InvalidLineID(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/bank-statements/lines/abc/assign", strings.NewReader(`{"loan_id":"loan_123"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("line_id")
+	c.SetParamValues("abc")
+
+	// Execute
+	err := handler.AssignLine(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid line ID")
+	mockService.AssertNotCalled(t, "AssignLine", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBankReconciliationHandler_AssignLine_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBankReconciliationServiceInterface(t)
+	handler := &BankReconciliationHandler{bankReconciliationService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("AssignLine", mock.Anything, uint(11), &models.BankStatementLineAssignRequest{LoanID: "loan_123"}).Return(nil, errors.New("bank statement line 11 is already matched"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/bank-statements/lines/11/assign", strings.NewReader(`{"loan_id":"loan_123"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("line_id")
+	c.SetParamValues("11")
+
+	// Execute
+	err := handler.AssignLine(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "already matched")
+}
@@ -0,0 +1,27 @@
+package bank_reconciliation
+
+import (
+	"billing-engine/models"
+	"context"
+)
+
+// BankReconciliationMySQLRepositoryInterface defines the interface for bank statement reconciliation repository
+type BankReconciliationMySQLRepositoryInterface interface {
+	CreateStatement(ctx context.Context, statement *models.BankStatement) error
+	UpdateStatement(ctx context.Context, statement *models.BankStatement) error
+	GetExistingLineKeys(ctx context.Context, lineKeys []string) ([]string, error)
+	CreateLines(ctx context.Context, lines []*models.BankStatementLine) error
+	GetLineByID(ctx context.Context, lineID uint) (*models.BankStatementLine, error)
+	GetUnmatchedLines(ctx context.Context, afterID uint, limit int) ([]*models.BankStatementLine, error)
+	MatchLine(ctx context.Context, line *models.BankStatementLine) (bool, error)
+	UpdateLineReason(ctx context.Context, lineID uint, reason string) error
+	GetLoanIDByVirtualAccount(ctx context.Context, virtualAccountNumber string) (string, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// BankReconciliationServiceInterface defines the interface for bank statement reconciliation service
+type BankReconciliationServiceInterface interface {
+	ImportStatement(ctx context.Context, req *models.BankStatementImportRequest) (*models.BankStatementImportResponse, error)
+	ListUnmatchedLines(ctx context.Context, req *models.BankStatementLineListRequest) (*models.BankStatementLineListResponse, error)
+	AssignLine(ctx context.Context, lineID uint, req *models.BankStatementLineAssignRequest) (*models.BankStatementLineResponse, error)
+}
@@ -0,0 +1,126 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"billing-engine/bank_reconciliation"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type bankReconciliationMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewBankReconciliationMySQLRepository creates a new bank statement reconciliation repository instance
+func NewBankReconciliationMySQLRepository(db *gorm.DB) bank_reconciliation.BankReconciliationMySQLRepositoryInterface {
+	return &bankReconciliationMySQLRepository{db: db}
+}
+
+func (r *bankReconciliationMySQLRepository) CreateStatement(ctx context.Context, statement *models.BankStatement) error {
+	return transaction.DB(ctx, r.db).Create(statement).Error
+}
+
+func (r *bankReconciliationMySQLRepository) UpdateStatement(ctx context.Context, statement *models.BankStatement) error {
+	return transaction.DB(ctx, r.db).Save(statement).Error
+}
+
+// GetExistingLineKeys returns the given line keys that were already imported
+func (r *bankReconciliationMySQLRepository) GetExistingLineKeys(ctx context.Context, lineKeys []string) ([]string, error) {
+	existing := make([]string, 0)
+	if len(lineKeys) == 0 {
+		return existing, nil
+	}
+	err := transaction.DB(ctx, r.db).
+		Model(&models.BankStatementLine{}).
+		Where("line_key IN ?", lineKeys).
+		Pluck("line_key", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *bankReconciliationMySQLRepository) CreateLines(ctx context.Context, lines []*models.BankStatementLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	return transaction.DB(ctx, r.db).Create(&lines).Error
+}
+
+func (r *bankReconciliationMySQLRepository) GetLineByID(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	err := transaction.DB(ctx, r.db).Where("id = ?", lineID).First(&line).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &line, nil
+}
+
+// GetUnmatchedLines returns the unmatched queue oldest first, continuing after afterID
+func (r *bankReconciliationMySQLRepository) GetUnmatchedLines(ctx context.Context, afterID uint, limit int) ([]*models.BankStatementLine, error) {
+	var lines []*models.BankStatementLine
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status = ?", afterID, models.StatementLineStatusUnmatched).
+		Order("id ASC").
+		Limit(limit).
+		Find(&lines).Error
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// MatchLine stores the match of a line that is still unmatched. It reports false when the line was
+// matched in the meantime, so a transfer is never applied twice.
+func (r *bankReconciliationMySQLRepository) MatchLine(ctx context.Context, line *models.BankStatementLine) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&models.BankStatementLine{}).
+		Where("id = ? AND status = ?", line.ID, models.StatementLineStatusUnmatched).
+		Updates(map[string]interface{}{
+			"status":       line.Status,
+			"match_method": line.MatchMethod,
+			"loan_id":      line.LoanID,
+			"reason":       "",
+			"matched_at":   line.MatchedAt,
+			"matched_by":   line.MatchedBy,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *bankReconciliationMySQLRepository) UpdateLineReason(ctx context.Context, lineID uint, reason string) error {
+	return transaction.DB(ctx, r.db).
+		Model(&models.BankStatementLine{}).
+		Where("id = ?", lineID).
+		Update("reason", reason).Error
+}
+
+// GetLoanIDByVirtualAccount returns the loan issued the virtual account, or an empty ID when no loan has it
+func (r *bankReconciliationMySQLRepository) GetLoanIDByVirtualAccount(ctx context.Context, virtualAccountNumber string) (string, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Select("loan_id").
+		Where("virtual_account_number = ? AND deleted_at IS NULL", virtualAccountNumber).
+		First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return loanSummary.LoanID, nil
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *bankReconciliationMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"billing-engine/bank_reconciliation"
+	"billing-engine/models"
+	"billing-engine/repayment"
+	"billing-engine/utils/pagination"
+)
+
+// loanIDPattern finds loan IDs ("loan_" and a UUID) in statement references and descriptions.
+// Banks often drop the separators or change the case, so both are optional.
+var loanIDPattern = regexp.MustCompile(`(?i)loan[_-]?([0-9a-f]{8})-?([0-9a-f]{4})-?([0-9a-f]{4})-?([0-9a-f]{4})-?([0-9a-f]{12})`)
+
+type bankReconciliationService struct {
+	bankReconciliationRepo bank_reconciliation.BankReconciliationMySQLRepositoryInterface
+	repaymentService       repayment.RepaymentServiceInterface
+}
+
+// NewBankReconciliationService creates a new bank statement reconciliation service instance
+func NewBankReconciliationService(bankReconciliationRepo bank_reconciliation.BankReconciliationMySQLRepositoryInterface, repaymentService repayment.RepaymentServiceInterface) bank_reconciliation.BankReconciliationServiceInterface {
+	return &bankReconciliationService{
+		bankReconciliationRepo: bankReconciliationRepo,
+		repaymentService:       repaymentService,
+	}
+}
+
+// ImportStatement stores the incoming transfers of a statement file and pays the loan of every
+// transfer referencing exactly one loan. Transfers already imported are skipped; the others wait
+// in the unmatched queue with the reason they could not be applied.
+func (s *bankReconciliationService) ImportStatement(ctx context.Context, req *models.BankStatementImportRequest) (*models.BankStatementImportResponse, error) {
+	format := strings.ToUpper(req.Format)
+	var parsed *parsedStatement
+	var err error
+	if format == models.StatementFormatMT940 {
+		parsed, err = parseMT940(req.Content)
+	} else {
+		parsed, err = parseCSV(req.Content, req.AccountNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse statement: %v", err)
+	}
+
+	statement := &models.BankStatement{
+		FileName:           req.FileName,
+		Format:             format,
+		AccountNumber:      parsed.accountNumber,
+		StatementReference: parsed.reference,
+		TotalLines:         len(parsed.lines),
+		CreatedBy:          "system",
+	}
+
+	// Debits are not repayments; identical credits in one file are told apart by their occurrence
+	lines := make([]*models.BankStatementLine, 0, len(parsed.lines))
+	lineKeys := make([]string, 0, len(parsed.lines))
+	occurrences := make(map[string]int)
+	for _, parsedLine := range parsed.lines {
+		if !parsedLine.credit {
+			continue
+		}
+		statement.CreditLines++
+		keyFields := []string{
+			parsedLine.accountNumber,
+			parsedLine.valueDate.Format("2006-01-02"),
+			parsedLine.amount.StringFixed(2),
+			parsedLine.currency,
+			parsedLine.reference,
+			parsedLine.description,
+		}
+		// Only lines with a virtual account hash it, so lines imported before keep their key
+		if parsedLine.virtualAccount != "" {
+			keyFields = append(keyFields, parsedLine.virtualAccount)
+		}
+		fields := strings.Join(keyFields, "|")
+		occurrences[fields]++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", fields, occurrences[fields])))
+		lineKey := hex.EncodeToString(sum[:])
+
+		lineKeys = append(lineKeys, lineKey)
+		lines = append(lines, &models.BankStatementLine{
+			LineKey:        lineKey,
+			AccountNumber:  parsedLine.accountNumber,
+			VirtualAccount: parsedLine.virtualAccount,
+			ValueDate:      parsedLine.valueDate,
+			Amount:         parsedLine.amount.InexactFloat64(),
+			Currency:       parsedLine.currency,
+			Reference:      parsedLine.reference,
+			Description:    parsedLine.description,
+			Status:         models.StatementLineStatusUnmatched,
+		})
+	}
+
+	existingKeys, err := s.bankReconciliationRepo.GetExistingLineKeys(ctx, lineKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check imported lines: %v", err)
+	}
+	imported := make(map[string]bool, len(existingKeys))
+	for _, key := range existingKeys {
+		imported[key] = true
+	}
+	newLines := make([]*models.BankStatementLine, 0, len(lines))
+	for _, line := range lines {
+		if imported[line.LineKey] {
+			statement.DuplicateLines++
+			continue
+		}
+		newLines = append(newLines, line)
+	}
+
+	// Lines are stored before any payment so a failure part way leaves them in the queue
+	err = s.bankReconciliationRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.bankReconciliationRepo.CreateStatement(ctx, statement); err != nil {
+			return err
+		}
+		for _, line := range newLines {
+			line.BankStatementID = statement.ID
+		}
+		return s.bankReconciliationRepo.CreateLines(ctx, newLines)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save statement: %v", err)
+	}
+
+	for _, line := range newLines {
+		matched, err := s.autoMatch(ctx, line)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			statement.MatchedLines++
+		} else {
+			statement.UnmatchedLines++
+		}
+	}
+
+	if err := s.bankReconciliationRepo.UpdateStatement(ctx, statement); err != nil {
+		return nil, fmt.Errorf("failed to update statement: %v", err)
+	}
+
+	response := &models.BankStatementImportResponse{
+		StatementID:        statement.ID,
+		FileName:           statement.FileName,
+		Format:             statement.Format,
+		AccountNumber:      statement.AccountNumber,
+		StatementReference: statement.StatementReference,
+		TotalLines:         statement.TotalLines,
+		CreditLines:        statement.CreditLines,
+		DuplicateLines:     statement.DuplicateLines,
+		MatchedLines:       statement.MatchedLines,
+		UnmatchedLines:     statement.UnmatchedLines,
+		Lines:              make([]models.BankStatementLineResponse, 0, len(newLines)),
+	}
+	for _, line := range newLines {
+		response.Lines = append(response.Lines, toLineResponse(line))
+	}
+	return response, nil
+}
+
+// ListUnmatchedLines returns the unmatched queue, oldest line first
+func (s *bankReconciliationService) ListUnmatchedLines(ctx context.Context, req *models.BankStatementLineListRequest) (*models.BankStatementLineListResponse, error) {
+	cursor, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	var afterID uint
+	if cursor != nil {
+		afterID = cursor.ID
+	}
+
+	// Fetch one line more than the page size to know whether another page follows
+	limit := pagination.Limit(req.Limit)
+	lines, err := s.bankReconciliationRepo.GetUnmatchedLines(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unmatched lines: %v", err)
+	}
+
+	response := &models.BankStatementLineListResponse{}
+	if len(lines) > limit {
+		lines = lines[:limit]
+		response.NextCursor = pagination.EncodeCursor(pagination.Cursor{ID: lines[limit-1].ID})
+	}
+	response.Lines = make([]models.BankStatementLineResponse, 0, len(lines))
+	for _, line := range lines {
+		response.Lines = append(response.Lines, toLineResponse(line))
+	}
+	return response, nil
+}
+
+// AssignLine pays the given loan with an unmatched line. The line stays in the queue when the
+// repayment is rejected.
+func (s *bankReconciliationService) AssignLine(ctx context.Context, lineID uint, req *models.BankStatementLineAssignRequest) (*models.BankStatementLineResponse, error) {
+	line, err := s.bankReconciliationRepo.GetLineByID(ctx, lineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank statement line: %v", err)
+	}
+	if line == nil {
+		return nil, fmt.Errorf("bank statement line not found")
+	}
+	if line.Status != models.StatementLineStatusUnmatched {
+		return nil, fmt.Errorf("bank statement line %d is already %s", line.ID, strings.ToLower(line.Status))
+	}
+
+	if err := s.applyLine(ctx, line, req.LoanID, models.StatementMatchManual, models.StatementLineStatusAssigned, "api"); err != nil {
+		return nil, err
+	}
+	response := toLineResponse(line)
+	return &response, nil
+}
+
+// autoMatch pays the loan whose virtual account the line was paid into, or else the loan the line
+// references when it references exactly one. A line that cannot be applied keeps the reason in the
+// unmatched queue.
+func (s *bankReconciliationService) autoMatch(ctx context.Context, line *models.BankStatementLine) (bool, error) {
+	loanID, err := s.virtualAccountLoanID(ctx, line)
+	if err != nil {
+		return false, err
+	}
+	matchMethod := models.StatementMatchVirtualAccount
+
+	var reason string
+	if loanID == "" {
+		loanIDs := referencedLoanIDs(line.Reference + " " + line.Description)
+		switch len(loanIDs) {
+		case 0:
+			reason = "no loan reference found"
+		case 1:
+			loanID = loanIDs[0]
+			matchMethod = models.StatementMatchReference
+		default:
+			reason = fmt.Sprintf("references %d loans", len(loanIDs))
+		}
+	}
+	if loanID != "" {
+		err := s.applyLine(ctx, line, loanID, matchMethod, models.StatementLineStatusMatched, "system")
+		if err == nil {
+			return true, nil
+		}
+		reason = fmt.Sprintf("repayment of %s rejected: %v", loanID, err)
+	}
+
+	line.Reason = reason
+	if err := s.bankReconciliationRepo.UpdateLineReason(ctx, line.ID, reason); err != nil {
+		return false, fmt.Errorf("failed to update bank statement line: %v", err)
+	}
+	return false, nil
+}
+
+// applyLine marks the line as matched to the loan and pays the loan with it in one transaction
+func (s *bankReconciliationService) applyLine(ctx context.Context, line *models.BankStatementLine, loanID, matchMethod, status, matchedBy string) error {
+	matchedAt := time.Now()
+	matchedLine := *line
+	matchedLine.Status = status
+	matchedLine.MatchMethod = matchMethod
+	matchedLine.LoanID = loanID
+	matchedLine.Reason = ""
+	matchedLine.MatchedAt = &matchedAt
+	matchedLine.MatchedBy = matchedBy
+
+	err := s.bankReconciliationRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.bankReconciliationRepo.MatchLine(ctx, &matchedLine)
+		if err != nil {
+			return fmt.Errorf("failed to match bank statement line: %v", err)
+		}
+		if !claimed {
+			return fmt.Errorf("bank statement line %d is already matched", line.ID)
+		}
+
+		_, err = s.repaymentService.ProcessRepayment(ctx, &models.RepaymentRequest{
+			LoanID:        loanID,
+			PaymentAmount: line.Amount,
+			Currency:      line.Currency,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	*line = matchedLine
+	return nil
+}
+
+// virtualAccountLoanID returns the loan issued the virtual account the line was paid into: the
+// virtual account the bank reported with the line, or the statement account itself when the bank
+// sends a statement per virtual account. It is empty when neither belongs to a loan.
+func (s *bankReconciliationService) virtualAccountLoanID(ctx context.Context, line *models.BankStatementLine) (string, error) {
+	for _, accountNumber := range []string{line.VirtualAccount, line.AccountNumber} {
+		if accountNumber == "" {
+			continue
+		}
+		loanID, err := s.bankReconciliationRepo.GetLoanIDByVirtualAccount(ctx, accountNumber)
+		if err != nil {
+			return "", fmt.Errorf("failed to get loan by virtual account: %v", err)
+		}
+		if loanID != "" {
+			return loanID, nil
+		}
+	}
+	return "", nil
+}
+
+// referencedLoanIDs returns the distinct loan IDs found in the text. Whitespace is ignored since
+// statement descriptions are wrapped at a fixed width.
+func referencedLoanIDs(text string) []string {
+	compact := strings.Join(strings.Fields(text), "")
+	loanIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, match := range loanIDPattern.FindAllStringSubmatch(compact, -1) {
+		loanID := strings.ToLower(fmt.Sprintf("loan_%s-%s-%s-%s-%s", match[1], match[2], match[3], match[4], match[5]))
+		if !seen[loanID] {
+			seen[loanID] = true
+			loanIDs = append(loanIDs, loanID)
+		}
+	}
+	return loanIDs
+}
+
+func toLineResponse(line *models.BankStatementLine) models.BankStatementLineResponse {
+	return models.BankStatementLineResponse{
+		ID:             line.ID,
+		StatementID:    line.BankStatementID,
+		ValueDate:      line.ValueDate,
+		Amount:         line.Amount,
+		Currency:       line.Currency,
+		VirtualAccount: line.VirtualAccount,
+		Reference:      line.Reference,
+		Description:    line.Description,
+		Status:         line.Status,
+		MatchMethod:    line.MatchMethod,
+		LoanID:         line.LoanID,
+		Reason:         line.Reason,
+		MatchedAt:      line.MatchedAt,
+		MatchedBy:      line.MatchedBy,
+	}
+}
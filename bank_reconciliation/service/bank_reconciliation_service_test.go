@@ -0,0 +1,317 @@
+package service
+
+import (
+	mocks "billing-engine/bank_reconciliation/_mock"
+	"billing-engine/models"
+	repaymentMocks "billing-engine/repayment/_mock"
+	"billing-engine/utils/pagination"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	matchedLoanID  = "loan_5f1c2a3b-6d7e-4f80-9a1b-2c3d4e5f6a7b"
+	rejectedLoanID = "loan_0b9e8d7c-6a5f-4e3d-8c2b-1a0f9e8d7c6b"
+)
+
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestReferencedLoanIDs(t *testing.T) {
+	assert.Equal(t, []string{matchedLoanID}, referencedLoanIDs("PEMBAYARAN LOAN_5F1C2A3B6D7E4F809A1B2C3D4E5F6A7B / loan_5f1c2a3b-6d7e-4f80-9a1b-2c3d4e5f6a7b"))
+	assert.Equal(t, []string{matchedLoanID}, referencedLoanIDs("loan_5f1c2a3b-6d7e-4f80- 9a1b-2c3d4e5f6a7b"))
+	assert.Len(t, referencedLoanIDs(matchedLoanID+" "+rejectedLoanID), 2)
+	assert.Empty(t, referencedLoanIDs("TRF DARI BUDI"))
+}
+
+func TestBankReconciliationService_ImportStatement(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	content := "value_date,amount,reference,description\n" +
+		"2026-01-04,561100.00,INV-1,PEMBAYARAN " + matchedLoanID + "\n" +
+		"2026-01-04,-6500,,BIAYA ADMIN\n" +
+		"2026-01-04,110000.00,,TRF DARI BUDI\n" +
+		"2026-01-04,220000.00,," + rejectedLoanID + "\n" +
+		"2026-01-03,99000.00,,IMPORTED BEFORE\n"
+
+	// Mock repository calls
+	mockRepo.On("GetExistingLineKeys", ctx, mock.AnythingOfType("[]string")).Return(func(ctx context.Context, lineKeys []string) ([]string, error) {
+		return lineKeys[3:], nil // the last credit was imported before
+	})
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetLoanIDByVirtualAccount", ctx, "1234567890").Return("", nil)
+	mockRepo.On("CreateStatement", ctx, mock.AnythingOfType("*models.BankStatement")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.BankStatement).ID = 5
+	}).Return(nil)
+	mockRepo.On("CreateLines", ctx, mock.AnythingOfType("[]*models.BankStatementLine")).Run(func(args mock.Arguments) {
+		for i, line := range args.Get(1).([]*models.BankStatementLine) {
+			line.ID = uint(i + 1)
+		}
+	}).Return(nil)
+	mockRepo.On("MatchLine", ctx, mock.MatchedBy(func(line *models.BankStatementLine) bool {
+		return line.ID == 1 && line.LoanID == matchedLoanID && line.Status == models.StatementLineStatusMatched && line.MatchMethod == models.StatementMatchReference
+	})).Return(true, nil)
+	mockRepo.On("MatchLine", ctx, mock.MatchedBy(func(line *models.BankStatementLine) bool {
+		return line.ID == 3 && line.LoanID == rejectedLoanID
+	})).Return(true, nil)
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: matchedLoanID, PaymentAmount: 561100.00, Currency: "IDR"}).Return(&models.RepaymentResponse{}, nil)
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: rejectedLoanID, PaymentAmount: 220000.00, Currency: "IDR"}).Return(nil, errors.New("payment amount 220000.00 does not match required amount 110000.00"))
+	mockRepo.On("UpdateLineReason", ctx, uint(2), "no loan reference found").Return(nil)
+	mockRepo.On("UpdateLineReason", ctx, uint(3), "repayment of "+rejectedLoanID+" rejected: payment amount 220000.00 does not match required amount 110000.00").Return(nil)
+	mockRepo.On("UpdateStatement", ctx, mock.MatchedBy(func(statement *models.BankStatement) bool {
+		return statement.TotalLines == 5 && statement.CreditLines == 4 && statement.DuplicateLines == 1 &&
+			statement.MatchedLines == 1 && statement.UnmatchedLines == 2
+	})).Return(nil)
+
+	// Execute
+	result, err := service.ImportStatement(ctx, &models.BankStatementImportRequest{
+		Format:        "csv",
+		AccountNumber: "1234567890",
+		FileName:      "statement-20260104.csv",
+		Content:       []byte(content),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), result.StatementID)
+	assert.Equal(t, models.StatementFormatCSV, result.Format)
+	assert.Equal(t, "1234567890", result.AccountNumber)
+	assert.Equal(t, 1, result.MatchedLines)
+	assert.Equal(t, 2, result.UnmatchedLines)
+	assert.Equal(t, 1, result.DuplicateLines)
+	assert.Len(t, result.Lines, 3)
+
+	assert.Equal(t, models.StatementLineStatusMatched, result.Lines[0].Status)
+	assert.Equal(t, matchedLoanID, result.Lines[0].LoanID)
+	assert.Equal(t, uint(5), result.Lines[0].StatementID)
+	assert.NotNil(t, result.Lines[0].MatchedAt)
+
+	assert.Equal(t, models.StatementLineStatusUnmatched, result.Lines[1].Status)
+	assert.Equal(t, "no loan reference found", result.Lines[1].Reason)
+
+	// A rejected repayment leaves the line unmatched and without a loan
+	assert.Equal(t, models.StatementLineStatusUnmatched, result.Lines[2].Status)
+	assert.Empty(t, result.Lines[2].LoanID)
+	assert.Contains(t, result.Lines[2].Reason, "rejected")
+}
+
+func TestBankReconciliationService_ImportStatement_MatchesVirtualAccount(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	// The virtual account wins over a loan referenced in the description
+	content := "value_date,amount,virtual_account,reference,description\n" +
+		"2026-01-04,110000.00,8808123456789012,,PEMBAYARAN " + rejectedLoanID + "\n"
+
+	// Mock repository calls
+	mockRepo.On("GetExistingLineKeys", ctx, mock.AnythingOfType("[]string")).Return([]string{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("CreateStatement", ctx, mock.AnythingOfType("*models.BankStatement")).Return(nil)
+	mockRepo.On("CreateLines", ctx, mock.AnythingOfType("[]*models.BankStatementLine")).Return(nil)
+	mockRepo.On("GetLoanIDByVirtualAccount", ctx, "8808123456789012").Return(matchedLoanID, nil)
+	mockRepo.On("MatchLine", ctx, mock.MatchedBy(func(line *models.BankStatementLine) bool {
+		return line.LoanID == matchedLoanID && line.VirtualAccount == "8808123456789012" && line.MatchMethod == models.StatementMatchVirtualAccount
+	})).Return(true, nil)
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: matchedLoanID, PaymentAmount: 110000.00, Currency: "IDR"}).Return(&models.RepaymentResponse{}, nil)
+	mockRepo.On("UpdateStatement", ctx, mock.MatchedBy(func(statement *models.BankStatement) bool {
+		return statement.MatchedLines == 1 && statement.UnmatchedLines == 0
+	})).Return(nil)
+
+	// Execute
+	result, err := service.ImportStatement(ctx, &models.BankStatementImportRequest{Format: "CSV", Content: []byte(content)})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Lines, 1)
+	assert.Equal(t, models.StatementLineStatusMatched, result.Lines[0].Status)
+	assert.Equal(t, models.StatementMatchVirtualAccount, result.Lines[0].MatchMethod)
+	assert.Equal(t, matchedLoanID, result.Lines[0].LoanID)
+}
+
+func TestBankReconciliationService_ImportStatement_ParseError(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+
+	// Execute
+	result, err := service.ImportStatement(context.Background(), &models.BankStatementImportRequest{
+		Format:  "mt940",
+		Content: []byte("not a statement"),
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to parse statement")
+}
+
+func TestBankReconciliationService_ImportStatement_SameKeyForIdenticalLines(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	content := "value_date,amount,description\n2026-01-04,50000,TOP UP\n2026-01-04,50000,TOP UP\n"
+
+	var firstKeys []string
+	mockRepo.On("GetExistingLineKeys", ctx, mock.AnythingOfType("[]string")).Run(func(args mock.Arguments) {
+		firstKeys = args.Get(1).([]string)
+	}).Return([]string{}, nil).Once()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("CreateStatement", ctx, mock.AnythingOfType("*models.BankStatement")).Return(nil)
+	mockRepo.On("CreateLines", ctx, mock.AnythingOfType("[]*models.BankStatementLine")).Return(nil)
+	mockRepo.On("UpdateLineReason", ctx, uint(0), "no loan reference found").Return(nil)
+	mockRepo.On("UpdateStatement", ctx, mock.AnythingOfType("*models.BankStatement")).Return(nil)
+
+	// Execute
+	_, err := service.ImportStatement(ctx, &models.BankStatementImportRequest{Format: "CSV", Content: []byte(content)})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, firstKeys, 2)
+	assert.NotEqual(t, firstKeys[0], firstKeys[1])
+
+	// Importing the file again yields the same keys
+	mockRepo.On("GetExistingLineKeys", ctx, firstKeys).Return(firstKeys, nil).Once()
+	result, err := service.ImportStatement(ctx, &models.BankStatementImportRequest{Format: "CSV", Content: []byte(content)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.DuplicateLines)
+	assert.Empty(t, result.Lines)
+}
+
+func TestBankReconciliationService_ListUnmatchedLines(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	lines := []*models.BankStatementLine{
+		{ID: 11, Status: models.StatementLineStatusUnmatched, Amount: 110000.00, Reason: "no loan reference found"},
+		{ID: 12, Status: models.StatementLineStatusUnmatched, Amount: 99000.00},
+		{ID: 14, Status: models.StatementLineStatusUnmatched, Amount: 5000.00},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetUnmatchedLines", ctx, uint(10), 3).Return(lines, nil)
+
+	// Execute
+	result, err := service.ListUnmatchedLines(ctx, &models.BankStatementLineListRequest{
+		Limit:  2,
+		Cursor: pagination.EncodeCursor(pagination.Cursor{ID: 10}),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Lines, 2)
+	assert.Equal(t, "no loan reference found", result.Lines[0].Reason)
+	assert.Equal(t, pagination.EncodeCursor(pagination.Cursor{ID: 12}), result.NextCursor)
+}
+
+func TestBankReconciliationService_AssignLine(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	line := &models.BankStatementLine{
+		ID:        11,
+		ValueDate: time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local),
+		Amount:    110000.00,
+		Currency:  models.CurrencyIDR,
+		Status:    models.StatementLineStatusUnmatched,
+		Reason:    "no loan reference found",
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLineByID", ctx, uint(11)).Return(line, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("MatchLine", ctx, mock.MatchedBy(func(matched *models.BankStatementLine) bool {
+		return matched.ID == 11 && matched.Status == models.StatementLineStatusAssigned && matched.MatchMethod == models.StatementMatchManual && matched.MatchedBy == "api"
+	})).Return(true, nil)
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00, Currency: "IDR"}).Return(&models.RepaymentResponse{}, nil)
+
+	// Execute
+	result, err := service.AssignLine(ctx, 11, &models.BankStatementLineAssignRequest{LoanID: "loan_123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatementLineStatusAssigned, result.Status)
+	assert.Equal(t, "loan_123", result.LoanID)
+	assert.Empty(t, result.Reason)
+}
+
+func TestBankReconciliationService_AssignLine_RepaymentRejected(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	line := &models.BankStatementLine{ID: 11, Amount: 110000.00, Currency: models.CurrencyIDR, Status: models.StatementLineStatusUnmatched}
+
+	// Mock repository calls
+	mockRepo.On("GetLineByID", ctx, uint(11)).Return(line, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("MatchLine", ctx, mock.AnythingOfType("*models.BankStatementLine")).Return(true, nil)
+	mockRepayment.On("ProcessRepayment", ctx, mock.AnythingOfType("*models.RepaymentRequest")).Return(nil, errors.New("loan is cancelled"))
+
+	// Execute
+	result, err := service.AssignLine(ctx, 11, &models.BankStatementLineAssignRequest{LoanID: "loan_123"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "loan is cancelled", err.Error())
+	assert.Equal(t, models.StatementLineStatusUnmatched, line.Status)
+}
+
+func TestBankReconciliationService_AssignLine_AlreadyMatched(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetLineByID", ctx, uint(11)).Return(&models.BankStatementLine{ID: 11, Status: models.StatementLineStatusMatched}, nil)
+
+	// Execute
+	result, err := service.AssignLine(ctx, 11, &models.BankStatementLineAssignRequest{LoanID: "loan_123"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "bank statement line 11 is already matched", err.Error())
+}
+
+func TestBankReconciliationService_AssignLine_ClaimedConcurrently(t *testing.T) {
+	mockRepo := mocks.NewBankReconciliationMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	service := NewBankReconciliationService(mockRepo, mockRepayment)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetLineByID", ctx, uint(11)).Return(&models.BankStatementLine{ID: 11, Status: models.StatementLineStatusUnmatched}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("MatchLine", ctx, mock.AnythingOfType("*models.BankStatementLine")).Return(false, nil)
+
+	// Execute
+	result, err := service.AssignLine(ctx, 11, &models.BankStatementLineAssignRequest{LoanID: "loan_123"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "bank statement line 11 is already matched", err.Error())
+	mockRepayment.AssertNotCalled(t, "ProcessRepayment", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+// statementLine is one booking of a parsed bank statement
+type statementLine struct {
+	accountNumber  string
+	virtualAccount string
+	valueDate      time.Time
+	amount         decimal.Decimal
+	currency       string
+	credit         bool
+	reference      string
+	description    string
+}
+
+// parsedStatement is the content of a statement file in either format
+type parsedStatement struct {
+	accountNumber string
+	reference     string
+	lines         []statementLine
+}
+
+var (
+	mt940TagPattern = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+
+	// :61: value date YYMMDD, optional entry date MMDD, debit/credit mark, optional funds code,
+	// amount with a decimal comma, transaction type, customer reference and optional //bank reference
+	mt940LinePattern = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^/]*)(?://(.*))?$`)
+)
+
+// parseMT940 parses a SWIFT MT940 customer statement. Every :61: booking takes the account of the
+// preceding :25: tag, the currency of the preceding opening balance and the text of the :86: tag
+// following it. A file may hold several statements.
+func parseMT940(content []byte) (*parsedStatement, error) {
+	type tag struct{ name, value string }
+	tags := make([]*tag, 0)
+	for _, rawLine := range strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(rawLine, " \r")
+		if line == "" || strings.HasPrefix(line, "{") || strings.HasPrefix(line, "-") {
+			continue // SWIFT block headers and statement trailers
+		}
+		if match := mt940TagPattern.FindStringSubmatch(line); match != nil {
+			tags = append(tags, &tag{name: match[1], value: match[2]})
+			continue
+		}
+		if len(tags) == 0 {
+			return nil, fmt.Errorf("unexpected line %q before the first tag", line)
+		}
+		tags[len(tags)-1].value += "\n" + line
+	}
+
+	statement := &parsedStatement{}
+	accountNumber, currencyCode := "", ""
+	var current *statementLine
+	for _, t := range tags {
+		switch t.name {
+		case "20":
+			if statement.reference == "" {
+				statement.reference = strings.TrimSpace(t.value)
+			}
+		case "25":
+			accountNumber = strings.TrimSpace(t.value)
+			if statement.accountNumber == "" {
+				statement.accountNumber = accountNumber
+			}
+		case "60F", "60M":
+			if len(t.value) < 10 {
+				return nil, fmt.Errorf("invalid opening balance %q", t.value)
+			}
+			currencyCode = currency.Normalize(t.value[7:10])
+		case "61":
+			line, err := parseMT940Line(t.value, accountNumber, currencyCode)
+			if err != nil {
+				return nil, err
+			}
+			statement.lines = append(statement.lines, *line)
+			current = &statement.lines[len(statement.lines)-1]
+		case "86":
+			if current != nil {
+				current.description = strings.Join(strings.Fields(t.value), " ")
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("no MT940 tags found")
+	}
+	return statement, nil
+}
+
+func parseMT940Line(value, accountNumber, currencyCode string) (*statementLine, error) {
+	firstLine := strings.SplitN(value, "\n", 2)[0]
+	match := mt940LinePattern.FindStringSubmatch(firstLine)
+	if match == nil {
+		return nil, fmt.Errorf("invalid statement line %q", firstLine)
+	}
+	if currencyCode == "" {
+		return nil, fmt.Errorf("statement line %q has no opening balance before it", firstLine)
+	}
+
+	valueDate, err := time.ParseInLocation("060102", match[1], time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value date in statement line %q", firstLine)
+	}
+	amountText := strings.Replace(match[5], ",", ".", 1)
+	if strings.HasSuffix(amountText, ".") {
+		amountText += "0"
+	}
+	amount, err := decimal.NewFromString(amountText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount in statement line %q", firstLine)
+	}
+
+	// Without a customer reference the bank's own reference identifies the transfer
+	reference := strings.TrimSpace(match[7])
+	if reference == "" || reference == "NONREF" {
+		reference = strings.TrimSpace(match[8])
+	}
+
+	return &statementLine{
+		accountNumber: accountNumber,
+		valueDate:     valueDate,
+		amount:        amount,
+		currency:      currencyCode,
+		credit:        match[3] == "C",
+		reference:     reference,
+	}, nil
+}
+
+// csvDirections maps the debit/credit column values used by banks to whether the line is a credit
+var csvDirections = map[string]bool{
+	"C": true, "CR": true, "CREDIT": true, "K": true, "KREDIT": true,
+	"D": false, "DR": false, "DB": false, "DEBIT": false, "DEBET": false,
+}
+
+// parseCSV parses a bank CSV statement. The header names the columns, in any order: value_date
+// (YYYY-MM-DD or DD/MM/YYYY) and amount are required; direction, currency, virtual_account,
+// reference and description are optional. Without a direction column negative amounts are debits.
+func parseCSV(content []byte, accountNumber string) (*parsedStatement, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"value_date", "amount"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header has no %s column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	statement := &parsedStatement{accountNumber: accountNumber}
+	for lineNumber := 2; ; lineNumber++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		valueDate, err := parseCSVDate(field(record, "value_date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid value_date %q", lineNumber, field(record, "value_date"))
+		}
+		amount, err := decimal.NewFromString(strings.ReplaceAll(field(record, "amount"), ",", ""))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", lineNumber, field(record, "amount"))
+		}
+		credit := amount.IsPositive()
+		if direction := field(record, "direction"); direction != "" {
+			isCredit, ok := csvDirections[strings.ToUpper(direction)]
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid direction %q", lineNumber, direction)
+			}
+			credit = isCredit
+		}
+		statement.lines = append(statement.lines, statementLine{
+			accountNumber:  accountNumber,
+			virtualAccount: field(record, "virtual_account"),
+			valueDate:      valueDate,
+			amount:         amount.Abs(),
+			currency:       currency.Normalize(field(record, "currency")),
+			credit:         credit,
+			reference:      field(record, "reference"),
+			description:    field(record, "description"),
+		})
+	}
+	return statement, nil
+}
+
+func parseCSVDate(value string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	return time.ParseInLocation("02/01/2006", value, time.Local)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mt940Statement = `{1:F01BMRIIDJAXXXX0000000000}{2:O9400000000000BMRIIDJAXXXX00000000000000000000N}{4:
+:20:STMT20260104
+:25:1234567890
+:28C:00004/001
+:60F:C260103IDR150000000,00
+:61:2601040104C561100,00NTRFLOAN5F1C2A3B//BANKREF001
+:86:PEMBAYARAN loan_5f1c2a3b-6d7e-4f80-
+9a1b-2c3d4e5f6a7b CICILAN KE 3
+:61:2601040104D2500000,00NTRFNONREF//BANKREF002
+:86:TRANSFER KELUAR
+:61:260104C110000,NMSCNONREF//BANKREF003
+:86:TRF DARI BUDI
+:62F:C260104IDR148171100,00
+-}`
+
+func TestParseMT940(t *testing.T) {
+	statement, err := parseMT940([]byte(mt940Statement))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890", statement.accountNumber)
+	assert.Equal(t, "STMT20260104", statement.reference)
+	assert.Len(t, statement.lines, 3)
+
+	first := statement.lines[0]
+	assert.True(t, first.credit)
+	assert.Equal(t, time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local), first.valueDate)
+	assert.Equal(t, "561100.00", first.amount.StringFixed(2))
+	assert.Equal(t, "IDR", first.currency)
+	assert.Equal(t, "1234567890", first.accountNumber)
+	assert.Equal(t, "LOAN5F1C2A3B", first.reference)
+	assert.Equal(t, "PEMBAYARAN loan_5f1c2a3b-6d7e-4f80- 9a1b-2c3d4e5f6a7b CICILAN KE 3", first.description)
+
+	assert.False(t, statement.lines[1].credit)
+
+	// Without a customer reference the bank reference is kept
+	third := statement.lines[2]
+	assert.True(t, third.credit)
+	assert.Equal(t, "110000.00", third.amount.StringFixed(2))
+	assert.Equal(t, "BANKREF003", third.reference)
+	assert.Equal(t, "TRF DARI BUDI", third.description)
+}
+
+func TestParseMT940_InvalidLine(t *testing.T) {
+	_, err := parseMT940([]byte(":20:STMT\n:25:123\n:60F:C260103IDR0,00\n:61:26010C100\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid statement line")
+
+	_, err = parseMT940([]byte(":20:STMT\n:25:123\n:61:2601040104C561100,00NTRFNONREF\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no opening balance")
+
+	_, err = parseMT940([]byte("not a statement"))
+	assert.Error(t, err)
+}
+
+func TestParseCSV(t *testing.T) {
+	content := "\xef\xbb\xbfValue_Date,Description,Reference,Amount,Direction,Currency\n" +
+		"04/01/2026,\"TRF loan_5F1C2A3B6D7E4F809A1B2C3D4E5F6A7B\",INV-1,\"561,100.00\",CR,idr\n" +
+		"\n" +
+		"2026-01-04,BIAYA ADMIN,,6500,DB,\n"
+
+	statement, err := parseCSV([]byte(content), "1234567890")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890", statement.accountNumber)
+	assert.Len(t, statement.lines, 2)
+	assert.True(t, statement.lines[0].credit)
+	assert.Equal(t, time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local), statement.lines[0].valueDate)
+	assert.Equal(t, "561100.00", statement.lines[0].amount.StringFixed(2))
+	assert.Equal(t, "IDR", statement.lines[0].currency)
+	assert.Equal(t, "INV-1", statement.lines[0].reference)
+	assert.False(t, statement.lines[1].credit)
+	assert.Equal(t, "IDR", statement.lines[1].currency)
+}
+
+func TestParseCSV_SignedAmounts(t *testing.T) {
+	statement, err := parseCSV([]byte("value_date,amount,reference\n2026-01-04,-6500,ADM\n2026-01-04,110000,TRF\n"), "")
+
+	assert.NoError(t, err)
+	assert.False(t, statement.lines[0].credit)
+	assert.Equal(t, "6500.00", statement.lines[0].amount.StringFixed(2))
+	assert.True(t, statement.lines[1].credit)
+}
+
+func TestParseCSV_Invalid(t *testing.T) {
+	_, err := parseCSV([]byte("value_date,reference\n2026-01-04,TRF\n"), "")
+	assert.Error(t, err)
+	assert.Equal(t, "CSV header has no amount column", err.Error())
+
+	_, err = parseCSV([]byte("value_date,amount\n2026-13-04,100\n"), "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: invalid value_date")
+
+	_, err = parseCSV([]byte("value_date,amount,direction\n2026-01-04,100,X\n"), "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: invalid direction")
+}
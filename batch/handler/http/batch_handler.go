@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type BatchHandler struct {
+	batchService batch.BatchServiceInterface
+	middleware   middlewares.GoMiddlewareInterface
+}
+
+// NewBatchHandler creates a new end-of-day batch handler instance
+func NewBatchHandler(e *echo.Echo, batchService batch.BatchServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &BatchHandler{
+		batchService: batchService,
+		middleware:   middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/batch/runs", handler.RunEndOfDay)
+	v1.GET("/batch/runs", handler.ListRuns)
+	v1.GET("/batch/runs/:run_id", handler.GetRun)
+}
+
+func (h *BatchHandler) RunEndOfDay(c echo.Context) error {
+	var req models.BatchRunRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Without a business date the run closes yesterday, like the daily job
+	businessDate := req.BusinessDate
+	if businessDate.IsZero() {
+		businessDate = time.Now().AddDate(0, 0, -1)
+	}
+
+	response, err := h.batchService.RunEndOfDay(c.Request().Context(), businessDate, "api")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.BatchRunSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *BatchHandler) ListRuns(c echo.Context) error {
+	limit := 0
+	if c.QueryParam("limit") != "" {
+		value, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil || value < 1 {
+			return c.JSON(http.StatusBadRequest, global.BadResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid limit",
+			})
+		}
+		limit = value
+	}
+
+	response, err := h.batchService.ListRuns(c.Request().Context(), limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.BatchRunListSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *BatchHandler) GetRun(c echo.Context) error {
+	runID, err := strconv.ParseUint(c.Param("run_id"), 10, 64)
+	if err != nil || runID == 0 {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid run ID",
+		})
+	}
+
+	response, err := h.batchService.GetRun(c.Request().Context(), uint(runID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.BatchRunSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mocks "billing-engine/batch/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestBatchHandler_RunEndOfDay_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBatchServiceInterface(t)
+	handler := &BatchHandler{batchService: mockService, middleware: new(MockMiddleware)}
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+	expectedResponse := &models.BatchRunResponse{
+		ID:           7,
+		BusinessDate: businessDate,
+		Status:       models.BatchStatusCompleted,
+		TriggeredBy:  "api",
+		Steps: []models.BatchStepRunResponse{
+			{StepName: models.BatchStepCollectibility, Sequence: 1, Status: models.BatchStatusCompleted, ProcessedLoans: 120},
+			{StepName: models.BatchStepInterestAccrual, Sequence: 2, Status: models.BatchStatusCompleted, ProcessedLoans: 118},
+		},
+	}
+
+	mockService.On("RunEndOfDay", mock.Anything, businessDate, "api").Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/batch/runs", strings.NewReader(`{"business_date":"2026-01-04T00:00:00Z"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.RunEndOfDay(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.BatchRunSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, models.BatchStatusCompleted, response.Data.Status)
+	assert.Len(t, response.Data.Steps, 2)
+}
+
+func TestBatchHandler_RunEndOfDay_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBatchServiceInterface(t)
+	handler := &BatchHandler{batchService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("RunEndOfDay", mock.Anything, mock.AnythingOfType("time.Time"), "api").Return(nil, errors.New("a batch run is already in progress"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/batch/runs", strings.NewReader(`{}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.RunEndOfDay(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestBatchHandler_ListRuns_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBatchServiceInterface(t)
+	handler := &BatchHandler{batchService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ListRuns", mock.Anything, 5).Return(&models.BatchRunListResponse{
+		Runs: []models.BatchRunResponse{{ID: 7, Status: models.BatchStatusRunning}},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/batch/runs?limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ListRuns(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.BatchRunListSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, models.BatchStatusRunning, response.Data.Runs[0].Status)
+}
+
+func TestBatchHandler_ListRuns_InvalidLimit(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBatchServiceInterface(t)
+	handler := &BatchHandler{batchService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/batch/runs?limit=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ListRuns(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestBatchHandler_GetRun_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBatchServiceInterface(t)
+	handler := &BatchHandler{batchService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("GetRun", mock.Anything, uint(7)).Return(&models.BatchRunResponse{ID: 7, Status: models.BatchStatusFailed, ErrorMessage: "step INTEREST_ACCRUAL failed: database error"}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/batch/runs/7", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("run_id")
+	c.SetParamValues("7")
+
+	// Execute
+	err := handler.GetRun(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.BatchRunSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, models.BatchStatusFailed, response.Data.Status)
+}
+
+func TestBatchHandler_GetRun_InvalidID(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewBatchServiceInterface(t)
+	handler := &BatchHandler{batchService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/batch/runs/abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("run_id")
+	c.SetParamValues("abc")
+
+	// Execute
+	err := handler.GetRun(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
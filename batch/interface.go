@@ -0,0 +1,45 @@
+package batch
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// BatchMySQLRepositoryInterface defines the interface for end-of-day batch repository
+type BatchMySQLRepositoryInterface interface {
+	GetRunByID(ctx context.Context, runID uint) (*models.BatchRun, error)
+	GetRunByBusinessDate(ctx context.Context, businessDate time.Time) (*models.BatchRun, error)
+	GetRuns(ctx context.Context, limit int) ([]*models.BatchRun, error)
+	GetUnfinishedRuns(ctx context.Context) ([]*models.BatchRun, error)
+	CreateRun(ctx context.Context, run *models.BatchRun) error
+	UpdateRun(ctx context.Context, run *models.BatchRun) error
+	CreateStepRun(ctx context.Context, stepRun *models.BatchStepRun) error
+	UpdateStepRun(ctx context.Context, stepRun *models.BatchStepRun) error
+	WithRunLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
+
+// BatchStepInterface defines one step of the end-of-day run. The step lists the loans it works on
+// in ID order and processes them one by one. A resumed run repeats the loans processed after the
+// last checkpoint, so processing a loan twice for the same business date must be harmless.
+type BatchStepInterface interface {
+	Name() string
+	GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error
+}
+
+// BatchRunHookInterface defines work done once the run of a business date completes, whether the
+// run was started on schedule or through the API, or resumed after failing. A run completes once,
+// so the hook runs once per business date unless it fails.
+type BatchRunHookInterface interface {
+	Name() string
+	RunCompleted(ctx context.Context, businessDate time.Time) error
+}
+
+// BatchServiceInterface defines the interface for end-of-day batch service
+type BatchServiceInterface interface {
+	RunEndOfDay(ctx context.Context, businessDate time.Time, triggeredBy string) (*models.BatchRunResponse, error)
+	ResumeUnfinishedRuns(ctx context.Context) error
+	GetRun(ctx context.Context, runID uint) (*models.BatchRunResponse, error)
+	ListRuns(ctx context.Context, limit int) (*models.BatchRunListResponse, error)
+}
@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type batchMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewBatchMySQLRepository creates a new end-of-day batch repository instance
+func NewBatchMySQLRepository(db *gorm.DB) batch.BatchMySQLRepositoryInterface {
+	return &batchMySQLRepository{db: db}
+}
+
+// withSteps loads the steps of the runs in the order they run
+func withSteps(db *gorm.DB) *gorm.DB {
+	return db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sequence ASC")
+	})
+}
+
+func (r *batchMySQLRepository) GetRunByID(ctx context.Context, runID uint) (*models.BatchRun, error) {
+	var run models.BatchRun
+	err := withSteps(transaction.DB(ctx, r.db)).Where("id = ?", runID).First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *batchMySQLRepository) GetRunByBusinessDate(ctx context.Context, businessDate time.Time) (*models.BatchRun, error) {
+	var run models.BatchRun
+	err := withSteps(transaction.DB(ctx, r.db)).Where("business_date = ?", businessDate.Format("2006-01-02")).First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetRuns returns the latest runs, most recent business date first
+func (r *batchMySQLRepository) GetRuns(ctx context.Context, limit int) ([]*models.BatchRun, error) {
+	var runs []*models.BatchRun
+	err := withSteps(transaction.DB(ctx, r.db)).Order("business_date DESC").Limit(limit).Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// GetUnfinishedRuns returns the runs that crashed or failed, oldest business date first
+func (r *batchMySQLRepository) GetUnfinishedRuns(ctx context.Context) ([]*models.BatchRun, error) {
+	var runs []*models.BatchRun
+	err := withSteps(transaction.DB(ctx, r.db)).
+		Where("status IN ?", []string{models.BatchStatusRunning, models.BatchStatusFailed}).
+		Order("business_date ASC").
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// CreateRun stores the run together with its steps
+func (r *batchMySQLRepository) CreateRun(ctx context.Context, run *models.BatchRun) error {
+	return transaction.DB(ctx, r.db).Create(run).Error
+}
+
+func (r *batchMySQLRepository) UpdateRun(ctx context.Context, run *models.BatchRun) error {
+	return transaction.DB(ctx, r.db).Omit("Steps").Save(run).Error
+}
+
+func (r *batchMySQLRepository) CreateStepRun(ctx context.Context, stepRun *models.BatchStepRun) error {
+	return transaction.DB(ctx, r.db).Create(stepRun).Error
+}
+
+func (r *batchMySQLRepository) UpdateStepRun(ctx context.Context, stepRun *models.BatchStepRun) error {
+	return transaction.DB(ctx, r.db).Save(stepRun).Error
+}
+
+// WithRunLock runs fn holding the database's batch run lock, a MySQL named lock taken on a
+// connection of its own. The lock is released when fn returns, or by MySQL when the connection
+// drops with a crashed process, so a run left RUNNING can be resumed by the next instance. It
+// reports false without calling fn when another session holds the lock.
+func (r *batchMySQLRepository) WithRunLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	err := r.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		var locked sql.NullInt64
+		if err := conn.Raw("SELECT GET_LOCK(CONCAT(DATABASE(), '.batch_run'), 0)").Row().Scan(&locked); err != nil {
+			return err
+		}
+		if !locked.Valid || locked.Int64 != 1 {
+			return nil
+		}
+		acquired = true
+		// Released even when ctx is cancelled, so the pooled connection does not keep the lock
+		defer conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT RELEASE_LOCK(CONCAT(DATABASE(), '.batch_run'))")
+		return fn(ctx)
+	})
+	return acquired, err
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/models"
+	"billing-engine/utils/pagination"
+)
+
+type batchService struct {
+	batchRepo batch.BatchMySQLRepositoryInterface
+	steps     []batch.BatchStepInterface
+	hooks     []batch.BatchRunHookInterface
+	workers   int // chunks processed in parallel
+	chunkSize int // loans per chunk
+}
+
+// NewBatchService creates a new end-of-day batch service instance running the steps in order and
+// the hooks once a run completes
+func NewBatchService(batchRepo batch.BatchMySQLRepositoryInterface, steps []batch.BatchStepInterface, hooks []batch.BatchRunHookInterface, workers, chunkSize int) batch.BatchServiceInterface {
+	return &batchService{
+		batchRepo: batchRepo,
+		steps:     steps,
+		hooks:     hooks,
+		workers:   workers,
+		chunkSize: chunkSize,
+	}
+}
+
+// RunEndOfDay runs the end-of-day steps for the business date. A run of the date that crashed or
+// failed is resumed: completed steps are skipped and the interrupted step continues after its
+// last checkpoint. A failing step stops the run, which is returned with status FAILED. A step
+// that leaves failed loans behind is marked FAILED without stopping the steps after it, and the
+// run ends FAILED so it is resumed. Once the run completes, the hooks run in order; a failing hook
+// is logged and leaves the run completed. Runs hold the database's batch run lock, so only one
+// executes at a time across every instance of the service.
+func (s *batchService) RunEndOfDay(ctx context.Context, businessDate time.Time, triggeredBy string) (*models.BatchRunResponse, error) {
+	var response *models.BatchRunResponse
+	acquired, err := s.batchRepo.WithRunLock(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = s.runEndOfDay(ctx, businessDate, triggeredBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, fmt.Errorf("a batch run is already in progress")
+	}
+	return response, nil
+}
+
+// runEndOfDay runs or resumes the run of the business date while holding the batch run lock
+func (s *batchService) runEndOfDay(ctx context.Context, businessDate time.Time, triggeredBy string) (*models.BatchRunResponse, error) {
+	businessDate = startOfDay(businessDate)
+	run, err := s.batchRepo.GetRunByBusinessDate(ctx, businessDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch run: %v", err)
+	}
+	if run != nil && run.Status == models.BatchStatusCompleted {
+		return nil, fmt.Errorf("batch run for %s is already completed", businessDate.Format("2006-01-02"))
+	}
+
+	if run == nil {
+		run = &models.BatchRun{
+			BusinessDate: businessDate,
+			Status:       models.BatchStatusRunning,
+			TriggeredBy:  triggeredBy,
+			StartedAt:    time.Now(),
+		}
+		for i, step := range s.steps {
+			run.Steps = append(run.Steps, &models.BatchStepRun{
+				StepName: step.Name(),
+				Sequence: i + 1,
+				Status:   models.BatchStatusPending,
+			})
+		}
+		if err := s.batchRepo.CreateRun(ctx, run); err != nil {
+			return nil, fmt.Errorf("failed to create batch run: %v", err)
+		}
+	} else {
+		log.Printf("resuming batch run %d for %s", run.ID, businessDate.Format("2006-01-02"))
+		run.Status = models.BatchStatusRunning
+		run.ErrorMessage = ""
+		run.FinishedAt = nil
+		if err := s.batchRepo.UpdateRun(ctx, run); err != nil {
+			return nil, fmt.Errorf("failed to update batch run: %v", err)
+		}
+	}
+
+	var failedSteps []string
+	for i, step := range s.steps {
+		stepRun, err := s.stepRunOf(ctx, run, step, i+1)
+		if err != nil {
+			return nil, err
+		}
+		if stepRun.Status == models.BatchStatusCompleted {
+			continue
+		}
+
+		if err := s.runStep(ctx, run.BusinessDate, step, stepRun); err != nil {
+			finishedAt := time.Now()
+			stepRun.Status = models.BatchStatusFailed
+			stepRun.ErrorMessage = err.Error()
+			stepRun.FinishedAt = &finishedAt
+			if updateErr := s.batchRepo.UpdateStepRun(ctx, stepRun); updateErr != nil {
+				log.Printf("failed to record failure of batch step %s: %v", step.Name(), updateErr)
+			}
+
+			run.Status = models.BatchStatusFailed
+			run.ErrorMessage = fmt.Sprintf("step %s failed: %v", step.Name(), err)
+			run.FinishedAt = &finishedAt
+			if err := s.batchRepo.UpdateRun(ctx, run); err != nil {
+				return nil, fmt.Errorf("failed to update batch run: %v", err)
+			}
+			return toRunResponse(run), nil
+		}
+		if stepRun.Status == models.BatchStatusFailed {
+			failedSteps = append(failedSteps, fmt.Sprintf("step %s failed: %s", step.Name(), stepRun.ErrorMessage))
+		}
+	}
+
+	finishedAt := time.Now()
+	run.Status = models.BatchStatusCompleted
+	if len(failedSteps) > 0 {
+		run.Status = models.BatchStatusFailed
+		run.ErrorMessage = strings.Join(failedSteps, "; ")
+	}
+	run.FinishedAt = &finishedAt
+	if err := s.batchRepo.UpdateRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to update batch run: %v", err)
+	}
+
+	if run.Status == models.BatchStatusCompleted {
+		for _, hook := range s.hooks {
+			if err := hook.RunCompleted(ctx, run.BusinessDate); err != nil {
+				log.Printf("batch hook %s failed for %s: %v", hook.Name(), run.BusinessDate.Format("2006-01-02"), err)
+			}
+		}
+	}
+
+	return toRunResponse(run), nil
+}
+
+// ResumeUnfinishedRuns resumes every run that crashed or failed, oldest business date first
+func (s *batchService) ResumeUnfinishedRuns(ctx context.Context) error {
+	runs, err := s.batchRepo.GetUnfinishedRuns(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get unfinished batch runs: %v", err)
+	}
+
+	for _, run := range runs {
+		response, err := s.RunEndOfDay(ctx, run.BusinessDate, run.TriggeredBy)
+		if err != nil {
+			return err
+		}
+		if response.Status != models.BatchStatusCompleted {
+			log.Printf("batch run %d for %s did not complete: %s", response.ID, run.BusinessDate.Format("2006-01-02"), response.ErrorMessage)
+		}
+	}
+
+	return nil
+}
+
+func (s *batchService) GetRun(ctx context.Context, runID uint) (*models.BatchRunResponse, error) {
+	run, err := s.batchRepo.GetRunByID(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch run: %v", err)
+	}
+	if run == nil {
+		return nil, fmt.Errorf("batch run not found")
+	}
+	return toRunResponse(run), nil
+}
+
+func (s *batchService) ListRuns(ctx context.Context, limit int) (*models.BatchRunListResponse, error) {
+	runs, err := s.batchRepo.GetRuns(ctx, pagination.Limit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch runs: %v", err)
+	}
+
+	response := &models.BatchRunListResponse{Runs: make([]models.BatchRunResponse, 0, len(runs))}
+	for _, run := range runs {
+		response.Runs = append(response.Runs, *toRunResponse(run))
+	}
+	return response, nil
+}
+
+// stepRunOf returns the progress row of the step in the run, adding one for a step registered
+// after the run was created
+func (s *batchService) stepRunOf(ctx context.Context, run *models.BatchRun, step batch.BatchStepInterface, sequence int) (*models.BatchStepRun, error) {
+	for _, stepRun := range run.Steps {
+		if stepRun.StepName == step.Name() {
+			return stepRun, nil
+		}
+	}
+
+	stepRun := &models.BatchStepRun{
+		BatchRunID: run.ID,
+		StepName:   step.Name(),
+		Sequence:   sequence,
+		Status:     models.BatchStatusPending,
+	}
+	if err := s.batchRepo.CreateStepRun(ctx, stepRun); err != nil {
+		return nil, fmt.Errorf("failed to create batch step: %v", err)
+	}
+	run.Steps = append(run.Steps, stepRun)
+	return stepRun, nil
+}
+
+// runStep processes the step's loans page by page from its checkpoint. Each page is split into
+// chunks processed in parallel, and the checkpoint moves to the last loan of the page once every
+// chunk is done. A failing loan is counted and logged without stopping the step; once all loans
+// are processed, a step with failed loans is marked FAILED and its checkpoint cleared, so the
+// resumed step goes over every loan again and retries the failed ones.
+func (s *batchService) runStep(ctx context.Context, businessDate time.Time, step batch.BatchStepInterface, stepRun *models.BatchStepRun) error {
+	if stepRun.StartedAt == nil {
+		startedAt := time.Now()
+		stepRun.StartedAt = &startedAt
+	}
+	// A step starting over counts its loans afresh
+	if stepRun.CheckpointLoanID == 0 {
+		stepRun.ProcessedLoans = 0
+		stepRun.FailedLoans = 0
+	}
+	stepRun.Status = models.BatchStatusRunning
+	stepRun.ErrorMessage = ""
+	stepRun.FinishedAt = nil
+	if err := s.batchRepo.UpdateStepRun(ctx, stepRun); err != nil {
+		return fmt.Errorf("failed to update batch step: %v", err)
+	}
+
+	pageSize := s.workers * s.chunkSize
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		loanSummaries, err := step.GetLoans(ctx, stepRun.CheckpointLoanID, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(loanSummaries) == 0 {
+			break
+		}
+
+		failedLoans := s.processPage(ctx, businessDate, step, loanSummaries)
+
+		stepRun.CheckpointLoanID = loanSummaries[len(loanSummaries)-1].ID
+		stepRun.ProcessedLoans += len(loanSummaries)
+		stepRun.FailedLoans += failedLoans
+		if err := s.batchRepo.UpdateStepRun(ctx, stepRun); err != nil {
+			return fmt.Errorf("failed to save checkpoint: %v", err)
+		}
+	}
+
+	finishedAt := time.Now()
+	stepRun.Status = models.BatchStatusCompleted
+	if stepRun.FailedLoans > 0 {
+		stepRun.Status = models.BatchStatusFailed
+		stepRun.ErrorMessage = fmt.Sprintf("%d loans failed", stepRun.FailedLoans)
+		stepRun.CheckpointLoanID = 0
+	}
+	stepRun.FinishedAt = &finishedAt
+	if err := s.batchRepo.UpdateStepRun(ctx, stepRun); err != nil {
+		return fmt.Errorf("failed to update batch step: %v", err)
+	}
+	return nil
+}
+
+// processPage processes the loans in chunks of chunkSize, one goroutine per chunk, and returns
+// the number of loans that failed
+func (s *batchService) processPage(ctx context.Context, businessDate time.Time, step batch.BatchStepInterface, loanSummaries []*models.LoanSummary) int {
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		failedLoans int
+	)
+
+	for start := 0; start < len(loanSummaries); start += s.chunkSize {
+		end := start + s.chunkSize
+		if end > len(loanSummaries) {
+			end = len(loanSummaries)
+		}
+
+		wg.Add(1)
+		go func(chunk []*models.LoanSummary) {
+			defer wg.Done()
+			for _, loanSummary := range chunk {
+				if err := step.ProcessLoan(ctx, loanSummary, businessDate); err != nil {
+					log.Printf("batch step %s failed for loan %s: %v", step.Name(), loanSummary.LoanID, err)
+					mu.Lock()
+					failedLoans++
+					mu.Unlock()
+				}
+			}
+		}(loanSummaries[start:end])
+	}
+	wg.Wait()
+
+	return failedLoans
+}
+
+func toRunResponse(run *models.BatchRun) *models.BatchRunResponse {
+	response := &models.BatchRunResponse{
+		ID:           run.ID,
+		BusinessDate: run.BusinessDate,
+		Status:       run.Status,
+		TriggeredBy:  run.TriggeredBy,
+		ErrorMessage: run.ErrorMessage,
+		StartedAt:    run.StartedAt,
+		FinishedAt:   run.FinishedAt,
+		Steps:        make([]models.BatchStepRunResponse, 0, len(run.Steps)),
+	}
+	for _, stepRun := range run.Steps {
+		response.Steps = append(response.Steps, models.BatchStepRunResponse{
+			StepName:         stepRun.StepName,
+			Sequence:         stepRun.Sequence,
+			Status:           stepRun.Status,
+			CheckpointLoanID: stepRun.CheckpointLoanID,
+			ProcessedLoans:   stepRun.ProcessedLoans,
+			FailedLoans:      stepRun.FailedLoans,
+			ErrorMessage:     stepRun.ErrorMessage,
+			StartedAt:        stepRun.StartedAt,
+			FinishedAt:       stepRun.FinishedAt,
+		})
+	}
+	return response
+}
+
+// startOfDay returns midnight local time of the day t falls on
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
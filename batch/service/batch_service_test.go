@@ -0,0 +1,376 @@
+package service
+
+import (
+	"billing-engine/batch"
+	mocks "billing-engine/batch/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func loans(ids ...uint) []*models.LoanSummary {
+	loanSummaries := make([]*models.LoanSummary, 0, len(ids))
+	for _, id := range ids {
+		loanSummaries = append(loanSummaries, &models.LoanSummary{ID: id, LoanID: fmt.Sprintf("loan_%d", id)})
+	}
+	return loanSummaries
+}
+
+func newStep(t *testing.T, name string) *mocks.BatchStepInterface {
+	step := mocks.NewBatchStepInterface(t)
+	step.On("Name").Return(name).Maybe()
+	return step
+}
+
+func TestBatchService_RunEndOfDay_NewRun(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	collectibilityStep := newStep(t, models.BatchStepCollectibility)
+	accrualStep := newStep(t, models.BatchStepInterestAccrual)
+	service := NewBatchService(mockRepo, []batch.BatchStepInterface{collectibilityStep, accrualStep}, nil, 2, 2)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(nil, nil)
+	mockRepo.On("CreateRun", ctx, mock.MatchedBy(func(run *models.BatchRun) bool {
+		return run.Status == models.BatchStatusRunning && run.TriggeredBy == "system" && len(run.Steps) == 2 &&
+			run.Steps[0].StepName == models.BatchStepCollectibility && run.Steps[0].Sequence == 1 &&
+			run.Steps[1].StepName == models.BatchStepInterestAccrual && run.Steps[1].Status == models.BatchStatusPending
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.BatchRun).ID = 7
+	}).Return(nil)
+	mockRepo.On("UpdateStepRun", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateRun", ctx, mock.Anything).Return(nil)
+
+	// The first page holds four loans, two chunks of two
+	collectibilityStep.On("GetLoans", ctx, uint(0), 4).Return(loans(1, 2, 3, 4), nil)
+	collectibilityStep.On("GetLoans", ctx, uint(4), 4).Return(loans(5), nil)
+	collectibilityStep.On("GetLoans", ctx, uint(5), 4).Return(loans(), nil)
+	collectibilityStep.On("ProcessLoan", ctx, mock.Anything, businessDate).Return(nil).Times(5)
+	accrualStep.On("GetLoans", ctx, uint(0), 4).Return(loans(2), nil)
+	accrualStep.On("GetLoans", ctx, uint(2), 4).Return(loans(), nil)
+	accrualStep.On("ProcessLoan", ctx, mock.Anything, businessDate).Return(nil).Once()
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, time.Date(2026, 1, 4, 23, 0, 0, 0, time.Local), "system")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, models.BatchStatusCompleted, response.Status)
+	assert.NotNil(t, response.FinishedAt)
+	assert.Equal(t, models.BatchStatusCompleted, response.Steps[0].Status)
+	assert.Equal(t, 5, response.Steps[0].ProcessedLoans)
+	assert.Equal(t, uint(5), response.Steps[0].CheckpointLoanID)
+	assert.Equal(t, 1, response.Steps[1].ProcessedLoans)
+}
+
+func TestBatchService_RunEndOfDay_ResumesFromCheckpoint(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	collectibilityStep := newStep(t, models.BatchStepCollectibility)
+	accrualStep := newStep(t, models.BatchStepInterestAccrual)
+	service := NewBatchService(mockRepo, []batch.BatchStepInterface{collectibilityStep, accrualStep}, nil, 1, 10)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+	startedAt := businessDate.Add(25 * time.Hour)
+	crashedRun := &models.BatchRun{
+		ID:           7,
+		BusinessDate: businessDate,
+		Status:       models.BatchStatusRunning,
+		TriggeredBy:  "system",
+		StartedAt:    startedAt,
+		Steps: []*models.BatchStepRun{
+			{ID: 1, BatchRunID: 7, StepName: models.BatchStepCollectibility, Sequence: 1, Status: models.BatchStatusCompleted, CheckpointLoanID: 30, ProcessedLoans: 30},
+			{ID: 2, BatchRunID: 7, StepName: models.BatchStepInterestAccrual, Sequence: 2, Status: models.BatchStatusRunning, CheckpointLoanID: 20, ProcessedLoans: 20, StartedAt: &startedAt},
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(crashedRun, nil)
+	mockRepo.On("UpdateRun", ctx, crashedRun).Return(nil)
+	mockRepo.On("UpdateStepRun", ctx, crashedRun.Steps[1]).Return(nil)
+	accrualStep.On("GetLoans", ctx, uint(20), 10).Return(loans(21, 22), nil)
+	accrualStep.On("GetLoans", ctx, uint(22), 10).Return(loans(), nil)
+	accrualStep.On("ProcessLoan", ctx, mock.Anything, businessDate).Return(nil).Twice()
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, businessDate, "system")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStatusCompleted, response.Status)
+	assert.Equal(t, 22, response.Steps[1].ProcessedLoans)
+	assert.Equal(t, &startedAt, response.Steps[1].StartedAt)
+	collectibilityStep.AssertNotCalled(t, "GetLoans", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBatchService_RunEndOfDay_FailedLoanFailsStep(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	collectibilityStep := newStep(t, models.BatchStepCollectibility)
+	accrualStep := newStep(t, models.BatchStepInterestAccrual)
+	service := NewBatchService(mockRepo, []batch.BatchStepInterface{collectibilityStep, accrualStep}, nil, 2, 1)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+	page := loans(1, 2)
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(nil, nil)
+	mockRepo.On("CreateRun", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateStepRun", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateRun", ctx, mock.Anything).Return(nil)
+	collectibilityStep.On("GetLoans", ctx, uint(0), 2).Return(page, nil)
+	collectibilityStep.On("GetLoans", ctx, uint(2), 2).Return(loans(), nil)
+	collectibilityStep.On("ProcessLoan", ctx, page[0], businessDate).Return(errors.New("database error"))
+	collectibilityStep.On("ProcessLoan", ctx, page[1], businessDate).Return(nil)
+	accrualStep.On("GetLoans", ctx, uint(0), 2).Return(loans(), nil)
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, businessDate, "system")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStatusFailed, response.Status)
+	assert.Equal(t, "step COLLECTIBILITY failed: 1 loans failed", response.ErrorMessage)
+	assert.Equal(t, models.BatchStatusFailed, response.Steps[0].Status)
+	assert.Equal(t, 2, response.Steps[0].ProcessedLoans)
+	assert.Equal(t, 1, response.Steps[0].FailedLoans)
+	assert.Equal(t, uint(0), response.Steps[0].CheckpointLoanID)
+	assert.Equal(t, models.BatchStatusCompleted, response.Steps[1].Status)
+}
+
+func TestBatchService_RunEndOfDay_RetriesFailedLoans(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	accrualStep := newStep(t, models.BatchStepInterestAccrual)
+	service := NewBatchService(mockRepo, []batch.BatchStepInterface{accrualStep}, nil, 1, 10)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+	startedAt := businessDate.Add(25 * time.Hour)
+	failedRun := &models.BatchRun{
+		ID:           7,
+		BusinessDate: businessDate,
+		Status:       models.BatchStatusFailed,
+		TriggeredBy:  "system",
+		StartedAt:    startedAt,
+		ErrorMessage: "step INTEREST_ACCRUAL failed: 1 loans failed",
+		Steps: []*models.BatchStepRun{
+			{ID: 1, BatchRunID: 7, StepName: models.BatchStepInterestAccrual, Sequence: 1, Status: models.BatchStatusFailed, ProcessedLoans: 2, FailedLoans: 1, ErrorMessage: "1 loans failed", StartedAt: &startedAt},
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(failedRun, nil)
+	mockRepo.On("UpdateRun", ctx, failedRun).Return(nil)
+	mockRepo.On("UpdateStepRun", ctx, failedRun.Steps[0]).Return(nil)
+	accrualStep.On("GetLoans", ctx, uint(0), 10).Return(loans(1, 2), nil)
+	accrualStep.On("GetLoans", ctx, uint(2), 10).Return(loans(), nil)
+	accrualStep.On("ProcessLoan", ctx, mock.Anything, businessDate).Return(nil).Twice()
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, businessDate, "system")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStatusCompleted, response.Status)
+	assert.Empty(t, response.ErrorMessage)
+	assert.Equal(t, models.BatchStatusCompleted, response.Steps[0].Status)
+	assert.Equal(t, 2, response.Steps[0].ProcessedLoans)
+	assert.Equal(t, 0, response.Steps[0].FailedLoans)
+}
+
+func TestBatchService_RunEndOfDay_ResumedRunRunsHooks(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	accrualStep := newStep(t, models.BatchStepInterestAccrual)
+	hook := mocks.NewBatchRunHookInterface(t)
+	service := NewBatchService(mockRepo, []batch.BatchStepInterface{accrualStep}, []batch.BatchRunHookInterface{hook}, 1, 10)
+	ctx := context.Background()
+
+	// The month-end run failed on one loan and completes when resumed the next day
+	businessDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+	failedRun := &models.BatchRun{
+		ID:           7,
+		BusinessDate: businessDate,
+		Status:       models.BatchStatusFailed,
+		TriggeredBy:  "system",
+		Steps: []*models.BatchStepRun{
+			{ID: 1, BatchRunID: 7, StepName: models.BatchStepInterestAccrual, Sequence: 1, Status: models.BatchStatusFailed, FailedLoans: 1},
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(failedRun, nil)
+	mockRepo.On("UpdateRun", ctx, failedRun).Return(nil)
+	mockRepo.On("UpdateStepRun", ctx, failedRun.Steps[0]).Return(nil)
+	accrualStep.On("GetLoans", ctx, uint(0), 10).Return(loans(1), nil)
+	accrualStep.On("GetLoans", ctx, uint(1), 10).Return(loans(), nil)
+	accrualStep.On("ProcessLoan", ctx, mock.Anything, businessDate).Return(nil).Once()
+	hook.On("Name").Return(models.BatchHookProvisioning).Maybe()
+	hook.On("RunCompleted", ctx, businessDate).Return(errors.New("provisioning failed")).Once()
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, businessDate, "system")
+
+	// Assert: a failing hook leaves the run completed
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStatusCompleted, response.Status)
+}
+
+func TestBatchService_RunEndOfDay_StepFails(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	collectibilityStep := newStep(t, models.BatchStepCollectibility)
+	accrualStep := newStep(t, models.BatchStepInterestAccrual)
+	hook := mocks.NewBatchRunHookInterface(t)
+	service := NewBatchService(mockRepo, []batch.BatchStepInterface{collectibilityStep, accrualStep}, []batch.BatchRunHookInterface{hook}, 1, 10)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(nil, nil)
+	mockRepo.On("CreateRun", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateStepRun", ctx, mock.Anything).Return(nil)
+	mockRepo.On("UpdateRun", ctx, mock.MatchedBy(func(run *models.BatchRun) bool {
+		return run.Status == models.BatchStatusFailed
+	})).Return(nil)
+	collectibilityStep.On("GetLoans", ctx, uint(0), 10).Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, businessDate, "system")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStatusFailed, response.Status)
+	assert.Equal(t, "step COLLECTIBILITY failed: database error", response.ErrorMessage)
+	assert.Equal(t, models.BatchStatusFailed, response.Steps[0].Status)
+	assert.Equal(t, models.BatchStatusPending, response.Steps[1].Status)
+	accrualStep.AssertNotCalled(t, "GetLoans", mock.Anything, mock.Anything, mock.Anything)
+	hook.AssertNotCalled(t, "RunCompleted", mock.Anything, mock.Anything)
+}
+
+func TestBatchService_RunEndOfDay_AlreadyCompleted(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	service := NewBatchService(mockRepo, nil, nil, 1, 10)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(&models.BatchRun{ID: 7, Status: models.BatchStatusCompleted}, nil)
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, businessDate, "api")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "batch run for 2026-01-04 is already completed", err.Error())
+}
+
+func TestBatchService_ResumeUnfinishedRuns(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	service := NewBatchService(mockRepo, nil, nil, 1, 10)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 1, 3, 0, 0, 0, 0, time.Local)
+	failedRun := &models.BatchRun{ID: 6, BusinessDate: businessDate, Status: models.BatchStatusFailed, TriggeredBy: "system"}
+
+	// Mock repository calls
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+		return true, fn(ctx)
+	})
+	mockRepo.On("GetUnfinishedRuns", ctx).Return([]*models.BatchRun{failedRun}, nil)
+	mockRepo.On("GetRunByBusinessDate", ctx, businessDate).Return(failedRun, nil)
+	mockRepo.On("UpdateRun", ctx, failedRun).Return(nil)
+
+	// Execute
+	err := service.ResumeUnfinishedRuns(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStatusCompleted, failedRun.Status)
+}
+
+func TestBatchService_RunEndOfDay_RunInProgress(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	service := NewBatchService(mockRepo, nil, nil, 1, 10)
+	ctx := context.Background()
+
+	// Mock repository calls: another instance holds the batch run lock
+	mockRepo.On("WithRunLock", ctx, mock.Anything).Return(false, nil)
+
+	// Execute
+	response, err := service.RunEndOfDay(ctx, time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local), "ops_admin")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "already in progress")
+	mockRepo.AssertNotCalled(t, "GetRunByBusinessDate", mock.Anything, mock.Anything)
+}
+
+func TestBatchService_GetRun_NotFound(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	service := NewBatchService(mockRepo, nil, nil, 1, 10)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetRunByID", ctx, uint(99)).Return(nil, nil)
+
+	// Execute
+	response, err := service.GetRun(ctx, 99)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "batch run not found", err.Error())
+}
+
+func TestBatchService_ListRuns_DefaultLimit(t *testing.T) {
+	mockRepo := mocks.NewBatchMySQLRepositoryInterface(t)
+	service := NewBatchService(mockRepo, nil, nil, 1, 10)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetRuns", ctx, 20).Return([]*models.BatchRun{
+		{ID: 7, Status: models.BatchStatusCompleted},
+		{ID: 6, Status: models.BatchStatusFailed},
+	}, nil)
+
+	// Execute
+	response, err := service.ListRuns(ctx, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Runs, 2)
+	assert.Equal(t, uint(7), response.Runs[0].ID)
+}
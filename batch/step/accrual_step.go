@@ -0,0 +1,32 @@
+package step
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/accrual"
+	"billing-engine/batch"
+	"billing-engine/models"
+)
+
+type interestAccrualStep struct {
+	accrualService accrual.AccrualServiceInterface
+}
+
+// NewInterestAccrualStep creates the end-of-day step that accrues the interest of every loan up
+// to the business date
+func NewInterestAccrualStep(accrualService accrual.AccrualServiceInterface) batch.BatchStepInterface {
+	return &interestAccrualStep{accrualService: accrualService}
+}
+
+func (s *interestAccrualStep) Name() string {
+	return models.BatchStepInterestAccrual
+}
+
+func (s *interestAccrualStep) GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	return s.accrualService.GetAccruableLoans(ctx, afterID, limit)
+}
+
+func (s *interestAccrualStep) ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	return s.accrualService.AccrueLoan(ctx, loanSummary, businessDate)
+}
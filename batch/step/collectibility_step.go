@@ -0,0 +1,34 @@
+package step
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/collectibility"
+	"billing-engine/models"
+)
+
+type collectibilityStep struct {
+	collectibilityService collectibility.CollectibilityServiceInterface
+}
+
+// NewCollectibilityStep creates the end-of-day step that recalculates the DPD and collectibility
+// grade of every active loan
+func NewCollectibilityStep(collectibilityService collectibility.CollectibilityServiceInterface) batch.BatchStepInterface {
+	return &collectibilityStep{collectibilityService: collectibilityService}
+}
+
+func (s *collectibilityStep) Name() string {
+	return models.BatchStepCollectibility
+}
+
+func (s *collectibilityStep) GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	return s.collectibilityService.GetActiveLoans(ctx, afterID, limit)
+}
+
+// ProcessLoan classifies the loan as of the end of the business date
+func (s *collectibilityStep) ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	_, err := s.collectibilityService.ClassifyLoan(ctx, loanSummary, businessDate.AddDate(0, 0, 1))
+	return err
+}
@@ -0,0 +1,33 @@
+package step
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/daily_report"
+	"billing-engine/models"
+)
+
+type dailyReportStep struct {
+	dailyReportService daily_report.DailyReportServiceInterface
+}
+
+// NewDailyReportStep creates the end-of-day step that records the position of every active loan
+// for the daily report
+func NewDailyReportStep(dailyReportService daily_report.DailyReportServiceInterface) batch.BatchStepInterface {
+	return &dailyReportStep{dailyReportService: dailyReportService}
+}
+
+func (s *dailyReportStep) Name() string {
+	return models.BatchStepDailyReport
+}
+
+func (s *dailyReportStep) GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	return s.dailyReportService.GetReportableLoans(ctx, afterID, limit)
+}
+
+// ProcessLoan records the loan's position at the end of the business date
+func (s *dailyReportStep) ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	return s.dailyReportService.RecordLoanPosition(ctx, loanSummary, businessDate)
+}
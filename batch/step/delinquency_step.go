@@ -0,0 +1,34 @@
+package step
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/delinquency"
+	"billing-engine/models"
+)
+
+type delinquencyStep struct {
+	delinquencyService delinquency.DelinquencyServiceInterface
+}
+
+// NewDelinquencyStep creates the end-of-day step that marks every active loan DELINQUENT, or back
+// to PENDING, under its product's delinquency policy
+func NewDelinquencyStep(delinquencyService delinquency.DelinquencyServiceInterface) batch.BatchStepInterface {
+	return &delinquencyStep{delinquencyService: delinquencyService}
+}
+
+func (s *delinquencyStep) Name() string {
+	return models.BatchStepDelinquency
+}
+
+func (s *delinquencyStep) GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	return s.delinquencyService.GetActiveLoans(ctx, afterID, limit)
+}
+
+// ProcessLoan marks the loan as of the end of the business date
+func (s *delinquencyStep) ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	_, err := s.delinquencyService.MarkLoan(ctx, loanSummary, businessDate.AddDate(0, 0, 1))
+	return err
+}
@@ -0,0 +1,33 @@
+package step
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/models"
+	"billing-engine/penalty"
+)
+
+type penaltyStep struct {
+	penaltyService penalty.PenaltyServiceInterface
+}
+
+// NewPenaltyStep creates the end-of-day step that assesses the late payment penalty of every
+// overdue installment
+func NewPenaltyStep(penaltyService penalty.PenaltyServiceInterface) batch.BatchStepInterface {
+	return &penaltyStep{penaltyService: penaltyService}
+}
+
+func (s *penaltyStep) Name() string {
+	return models.BatchStepPenalty
+}
+
+func (s *penaltyStep) GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	return s.penaltyService.GetPenalisableLoans(ctx, afterID, limit)
+}
+
+// ProcessLoan assesses the loan as of the end of the business date
+func (s *penaltyStep) ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	return s.penaltyService.AssessLoan(ctx, loanSummary, businessDate.AddDate(0, 0, 1))
+}
@@ -0,0 +1,39 @@
+package step
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/models"
+	"billing-engine/provisioning"
+)
+
+type provisioningHook struct {
+	provisioningService provisioning.ProvisioningServiceInterface
+}
+
+// NewProvisioningHook creates the hook that runs the monthly provisioning once the last day of the
+// month is closed, so every loan is staged on its month-end DPD. A month-end run that failed and is
+// resumed later is provisioned once it completes.
+func NewProvisioningHook(provisioningService provisioning.ProvisioningServiceInterface) batch.BatchRunHookInterface {
+	return &provisioningHook{provisioningService: provisioningService}
+}
+
+func (h *provisioningHook) Name() string {
+	return models.BatchHookProvisioning
+}
+
+func (h *provisioningHook) RunCompleted(ctx context.Context, businessDate time.Time) error {
+	if businessDate.AddDate(0, 0, 1).Day() != 1 {
+		return nil
+	}
+
+	provisionRun, err := h.provisioningService.RunProvisioning(ctx, businessDate, "system")
+	if err != nil {
+		return err
+	}
+	log.Printf("provision run for %s done: loans=%d", provisionRun.Period, provisionRun.LoanCount)
+	return nil
+}
@@ -0,0 +1,32 @@
+package step
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/batch"
+	"billing-engine/models"
+	"billing-engine/reminder"
+)
+
+type reminderStep struct {
+	reminderService reminder.ReminderServiceInterface
+}
+
+// NewReminderStep creates the end-of-day step that sends the payment reminders of every active loan
+func NewReminderStep(reminderService reminder.ReminderServiceInterface) batch.BatchStepInterface {
+	return &reminderStep{reminderService: reminderService}
+}
+
+func (s *reminderStep) Name() string {
+	return models.BatchStepReminder
+}
+
+func (s *reminderStep) GetLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	return s.reminderService.GetRemindableLoans(ctx, afterID, limit)
+}
+
+// ProcessLoan sends the reminders due once the business date is closed
+func (s *reminderStep) ProcessLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	return s.reminderService.RemindLoan(ctx, loanSummary, businessDate)
+}
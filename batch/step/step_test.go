@@ -0,0 +1,169 @@
+package step
+
+import (
+	accrualMocks "billing-engine/accrual/_mock"
+	collectibilityMocks "billing-engine/collectibility/_mock"
+	dailyReportMocks "billing-engine/daily_report/_mock"
+	delinquencyMocks "billing-engine/delinquency/_mock"
+	"billing-engine/models"
+	penaltyMocks "billing-engine/penalty/_mock"
+	provisioningMocks "billing-engine/provisioning/_mock"
+	reminderMocks "billing-engine/reminder/_mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectibilityStep_ClassifiesAtEndOfBusinessDate(t *testing.T) {
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	step := NewCollectibilityStep(mockCollectibility)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123"}
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockCollectibility.On("GetActiveLoans", ctx, uint(0), 100).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockCollectibility.On("ClassifyLoan", ctx, loanSummary, time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)).Return(true, nil)
+
+	// Execute
+	loanSummaries, err := step.GetLoans(ctx, 0, 100)
+	assert.NoError(t, err)
+	err = step.ProcessLoan(ctx, loanSummaries[0], businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStepCollectibility, step.Name())
+}
+
+func TestDelinquencyStep_MarksAtEndOfBusinessDate(t *testing.T) {
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	step := NewDelinquencyStep(mockDelinquency)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123"}
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockDelinquency.On("GetActiveLoans", ctx, uint(0), 100).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockDelinquency.On("MarkLoan", ctx, loanSummary, time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)).Return(true, nil)
+
+	// Execute
+	loanSummaries, err := step.GetLoans(ctx, 0, 100)
+	assert.NoError(t, err)
+	err = step.ProcessLoan(ctx, loanSummaries[0], businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStepDelinquency, step.Name())
+}
+
+func TestInterestAccrualStep_AccruesUpToBusinessDate(t *testing.T) {
+	mockAccrual := accrualMocks.NewAccrualServiceInterface(t)
+	step := NewInterestAccrualStep(mockAccrual)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123"}
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockAccrual.On("GetAccruableLoans", ctx, uint(0), 100).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockAccrual.On("AccrueLoan", ctx, loanSummary, businessDate).Return(nil)
+
+	// Execute
+	loanSummaries, err := step.GetLoans(ctx, 0, 100)
+	assert.NoError(t, err)
+	err = step.ProcessLoan(ctx, loanSummaries[0], businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStepInterestAccrual, step.Name())
+}
+
+func TestPenaltyStep_AssessesAtEndOfBusinessDate(t *testing.T) {
+	mockPenalty := penaltyMocks.NewPenaltyServiceInterface(t)
+	step := NewPenaltyStep(mockPenalty)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123"}
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockPenalty.On("GetPenalisableLoans", ctx, uint(0), 100).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockPenalty.On("AssessLoan", ctx, loanSummary, time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)).Return(nil)
+
+	// Execute
+	loanSummaries, err := step.GetLoans(ctx, 0, 100)
+	assert.NoError(t, err)
+	err = step.ProcessLoan(ctx, loanSummaries[0], businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStepPenalty, step.Name())
+}
+
+func TestReminderStep_RemindsForBusinessDate(t *testing.T) {
+	mockReminder := reminderMocks.NewReminderServiceInterface(t)
+	step := NewReminderStep(mockReminder)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123"}
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockReminder.On("GetRemindableLoans", ctx, uint(0), 100).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockReminder.On("RemindLoan", ctx, loanSummary, businessDate).Return(nil)
+
+	// Execute
+	loanSummaries, err := step.GetLoans(ctx, 0, 100)
+	assert.NoError(t, err)
+	err = step.ProcessLoan(ctx, loanSummaries[0], businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStepReminder, step.Name())
+}
+
+func TestDailyReportStep_RecordsBusinessDatePosition(t *testing.T) {
+	mockDailyReport := dailyReportMocks.NewDailyReportServiceInterface(t)
+	step := NewDailyReportStep(mockDailyReport)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123"}
+	businessDate := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockDailyReport.On("GetReportableLoans", ctx, uint(0), 100).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockDailyReport.On("RecordLoanPosition", ctx, loanSummary, businessDate).Return(nil)
+
+	// Execute
+	loanSummaries, err := step.GetLoans(ctx, 0, 100)
+	assert.NoError(t, err)
+	err = step.ProcessLoan(ctx, loanSummaries[0], businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchStepDailyReport, step.Name())
+}
+
+func TestProvisioningHook_ProvisionsMonthEnd(t *testing.T) {
+	mockProvisioning := provisioningMocks.NewProvisioningServiceInterface(t)
+	hook := NewProvisioningHook(mockProvisioning)
+	ctx := context.Background()
+
+	monthEnd := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+
+	// Mock service calls
+	mockProvisioning.On("RunProvisioning", ctx, monthEnd, "system").Return(&models.ProvisionRunResponse{Period: "2026-01", LoanCount: 3}, nil).Once()
+
+	// Execute
+	err := hook.RunCompleted(ctx, monthEnd)
+	assert.NoError(t, err)
+	err = hook.RunCompleted(ctx, time.Date(2026, 1, 30, 0, 0, 0, 0, time.Local))
+
+	// Assert: only the last day of the month is provisioned
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchHookProvisioning, hook.Name())
+}
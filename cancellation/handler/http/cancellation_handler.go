@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+
+	"billing-engine/cancellation"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CancellationHandler struct {
+	cancellationService cancellation.CancellationServiceInterface
+	middleware          middlewares.GoMiddlewareInterface
+}
+
+// NewCancellationHandler creates a new loan cancellation handler instance
+func NewCancellationHandler(e *echo.Echo, cancellationService cancellation.CancellationServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &CancellationHandler{
+		cancellationService: cancellationService,
+		middleware:          middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/loans/:loan_id/cancel", handler.CancelLoan)
+}
+
+func (h *CancellationHandler) CancelLoan(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	var req models.CancellationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.cancellationService.CancelLoan(c.Request().Context(), loanID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.CancellationSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "billing-engine/cancellation/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestCancellationHandler_CancelLoan_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewCancellationServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &CancellationHandler{
+		cancellationService: mockService,
+		middleware:          mockMiddleware,
+	}
+
+	req := models.CancellationRequest{
+		Reason:      "Borrower changed their mind",
+		CancelledBy: "cs_agent_01",
+	}
+	expectedResponse := &models.CancellationResponse{
+		LoanID:                "loan_123",
+		Status:                models.StatusCancelled,
+		CollectableAmount:     5000000.00,
+		CancelledInstallments: 50,
+	}
+
+	mockService.On("CancelLoan", mock.Anything, "loan_123", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/cancel", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.CancelLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.CancellationSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, models.StatusCancelled, response.Data.Status)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCancellationHandler_CancelLoan_MissingCancelledBy(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewCancellationServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &CancellationHandler{
+		cancellationService: mockService,
+		middleware:          mockMiddleware,
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(models.CancellationRequest{
+		Reason: "Borrower changed their mind",
+	})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/cancel", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.CancelLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCancellationHandler_CancelLoan_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewCancellationServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &CancellationHandler{
+		cancellationService: mockService,
+		middleware:          mockMiddleware,
+	}
+
+	req := models.CancellationRequest{Reason: "Borrower changed their mind", CancelledBy: "cs_agent_01"}
+	mockService.On("CancelLoan", mock.Anything, "loan_123", &req).Return(nil, errors.New("cooling-off period of 14 days has ended"))
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/cancel", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.CancelLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
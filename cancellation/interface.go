@@ -0,0 +1,20 @@
+package cancellation
+
+import (
+	"billing-engine/models"
+	"context"
+)
+
+// CancellationMySQLRepositoryInterface defines the interface for loan cancellation repository
+type CancellationMySQLRepositoryInterface interface {
+	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetDisbursementByLoanID(ctx context.Context, loanID string) (*models.DisbursementDetail, error)
+	GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	CancelLoan(ctx context.Context, loanSummary *models.LoanSummary, disbursement *models.DisbursementDetail, cancellation *models.LoanCancellation, histories []*models.PaymentScheduleHistory) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CancellationServiceInterface defines the interface for loan cancellation service
+type CancellationServiceInterface interface {
+	CancelLoan(ctx context.Context, loanID string, req *models.CancellationRequest) (*models.CancellationResponse, error)
+}
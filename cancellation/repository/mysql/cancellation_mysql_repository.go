@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"billing-engine/cancellation"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type cancellationMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewCancellationMySQLRepository creates a new loan cancellation repository instance
+func NewCancellationMySQLRepository(db *gorm.DB) cancellation.CancellationMySQLRepositoryInterface {
+	return &cancellationMySQLRepository{db: db}
+}
+
+// GetLoanSummaryByLoanID reads the loan and locks it until the transaction ends, so it cannot be
+// repaid while it is cancelled
+func (r *cancellationMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
+
+// GetDisbursementByLoanID reads the loan's disbursement and locks it until the transaction ends
+func (r *cancellationMySQLRepository) GetDisbursementByLoanID(ctx context.Context, loanID string) (*models.DisbursementDetail, error) {
+	var disbursement models.DisbursementDetail
+	err := transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&disbursement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &disbursement, nil
+}
+
+// GetPaymentSchedulesByLoanID reads the loan's installments and locks them until the transaction ends
+func (r *cancellationMySQLRepository) GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("loan_id = ? AND deleted_at IS NULL", loanID).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// CancelLoan stores the cancellation, soft-deletes the schedule with its history rows, voids the
+// loan's fees and cancels its tax lines, and moves the disbursement and the loan to CANCELLED in
+// one transaction. Every update is conditional on the state the cancellation was decided on, and
+// fails when a repayment or another cancellation changed it first.
+func (r *cancellationMySQLRepository) CancelLoan(ctx context.Context, loanSummary *models.LoanSummary, disbursement *models.DisbursementDetail, cancellation *models.LoanCancellation, histories []*models.PaymentScheduleHistory) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cancellation).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.PaymentSchedule{}).
+			Where("loan_id = ? AND deleted_at IS NULL AND status <> ? AND installment_paid = 0", loanSummary.LoanID, models.StatusPaid).
+			Updates(map[string]interface{}{
+				"status":     models.StatusCancelled,
+				"deleted_at": cancellation.CancelledAt,
+				"deleted_by": cancellation.CancelledBy,
+				"updated_by": "system",
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(histories)) {
+			return fmt.Errorf("loan %s has repayments", loanSummary.LoanID)
+		}
+
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.LoanFee{}).
+			Where("loan_id = ? AND deleted_at IS NULL", loanSummary.LoanID).
+			Updates(map[string]interface{}{
+				"deleted_at": cancellation.CancelledAt,
+				"deleted_by": cancellation.CancelledBy,
+			}).Error; err != nil {
+			return err
+		}
+
+		// Tax withheld from the payout was collected at disbursement; it is reversed with the
+		// loan's journal entries, so it leaves the tax report with the pending lines
+		if err := tx.Model(&models.TaxLine{}).
+			Where("loan_id = ? AND status <> ?", loanSummary.LoanID, models.StatusCancelled).
+			Updates(map[string]interface{}{
+				"status":     models.StatusCancelled,
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+
+		result = tx.Model(disbursement).
+			Where("status = ?", models.DisbursementStatusDisbursed).
+			Updates(map[string]interface{}{
+				"status":     disbursement.Status,
+				"updated_by": disbursement.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("disbursement of loan %s is no longer disbursed", loanSummary.LoanID)
+		}
+
+		result = tx.Model(loanSummary).
+			Where("status <> ?", models.StatusCancelled).
+			Updates(map[string]interface{}{
+				"status":             loanSummary.Status,
+				"outstanding_amount": loanSummary.OutstandingAmount,
+				"updated_by":         loanSummary.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("loan %s is already cancelled", loanSummary.LoanID)
+		}
+		return nil
+	})
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *cancellationMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
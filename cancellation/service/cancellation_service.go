@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"billing-engine/cancellation"
+	"billing-engine/disbursement"
+	"billing-engine/ledger"
+	"billing-engine/models"
+)
+
+type cancellationService struct {
+	cancellationRepo       cancellation.CancellationMySQLRepositoryInterface
+	ledgerService          ledger.LedgerServiceInterface
+	virtualAccountProvider disbursement.VirtualAccountProviderInterface
+	coolingOffDays         int // days after disbursement during which the borrower may cancel
+}
+
+// NewCancellationService creates a new loan cancellation service instance
+func NewCancellationService(cancellationRepo cancellation.CancellationMySQLRepositoryInterface, ledgerService ledger.LedgerServiceInterface, virtualAccountProvider disbursement.VirtualAccountProviderInterface, coolingOffDays int) cancellation.CancellationServiceInterface {
+	return &cancellationService{
+		cancellationRepo:       cancellationRepo,
+		ledgerService:          ledgerService,
+		virtualAccountProvider: virtualAccountProvider,
+		coolingOffDays:         coolingOffDays,
+	}
+}
+
+// CancelLoan cancels a loan within its cooling-off period. The loan, its disbursement and its
+// installments are read and locked, and the loan cancelled, in one transaction, so a repayment
+// committed in the meantime either blocks the cancellation or is seen by it. The virtual account
+// of the loan is closed once the cancellation is committed.
+func (s *cancellationService) CancelLoan(ctx context.Context, loanID string, req *models.CancellationRequest) (*models.CancellationResponse, error) {
+	var (
+		response             *models.CancellationResponse
+		virtualAccountNumber string
+	)
+	err := s.cancellationRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		loanSummary, err := s.cancellationRepo.GetLoanSummaryByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get loan summary: %v", err)
+		}
+		if loanSummary == nil {
+			return fmt.Errorf("loan not found")
+		}
+		if loanSummary.Status == models.StatusCancelled {
+			return fmt.Errorf("loan is already cancelled")
+		}
+
+		disbursement, err := s.cancellationRepo.GetDisbursementByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get disbursement: %v", err)
+		}
+		if disbursement == nil {
+			return fmt.Errorf("disbursement not found")
+		}
+		// A payout in flight could still reach the borrower after the loan is cancelled. A failed
+		// payout cancels the loan itself, so only a disbursed loan gets here.
+		if disbursement.Status != models.DisbursementStatusDisbursed {
+			return fmt.Errorf("disbursement is still %s, the loan can be cancelled once the payout completes", disbursement.Status)
+		}
+
+		// The window runs from the date the money reached the borrower, once confirmed
+		disbursementDate := disbursement.DisbursementDate
+		if disbursement.DisbursedAt != nil {
+			disbursementDate = *disbursement.DisbursedAt
+		}
+
+		cancelledAt := time.Now()
+		if !withinCoolingOff(disbursementDate, cancelledAt, s.coolingOffDays) {
+			return fmt.Errorf("cooling-off period of %d days has ended", s.coolingOffDays)
+		}
+
+		schedules, err := s.cancellationRepo.GetPaymentSchedulesByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment schedules: %v", err)
+		}
+
+		histories := make([]*models.PaymentScheduleHistory, 0, len(schedules))
+		for _, schedule := range schedules {
+			if schedule.InstallmentPaid > 0 || schedule.Status == models.StatusPaid {
+				return fmt.Errorf("loan with repayments cannot be cancelled")
+			}
+			histories = append(histories, &models.PaymentScheduleHistory{
+				ScheduleID:         schedule.ID,
+				LoanID:             schedule.LoanID,
+				Action:             models.ActionCancel,
+				InstallmentNumber:  schedule.InstallmentNumber,
+				InstallmentAmount:  schedule.InstallmentAmount,
+				InstallmentDueDate: schedule.InstallmentDueDate,
+				Status:             models.StatusCancelled,
+				Currency:           schedule.Currency,
+				CreatedBy:          req.CancelledBy,
+			})
+		}
+
+		// The borrower returns the disbursed principal; interest is not charged on a cancelled loan
+		cancellationRecord := &models.LoanCancellation{
+			LoanID:               loanID,
+			PrincipalAmount:      loanSummary.PrincipalAmount,
+			WaivedInterestAmount: loanSummary.InterestAmount,
+			CollectableAmount:    disbursement.DisbursedAmount,
+			Reason:               req.Reason,
+			CancelledBy:          req.CancelledBy,
+			CancelledAt:          cancelledAt,
+			CreatedBy:            "system",
+		}
+
+		disbursement.Status = models.DisbursementStatusCancelled
+		disbursement.UpdatedBy = req.CancelledBy
+		loanSummary.Status = models.StatusCancelled
+		loanSummary.OutstandingAmount = 0
+		loanSummary.UpdatedBy = req.CancelledBy
+
+		// Journal entries of the loan are reversed so the ledger no longer carries the receivable
+		if err := s.cancellationRepo.CancelLoan(ctx, loanSummary, disbursement, cancellationRecord, histories); err != nil {
+			return fmt.Errorf("failed to cancel loan: %v", err)
+		}
+		if err := s.ledgerService.ReverseLoanEntries(ctx, loanID, req.Reason, cancelledAt); err != nil {
+			return fmt.Errorf("failed to reverse journal entries: %v", err)
+		}
+
+		virtualAccountNumber = loanSummary.VirtualAccountNumber
+		response = &models.CancellationResponse{
+			LoanID:                loanID,
+			Status:                loanSummary.Status,
+			PrincipalAmount:       cancellationRecord.PrincipalAmount,
+			WaivedInterestAmount:  cancellationRecord.WaivedInterestAmount,
+			CollectableAmount:     cancellationRecord.CollectableAmount,
+			CancelledInstallments: len(schedules),
+			Reason:                req.Reason,
+			CancelledBy:           req.CancelledBy,
+			DisbursementDate:      disbursementDate,
+			CancelledAt:           cancelledAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The loan stays cancelled if the provider fails; the account is left for ops to close
+	if virtualAccountNumber != "" {
+		if err := s.virtualAccountProvider.CloseVirtualAccount(ctx, virtualAccountNumber); err != nil {
+			log.Printf("failed to close virtual account %s of loan %s: %v", virtualAccountNumber, loanID, err)
+		}
+	}
+	return response, nil
+}
+
+// withinCoolingOff reports whether the cancellation date is no more than coolingOffDays calendar days after disbursement
+func withinCoolingOff(disbursementDate, cancelledAt time.Time, coolingOffDays int) bool {
+	start := time.Date(disbursementDate.Year(), disbursementDate.Month(), disbursementDate.Day(), 0, 0, 0, 0, time.Local)
+	today := time.Date(cancelledAt.Year(), cancelledAt.Month(), cancelledAt.Day(), 0, 0, 0, 0, time.Local)
+	return !today.After(start.AddDate(0, 0, coolingOffDays))
+}
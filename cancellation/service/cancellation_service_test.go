@@ -0,0 +1,269 @@
+package service
+
+import (
+	mocks "billing-engine/cancellation/_mock"
+	disbursementMocks "billing-engine/disbursement/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithinCoolingOff(t *testing.T) {
+	disbursementDate := time.Date(2026, 1, 1, 15, 0, 0, 0, time.Local)
+
+	assert.True(t, withinCoolingOff(disbursementDate, time.Date(2026, 1, 1, 16, 0, 0, 0, time.Local), 14))
+	assert.True(t, withinCoolingOff(disbursementDate, time.Date(2026, 1, 15, 23, 0, 0, 0, time.Local), 14))
+	assert.False(t, withinCoolingOff(disbursementDate, time.Date(2026, 1, 16, 0, 0, 0, 0, time.Local), 14))
+}
+
+func TestCancellationService_CancelLoan_Success(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	req := &models.CancellationRequest{
+		Reason:      "Borrower changed their mind",
+		CancelledBy: "cs_agent_01",
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		PrincipalAmount:   5000000.00,
+		InterestAmount:    500000.00,
+		OutstandingAmount: 5500000.00,
+		Status:            models.StatusPending,
+
+		VirtualAccountNumber: "8808000000000123",
+	}
+	disbursement := &models.DisbursementDetail{
+		LoanID:           "loan_123",
+		DisbursementDate: time.Now().AddDate(0, 0, -3),
+		DisbursedAmount:  5000000.00,
+		Status:           models.DisbursementStatusDisbursed,
+	}
+	schedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 110000.00, Status: models.StatusPending},
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursement, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("ReverseLoanEntries", ctx, "loan_123", "Borrower changed their mind", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("CancelLoan", ctx, loanSummary, disbursement,
+		mock.MatchedBy(func(cancellation *models.LoanCancellation) bool {
+			return cancellation.CollectableAmount == 5000000.00 && cancellation.WaivedInterestAmount == 500000.00 &&
+				cancellation.CancelledBy == "cs_agent_01"
+		}),
+		mock.MatchedBy(func(histories []*models.PaymentScheduleHistory) bool {
+			return len(histories) == 2 && histories[0].Action == models.ActionCancel && histories[0].Status == models.StatusCancelled
+		}),
+	).Return(nil)
+	mockVAProvider.On("CloseVirtualAccount", ctx, "8808000000000123").Return(nil).Once()
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, models.StatusCancelled, response.Status)
+	assert.Equal(t, 5000000.00, response.CollectableAmount)
+	assert.Equal(t, 500000.00, response.WaivedInterestAmount)
+	assert.Equal(t, 2, response.CancelledInstallments)
+
+	// Disbursement and loan are both cancelled
+	assert.Equal(t, models.DisbursementStatusCancelled, disbursement.Status)
+	assert.Equal(t, models.StatusCancelled, loanSummary.Status)
+	assert.Equal(t, 0.0, loanSummary.OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCancellationService_CancelLoan_VirtualAccountNotClosed(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending, VirtualAccountNumber: "8808000000000123"}
+	disbursement := &models.DisbursementDetail{
+		LoanID:           "loan_123",
+		DisbursementDate: time.Now().AddDate(0, 0, -1),
+		Status:           models.DisbursementStatusDisbursed,
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursement, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CancelLoan", ctx, loanSummary, disbursement, mock.Anything, mock.Anything).Return(nil)
+	mockLedger.On("ReverseLoanEntries", ctx, "loan_123", "Duplicate loan", mock.AnythingOfType("time.Time")).Return(nil)
+	mockVAProvider.On("CloseVirtualAccount", ctx, "8808000000000123").Return(errors.New("virtual account provider unavailable")).Once()
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", &models.CancellationRequest{Reason: "Duplicate loan", CancelledBy: "cs_agent_01"})
+
+	// Assert: the cancellation is committed, so it stands
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCancelled, response.Status)
+}
+
+func TestCancellationService_CancelLoan_PayoutInFlight(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", PrincipalAmount: 5000000.00, Status: models.StatusInactive}
+	disbursement := &models.DisbursementDetail{
+		LoanID:           "loan_123",
+		DisbursementDate: time.Now(),
+		DisbursedAmount:  5000000.00,
+		Status:           models.DisbursementStatusProcessing,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursement, nil)
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", &models.CancellationRequest{Reason: "Changed mind", CancelledBy: "cs_agent_01"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "still PROCESSING")
+	assert.Equal(t, models.DisbursementStatusProcessing, disbursement.Status)
+	mockRepo.AssertNotCalled(t, "CancelLoan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancellationService_CancelLoan_CoolingOffEnded(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending}, nil)
+	disbursedAt := time.Now().AddDate(0, 0, -30)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(&models.DisbursementDetail{
+		LoanID:           "loan_123",
+		DisbursementDate: time.Now().AddDate(0, 0, -31),
+		DisbursedAt:      &disbursedAt,
+		Status:           models.DisbursementStatusDisbursed,
+	}, nil)
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", &models.CancellationRequest{Reason: "Changed mind", CancelledBy: "cs_agent_01"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "cooling-off period of 14 days has ended")
+}
+
+func TestCancellationService_CancelLoan_HasRepayment(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending}, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(&models.DisbursementDetail{
+		LoanID:           "loan_123",
+		DisbursementDate: time.Now().AddDate(0, 0, -7),
+		Status:           models.DisbursementStatusDisbursed,
+	}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentPaid: 110000.00, Status: models.StatusPaid},
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}, nil)
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", &models.CancellationRequest{Reason: "Changed mind", CancelledBy: "cs_agent_01"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "loan with repayments cannot be cancelled")
+}
+
+func TestCancellationService_CancelLoan_AlreadyCancelled(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: models.StatusCancelled}, nil)
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", &models.CancellationRequest{Reason: "Changed mind", CancelledBy: "cs_agent_01"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "loan is already cancelled")
+}
+
+func TestCancellationService_CancelLoan_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewCancellationMySQLRepositoryInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	mockVAProvider := disbursementMocks.NewVirtualAccountProviderInterface(t)
+	service := NewCancellationService(mockRepo, mockLedger, mockVAProvider, 14)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending}
+	disbursement := &models.DisbursementDetail{LoanID: "loan_123", DisbursementDate: time.Now(), Status: models.DisbursementStatusDisbursed}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursement, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CancelLoan", ctx, loanSummary, disbursement, mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	// Execute
+	response, err := service.CancelLoan(ctx, "loan_123", &models.CancellationRequest{Reason: "Changed mind", CancelledBy: "cs_agent_01"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to cancel loan")
+}
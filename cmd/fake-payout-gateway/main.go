@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"billing-engine/disbursement/gateway/fake"
+)
+
+// Runs the fake payout gateway locally so disbursements can settle without a real bank
+func main() {
+	port := flag.Int("port", 9010, "port to listen on")
+	settlementDelay := flag.Duration("settlement-delay", 30*time.Second, "how long payouts to accounts ending in 9999 stay PENDING")
+	webhookURL := flag.String("webhook-url", "http://localhost:9006/v1/payouts/webhook", "settlement webhook url, empty to disable")
+	webhookToken := flag.String("webhook-token", "", "token sent in the X-Callback-Token header, must match PAYOUT_WEBHOOK_TOKEN")
+	flag.Parse()
+
+	server := fake.NewServer(fake.Config{
+		SettlementDelay: *settlementDelay,
+		WebhookURL:      *webhookURL,
+		WebhookToken:    *webhookToken,
+	})
+
+	log.Printf("fake payout gateway listening on :%d", *port)
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", *port), server))
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"billing-engine/collectibility"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CollectibilityHandler struct {
+	collectibilityService collectibility.CollectibilityServiceInterface
+	middleware            middlewares.GoMiddlewareInterface
+}
+
+// NewCollectibilityHandler creates a new collectibility handler instance
+func NewCollectibilityHandler(e *echo.Echo, collectibilityService collectibility.CollectibilityServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &CollectibilityHandler{
+		collectibilityService: collectibilityService,
+		middleware:            middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/collectibility/evaluate", handler.EvaluatePortfolio)
+	v1.GET("/reports/collectibility", handler.GetPortfolioReport)
+}
+
+func (h *CollectibilityHandler) EvaluatePortfolio(c echo.Context) error {
+	var req models.CollectibilityEvaluationRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	asOf := req.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	response, err := h.collectibilityService.EvaluatePortfolio(c.Request().Context(), asOf)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.CollectibilityEvaluationSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *CollectibilityHandler) GetPortfolioReport(c echo.Context) error {
+	response, err := h.collectibilityService.GetPortfolioReport(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.CollectibilityReportSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "billing-engine/collectibility/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestCollectibilityHandler_EvaluatePortfolio_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewCollectibilityServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &CollectibilityHandler{
+		collectibilityService: mockService,
+		middleware:            mockMiddleware,
+	}
+
+	asOf := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	expectedResponse := &models.CollectibilityEvaluationResponse{
+		AsOf:              asOf,
+		EvaluatedLoans:    10,
+		ReclassifiedLoans: 2,
+	}
+
+	mockService.On("EvaluatePortfolio", mock.Anything, asOf).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(models.CollectibilityEvaluationRequest{AsOf: asOf})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/collectibility/evaluate", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.EvaluatePortfolio(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.CollectibilityEvaluationSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 10, response.Data.EvaluatedLoans)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCollectibilityHandler_GetPortfolioReport_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewCollectibilityServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &CollectibilityHandler{
+		collectibilityService: mockService,
+		middleware:            mockMiddleware,
+	}
+
+	expectedResponse := &models.CollectibilityReportResponse{
+		TotalLoans: 1,
+		Currencies: []models.CollectibilityCurrencyReport{
+			{
+				Currency:          models.CurrencyIDR,
+				TotalLoans:        1,
+				OutstandingAmount: 5500000.00,
+				Grades: []models.CollectibilityGradeReport{
+					{Currency: models.CurrencyIDR, Collectibility: 1, Label: "Lancar", LoanCount: 1, OutstandingAmount: 5500000.00, OutstandingRatio: 1},
+				},
+			},
+		},
+	}
+
+	mockService.On("GetPortfolioReport", mock.Anything).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/collectibility", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetPortfolioReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.CollectibilityReportSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Currencies, 1)
+	assert.Len(t, response.Data.Currencies[0].Grades, 1)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCollectibilityHandler_GetPortfolioReport_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewCollectibilityServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &CollectibilityHandler{
+		collectibilityService: mockService,
+		middleware:            mockMiddleware,
+	}
+
+	mockService.On("GetPortfolioReport", mock.Anything).Return(nil, errors.New("database error"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/collectibility", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetPortfolioReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "database error", response.Message)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,25 @@
+package collectibility
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// CollectibilityMySQLRepositoryInterface defines the interface for collectibility repository
+type CollectibilityMySQLRepositoryInterface interface {
+	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetActiveLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetOldestOverduePaymentSchedule(ctx context.Context, loanID string, asOf time.Time) (*models.PaymentSchedule, error)
+	UpdateLoanClassification(ctx context.Context, loanSummary *models.LoanSummary, collectibility *models.LoanCollectibility) error
+	GetCollectibilityPortfolio(ctx context.Context) ([]models.CollectibilityGradeReport, error)
+}
+
+// CollectibilityServiceInterface defines the interface for collectibility service
+type CollectibilityServiceInterface interface {
+	EvaluateLoan(ctx context.Context, loanID string, asOf time.Time) (*models.CollectibilityResponse, error)
+	EvaluatePortfolio(ctx context.Context, asOf time.Time) (*models.CollectibilityEvaluationResponse, error)
+	GetActiveLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	ClassifyLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) (bool, error)
+	GetPortfolioReport(ctx context.Context) (*models.CollectibilityReportResponse, error)
+}
@@ -0,0 +1,104 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"billing-engine/collectibility"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type collectibilityMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectibilityMySQLRepository creates a new collectibility repository instance
+func NewCollectibilityMySQLRepository(db *gorm.DB) collectibility.CollectibilityMySQLRepositoryInterface {
+	return &collectibilityMySQLRepository{db: db}
+}
+
+func (r *collectibilityMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
+
+func (r *collectibilityMySQLRepository) GetActiveLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status NOT IN ? AND deleted_at IS NULL", afterID, []string{models.StatusPaid, models.StatusWrittenOff, models.StatusCancelled, models.StatusInactive}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+func (r *collectibilityMySQLRepository) GetOldestOverduePaymentSchedule(ctx context.Context, loanID string, asOf time.Time) (*models.PaymentSchedule, error) {
+	var schedule models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND installment_due_date < ? AND deleted_at IS NULL", loanID, models.StatusPending, asOf).
+		Order("installment_due_date ASC").
+		First(&schedule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// UpdateLoanClassification stores the DPD and grade on the loan summary. When a new
+// collectibility record is given, the currently open record is closed on the date
+// the new one takes effect, so every record covers [effective_from, effective_to).
+func (r *collectibilityMySQLRepository) UpdateLoanClassification(ctx context.Context, loanSummary *models.LoanSummary, collectibility *models.LoanCollectibility) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if collectibility != nil {
+			if err := tx.Model(&models.LoanCollectibility{}).
+				Where("loan_id = ? AND effective_to IS NULL", collectibility.LoanID).
+				Update("effective_to", collectibility.EffectiveFrom).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(collectibility).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.LoanSummary{}).
+			Where("id = ?", loanSummary.ID).
+			Updates(map[string]interface{}{
+				"dpd":                 loanSummary.Dpd,
+				"collectibility":      loanSummary.Collectibility,
+				"collectibility_date": loanSummary.CollectibilityDate,
+				"updated_by":          loanSummary.UpdatedBy,
+			}).Error
+	})
+}
+
+func (r *collectibilityMySQLRepository) GetCollectibilityPortfolio(ctx context.Context) ([]models.CollectibilityGradeReport, error) {
+	var grades []models.CollectibilityGradeReport
+	err := transaction.DB(ctx, r.db).
+		Model(&models.LoanSummary{}).
+		Select("currency, collectibility, COUNT(*) AS loan_count, COALESCE(SUM(outstanding_amount), 0) AS outstanding_amount").
+		Where("status NOT IN ? AND deleted_at IS NULL", []string{models.StatusPaid, models.StatusWrittenOff, models.StatusCancelled, models.StatusInactive}).
+		Group("currency, collectibility").
+		Order("currency ASC, collectibility ASC").
+		Scan(&grades).Error
+	if err != nil {
+		return nil, err
+	}
+	return grades, nil
+}
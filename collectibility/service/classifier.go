@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"billing-engine/models"
+)
+
+// DefaultDpdThresholds are the OJK upper DPD bounds of Kol 1 to Kol 4;
+// anything above the last bound is Kol 5 (Macet)
+var DefaultDpdThresholds = []int{0, 90, 120, 180}
+
+// Classifier maps days past due to an OJK collectibility grade
+type Classifier struct {
+	upperBounds []int
+}
+
+// NewClassifier creates a classifier from the inclusive upper DPD bounds of Kol 1 to Kol 4
+func NewClassifier(upperBounds []int) (*Classifier, error) {
+	if len(upperBounds) != models.CollectibilityLoss-1 {
+		return nil, fmt.Errorf("expected %d dpd thresholds, got %d", models.CollectibilityLoss-1, len(upperBounds))
+	}
+	for i, bound := range upperBounds {
+		if bound < 0 {
+			return nil, fmt.Errorf("dpd threshold %d cannot be negative", bound)
+		}
+		if i > 0 && bound <= upperBounds[i-1] {
+			return nil, fmt.Errorf("dpd thresholds must be strictly increasing")
+		}
+	}
+	return &Classifier{upperBounds: upperBounds}, nil
+}
+
+// ParseDpdThresholds parses a comma separated threshold list such as "0,90,120,180".
+// An empty value yields the OJK defaults.
+func ParseDpdThresholds(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultDpdThresholds, nil
+	}
+	parts := strings.Split(value, ",")
+	thresholds := make([]int, 0, len(parts))
+	for _, part := range parts {
+		threshold, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid dpd threshold %q: %v", part, err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// Classify returns the collectibility grade for the given days past due
+func (c *Classifier) Classify(dpd int) int {
+	for i, bound := range c.upperBounds {
+		if dpd <= bound {
+			return i + 1
+		}
+	}
+	return models.CollectibilityLoss
+}
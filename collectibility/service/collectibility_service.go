@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"billing-engine/collectibility"
+	"billing-engine/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// evaluationBatchSize is the number of loans loaded per page by the portfolio evaluation
+const evaluationBatchSize = 500
+
+type collectibilityService struct {
+	collectibilityRepo collectibility.CollectibilityMySQLRepositoryInterface
+	classifier         *Classifier
+}
+
+// NewCollectibilityService creates a new collectibility service instance
+func NewCollectibilityService(collectibilityRepo collectibility.CollectibilityMySQLRepositoryInterface, classifier *Classifier) collectibility.CollectibilityServiceInterface {
+	return &collectibilityService{
+		collectibilityRepo: collectibilityRepo,
+		classifier:         classifier,
+	}
+}
+
+func (s *collectibilityService) EvaluateLoan(ctx context.Context, loanID string, asOf time.Time) (*models.CollectibilityResponse, error) {
+	loanSummary, err := s.collectibilityRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	// Written-off loans keep the grade they had when they were written off
+	if loanSummary.Status != models.StatusWrittenOff {
+		if _, err := s.ClassifyLoan(ctx, loanSummary, asOf); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.CollectibilityResponse{
+		Collectibility: loanSummary.Collectibility,
+		Label:          models.CollectibilityLabels[loanSummary.Collectibility],
+		Dpd:            loanSummary.Dpd,
+		EffectiveDate:  loanSummary.CollectibilityDate,
+	}, nil
+}
+
+func (s *collectibilityService) EvaluatePortfolio(ctx context.Context, asOf time.Time) (*models.CollectibilityEvaluationResponse, error) {
+	result := &models.CollectibilityEvaluationResponse{AsOf: asOf}
+
+	var afterID uint
+	for {
+		loanSummaries, err := s.collectibilityRepo.GetActiveLoanSummaries(ctx, afterID, evaluationBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active loans: %v", err)
+		}
+		if len(loanSummaries) == 0 {
+			break
+		}
+
+		for _, loanSummary := range loanSummaries {
+			afterID = loanSummary.ID
+			result.EvaluatedLoans++
+
+			// A single bad loan must not stop the rest of the portfolio from being classified
+			reclassified, err := s.ClassifyLoan(ctx, loanSummary, asOf)
+			if err != nil {
+				log.Printf("collectibility evaluation failed for loan %s: %v", loanSummary.LoanID, err)
+				result.FailedLoans++
+				continue
+			}
+			if reclassified {
+				result.ReclassifiedLoans++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetPortfolioReport reports the active loans per currency and collectibility grade. Outstanding
+// amounts and ratios are only added up within a currency.
+func (s *collectibilityService) GetPortfolioReport(ctx context.Context) (*models.CollectibilityReportResponse, error) {
+	rows, err := s.collectibilityRepo.GetCollectibilityPortfolio(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collectibility portfolio: %v", err)
+	}
+
+	report := &models.CollectibilityReportResponse{
+		GeneratedAt: time.Now(),
+		Currencies:  make([]models.CollectibilityCurrencyReport, 0),
+	}
+
+	// Rows are ordered by currency, so each currency's grades are contiguous
+	var currencyOutstanding decimal.Decimal
+	for _, row := range rows {
+		if row.Collectibility < models.CollectibilityCurrent || row.Collectibility > models.CollectibilityLoss {
+			continue
+		}
+		if len(report.Currencies) == 0 || report.Currencies[len(report.Currencies)-1].Currency != row.Currency {
+			report.Currencies = append(report.Currencies, newCollectibilityCurrencyReport(row.Currency))
+			currencyOutstanding = decimal.Zero
+		}
+		currencyReport := &report.Currencies[len(report.Currencies)-1]
+		grade := &currencyReport.Grades[row.Collectibility-1]
+		grade.LoanCount = row.LoanCount
+		grade.OutstandingAmount = row.OutstandingAmount
+		currencyReport.TotalLoans += row.LoanCount
+		currencyOutstanding = currencyOutstanding.Add(decimal.NewFromFloat(row.OutstandingAmount))
+		currencyReport.OutstandingAmount = currencyOutstanding.InexactFloat64()
+		report.TotalLoans += row.LoanCount
+	}
+
+	for i := range report.Currencies {
+		currencyReport := &report.Currencies[i]
+		totalOutstanding := decimal.NewFromFloat(currencyReport.OutstandingAmount)
+		if !totalOutstanding.IsPositive() {
+			continue
+		}
+		for j := range currencyReport.Grades {
+			currencyReport.Grades[j].OutstandingRatio = decimal.NewFromFloat(currencyReport.Grades[j].OutstandingAmount).
+				Div(totalOutstanding).
+				Round(4).
+				InexactFloat64()
+		}
+	}
+
+	return report, nil
+}
+
+// newCollectibilityCurrencyReport returns an empty report of the currency that lists all five
+// grades, even those without loans
+func newCollectibilityCurrencyReport(currencyCode string) models.CollectibilityCurrencyReport {
+	grades := make([]models.CollectibilityGradeReport, models.CollectibilityLoss)
+	for i := range grades {
+		grades[i] = models.CollectibilityGradeReport{
+			Currency:       currencyCode,
+			Collectibility: i + 1,
+			Label:          models.CollectibilityLabels[i+1],
+		}
+	}
+	return models.CollectibilityCurrencyReport{
+		Currency: currencyCode,
+		Grades:   grades,
+	}
+}
+
+// GetActiveLoans returns the loans the portfolio evaluation classifies, in ID order after afterID
+func (s *collectibilityService) GetActiveLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	loanSummaries, err := s.collectibilityRepo.GetActiveLoanSummaries(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active loans: %v", err)
+	}
+	return loanSummaries, nil
+}
+
+// ClassifyLoan recalculates DPD and grade for the loan and persists any change.
+// It reports whether the loan moved to a different grade.
+func (s *collectibilityService) ClassifyLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) (bool, error) {
+	oldestOverdue, err := s.collectibilityRepo.GetOldestOverduePaymentSchedule(ctx, loanSummary.LoanID, asOf)
+	if err != nil {
+		return false, fmt.Errorf("failed to get overdue schedules: %v", err)
+	}
+
+	dpd := 0
+	if oldestOverdue != nil {
+		dpd = oldestOverdue.DaysPastDue(asOf)
+	}
+	grade := s.classifier.Classify(dpd)
+
+	var record *models.LoanCollectibility
+	reclassified := loanSummary.CollectibilityDate == nil || loanSummary.Collectibility != grade
+	if reclassified {
+		effectiveDate := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location())
+		record = &models.LoanCollectibility{
+			LoanID:            loanSummary.LoanID,
+			Collectibility:    grade,
+			Dpd:               dpd,
+			OutstandingAmount: loanSummary.OutstandingAmount,
+			EffectiveFrom:     effectiveDate,
+			CreatedBy:         "system",
+		}
+		loanSummary.Collectibility = grade
+		loanSummary.CollectibilityDate = &effectiveDate
+	} else if loanSummary.Dpd == dpd {
+		// Nothing changed since the last evaluation
+		return false, nil
+	}
+
+	loanSummary.Dpd = dpd
+	loanSummary.UpdatedBy = "system"
+	if err := s.collectibilityRepo.UpdateLoanClassification(ctx, loanSummary, record); err != nil {
+		return false, fmt.Errorf("failed to update loan classification: %v", err)
+	}
+
+	return reclassified, nil
+}
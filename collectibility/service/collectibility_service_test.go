@@ -0,0 +1,287 @@
+package service
+
+import (
+	mocks "billing-engine/collectibility/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestClassifier(t *testing.T) *Classifier {
+	classifier, err := NewClassifier(DefaultDpdThresholds)
+	assert.NoError(t, err)
+	return classifier
+}
+
+func TestClassifier_Classify(t *testing.T) {
+	classifier := newTestClassifier(t)
+
+	tests := []struct {
+		dpd      int
+		expected int
+	}{
+		{dpd: 0, expected: models.CollectibilityCurrent},
+		{dpd: 1, expected: models.CollectibilitySpecialMention},
+		{dpd: 90, expected: models.CollectibilitySpecialMention},
+		{dpd: 91, expected: models.CollectibilitySubstandard},
+		{dpd: 120, expected: models.CollectibilitySubstandard},
+		{dpd: 121, expected: models.CollectibilityDoubtful},
+		{dpd: 180, expected: models.CollectibilityDoubtful},
+		{dpd: 181, expected: models.CollectibilityLoss},
+		{dpd: 720, expected: models.CollectibilityLoss},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, classifier.Classify(tt.dpd), "dpd %d", tt.dpd)
+	}
+}
+
+func TestNewClassifier_InvalidThresholds(t *testing.T) {
+	_, err := NewClassifier([]int{0, 90, 120})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expected 4 dpd thresholds")
+
+	_, err = NewClassifier([]int{0, 90, 90, 180})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "strictly increasing")
+}
+
+func TestParseDpdThresholds(t *testing.T) {
+	thresholds, err := ParseDpdThresholds("0, 30, 60, 90")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 30, 60, 90}, thresholds)
+
+	thresholds, err = ParseDpdThresholds("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDpdThresholds, thresholds)
+
+	_, err = ParseDpdThresholds("0,abc")
+	assert.Error(t, err)
+}
+
+func TestCollectibilityService_EvaluateLoan_Reclassified(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+	previousDate := time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{
+		ID:                 1,
+		LoanID:             "loan_123",
+		OutstandingAmount:  3300000.00,
+		Collectibility:     models.CollectibilitySpecialMention,
+		CollectibilityDate: &previousDate,
+		Dpd:                88,
+	}
+	oldestOverdue := &models.PaymentSchedule{
+		LoanID:             "loan_123",
+		InstallmentDueDate: time.Date(2025, 8, 25, 0, 0, 0, 0, time.Local), // 98 days before asOf
+		Status:             models.StatusPending,
+	}
+
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOldestOverduePaymentSchedule", ctx, "loan_123", asOf).Return(oldestOverdue, nil)
+	mockRepo.On("UpdateLoanClassification", ctx, loanSummary, mock.MatchedBy(func(c *models.LoanCollectibility) bool {
+		return c.Collectibility == models.CollectibilitySubstandard &&
+			c.Dpd == 98 &&
+			c.OutstandingAmount == 3300000.00 &&
+			c.EffectiveFrom.Equal(time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local))
+	})).Return(nil)
+
+	// Execute
+	response, err := service.EvaluateLoan(ctx, "loan_123", asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, models.CollectibilitySubstandard, response.Collectibility)
+	assert.Equal(t, "Kurang Lancar", response.Label)
+	assert.Equal(t, 98, response.Dpd)
+	assert.Equal(t, 98, loanSummary.Dpd)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCollectibilityService_EvaluateLoan_SameGradeUpdatesDpdOnly(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+	previousDate := time.Date(2025, 11, 20, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{
+		ID:                 1,
+		LoanID:             "loan_123",
+		Collectibility:     models.CollectibilitySpecialMention,
+		CollectibilityDate: &previousDate,
+		Dpd:                3,
+	}
+	oldestOverdue := &models.PaymentSchedule{
+		LoanID:             "loan_123",
+		InstallmentDueDate: time.Date(2025, 11, 17, 0, 0, 0, 0, time.Local),
+		Status:             models.StatusPending,
+	}
+
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOldestOverduePaymentSchedule", ctx, "loan_123", asOf).Return(oldestOverdue, nil)
+	mockRepo.On("UpdateLoanClassification", ctx, loanSummary, (*models.LoanCollectibility)(nil)).Return(nil)
+
+	// Execute
+	response, err := service.EvaluateLoan(ctx, "loan_123", asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.CollectibilitySpecialMention, response.Collectibility)
+	assert.Equal(t, 14, response.Dpd)
+	assert.Equal(t, previousDate, *response.EffectiveDate)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCollectibilityService_EvaluateLoan_Unchanged(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+	previousDate := time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{
+		ID:                 1,
+		LoanID:             "loan_123",
+		Collectibility:     models.CollectibilityCurrent,
+		CollectibilityDate: &previousDate,
+	}
+
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOldestOverduePaymentSchedule", ctx, "loan_123", asOf).Return(nil, nil)
+
+	// Execute
+	response, err := service.EvaluateLoan(ctx, "loan_123", asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.CollectibilityCurrent, response.Collectibility)
+	assert.Equal(t, 0, response.Dpd)
+	mockRepo.AssertNotCalled(t, "UpdateLoanClassification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCollectibilityService_EvaluateLoan_LoanNotFound(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(nil, nil)
+
+	// Execute
+	response, err := service.EvaluateLoan(ctx, "loan_123", time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "loan not found")
+}
+
+func TestCollectibilityService_EvaluateLoan_WrittenOffKeepsGrade(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{
+		LoanID:         "loan_123",
+		Status:         models.StatusWrittenOff,
+		Dpd:            200,
+		Collectibility: models.CollectibilityLoss,
+	}
+
+	// Written-off loans have no pending installments left, so no reclassification happens
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+
+	// Execute
+	response, err := service.EvaluateLoan(ctx, "loan_123", time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.CollectibilityLoss, response.Collectibility)
+	assert.Equal(t, 200, response.Dpd)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCollectibilityService_EvaluatePortfolio(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	asOf := time.Date(2025, 12, 1, 0, 30, 0, 0, time.Local)
+	loans := []*models.LoanSummary{
+		{ID: 1, LoanID: "loan_1"}, // never classified
+		{ID: 2, LoanID: "loan_2"}, // repository error
+	}
+
+	mockRepo.On("GetActiveLoanSummaries", ctx, uint(0), evaluationBatchSize).Return(loans, nil)
+	mockRepo.On("GetActiveLoanSummaries", ctx, uint(2), evaluationBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetOldestOverduePaymentSchedule", ctx, "loan_1", asOf).Return(nil, nil)
+	mockRepo.On("GetOldestOverduePaymentSchedule", ctx, "loan_2", asOf).Return(nil, errors.New("database error"))
+	mockRepo.On("UpdateLoanClassification", ctx, loans[0], mock.AnythingOfType("*models.LoanCollectibility")).Return(nil)
+
+	// Execute
+	response, err := service.EvaluatePortfolio(ctx, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.EvaluatedLoans)
+	assert.Equal(t, 1, response.ReclassifiedLoans)
+	assert.Equal(t, 1, response.FailedLoans)
+	assert.Equal(t, models.CollectibilityCurrent, loans[0].Collectibility)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCollectibilityService_GetPortfolioReport(t *testing.T) {
+	mockRepo := mocks.NewCollectibilityMySQLRepositoryInterface(t)
+	service := NewCollectibilityService(mockRepo, newTestClassifier(t))
+	ctx := context.Background()
+
+	rows := []models.CollectibilityGradeReport{
+		{Currency: models.CurrencyIDR, Collectibility: models.CollectibilityCurrent, LoanCount: 8, OutstandingAmount: 7500000.00},
+		{Currency: models.CurrencyIDR, Collectibility: models.CollectibilityLoss, LoanCount: 2, OutstandingAmount: 2500000.00},
+		{Currency: "USD", Collectibility: models.CollectibilityCurrent, LoanCount: 3, OutstandingAmount: 900.00},
+		{Currency: "USD", Collectibility: models.CollectibilitySpecialMention, LoanCount: 1, OutstandingAmount: 100.00},
+	}
+	mockRepo.On("GetCollectibilityPortfolio", ctx).Return(rows, nil)
+
+	// Execute
+	response, err := service.GetPortfolioReport(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 14, response.TotalLoans)
+	assert.Len(t, response.Currencies, 2)
+
+	// Amounts and ratios are only added up within a currency
+	idr := response.Currencies[0]
+	assert.Equal(t, models.CurrencyIDR, idr.Currency)
+	assert.Equal(t, 10, idr.TotalLoans)
+	assert.Equal(t, 10000000.00, idr.OutstandingAmount)
+	assert.Len(t, idr.Grades, 5)
+	assert.Equal(t, "Lancar", idr.Grades[0].Label)
+	assert.Equal(t, 0.75, idr.Grades[0].OutstandingRatio)
+	assert.Equal(t, 0, idr.Grades[2].LoanCount)
+	assert.Equal(t, "Macet", idr.Grades[4].Label)
+	assert.Equal(t, 0.25, idr.Grades[4].OutstandingRatio)
+
+	usd := response.Currencies[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Equal(t, 4, usd.TotalLoans)
+	assert.Equal(t, 1000.00, usd.OutstandingAmount)
+	assert.Len(t, usd.Grades, 5)
+	assert.Equal(t, 0.9, usd.Grades[0].OutstandingRatio)
+	assert.Equal(t, 0.1, usd.Grades[1].OutstandingRatio)
+	assert.Equal(t, "USD", usd.Grades[4].Currency)
+	assert.Equal(t, 0, usd.Grades[4].LoanCount)
+}
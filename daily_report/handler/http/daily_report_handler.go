@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"billing-engine/daily_report"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DailyReportHandler struct {
+	dailyReportService daily_report.DailyReportServiceInterface
+	middleware         middlewares.GoMiddlewareInterface
+}
+
+// NewDailyReportHandler creates a new daily report handler instance
+func NewDailyReportHandler(e *echo.Echo, dailyReportService daily_report.DailyReportServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &DailyReportHandler{
+		dailyReportService: dailyReportService,
+		middleware:         middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/reports/daily", handler.GetDailyReport)
+}
+
+func (h *DailyReportHandler) GetDailyReport(c echo.Context) error {
+	// Without a business date the report covers yesterday, the date the daily job closed last
+	now := time.Now()
+	businessDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+	if c.QueryParam("business_date") != "" {
+		date, err := time.ParseInLocation("2006-01-02", c.QueryParam("business_date"), time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, global.BadResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Business date must use the YYYY-MM-DD format",
+			})
+		}
+		businessDate = date
+	}
+
+	response, err := h.dailyReportService.GetDailyReport(c.Request().Context(), businessDate)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DailyReportSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "billing-engine/daily_report/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestDailyReportHandler_GetDailyReport_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDailyReportServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DailyReportHandler{
+		dailyReportService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	expectedResponse := &models.DailyReportResponse{
+		BusinessDate: businessDate,
+		TotalLoans:   10,
+		Currencies: []models.DailyReportCurrencyReport{
+			{
+				Currency:          models.CurrencyIDR,
+				TotalLoans:        10,
+				OutstandingAmount: 46600000.00,
+				OverdueAmount:     221709.00,
+				Buckets: []models.DailyReportBucketReport{
+					{DpdBucket: models.DpdBucketCurrent, Loans: 8, OutstandingAmount: 40000000.00},
+					{DpdBucket: models.DpdBucket1To30, Loans: 2, OutstandingAmount: 6600000.00, OverdueAmount: 221709.00},
+				},
+			},
+		},
+	}
+	mockService.On("GetDailyReport", mock.Anything, businessDate).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/daily?business_date=2026-03-10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetDailyReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.DailyReportSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 10, response.Data.TotalLoans)
+	assert.Len(t, response.Data.Currencies[0].Buckets, 2)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDailyReportHandler_GetDailyReport_DefaultsToYesterday(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDailyReportServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DailyReportHandler{
+		dailyReportService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	now := time.Now()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+	mockService.On("GetDailyReport", mock.Anything, yesterday).Return(nil, errors.New("failed to get daily positions: connection refused"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/daily", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetDailyReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestDailyReportHandler_GetDailyReport_InvalidDateFormat(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDailyReportServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DailyReportHandler{
+		dailyReportService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/daily?business_date=10-03-2026", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetDailyReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "Business date must use the YYYY-MM-DD format", response.Message)
+}
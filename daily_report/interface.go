@@ -0,0 +1,22 @@
+package daily_report
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// DailyReportMySQLRepositoryInterface defines the interface for daily loan position repository
+type DailyReportMySQLRepositoryInterface interface {
+	GetReportableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetOverduePaymentSchedules(ctx context.Context, loanID string, asOf time.Time) ([]*models.PaymentSchedule, error)
+	SavePosition(ctx context.Context, position *models.LoanDailyPosition) error
+	GetPositionTotals(ctx context.Context, businessDate time.Time) ([]*models.DailyPositionTotals, error)
+}
+
+// DailyReportServiceInterface defines the interface for daily loan position service
+type DailyReportServiceInterface interface {
+	GetReportableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	RecordLoanPosition(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error
+	GetDailyReport(ctx context.Context, businessDate time.Time) (*models.DailyReportResponse, error)
+}
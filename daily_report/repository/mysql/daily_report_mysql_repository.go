@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/daily_report"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dailyReportMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewDailyReportMySQLRepository creates a new daily loan position repository instance
+func NewDailyReportMySQLRepository(db *gorm.DB) daily_report.DailyReportMySQLRepositoryInterface {
+	return &dailyReportMySQLRepository{db: db}
+}
+
+// GetReportableLoanSummaries returns the disbursed loans that are not settled yet
+func (r *dailyReportMySQLRepository) GetReportableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status IN ? AND deleted_at IS NULL", afterID, []string{models.StatusPending, models.StatusDelinquent}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+func (r *dailyReportMySQLRepository) GetOverduePaymentSchedules(ctx context.Context, loanID string, asOf time.Time) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND installment_due_date < ? AND deleted_at IS NULL", loanID, models.StatusPending, asOf).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// SavePosition stores the position of the loan on its business date, replacing the one recorded
+// by an earlier attempt of the same run
+func (r *dailyReportMySQLRepository) SavePosition(ctx context.Context, position *models.LoanDailyPosition) error {
+	return transaction.DB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "business_date"}, {Name: "loan_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"customer_id", "product_code", "currency", "status", "outstanding_amount", "overdue_amount", "dpd", "collectibility", "updated_at",
+		}),
+	}).Create(position).Error
+}
+
+// GetPositionTotals aggregates the positions of the business date per currency and DPD bucket,
+// ordered by currency and DPD
+func (r *dailyReportMySQLRepository) GetPositionTotals(ctx context.Context, businessDate time.Time) ([]*models.DailyPositionTotals, error) {
+	var totals []*models.DailyPositionTotals
+	err := transaction.DB(ctx, r.db).
+		Model(&models.LoanDailyPosition{}).
+		Select("currency, CASE WHEN dpd = 0 THEN ? WHEN dpd <= 30 THEN ? WHEN dpd <= 60 THEN ? WHEN dpd <= 90 THEN ? ELSE ? END AS dpd_bucket, "+
+			"COUNT(*) AS loans, COALESCE(SUM(outstanding_amount), 0) AS outstanding_amount, COALESCE(SUM(overdue_amount), 0) AS overdue_amount",
+			models.DpdBucketCurrent, models.DpdBucket1To30, models.DpdBucket31To60, models.DpdBucket61To90, models.DpdBucketOver90).
+		Where("business_date = ?", businessDate.Format("2006-01-02")).
+		Group("currency, dpd_bucket").
+		Order("currency ASC, MIN(dpd) ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
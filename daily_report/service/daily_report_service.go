@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"billing-engine/daily_report"
+	"billing-engine/models"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+type dailyReportService struct {
+	dailyReportRepo daily_report.DailyReportMySQLRepositoryInterface
+	taxService      tax.TaxServiceInterface
+}
+
+// NewDailyReportService creates a new daily loan position service instance
+func NewDailyReportService(dailyReportRepo daily_report.DailyReportMySQLRepositoryInterface, taxService tax.TaxServiceInterface) daily_report.DailyReportServiceInterface {
+	return &dailyReportService{
+		dailyReportRepo: dailyReportRepo,
+		taxService:      taxService,
+	}
+}
+
+// GetReportableLoans returns the loans the daily report covers, in ID order after afterID
+func (s *dailyReportService) GetReportableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	loanSummaries, err := s.dailyReportRepo.GetReportableLoanSummaries(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reportable loans: %v", err)
+	}
+	return loanSummaries, nil
+}
+
+// RecordLoanPosition records the loan's position at the end of the business date. The step runs
+// after the other end-of-day steps, so the DPD, grade, status and penalties are those of the date.
+// Recording a date again replaces its position.
+func (s *dailyReportService) RecordLoanPosition(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	overdueSchedules, err := s.dailyReportRepo.GetOverduePaymentSchedules(ctx, loanSummary.LoanID, businessDate.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("failed to get overdue payment schedules: %v", err)
+	}
+
+	loanCurrency := currency.Normalize(loanSummary.Currency)
+	overdueAmount := decimal.Zero
+	for _, schedule := range overdueSchedules {
+		overdueAmount = overdueAmount.Add(s.amountDue(schedule, loanCurrency))
+	}
+
+	position := &models.LoanDailyPosition{
+		BusinessDate:      businessDate,
+		LoanID:            loanSummary.LoanID,
+		CustomerID:        loanSummary.CustomerID,
+		ProductCode:       loanSummary.ProductCode,
+		Currency:          loanCurrency,
+		Status:            loanSummary.Status,
+		OutstandingAmount: loanSummary.OutstandingAmount,
+		OverdueAmount:     overdueAmount.InexactFloat64(),
+		Dpd:               loanSummary.Dpd,
+		Collectibility:    loanSummary.Collectibility,
+	}
+	if err := s.dailyReportRepo.SavePosition(ctx, position); err != nil {
+		return fmt.Errorf("failed to save daily position: %v", err)
+	}
+	return nil
+}
+
+// GetDailyReport reports the positions recorded for the business date per currency and DPD bucket
+func (s *dailyReportService) GetDailyReport(ctx context.Context, businessDate time.Time) (*models.DailyReportResponse, error) {
+	totals, err := s.dailyReportRepo.GetPositionTotals(ctx, businessDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily positions: %v", err)
+	}
+
+	report := &models.DailyReportResponse{
+		BusinessDate: businessDate,
+		GeneratedAt:  time.Now(),
+		Currencies:   make([]models.DailyReportCurrencyReport, 0),
+	}
+
+	// Totals are ordered by currency, so each currency's buckets are contiguous
+	var outstandingAmount, overdueAmount decimal.Decimal
+	for _, total := range totals {
+		if len(report.Currencies) == 0 || report.Currencies[len(report.Currencies)-1].Currency != total.Currency {
+			report.Currencies = append(report.Currencies, models.DailyReportCurrencyReport{
+				Currency: total.Currency,
+				Buckets:  make([]models.DailyReportBucketReport, 0),
+			})
+			outstandingAmount, overdueAmount = decimal.Zero, decimal.Zero
+		}
+		currencyReport := &report.Currencies[len(report.Currencies)-1]
+		currencyReport.Buckets = append(currencyReport.Buckets, models.DailyReportBucketReport{
+			DpdBucket:         total.DpdBucket,
+			Loans:             total.Loans,
+			OutstandingAmount: total.OutstandingAmount,
+			OverdueAmount:     total.OverdueAmount,
+		})
+
+		currencyReport.TotalLoans += total.Loans
+		outstandingAmount = outstandingAmount.Add(decimal.NewFromFloat(total.OutstandingAmount))
+		overdueAmount = overdueAmount.Add(decimal.NewFromFloat(total.OverdueAmount))
+		currencyReport.OutstandingAmount = outstandingAmount.InexactFloat64()
+		currencyReport.OverdueAmount = overdueAmount.InexactFloat64()
+		report.TotalLoans += total.Loans
+	}
+
+	return report, nil
+}
+
+// amountDue is what paying the installment takes, as the repayment requires it: the installment,
+// its penalty and the tax on that penalty
+func (s *dailyReportService) amountDue(schedule *models.PaymentSchedule, currencyCode string) decimal.Decimal {
+	amount := decimal.NewFromFloat(schedule.InstallmentAmount)
+	if schedule.PenaltyAmount <= 0 {
+		return amount
+	}
+
+	amount = amount.Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+	if taxLine := s.taxService.TaxCharge(models.ChargeTypePenalty, schedule.PenaltyAmount, currencyCode); taxLine != nil {
+		amount = amount.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+	}
+	return amount
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	mocks "billing-engine/daily_report/_mock"
+	"billing-engine/models"
+	taxMocks "billing-engine/tax/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDailyReportService_RecordLoanPosition(t *testing.T) {
+	mockRepo := mocks.NewDailyReportMySQLRepositoryInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	service := NewDailyReportService(mockRepo, mockTax)
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{
+		ID:                1,
+		LoanID:            "loan_123",
+		CustomerID:        "customer_123",
+		ProductCode:       "WEEKLY_50",
+		Status:            models.StatusDelinquent,
+		OutstandingAmount: 3300000.00,
+		Dpd:               14,
+		Collectibility:    models.CollectibilityCurrent,
+	}
+	overdueSchedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 110000.00, PenaltyAmount: 1540.00},
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00},
+	}
+
+	// Mock repository calls: overdue as of the end of the business date
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)).Return(overdueSchedules, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 1540.00, models.CurrencyIDR).Return(&models.TaxLine{TaxAmount: 169.00})
+	mockRepo.On("SavePosition", ctx, mock.MatchedBy(func(position *models.LoanDailyPosition) bool {
+		return position.BusinessDate.Equal(businessDate) && position.LoanID == "loan_123" && position.Currency == models.CurrencyIDR &&
+			position.Status == models.StatusDelinquent && position.OutstandingAmount == 3300000.00 &&
+			position.OverdueAmount == 221709.00 && position.Dpd == 14
+	})).Return(nil)
+
+	// Execute
+	err := service.RecordLoanPosition(ctx, loanSummary, businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDailyReportService_RecordLoanPosition_SaveFails(t *testing.T) {
+	mockRepo := mocks.NewDailyReportMySQLRepositoryInterface(t)
+	service := NewDailyReportService(mockRepo, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Status: models.StatusPending}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("SavePosition", ctx, mock.Anything).Return(errors.New("connection refused"))
+
+	// Execute
+	err := service.RecordLoanPosition(ctx, loanSummary, businessDate)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to save daily position")
+}
+
+func TestDailyReportService_GetDailyReport(t *testing.T) {
+	mockRepo := mocks.NewDailyReportMySQLRepositoryInterface(t)
+	service := NewDailyReportService(mockRepo, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls, ordered by currency and DPD
+	mockRepo.On("GetPositionTotals", ctx, businessDate).Return([]*models.DailyPositionTotals{
+		{Currency: models.CurrencyIDR, DpdBucket: models.DpdBucketCurrent, Loans: 8, OutstandingAmount: 40000000.00},
+		{Currency: models.CurrencyIDR, DpdBucket: models.DpdBucket1To30, Loans: 2, OutstandingAmount: 6600000.00, OverdueAmount: 221709.00},
+		{Currency: "USD", DpdBucket: models.DpdBucketOver90, Loans: 1, OutstandingAmount: 1200.00, OverdueAmount: 450.50},
+	}, nil)
+
+	// Execute
+	report, err := service.GetDailyReport(ctx, businessDate)
+
+	// Assert: amounts are only added up within a currency
+	assert.NoError(t, err)
+	assert.Equal(t, 11, report.TotalLoans)
+	assert.Len(t, report.Currencies, 2)
+
+	idr := report.Currencies[0]
+	assert.Equal(t, models.CurrencyIDR, idr.Currency)
+	assert.Equal(t, 10, idr.TotalLoans)
+	assert.Equal(t, 46600000.00, idr.OutstandingAmount)
+	assert.Equal(t, 221709.00, idr.OverdueAmount)
+	assert.Len(t, idr.Buckets, 2)
+	assert.Equal(t, models.DpdBucket1To30, idr.Buckets[1].DpdBucket)
+
+	usd := report.Currencies[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Equal(t, 1, usd.TotalLoans)
+	assert.Equal(t, 1200.00, usd.OutstandingAmount)
+	assert.Equal(t, 450.50, usd.OverdueAmount)
+}
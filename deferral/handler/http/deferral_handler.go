@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+
+	"billing-engine/deferral"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeferralHandler struct {
+	deferralService deferral.DeferralServiceInterface
+	middleware      middlewares.GoMiddlewareInterface
+}
+
+// NewDeferralHandler creates a new installment deferral handler instance
+func NewDeferralHandler(e *echo.Echo, deferralService deferral.DeferralServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &DeferralHandler{
+		deferralService: deferralService,
+		middleware:      middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/loans/:loan_id/deferral", handler.DeferInstallments)
+}
+
+func (h *DeferralHandler) DeferInstallments(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	var req models.DeferralRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.deferralService.DeferInstallments(c.Request().Context(), loanID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DeferralSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "billing-engine/deferral/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestDeferralHandler_DeferInstallments_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDeferralServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DeferralHandler{
+		deferralService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	req := models.DeferralRequest{
+		Periods: 2,
+		Reason:  "Payment holiday",
+	}
+	expectedResponse := &models.DeferralResponse{
+		LoanID:               "loan_123",
+		DeferralID:           1,
+		Periods:              2,
+		InstallmentUnit:      models.InstallmentUnitWeek,
+		DeferredInstallments: 3,
+		OutstandingAmount:    330000.00,
+	}
+
+	mockService.On("DeferInstallments", mock.Anything, "loan_123", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/deferral", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.DeferInstallments(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.DeferralSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 3, response.Data.DeferredInstallments)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDeferralHandler_DeferInstallments_InvalidPeriods(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDeferralServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DeferralHandler{
+		deferralService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(models.DeferralRequest{
+		Periods: 0,
+		Reason:  "Payment holiday",
+	})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/deferral", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.DeferInstallments(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDeferralHandler_DeferInstallments_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDeferralServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DeferralHandler{
+		deferralService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	req := models.DeferralRequest{Periods: 1, Reason: "Payment holiday"}
+	mockService.On("DeferInstallments", mock.Anything, "loan_123", &req).Return(nil, errors.New("loan not found"))
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/deferral", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.DeferInstallments(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,19 @@
+package deferral
+
+import (
+	"billing-engine/models"
+	"context"
+)
+
+// DeferralMySQLRepositoryInterface defines the interface for installment deferral repository
+type DeferralMySQLRepositoryInterface interface {
+	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	DeferPaymentSchedules(ctx context.Context, loanSummary *models.LoanSummary, deferral *models.LoanDeferral, schedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DeferralServiceInterface defines the interface for installment deferral service
+type DeferralServiceInterface interface {
+	DeferInstallments(ctx context.Context, loanID string, req *models.DeferralRequest) (*models.DeferralResponse, error)
+}
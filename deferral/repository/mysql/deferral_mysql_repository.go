@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"billing-engine/deferral"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type deferralMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewDeferralMySQLRepository creates a new installment deferral repository instance
+func NewDeferralMySQLRepository(db *gorm.DB) deferral.DeferralMySQLRepositoryInterface {
+	return &deferralMySQLRepository{db: db}
+}
+
+// GetLoanSummaryByLoanID reads the loan and locks it until the transaction ends
+func (r *deferralMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
+
+func (r *deferralMySQLRepository) GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanID, models.StatusPending).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// DeferPaymentSchedules stores the deferral, the shifted installments with their history rows
+// and the loan summary amounts in one transaction. It fails when a shifted installment is no
+// longer pending or the loan is no longer pending or delinquent.
+func (r *deferralMySQLRepository) DeferPaymentSchedules(ctx context.Context, loanSummary *models.LoanSummary, deferral *models.LoanDeferral, schedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(deferral).Error; err != nil {
+			return err
+		}
+
+		for _, schedule := range schedules {
+			result := tx.Model(schedule).
+				Where("status = ? AND deleted_at IS NULL", models.StatusPending).
+				Updates(map[string]interface{}{
+					"installment_due_date": schedule.InstallmentDueDate,
+					"installment_amount":   schedule.InstallmentAmount,
+					"updated_by":           schedule.UpdatedBy,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("installment %d of loan %s is no longer pending", schedule.InstallmentNumber, loanSummary.LoanID)
+			}
+		}
+
+		if err := tx.Create(&histories).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(loanSummary).
+			Where("status IN ?", []string{models.StatusPending, models.StatusDelinquent}).
+			Updates(map[string]interface{}{
+				"interest_amount":    loanSummary.InterestAmount,
+				"outstanding_amount": loanSummary.OutstandingAmount,
+				"updated_by":         loanSummary.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("loan %s can no longer be deferred", loanSummary.LoanID)
+		}
+		return nil
+	})
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *deferralMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
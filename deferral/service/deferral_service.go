@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"billing-engine/collectibility"
+	"billing-engine/deferral"
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+type deferralService struct {
+	deferralRepo          deferral.DeferralMySQLRepositoryInterface
+	collectibilityService collectibility.CollectibilityServiceInterface
+	ledgerService         ledger.LedgerServiceInterface
+}
+
+// NewDeferralService creates a new installment deferral service instance
+func NewDeferralService(deferralRepo deferral.DeferralMySQLRepositoryInterface, collectibilityService collectibility.CollectibilityServiceInterface, ledgerService ledger.LedgerServiceInterface) deferral.DeferralServiceInterface {
+	return &deferralService{
+		deferralRepo:          deferralRepo,
+		collectibilityService: collectibilityService,
+		ledgerService:         ledgerService,
+	}
+}
+
+// DeferInstallments shifts the pending installments of a loan from the requested one onwards. The
+// loan is read and locked, and deferred, in one transaction, so the deferral applies to the
+// installments and balance left by any repayment committed before.
+func (s *deferralService) DeferInstallments(ctx context.Context, loanID string, req *models.DeferralRequest) (*models.DeferralResponse, error) {
+	var (
+		loanSummary       *models.LoanSummary
+		affectedSchedules []*models.PaymentSchedule
+		installments      []models.DeferredInstallmentResponse
+		deferralRecord    *models.LoanDeferral
+	)
+	deferredAt := time.Now()
+	err := s.deferralRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		loanSummary, err = s.deferralRepo.GetLoanSummaryByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get loan summary: %v", err)
+		}
+		if loanSummary == nil {
+			return fmt.Errorf("loan not found")
+		}
+		switch loanSummary.Status {
+		case models.StatusPaid, models.StatusWrittenOff, models.StatusCancelled, models.StatusInactive:
+			return fmt.Errorf("loan with status %s cannot be deferred", loanSummary.Status)
+		}
+
+		pendingSchedules, err := s.deferralRepo.GetPendingPaymentSchedulesByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get pending schedules: %v", err)
+		}
+		if len(pendingSchedules) == 0 {
+			return fmt.Errorf("no pending installments found")
+		}
+
+		// Defer from the next pending installment unless a later one is requested
+		fromInstallment := req.FromInstallment
+		if fromInstallment == 0 {
+			fromInstallment = pendingSchedules[0].InstallmentNumber
+		}
+
+		affectedSchedules = make([]*models.PaymentSchedule, 0, len(pendingSchedules))
+		for _, schedule := range pendingSchedules {
+			if schedule.InstallmentNumber >= fromInstallment {
+				affectedSchedules = append(affectedSchedules, schedule)
+			}
+		}
+		if len(affectedSchedules) == 0 || affectedSchedules[0].InstallmentNumber != fromInstallment {
+			return fmt.Errorf("installment %d is not a pending installment", fromInstallment)
+		}
+
+		// Optional deferral interest on the deferred amount, charged on the first installment after the holiday
+		deferredAmount := decimal.Zero
+		for _, schedule := range affectedSchedules {
+			deferredAmount = deferredAmount.Add(decimal.NewFromFloat(schedule.InstallmentAmount))
+		}
+		deferralInterest := currency.Round(deferredAmount.Mul(decimal.NewFromFloat(req.DeferralInterestRate)), loanSummary.Currency)
+
+		installments = make([]models.DeferredInstallmentResponse, 0, len(affectedSchedules))
+		histories := make([]*models.PaymentScheduleHistory, 0, len(affectedSchedules))
+		for i, schedule := range affectedSchedules {
+			previousDueDate := schedule.InstallmentDueDate
+			schedule.InstallmentDueDate = shiftDueDate(previousDueDate, loanSummary.InstallmentUnit, req.Periods)
+			if i == 0 && deferralInterest.IsPositive() {
+				schedule.InstallmentAmount = decimal.NewFromFloat(schedule.InstallmentAmount).Add(deferralInterest).InexactFloat64()
+			}
+			schedule.UpdatedBy = "system"
+
+			histories = append(histories, &models.PaymentScheduleHistory{
+				ScheduleID:         schedule.ID,
+				LoanID:             schedule.LoanID,
+				Action:             models.ActionReschedule,
+				InstallmentNumber:  schedule.InstallmentNumber,
+				InstallmentAmount:  schedule.InstallmentAmount,
+				InstallmentDueDate: schedule.InstallmentDueDate,
+				Status:             schedule.Status,
+				Currency:           schedule.Currency,
+				CreatedBy:          "system",
+			})
+			installments = append(installments, models.DeferredInstallmentResponse{
+				InstallmentNumber: schedule.InstallmentNumber,
+				PreviousDueDate:   previousDueDate,
+				DueDate:           schedule.InstallmentDueDate,
+				InstallmentAmount: schedule.InstallmentAmount,
+			})
+		}
+
+		deferralRecord = &models.LoanDeferral{
+			LoanID:                 loanID,
+			FromInstallment:        fromInstallment,
+			Periods:                req.Periods,
+			DeferredInstallments:   len(affectedSchedules),
+			DeferralInterestRate:   req.DeferralInterestRate,
+			DeferralInterestAmount: deferralInterest.InexactFloat64(),
+			Reason:                 req.Reason,
+			DeferredAt:             deferredAt,
+			CreatedBy:              "system",
+		}
+
+		loanSummary.InterestAmount = decimal.NewFromFloat(loanSummary.InterestAmount).Add(deferralInterest).InexactFloat64()
+		loanSummary.OutstandingAmount = decimal.NewFromFloat(loanSummary.OutstandingAmount).Add(deferralInterest).InexactFloat64()
+		loanSummary.UpdatedBy = "system"
+
+		if err := s.deferralRepo.DeferPaymentSchedules(ctx, loanSummary, deferralRecord, affectedSchedules, histories); err != nil {
+			return fmt.Errorf("failed to defer installments: %v", err)
+		}
+		if err := s.ledgerService.PostDeferralInterest(ctx, loanSummary, deferralInterest.InexactFloat64(), deferredAt); err != nil {
+			return fmt.Errorf("failed to post deferral interest: %v", err)
+		}
+
+		// Shifted due dates can clear overdue installments, so the overdue position must be re-evaluated
+		if _, err := s.collectibilityService.EvaluateLoan(ctx, loanID, deferredAt); err != nil {
+			return fmt.Errorf("failed to evaluate collectibility: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DeferralResponse{
+		LoanID:                 loanID,
+		DeferralID:             deferralRecord.ID,
+		Periods:                req.Periods,
+		InstallmentUnit:        loanSummary.InstallmentUnit,
+		DeferredInstallments:   len(affectedSchedules),
+		DeferralInterestAmount: deferralRecord.DeferralInterestAmount,
+		OutstandingAmount:      loanSummary.OutstandingAmount,
+		DeferredAt:             deferredAt,
+		Installments:           installments,
+	}, nil
+}
+
+// shiftDueDate moves a due date forward by the given number of installment periods
+func shiftDueDate(dueDate time.Time, installmentUnit string, periods int) time.Time {
+	if installmentUnit == models.InstallmentUnitWeek {
+		return dueDate.AddDate(0, 0, 7*periods)
+	}
+	return dueDate.AddDate(0, periods, 0) // month
+}
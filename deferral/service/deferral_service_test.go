@@ -0,0 +1,245 @@
+package service
+
+import (
+	collectibilityMocks "billing-engine/collectibility/_mock"
+	mocks "billing-engine/deferral/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShiftDueDate(t *testing.T) {
+	dueDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC), shiftDueDate(dueDate, models.InstallmentUnitWeek, 2))
+	assert.Equal(t, time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), shiftDueDate(dueDate, models.InstallmentUnitMonth, 1))
+}
+
+func TestDeferralService_DeferInstallments_Success(t *testing.T) {
+	mockRepo := mocks.NewDeferralMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewDeferralService(mockRepo, mockCollectibility, mockLedger)
+	ctx := context.Background()
+
+	req := &models.DeferralRequest{
+		Periods:              2,
+		DeferralInterestRate: 0.01,
+		Reason:               "Payment holiday - medical leave",
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		InterestAmount:    500000.00,
+		OutstandingAmount: 330000.00,
+		InstallmentUnit:   models.InstallmentUnitWeek,
+		Status:            models.StatusPending,
+	}
+
+	firstDueDate := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 48, LoanID: "loan_123", InstallmentNumber: 48, InstallmentAmount: 110000.00, InstallmentDueDate: firstDueDate, Status: models.StatusPending},
+		{ID: 49, LoanID: "loan_123", InstallmentNumber: 49, InstallmentAmount: 110000.00, InstallmentDueDate: firstDueDate.AddDate(0, 0, 7), Status: models.StatusPending},
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, InstallmentDueDate: firstDueDate.AddDate(0, 0, 14), Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostDeferralInterest", ctx, loanSummary, 3300.00, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("DeferPaymentSchedules", ctx, loanSummary,
+		mock.MatchedBy(func(deferral *models.LoanDeferral) bool {
+			return deferral.FromInstallment == 48 && deferral.DeferredInstallments == 3 &&
+				deferral.DeferralInterestAmount == 3300.00
+		}),
+		pendingSchedules,
+		mock.MatchedBy(func(histories []*models.PaymentScheduleHistory) bool {
+			return len(histories) == 3 && histories[0].Action == models.ActionReschedule
+		}),
+	).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{}, nil)
+
+	// Execute
+	response, err := service.DeferInstallments(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 3, response.DeferredInstallments)
+	assert.Equal(t, 3300.00, response.DeferralInterestAmount)
+	assert.Equal(t, 333300.00, response.OutstandingAmount)
+	assert.Len(t, response.Installments, 3)
+	assert.Equal(t, firstDueDate, response.Installments[0].PreviousDueDate)
+	assert.Equal(t, time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC), response.Installments[0].DueDate)
+	assert.Equal(t, 113300.00, response.Installments[0].InstallmentAmount)
+	assert.Equal(t, 110000.00, response.Installments[1].InstallmentAmount)
+
+	// Loan summary carries the deferral interest
+	assert.Equal(t, 503300.00, loanSummary.InterestAmount)
+	assert.Equal(t, 333300.00, loanSummary.OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+	mockCollectibility.AssertExpectations(t)
+}
+
+func TestDeferralService_DeferInstallments_FromLaterInstallment(t *testing.T) {
+	mockRepo := mocks.NewDeferralMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewDeferralService(mockRepo, mockCollectibility, mockLedger)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 330000.00, InstallmentUnit: models.InstallmentUnitMonth, Status: models.StatusPending}
+	firstDueDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 48, LoanID: "loan_123", InstallmentNumber: 48, InstallmentAmount: 110000.00, InstallmentDueDate: firstDueDate, Status: models.StatusPending},
+		{ID: 49, LoanID: "loan_123", InstallmentNumber: 49, InstallmentAmount: 110000.00, InstallmentDueDate: firstDueDate.AddDate(0, 1, 0), Status: models.StatusPending},
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, InstallmentDueDate: firstDueDate.AddDate(0, 2, 0), Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostDeferralInterest", ctx, loanSummary, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("DeferPaymentSchedules", ctx, loanSummary, mock.AnythingOfType("*models.LoanDeferral"),
+		mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+			return len(schedules) == 2 && schedules[0].InstallmentNumber == 49
+		}),
+		mock.AnythingOfType("[]*models.PaymentScheduleHistory"),
+	).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{}, nil)
+
+	// Execute
+	response, err := service.DeferInstallments(ctx, "loan_123", &models.DeferralRequest{
+		Periods:         1,
+		FromInstallment: 49,
+		Reason:          "Payment holiday",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.DeferredInstallments)
+	assert.Equal(t, 0.0, response.DeferralInterestAmount)
+	assert.Equal(t, 330000.00, response.OutstandingAmount)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), response.Installments[0].DueDate)
+
+	// Installment before the holiday keeps its due date
+	assert.Equal(t, firstDueDate, pendingSchedules[0].InstallmentDueDate)
+}
+
+func TestDeferralService_DeferInstallments_InstallmentNotPending(t *testing.T) {
+	mockRepo := mocks.NewDeferralMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewDeferralService(mockRepo, mockCollectibility, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending}, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{
+		{ID: 48, LoanID: "loan_123", InstallmentNumber: 48, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}, nil)
+
+	// Execute
+	response, err := service.DeferInstallments(ctx, "loan_123", &models.DeferralRequest{
+		Periods:         1,
+		FromInstallment: 10,
+		Reason:          "Payment holiday",
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "is not a pending installment")
+}
+
+func TestDeferralService_DeferInstallments_PaidLoan(t *testing.T) {
+	mockRepo := mocks.NewDeferralMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewDeferralService(mockRepo, mockCollectibility, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: models.StatusPaid}, nil)
+
+	// Execute
+	response, err := service.DeferInstallments(ctx, "loan_123", &models.DeferralRequest{Periods: 1, Reason: "Payment holiday"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "cannot be deferred")
+}
+
+func TestDeferralService_DeferInstallments_NotDisbursedLoan(t *testing.T) {
+	mockRepo := mocks.NewDeferralMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewDeferralService(mockRepo, mockCollectibility, mockLedger)
+	ctx := context.Background()
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+
+	for _, status := range []string{models.StatusInactive, models.StatusCancelled} {
+		// Mock repository calls
+		mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: status}, nil).Once()
+
+		// Execute
+		response, err := service.DeferInstallments(ctx, "loan_123", &models.DeferralRequest{Periods: 1, Reason: "Payment holiday"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "loan with status "+status+" cannot be deferred")
+	}
+}
+
+func TestDeferralService_DeferInstallments_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewDeferralMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewDeferralService(mockRepo, mockCollectibility, mockLedger)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", InstallmentUnit: models.InstallmentUnitWeek, Status: models.StatusPending}
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("DeferPaymentSchedules", ctx, loanSummary, mock.Anything, pendingSchedules, mock.Anything).
+		Return(errors.New("database error"))
+
+	// Execute
+	response, err := service.DeferInstallments(ctx, "loan_123", &models.DeferralRequest{Periods: 1, Reason: "Payment holiday"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to defer installments")
+}
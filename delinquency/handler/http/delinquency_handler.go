@@ -0,0 +1,93 @@
+package http
+
+import (
+	"net/http"
+
+	"billing-engine/delinquency"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DelinquencyHandler struct {
+	delinquencyService delinquency.DelinquencyServiceInterface
+	middleware         middlewares.GoMiddlewareInterface
+}
+
+// NewDelinquencyHandler creates a new delinquency policy handler instance
+func NewDelinquencyHandler(e *echo.Echo, delinquencyService delinquency.DelinquencyServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &DelinquencyHandler{
+		delinquencyService: delinquencyService,
+		middleware:         middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/products/:product_code/delinquency-policy", handler.GetPolicy)
+	v1.PUT("/products/:product_code/delinquency-policy", handler.UpdatePolicy)
+}
+
+func (h *DelinquencyHandler) GetPolicy(c echo.Context) error {
+	productCode := c.Param("product_code")
+	if productCode == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Product code is required",
+		})
+	}
+
+	response, err := h.delinquencyService.GetPolicy(c.Request().Context(), productCode)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DelinquencyPolicySuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *DelinquencyHandler) UpdatePolicy(c echo.Context) error {
+	productCode := c.Param("product_code")
+	if productCode == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Product code is required",
+		})
+	}
+
+	var req models.DelinquencyPolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.delinquencyService.UpdatePolicy(c.Request().Context(), productCode, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DelinquencyPolicySuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
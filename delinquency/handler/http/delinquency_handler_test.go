@@ -0,0 +1,143 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "billing-engine/delinquency/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestDelinquencyHandler_GetPolicy_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDelinquencyServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DelinquencyHandler{
+		delinquencyService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	expectedResponse := &models.DelinquencyPolicyResponse{
+		ProductCode: "WEEKLY_50",
+		Source:      models.DelinquencyPolicySourceDefaultProduct,
+		Rules: []models.DelinquencyRuleResponse{
+			{RuleType: models.DelinquencyRuleOverdueInstallments, Threshold: 1, Description: "more than 1 overdue installment(s)"},
+		},
+	}
+
+	mockService.On("GetPolicy", mock.Anything, "WEEKLY_50").Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/products/WEEKLY_50/delinquency-policy", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("WEEKLY_50")
+
+	// Execute
+	err := handler.GetPolicy(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.DelinquencyPolicySuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, models.DelinquencyPolicySourceDefaultProduct, response.Data.Source)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDelinquencyHandler_UpdatePolicy_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDelinquencyServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DelinquencyHandler{
+		delinquencyService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	req := models.DelinquencyPolicyRequest{
+		Rules: []models.DelinquencyRuleRequest{
+			{RuleType: models.DelinquencyRuleDpdAbove, Threshold: 30},
+		},
+	}
+	expectedResponse := &models.DelinquencyPolicyResponse{
+		ProductCode: "WEEKLY_50",
+		Source:      models.DelinquencyPolicySourceProduct,
+	}
+
+	mockService.On("UpdatePolicy", mock.Anything, "WEEKLY_50", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPut, "/v1/products/WEEKLY_50/delinquency-policy", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("WEEKLY_50")
+
+	// Execute
+	err := handler.UpdatePolicy(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDelinquencyHandler_UpdatePolicy_InvalidRuleType(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDelinquencyServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DelinquencyHandler{
+		delinquencyService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	// Create request
+	reqBody := []byte(`{"rules":[{"rule_type":"UNKNOWN","threshold":1}]}`)
+	httpReq := httptest.NewRequest(http.MethodPut, "/v1/products/WEEKLY_50/delinquency-policy", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("WEEKLY_50")
+
+	// Execute
+	err := handler.UpdatePolicy(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,25 @@
+package delinquency
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// DelinquencyMySQLRepositoryInterface defines the interface for delinquency policy repository
+type DelinquencyMySQLRepositoryInterface interface {
+	GetRulesByProductCode(ctx context.Context, productCode string) ([]*models.DelinquencyRule, error)
+	ReplaceRules(ctx context.Context, productCode string, rules []*models.DelinquencyRule) error
+	GetActiveLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetOverduePaymentSchedules(ctx context.Context, loanID string, asOf time.Time) ([]*models.PaymentSchedule, error)
+	UpdateLoanStatus(ctx context.Context, loanSummary *models.LoanSummary, fromStatus string) (bool, error)
+}
+
+// DelinquencyServiceInterface defines the interface for delinquency policy service
+type DelinquencyServiceInterface interface {
+	EvaluateLoan(ctx context.Context, loanSummary *models.LoanSummary, overdueSchedules []*models.PaymentSchedule, asOf time.Time) (*models.DelinquencyEvaluation, error)
+	GetPolicy(ctx context.Context, productCode string) (*models.DelinquencyPolicyResponse, error)
+	UpdatePolicy(ctx context.Context, productCode string, req *models.DelinquencyPolicyRequest) (*models.DelinquencyPolicyResponse, error)
+	GetActiveLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	MarkLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) (bool, error)
+}
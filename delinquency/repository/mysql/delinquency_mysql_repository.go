@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/delinquency"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type delinquencyMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewDelinquencyMySQLRepository creates a new delinquency policy repository instance
+func NewDelinquencyMySQLRepository(db *gorm.DB) delinquency.DelinquencyMySQLRepositoryInterface {
+	return &delinquencyMySQLRepository{db: db}
+}
+
+func (r *delinquencyMySQLRepository) GetRulesByProductCode(ctx context.Context, productCode string) ([]*models.DelinquencyRule, error) {
+	var rules []*models.DelinquencyRule
+	err := transaction.DB(ctx, r.db).
+		Where("product_code = ? AND deleted_at IS NULL", productCode).
+		Order("id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceRules soft-deletes the current rules of the product and stores the new set in one transaction
+func (r *delinquencyMySQLRepository) ReplaceRules(ctx context.Context, productCode string, rules []*models.DelinquencyRule) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DelinquencyRule{}).
+			Where("product_code = ? AND deleted_at IS NULL", productCode).
+			Updates(map[string]interface{}{
+				"deleted_at": time.Now(),
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
+// GetActiveLoanSummaries returns the disbursed loans that are not settled yet
+func (r *delinquencyMySQLRepository) GetActiveLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status IN ? AND deleted_at IS NULL", afterID, []string{models.StatusPending, models.StatusDelinquent}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+func (r *delinquencyMySQLRepository) GetOverduePaymentSchedules(ctx context.Context, loanID string, asOf time.Time) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND installment_due_date < ? AND deleted_at IS NULL", loanID, models.StatusPending, asOf).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// UpdateLoanStatus stores the loan's status only while it is still fromStatus, so a loan paid,
+// written off or restructured in the meantime keeps the status it was given. It reports whether
+// the loan was updated.
+func (r *delinquencyMySQLRepository) UpdateLoanStatus(ctx context.Context, loanSummary *models.LoanSummary, fromStatus string) (bool, error) {
+	result := transaction.DB(ctx, r.db).Model(&models.LoanSummary{}).
+		Where("id = ? AND status = ?", loanSummary.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":     loanSummary.Status,
+			"updated_by": loanSummary.UpdatedBy,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
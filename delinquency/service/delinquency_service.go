@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"billing-engine/delinquency"
+	"billing-engine/models"
+)
+
+type delinquencyService struct {
+	delinquencyRepo delinquency.DelinquencyMySQLRepositoryInterface
+}
+
+// NewDelinquencyService creates a new delinquency policy service instance
+func NewDelinquencyService(delinquencyRepo delinquency.DelinquencyMySQLRepositoryInterface) delinquency.DelinquencyServiceInterface {
+	return &delinquencyService{
+		delinquencyRepo: delinquencyRepo,
+	}
+}
+
+func (s *delinquencyService) EvaluateLoan(ctx context.Context, loanSummary *models.LoanSummary, overdueSchedules []*models.PaymentSchedule, asOf time.Time) (*models.DelinquencyEvaluation, error) {
+	rules, source, err := s.resolvePolicy(ctx, loanSummary.ProductCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// DPD is driven by the oldest overdue installment
+	dpd := 0
+	for _, schedule := range overdueSchedules {
+		if scheduleDpd := schedule.DaysPastDue(asOf); scheduleDpd > dpd {
+			dpd = scheduleDpd
+		}
+	}
+
+	matchedRules := evaluateRules(rules, loanFacts{
+		overdueSchedules: overdueSchedules,
+		dpd:              dpd,
+	})
+
+	return &models.DelinquencyEvaluation{
+		IsDelinquent: len(matchedRules) > 0,
+		Dpd:          dpd,
+		PolicySource: source,
+		MatchedRules: matchedRules,
+	}, nil
+}
+
+// GetActiveLoans returns the loans whose delinquency is tracked, in ID order after afterID
+func (s *delinquencyService) GetActiveLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	loanSummaries, err := s.delinquencyRepo.GetActiveLoanSummaries(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active loans: %v", err)
+	}
+	return loanSummaries, nil
+}
+
+// MarkLoan evaluates the loan's policy as of asOf and sets the loan DELINQUENT, or back to PENDING
+// once it no longer matches. It reports whether the status changed. A loan whose status changed
+// since it was loaded is left alone; the next evaluation picks it up.
+func (s *delinquencyService) MarkLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) (bool, error) {
+	overdueSchedules, err := s.delinquencyRepo.GetOverduePaymentSchedules(ctx, loanSummary.LoanID, asOf)
+	if err != nil {
+		return false, fmt.Errorf("failed to get overdue payment schedules: %v", err)
+	}
+
+	evaluation, err := s.EvaluateLoan(ctx, loanSummary, overdueSchedules, asOf)
+	if err != nil {
+		return false, err
+	}
+
+	status := models.StatusPending
+	if evaluation.IsDelinquent {
+		status = models.StatusDelinquent
+	}
+	if loanSummary.Status == status {
+		return false, nil
+	}
+
+	fromStatus := loanSummary.Status
+	loanSummary.Status = status
+	loanSummary.UpdatedBy = "system"
+	updated, err := s.delinquencyRepo.UpdateLoanStatus(ctx, loanSummary, fromStatus)
+	if err != nil {
+		return false, fmt.Errorf("failed to update loan status: %v", err)
+	}
+	if !updated {
+		loanSummary.Status = fromStatus
+	}
+	return updated, nil
+}
+
+func (s *delinquencyService) GetPolicy(ctx context.Context, productCode string) (*models.DelinquencyPolicyResponse, error) {
+	rules, source, err := s.resolvePolicy(ctx, productCode)
+	if err != nil {
+		return nil, err
+	}
+	return buildPolicyResponse(productCode, source, rules), nil
+}
+
+func (s *delinquencyService) UpdatePolicy(ctx context.Context, productCode string, req *models.DelinquencyPolicyRequest) (*models.DelinquencyPolicyResponse, error) {
+	if len(req.Rules) == 0 {
+		return nil, fmt.Errorf("delinquency policy must have at least one rule")
+	}
+
+	rules := make([]*models.DelinquencyRule, 0, len(req.Rules))
+	for _, ruleReq := range req.Rules {
+		if ruleReq.RuleType == models.DelinquencyRuleConsecutiveMissed && ruleReq.Threshold < 1 {
+			return nil, fmt.Errorf("%s threshold must be at least 1", models.DelinquencyRuleConsecutiveMissed)
+		}
+		rules = append(rules, &models.DelinquencyRule{
+			ProductCode: productCode,
+			RuleType:    ruleReq.RuleType,
+			Threshold:   ruleReq.Threshold,
+			CreatedBy:   "system",
+			UpdatedBy:   "system",
+		})
+	}
+
+	if err := s.delinquencyRepo.ReplaceRules(ctx, productCode, rules); err != nil {
+		return nil, fmt.Errorf("failed to update delinquency policy: %v", err)
+	}
+
+	return buildPolicyResponse(productCode, models.DelinquencyPolicySourceProduct, rules), nil
+}
+
+// resolvePolicy returns the rules of the product, falling back to the DEFAULT product
+// and finally to the built-in rule when nothing is configured
+func (s *delinquencyService) resolvePolicy(ctx context.Context, productCode string) ([]*models.DelinquencyRule, string, error) {
+	if productCode == "" {
+		productCode = models.DefaultProductCode
+	}
+
+	rules, err := s.delinquencyRepo.GetRulesByProductCode(ctx, productCode)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get delinquency policy: %v", err)
+	}
+	if len(rules) > 0 {
+		return rules, models.DelinquencyPolicySourceProduct, nil
+	}
+
+	if productCode != models.DefaultProductCode {
+		rules, err = s.delinquencyRepo.GetRulesByProductCode(ctx, models.DefaultProductCode)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get default delinquency policy: %v", err)
+		}
+		if len(rules) > 0 {
+			return rules, models.DelinquencyPolicySourceDefaultProduct, nil
+		}
+	}
+
+	return builtInRules, models.DelinquencyPolicySourceBuiltIn, nil
+}
+
+// buildPolicyResponse converts policy rules into the API response
+func buildPolicyResponse(productCode, source string, rules []*models.DelinquencyRule) *models.DelinquencyPolicyResponse {
+	ruleResponses := make([]models.DelinquencyRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		ruleResponses = append(ruleResponses, models.DelinquencyRuleResponse{
+			RuleType:    rule.RuleType,
+			Threshold:   rule.Threshold,
+			Description: describeRule(rule),
+		})
+	}
+
+	return &models.DelinquencyPolicyResponse{
+		ProductCode: productCode,
+		Source:      source,
+		Rules:       ruleResponses,
+	}
+}
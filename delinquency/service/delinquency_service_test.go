@@ -0,0 +1,305 @@
+package service
+
+import (
+	mocks "billing-engine/delinquency/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func overdueInstallments(asOf time.Time, numbers ...int) []*models.PaymentSchedule {
+	schedules := make([]*models.PaymentSchedule, 0, len(numbers))
+	for i, number := range numbers {
+		schedules = append(schedules, &models.PaymentSchedule{
+			ID:                 uint(number),
+			InstallmentNumber:  number,
+			InstallmentAmount:  110000.00,
+			InstallmentDueDate: asOf.AddDate(0, 0, -7*(len(numbers)-i)),
+			Status:             models.StatusPending,
+		})
+	}
+	return schedules
+}
+
+func TestEvaluateRules(t *testing.T) {
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		rule     *models.DelinquencyRule
+		overdue  []*models.PaymentSchedule
+		expected bool
+	}{
+		{
+			name:     "any overdue installment",
+			rule:     &models.DelinquencyRule{RuleType: models.DelinquencyRuleOverdueInstallments, Threshold: 0},
+			overdue:  overdueInstallments(asOf, 1),
+			expected: true,
+		},
+		{
+			name:     "more than one overdue installment with a single overdue",
+			rule:     &models.DelinquencyRule{RuleType: models.DelinquencyRuleOverdueInstallments, Threshold: 1},
+			overdue:  overdueInstallments(asOf, 1),
+			expected: false,
+		},
+		{
+			name:     "two consecutive missed installments",
+			rule:     &models.DelinquencyRule{RuleType: models.DelinquencyRuleConsecutiveMissed, Threshold: 2},
+			overdue:  overdueInstallments(asOf, 3, 4),
+			expected: true,
+		},
+		{
+			name:     "two missed installments that are not consecutive",
+			rule:     &models.DelinquencyRule{RuleType: models.DelinquencyRuleConsecutiveMissed, Threshold: 2},
+			overdue:  overdueInstallments(asOf, 1, 3),
+			expected: false,
+		},
+		{
+			name:     "dpd above threshold",
+			rule:     &models.DelinquencyRule{RuleType: models.DelinquencyRuleDpdAbove, Threshold: 10},
+			overdue:  overdueInstallments(asOf, 1, 2),
+			expected: true,
+		},
+		{
+			name:     "dpd at threshold",
+			rule:     &models.DelinquencyRule{RuleType: models.DelinquencyRuleDpdAbove, Threshold: 14},
+			overdue:  overdueInstallments(asOf, 1, 2),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpd := 0
+			if len(tt.overdue) > 0 {
+				dpd = tt.overdue[0].DaysPastDue(asOf)
+			}
+			matched := evaluateRules([]*models.DelinquencyRule{tt.rule}, loanFacts{overdueSchedules: tt.overdue, dpd: dpd})
+			assert.Equal(t, tt.expected, len(matched) > 0)
+		})
+	}
+}
+
+func TestDelinquencyService_EvaluateLoan_ProductPolicy(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", ProductCode: "WEEKLY_50"}
+	rules := []*models.DelinquencyRule{
+		{ProductCode: "WEEKLY_50", RuleType: models.DelinquencyRuleOverdueInstallments, Threshold: 0},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetRulesByProductCode", ctx, "WEEKLY_50").Return(rules, nil)
+
+	// Execute
+	evaluation, err := service.EvaluateLoan(ctx, loanSummary, overdueInstallments(asOf, 1), asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, evaluation.IsDelinquent)
+	assert.Equal(t, 7, evaluation.Dpd)
+	assert.Equal(t, models.DelinquencyPolicySourceProduct, evaluation.PolicySource)
+	assert.Equal(t, []string{"more than 0 overdue installment(s)"}, evaluation.MatchedRules)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_EvaluateLoan_FallsBackToDefaultProduct(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", ProductCode: "WEEKLY_50"}
+	defaultRules := []*models.DelinquencyRule{
+		{ProductCode: models.DefaultProductCode, RuleType: models.DelinquencyRuleDpdAbove, Threshold: 30},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetRulesByProductCode", ctx, "WEEKLY_50").Return([]*models.DelinquencyRule{}, nil)
+	mockRepo.On("GetRulesByProductCode", ctx, models.DefaultProductCode).Return(defaultRules, nil)
+
+	// Execute
+	evaluation, err := service.EvaluateLoan(ctx, loanSummary, overdueInstallments(asOf, 1, 2), asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, evaluation.IsDelinquent)
+	assert.Equal(t, 14, evaluation.Dpd)
+	assert.Equal(t, models.DelinquencyPolicySourceDefaultProduct, evaluation.PolicySource)
+	assert.Empty(t, evaluation.MatchedRules)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_EvaluateLoan_BuiltInPolicy(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 1, 10, 0, 0, 0, time.Local)
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123"}
+
+	// Mock repository calls
+	mockRepo.On("GetRulesByProductCode", ctx, models.DefaultProductCode).Return([]*models.DelinquencyRule{}, nil)
+
+	// Execute
+	evaluation, err := service.EvaluateLoan(ctx, loanSummary, overdueInstallments(asOf, 1, 2), asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, evaluation.IsDelinquent)
+	assert.Equal(t, models.DelinquencyPolicySourceBuiltIn, evaluation.PolicySource)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_EvaluateLoan_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", ProductCode: "WEEKLY_50"}
+
+	// Mock repository error
+	mockRepo.On("GetRulesByProductCode", ctx, "WEEKLY_50").Return(nil, errors.New("database error"))
+
+	// Execute
+	evaluation, err := service.EvaluateLoan(ctx, loanSummary, nil, time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, evaluation)
+	assert.Contains(t, err.Error(), "failed to get delinquency policy")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_MarkLoan_Delinquent(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Status: models.StatusPending}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", asOf).Return(overdueInstallments(asOf, 1, 2), nil)
+	mockRepo.On("GetRulesByProductCode", ctx, models.DefaultProductCode).Return([]*models.DelinquencyRule{}, nil)
+	mockRepo.On("UpdateLoanStatus", ctx, mock.MatchedBy(func(l *models.LoanSummary) bool {
+		return l.Status == models.StatusDelinquent
+	}), models.StatusPending).Return(true, nil)
+
+	// Execute
+	marked, err := service.MarkLoan(ctx, loanSummary, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	assert.Equal(t, models.StatusDelinquent, loanSummary.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_MarkLoan_BackToPending(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Status: models.StatusDelinquent}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", asOf).Return(overdueInstallments(asOf, 3), nil)
+	mockRepo.On("GetRulesByProductCode", ctx, models.DefaultProductCode).Return([]*models.DelinquencyRule{}, nil)
+	mockRepo.On("UpdateLoanStatus", ctx, loanSummary, models.StatusDelinquent).Return(true, nil)
+
+	// Execute
+	marked, err := service.MarkLoan(ctx, loanSummary, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	assert.Equal(t, models.StatusPending, loanSummary.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_MarkLoan_Unchanged(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
+
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Status: models.StatusPending}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", asOf).Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("GetRulesByProductCode", ctx, models.DefaultProductCode).Return([]*models.DelinquencyRule{}, nil)
+
+	// Execute
+	marked, err := service.MarkLoan(ctx, loanSummary, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, marked)
+	assert.Equal(t, models.StatusPending, loanSummary.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_UpdatePolicy_Success(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+
+	req := &models.DelinquencyPolicyRequest{
+		Rules: []models.DelinquencyRuleRequest{
+			{RuleType: models.DelinquencyRuleConsecutiveMissed, Threshold: 2},
+			{RuleType: models.DelinquencyRuleDpdAbove, Threshold: 30},
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("ReplaceRules", ctx, "WEEKLY_50", mock.MatchedBy(func(rules []*models.DelinquencyRule) bool {
+		return len(rules) == 2 && rules[0].ProductCode == "WEEKLY_50" && rules[1].Threshold == 30
+	})).Return(nil)
+
+	// Execute
+	response, err := service.UpdatePolicy(ctx, "WEEKLY_50", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "WEEKLY_50", response.ProductCode)
+	assert.Equal(t, models.DelinquencyPolicySourceProduct, response.Source)
+	assert.Len(t, response.Rules, 2)
+	assert.Equal(t, "2 or more consecutive missed installment(s)", response.Rules[0].Description)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelinquencyService_UpdatePolicy_InvalidRules(t *testing.T) {
+	mockRepo := mocks.NewDelinquencyMySQLRepositoryInterface(t)
+	service := NewDelinquencyService(mockRepo)
+	ctx := context.Background()
+
+	// Execute
+	_, emptyErr := service.UpdatePolicy(ctx, "WEEKLY_50", &models.DelinquencyPolicyRequest{})
+	_, thresholdErr := service.UpdatePolicy(ctx, "WEEKLY_50", &models.DelinquencyPolicyRequest{
+		Rules: []models.DelinquencyRuleRequest{{RuleType: models.DelinquencyRuleConsecutiveMissed, Threshold: 0}},
+	})
+
+	// Assert
+	assert.Error(t, emptyErr)
+	assert.Contains(t, emptyErr.Error(), "at least one rule")
+	assert.Error(t, thresholdErr)
+	assert.Contains(t, thresholdErr.Error(), "threshold must be at least 1")
+}
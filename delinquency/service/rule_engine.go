@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"billing-engine/models"
+)
+
+// loanFacts holds everything a delinquency rule can look at
+type loanFacts struct {
+	overdueSchedules []*models.PaymentSchedule
+	dpd              int
+}
+
+// ruleMatchers reports, per rule type, whether a rule with the given threshold matches the loan facts
+var ruleMatchers = map[string]func(threshold int, facts loanFacts) bool{
+	models.DelinquencyRuleOverdueInstallments: func(threshold int, facts loanFacts) bool {
+		return len(facts.overdueSchedules) > threshold
+	},
+	models.DelinquencyRuleConsecutiveMissed: func(threshold int, facts loanFacts) bool {
+		run := longestConsecutiveRun(facts.overdueSchedules)
+		return run > 0 && run >= threshold
+	},
+	models.DelinquencyRuleDpdAbove: func(threshold int, facts loanFacts) bool {
+		return facts.dpd > threshold
+	},
+}
+
+// builtInRules is the policy used when neither the loan's product nor the DEFAULT
+// product has rules configured: more than one overdue installment
+var builtInRules = []*models.DelinquencyRule{
+	{ProductCode: models.DefaultProductCode, RuleType: models.DelinquencyRuleOverdueInstallments, Threshold: 1},
+}
+
+// evaluateRules returns the description of every rule matching the loan facts
+func evaluateRules(rules []*models.DelinquencyRule, facts loanFacts) []string {
+	matched := make([]string, 0)
+	for _, rule := range rules {
+		matcher, ok := ruleMatchers[rule.RuleType]
+		if !ok {
+			continue
+		}
+		if matcher(rule.Threshold, facts) {
+			matched = append(matched, describeRule(rule))
+		}
+	}
+	return matched
+}
+
+// describeRule renders a rule as a human readable condition
+func describeRule(rule *models.DelinquencyRule) string {
+	switch rule.RuleType {
+	case models.DelinquencyRuleOverdueInstallments:
+		return fmt.Sprintf("more than %d overdue installment(s)", rule.Threshold)
+	case models.DelinquencyRuleConsecutiveMissed:
+		return fmt.Sprintf("%d or more consecutive missed installment(s)", rule.Threshold)
+	case models.DelinquencyRuleDpdAbove:
+		return fmt.Sprintf("more than %d days past due", rule.Threshold)
+	default:
+		return rule.RuleType
+	}
+}
+
+// longestConsecutiveRun returns the length of the longest run of consecutive installment numbers
+func longestConsecutiveRun(schedules []*models.PaymentSchedule) int {
+	if len(schedules) == 0 {
+		return 0
+	}
+
+	numbers := make([]int, 0, len(schedules))
+	for _, schedule := range schedules {
+		numbers = append(numbers, schedule.InstallmentNumber)
+	}
+	sort.Ints(numbers)
+
+	longest, current := 1, 1
+	for i := 1; i < len(numbers); i++ {
+		if numbers[i] == numbers[i-1]+1 {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
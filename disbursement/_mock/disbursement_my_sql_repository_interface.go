@@ -15,6 +15,34 @@ type DisbursementMySQLRepositoryInterface struct {
 	mock.Mock
 }
 
+// ActivateLoan provides a mock function with given fields: ctx, _a1, loanSummary, schedules, histories
+func (_m *DisbursementMySQLRepositoryInterface) ActivateLoan(ctx context.Context, _a1 *models.DisbursementDetail, loanSummary *models.LoanSummary, schedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) (bool, error) {
+	ret := _m.Called(ctx, _a1, loanSummary, schedules, histories)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateLoan")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementDetail, *models.LoanSummary, []*models.PaymentSchedule, []*models.PaymentScheduleHistory) (bool, error)); ok {
+		return rf(ctx, _a1, loanSummary, schedules, histories)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementDetail, *models.LoanSummary, []*models.PaymentSchedule, []*models.PaymentScheduleHistory) bool); ok {
+		r0 = rf(ctx, _a1, loanSummary, schedules, histories)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DisbursementDetail, *models.LoanSummary, []*models.PaymentSchedule, []*models.PaymentScheduleHistory) error); ok {
+		r1 = rf(ctx, _a1, loanSummary, schedules, histories)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDisbursement provides a mock function with given fields: ctx, _a1
 func (_m *DisbursementMySQLRepositoryInterface) CreateDisbursement(ctx context.Context, _a1 *models.DisbursementDetail) error {
 	ret := _m.Called(ctx, _a1)
@@ -33,6 +61,24 @@ func (_m *DisbursementMySQLRepositoryInterface) CreateDisbursement(ctx context.C
 	return r0
 }
 
+// CreateLoanFees provides a mock function with given fields: ctx, loanFees
+func (_m *DisbursementMySQLRepositoryInterface) CreateLoanFees(ctx context.Context, loanFees []*models.LoanFee) error {
+	ret := _m.Called(ctx, loanFees)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoanFees")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.LoanFee) error); ok {
+		r0 = rf(ctx, loanFees)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateLoanSummary provides a mock function with given fields: ctx, loanSummary
 func (_m *DisbursementMySQLRepositoryInterface) CreateLoanSummary(ctx context.Context, loanSummary *models.LoanSummary) error {
 	ret := _m.Called(ctx, loanSummary)
@@ -69,6 +115,52 @@ func (_m *DisbursementMySQLRepositoryInterface) CreatePaymentSchedules(ctx conte
 	return r0
 }
 
+// CreateTaxLines provides a mock function with given fields: ctx, taxLines
+func (_m *DisbursementMySQLRepositoryInterface) CreateTaxLines(ctx context.Context, taxLines []*models.TaxLine) error {
+	ret := _m.Called(ctx, taxLines)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTaxLines")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.TaxLine) error); ok {
+		r0 = rf(ctx, taxLines)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailDisbursement provides a mock function with given fields: ctx, _a1, loanSummary
+func (_m *DisbursementMySQLRepositoryInterface) FailDisbursement(ctx context.Context, _a1 *models.DisbursementDetail, loanSummary *models.LoanSummary) (bool, error) {
+	ret := _m.Called(ctx, _a1, loanSummary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailDisbursement")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementDetail, *models.LoanSummary) (bool, error)); ok {
+		return rf(ctx, _a1, loanSummary)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementDetail, *models.LoanSummary) bool); ok {
+		r0 = rf(ctx, _a1, loanSummary)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DisbursementDetail, *models.LoanSummary) error); ok {
+		r1 = rf(ctx, _a1, loanSummary)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDisbursementByLoanID provides a mock function with given fields: ctx, loanID
 func (_m *DisbursementMySQLRepositoryInterface) GetDisbursementByLoanID(ctx context.Context, loanID string) (*models.DisbursementDetail, error) {
 	ret := _m.Called(ctx, loanID)
@@ -129,6 +221,142 @@ func (_m *DisbursementMySQLRepositoryInterface) GetLoanSummaryByLoanID(ctx conte
 	return r0, r1
 }
 
+// GetPaymentSchedulesByLoanID provides a mock function with given fields: ctx, loanID
+func (_m *DisbursementMySQLRepositoryInterface) GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentSchedulesByLoanID")
+	}
+
+	var r0 []*models.PaymentSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.PaymentSchedule, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.PaymentSchedule); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PaymentSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaxLinesByLoanID provides a mock function with given fields: ctx, loanID
+func (_m *DisbursementMySQLRepositoryInterface) GetTaxLinesByLoanID(ctx context.Context, loanID string) ([]*models.TaxLine, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaxLinesByLoanID")
+	}
+
+	var r0 []*models.TaxLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.TaxLine, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.TaxLine); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaxLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnsettledDisbursements provides a mock function with given fields: ctx
+func (_m *DisbursementMySQLRepositoryInterface) GetUnsettledDisbursements(ctx context.Context) ([]*models.DisbursementDetail, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnsettledDisbursements")
+	}
+
+	var r0 []*models.DisbursementDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.DisbursementDetail, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.DisbursementDetail); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DisbursementDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDisbursement provides a mock function with given fields: ctx, _a1
+func (_m *DisbursementMySQLRepositoryInterface) UpdateDisbursement(ctx context.Context, _a1 *models.DisbursementDetail) (bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDisbursement")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementDetail) (bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementDetail) bool); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DisbursementDetail) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithinTransaction provides a mock function with given fields: ctx, fn
+func (_m *DisbursementMySQLRepositoryInterface) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewDisbursementMySQLRepositoryInterface creates a new instance of DisbursementMySQLRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDisbursementMySQLRepositoryInterface(t interface {
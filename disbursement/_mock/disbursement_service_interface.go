@@ -45,6 +45,156 @@ func (_m *DisbursementServiceInterface) CreateDisbursement(ctx context.Context,
 	return r0, r1
 }
 
+// GetDisbursementStatus provides a mock function with given fields: ctx, loanID
+func (_m *DisbursementServiceInterface) GetDisbursementStatus(ctx context.Context, loanID string) (*models.DisbursementStatusResponse, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisbursementStatus")
+	}
+
+	var r0 *models.DisbursementStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.DisbursementStatusResponse, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DisbursementStatusResponse); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DisbursementStatusResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandlePayoutWebhook provides a mock function with given fields: ctx, req
+func (_m *DisbursementServiceInterface) HandlePayoutWebhook(ctx context.Context, req *models.PayoutWebhookRequest) (*models.DisbursementStatusResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandlePayoutWebhook")
+	}
+
+	var r0 *models.DisbursementStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PayoutWebhookRequest) (*models.DisbursementStatusResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PayoutWebhookRequest) *models.DisbursementStatusResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DisbursementStatusResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PayoutWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SimulateLoan provides a mock function with given fields: ctx, req
+func (_m *DisbursementServiceInterface) SimulateLoan(ctx context.Context, req *models.DisbursementRequest) (*models.LoanSimulationResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateLoan")
+	}
+
+	var r0 *models.LoanSimulationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementRequest) (*models.LoanSimulationResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DisbursementRequest) *models.LoanSimulationResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanSimulationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DisbursementRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncPayouts provides a mock function with given fields: ctx
+func (_m *DisbursementServiceInterface) SyncPayouts(ctx context.Context) (*models.PayoutSyncResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncPayouts")
+	}
+
+	var r0 *models.PayoutSyncResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.PayoutSyncResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.PayoutSyncResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PayoutSyncResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDisbursementStatus provides a mock function with given fields: ctx, loanID, req
+func (_m *DisbursementServiceInterface) UpdateDisbursementStatus(ctx context.Context, loanID string, req *models.DisbursementCallbackRequest) (*models.DisbursementStatusResponse, error) {
+	ret := _m.Called(ctx, loanID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDisbursementStatus")
+	}
+
+	var r0 *models.DisbursementStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.DisbursementCallbackRequest) (*models.DisbursementStatusResponse, error)); ok {
+		return rf(ctx, loanID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.DisbursementCallbackRequest) *models.DisbursementStatusResponse); ok {
+		r0 = rf(ctx, loanID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DisbursementStatusResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.DisbursementCallbackRequest) error); ok {
+		r1 = rf(ctx, loanID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewDisbursementServiceInterface creates a new instance of DisbursementServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDisbursementServiceInterface(t interface {
@@ -0,0 +1,170 @@
+// Package fake implements an in-memory payout gateway for local runs and tests.
+//
+// The outcome of a payout is picked from the last four digits of the account number:
+//   - 0000: the payout fails
+//   - 9999: the payout stays PENDING until SettlementDelay has passed, then succeeds
+//   - 5003: the first request for the payout returns 503, later requests succeed
+//   - anything else: the payout succeeds immediately
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"billing-engine/models"
+)
+
+const (
+	failingAccountSuffix     = "0000"
+	delayedAccountSuffix     = "9999"
+	unavailableAccountSuffix = "5003"
+)
+
+// Config controls the behaviour of the fake payout gateway
+type Config struct {
+	SettlementDelay time.Duration // how long delayed payouts stay PENDING
+	WebhookURL      string        // settlement notifications are posted here when set
+	WebhookToken    string        // sent in the X-Callback-Token header of notifications
+}
+
+type payout struct {
+	result    models.PayoutResult
+	settlesAt time.Time
+}
+
+// Server is the fake payout gateway HTTP handler
+type Server struct {
+	config      Config
+	client      *http.Client
+	mu          sync.Mutex
+	payouts     map[string]*payout
+	unavailable map[string]bool
+	sequence    int
+}
+
+// NewServer creates a fake payout gateway
+func NewServer(config Config) *Server {
+	return &Server{
+		config:      config,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		payouts:     make(map[string]*payout),
+		unavailable: make(map[string]bool),
+	}
+}
+
+// ServeHTTP routes POST /payouts and GET /payouts/{external_id}
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/payouts":
+		s.createPayout(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/payouts/"):
+		s.getPayout(w, strings.TrimPrefix(r.URL.Path, "/payouts/"))
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+	}
+}
+
+func (s *Server) createPayout(w http.ResponseWriter, r *http.Request) {
+	var req models.PayoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ExternalID == "" || req.AccountNumber == "" || req.Amount <= 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid payout request"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasSuffix(req.AccountNumber, unavailableAccountSuffix) && !s.unavailable[req.ExternalID] {
+		s.unavailable[req.ExternalID] = true
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"message": "temporarily unavailable"})
+		return
+	}
+
+	// Payouts are idempotent per external ID
+	if existing, ok := s.payouts[req.ExternalID]; ok {
+		writeJSON(w, http.StatusOK, s.refresh(existing))
+		return
+	}
+
+	s.sequence++
+	now := time.Now()
+	p := &payout{result: models.PayoutResult{
+		PayoutID:   fmt.Sprintf("fake_po_%06d", s.sequence),
+		ExternalID: req.ExternalID,
+		Status:     models.PayoutStatusSuccess,
+		SettledAt:  &now,
+	}}
+	switch {
+	case strings.HasSuffix(req.AccountNumber, failingAccountSuffix):
+		p.result.Status = models.PayoutStatusFailed
+		p.result.FailureReason = "Beneficiary account not found"
+		p.result.SettledAt = nil
+	case strings.HasSuffix(req.AccountNumber, delayedAccountSuffix):
+		p.result.Status = models.PayoutStatusPending
+		p.result.SettledAt = nil
+		p.settlesAt = now.Add(s.config.SettlementDelay)
+		time.AfterFunc(s.config.SettlementDelay, func() { s.settle(req.ExternalID) })
+	}
+	s.payouts[req.ExternalID] = p
+
+	writeJSON(w, http.StatusOK, p.result)
+}
+
+func (s *Server) getPayout(w http.ResponseWriter, externalID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payouts[externalID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "payout not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, s.refresh(p))
+}
+
+// refresh settles a delayed payout whose settlement time has passed; callers hold s.mu
+func (s *Server) refresh(p *payout) models.PayoutResult {
+	if p.result.Status == models.PayoutStatusPending && !time.Now().Before(p.settlesAt) {
+		settledAt := p.settlesAt
+		p.result.Status = models.PayoutStatusSuccess
+		p.result.SettledAt = &settledAt
+	}
+	return p.result
+}
+
+// settle completes a delayed payout and notifies the webhook
+func (s *Server) settle(externalID string) {
+	s.mu.Lock()
+	result := s.refresh(s.payouts[externalID])
+	s.mu.Unlock()
+
+	if s.config.WebhookURL == "" {
+		return
+	}
+	body, _ := json.Marshal(result)
+	req, err := http.NewRequest(http.MethodPost, s.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("fake payout gateway: invalid webhook url: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Callback-Token", s.config.WebhookToken)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Printf("fake payout gateway: webhook for %s failed: %v", externalID, err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
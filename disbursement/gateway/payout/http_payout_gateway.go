@@ -0,0 +1,136 @@
+package payout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"billing-engine/disbursement"
+	"billing-engine/models"
+)
+
+type httpPayoutGateway struct {
+	baseURL      string
+	client       *http.Client
+	maxRetries   int           // extra attempts after the first one on network errors and 5xx responses
+	retryBackoff time.Duration // wait before the first retry, doubled on every further retry
+}
+
+// NewHTTPPayoutGateway creates a payout gateway client for the payout REST API at baseURL
+func NewHTTPPayoutGateway(baseURL string, timeout time.Duration, maxRetries int, retryBackoff time.Duration) disbursement.PayoutGatewayInterface {
+	return &httpPayoutGateway{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		client:       &http.Client{Timeout: timeout},
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+	}
+}
+
+func (g *httpPayoutGateway) CreatePayout(ctx context.Context, req *models.PayoutRequest) (*models.PayoutResult, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PayoutResult
+	if err := g.do(ctx, http.MethodPost, "/payouts", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (g *httpPayoutGateway) GetPayout(ctx context.Context, externalID string) (*models.PayoutResult, error) {
+	var result models.PayoutResult
+	if err := g.do(ctx, http.MethodGet, "/payouts/"+url.PathEscape(externalID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do sends the request and decodes the JSON response into out, retrying failures that may be
+// transient. Payouts are keyed by external ID, so retrying a create does not pay out twice.
+func (g *httpPayoutGateway) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	var lastErr error
+	backoff := g.retryBackoff
+	for attempt := 0; attempt <= g.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		retryable, err := g.send(ctx, method, path, body, out)
+		if err == nil {
+			return nil
+		}
+		if !retryable {
+			return err
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("payout gateway unavailable after %d attempts: %v", g.maxRetries+1, lastErr)
+}
+
+func (g *httpPayoutGateway) send(ctx context.Context, method, path string, body []byte, out interface{}) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, reader)
+	if err != nil {
+		return false, err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return true, fmt.Errorf("payout gateway returned %d", resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity {
+		reason := rejectionReason(respBody)
+		return false, &models.PayoutRejectionError{
+			StatusCode: resp.StatusCode,
+			Reason:     reason,
+			Message:    fmt.Sprintf("payout gateway rejected request with %d: %s", resp.StatusCode, reason),
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return false, fmt.Errorf("payout gateway rejected request with %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return false, fmt.Errorf("invalid payout gateway response: %v", err)
+	}
+	return false, nil
+}
+
+// rejectionReason returns the message of a gateway error response, or the raw body when it has none
+func rejectionReason(body []byte) string {
+	var response struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &response); err == nil && response.Message != "" {
+		return response.Message
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,157 @@
+package payout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"billing-engine/disbursement/gateway/fake"
+	"billing-engine/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeGateway(t *testing.T, config fake.Config) *httptest.Server {
+	server := httptest.NewServer(fake.NewServer(config))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPPayoutGateway_CreatePayout_Success(t *testing.T) {
+	server := newFakeGateway(t, fake.Config{})
+	gateway := NewHTTPPayoutGateway(server.URL, time.Second, 0, time.Millisecond)
+
+	result, err := gateway.CreatePayout(context.Background(), &models.PayoutRequest{
+		ExternalID:    "loan_123",
+		BankCode:      "BCA",
+		AccountNumber: "1234567890",
+		Amount:        5000000.00,
+		Currency:      models.CurrencyIDR,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusSuccess, result.Status)
+	assert.Equal(t, "loan_123", result.ExternalID)
+	assert.NotEmpty(t, result.PayoutID)
+	assert.NotNil(t, result.SettledAt)
+}
+
+func TestHTTPPayoutGateway_CreatePayout_Idempotent(t *testing.T) {
+	server := newFakeGateway(t, fake.Config{})
+	gateway := NewHTTPPayoutGateway(server.URL, time.Second, 0, time.Millisecond)
+	req := &models.PayoutRequest{ExternalID: "loan_123", BankCode: "BCA", AccountNumber: "1234567890", Amount: 100.00}
+
+	first, err := gateway.CreatePayout(context.Background(), req)
+	assert.NoError(t, err)
+	second, err := gateway.CreatePayout(context.Background(), req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.PayoutID, second.PayoutID)
+}
+
+func TestHTTPPayoutGateway_CreatePayout_Failure(t *testing.T) {
+	server := newFakeGateway(t, fake.Config{})
+	gateway := NewHTTPPayoutGateway(server.URL, time.Second, 0, time.Millisecond)
+
+	result, err := gateway.CreatePayout(context.Background(), &models.PayoutRequest{
+		ExternalID:    "loan_123",
+		BankCode:      "BCA",
+		AccountNumber: "1230000",
+		Amount:        5000000.00,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusFailed, result.Status)
+	assert.Equal(t, "Beneficiary account not found", result.FailureReason)
+}
+
+func TestHTTPPayoutGateway_CreatePayout_RetriesUnavailableGateway(t *testing.T) {
+	server := newFakeGateway(t, fake.Config{})
+	req := &models.PayoutRequest{ExternalID: "loan_123", BankCode: "BCA", AccountNumber: "1235003", Amount: 100.00}
+
+	// Without retries the 503 is returned
+	_, err := NewHTTPPayoutGateway(server.URL, time.Second, 0, time.Millisecond).CreatePayout(context.Background(), req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "payout gateway unavailable after 1 attempts")
+
+	// The fake only fails the first request for a payout, so a retried call succeeds
+	req.ExternalID = "loan_456"
+	result, err := NewHTTPPayoutGateway(server.URL, time.Second, 2, time.Millisecond).CreatePayout(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusSuccess, result.Status)
+}
+
+func TestHTTPPayoutGateway_CreatePayout_InvalidRequestNotRetried(t *testing.T) {
+	server := newFakeGateway(t, fake.Config{})
+	gateway := NewHTTPPayoutGateway(server.URL, time.Second, 3, time.Hour)
+
+	_, err := gateway.CreatePayout(context.Background(), &models.PayoutRequest{ExternalID: "loan_123"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rejected request with 400")
+
+	// The refusal is permanent and carries the gateway's explanation
+	var rejection *models.PayoutRejectionError
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "invalid payout request", rejection.Reason)
+}
+
+func TestHTTPPayoutGateway_DelayedSettlement(t *testing.T) {
+	webhooks := make(chan models.PayoutResult, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result models.PayoutResult
+		json.NewDecoder(r.Body).Decode(&result)
+		assert.Equal(t, "secret", r.Header.Get("X-Callback-Token"))
+		webhooks <- result
+	}))
+	defer receiver.Close()
+
+	server := newFakeGateway(t, fake.Config{
+		SettlementDelay: 50 * time.Millisecond,
+		WebhookURL:      receiver.URL,
+		WebhookToken:    "secret",
+	})
+	gateway := NewHTTPPayoutGateway(server.URL, time.Second, 0, time.Millisecond)
+
+	result, err := gateway.CreatePayout(context.Background(), &models.PayoutRequest{
+		ExternalID:    "loan_123",
+		BankCode:      "BCA",
+		AccountNumber: "1239999",
+		Amount:        5000000.00,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusPending, result.Status)
+
+	// Polling reports the payout as pending until it settles
+	polled, err := gateway.GetPayout(context.Background(), "loan_123")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusPending, polled.Status)
+
+	select {
+	case notified := <-webhooks:
+		assert.Equal(t, "loan_123", notified.ExternalID)
+		assert.Equal(t, models.PayoutStatusSuccess, notified.Status)
+	case <-time.After(2 * time.Second):
+		t.Fatal("settlement webhook not received")
+	}
+
+	polled, err = gateway.GetPayout(context.Background(), "loan_123")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusSuccess, polled.Status)
+}
+
+func TestHTTPPayoutGateway_GetPayout_NotFound(t *testing.T) {
+	server := newFakeGateway(t, fake.Config{})
+	gateway := NewHTTPPayoutGateway(server.URL, time.Second, 0, time.Millisecond)
+
+	_, err := gateway.GetPayout(context.Background(), "loan_unknown")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rejected request with 404")
+	var rejection *models.PayoutRejectionError
+	assert.False(t, errors.As(err, &rejection))
+}
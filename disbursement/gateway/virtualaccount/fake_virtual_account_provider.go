@@ -0,0 +1,83 @@
+// Package virtualaccount implements a local virtual account provider for development and tests.
+//
+// The fake provider issues numbers the way Indonesian banks do: the company prefix followed by a
+// customer number, 16 digits in total. The customer number is derived from the external ID, so
+// asking again for the same loan returns the same account.
+package virtualaccount
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"billing-engine/disbursement"
+	"billing-engine/models"
+)
+
+const accountNumberLength = 16
+
+// ValidatePrefix checks that the company prefix is numeric and leaves at least 8 digits for the
+// customer number
+func ValidatePrefix(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("prefix is required")
+	}
+	for _, digit := range prefix {
+		if digit < '0' || digit > '9' {
+			return fmt.Errorf("prefix %s must contain digits only", prefix)
+		}
+	}
+	if len(prefix) > accountNumberLength-8 {
+		return fmt.Errorf("prefix %s is longer than %d digits", prefix, accountNumberLength-8)
+	}
+	return nil
+}
+
+type fakeVirtualAccountProvider struct {
+	bankCode string
+	prefix   string
+}
+
+// NewFakeVirtualAccountProvider creates a virtual account provider that issues accounts at bankCode
+// under the numeric company prefix without calling a bank
+func NewFakeVirtualAccountProvider(bankCode string, prefix string) disbursement.VirtualAccountProviderInterface {
+	return &fakeVirtualAccountProvider{
+		bankCode: bankCode,
+		prefix:   prefix,
+	}
+}
+
+func (p *fakeVirtualAccountProvider) CreateVirtualAccount(ctx context.Context, req *models.VirtualAccountRequest) (*models.VirtualAccountResult, error) {
+	if req.ExternalID == "" {
+		return nil, fmt.Errorf("external ID is required")
+	}
+	digits := accountNumberLength - len(p.prefix)
+	if digits <= 0 {
+		return nil, fmt.Errorf("prefix %s leaves no room for the customer number", p.prefix)
+	}
+
+	sum := sha256.Sum256([]byte(req.ExternalID))
+	customerNumber := binary.BigEndian.Uint64(sum[:8]) % pow10(digits)
+	return &models.VirtualAccountResult{
+		AccountNumber: fmt.Sprintf("%s%0*d", p.prefix, digits, customerNumber),
+		BankCode:      p.bankCode,
+	}, nil
+}
+
+// CloseVirtualAccount closes an issued account. The fake provider keeps no accounts, so there is
+// nothing to close beyond checking the number.
+func (p *fakeVirtualAccountProvider) CloseVirtualAccount(ctx context.Context, accountNumber string) error {
+	if accountNumber == "" {
+		return fmt.Errorf("account number is required")
+	}
+	return nil
+}
+
+func pow10(n int) uint64 {
+	result := uint64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
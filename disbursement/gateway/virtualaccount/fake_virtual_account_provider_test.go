@@ -0,0 +1,65 @@
+package virtualaccount
+
+import (
+	"context"
+	"testing"
+
+	"billing-engine/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeVirtualAccountProvider_CreateVirtualAccount_Success(t *testing.T) {
+	provider := NewFakeVirtualAccountProvider("BCA", "88088")
+
+	result, err := provider.CreateVirtualAccount(context.Background(), &models.VirtualAccountRequest{
+		ExternalID: "loan_123",
+		CustomerID: "12312312",
+		Currency:   models.CurrencyIDR,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "BCA", result.BankCode)
+	assert.Len(t, result.AccountNumber, 16)
+	assert.Regexp(t, "^88088[0-9]{11}$", result.AccountNumber)
+}
+
+func TestFakeVirtualAccountProvider_CreateVirtualAccount_Idempotent(t *testing.T) {
+	provider := NewFakeVirtualAccountProvider("BCA", "88088")
+	req := &models.VirtualAccountRequest{ExternalID: "loan_123", CustomerID: "12312312"}
+
+	first, err := provider.CreateVirtualAccount(context.Background(), req)
+	assert.NoError(t, err)
+	second, err := provider.CreateVirtualAccount(context.Background(), req)
+	assert.NoError(t, err)
+	other, err := provider.CreateVirtualAccount(context.Background(), &models.VirtualAccountRequest{ExternalID: "loan_456", CustomerID: "12312312"})
+	assert.NoError(t, err)
+
+	// Asking again for the same loan returns the same account; another loan gets its own
+	assert.Equal(t, first.AccountNumber, second.AccountNumber)
+	assert.NotEqual(t, first.AccountNumber, other.AccountNumber)
+}
+
+func TestFakeVirtualAccountProvider_CreateVirtualAccount_PrefixTooLong(t *testing.T) {
+	provider := NewFakeVirtualAccountProvider("BCA", "8808812345678901")
+
+	result, err := provider.CreateVirtualAccount(context.Background(), &models.VirtualAccountRequest{ExternalID: "loan_123"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "leaves no room")
+}
+
+func TestFakeVirtualAccountProvider_CloseVirtualAccount(t *testing.T) {
+	provider := NewFakeVirtualAccountProvider("BCA", "88088")
+
+	assert.NoError(t, provider.CloseVirtualAccount(context.Background(), "8808812345678901"))
+	assert.Error(t, provider.CloseVirtualAccount(context.Background(), ""))
+}
+
+func TestValidatePrefix(t *testing.T) {
+	assert.NoError(t, ValidatePrefix("88088"))
+	assert.Error(t, ValidatePrefix(""))
+	assert.Error(t, ValidatePrefix("88O88"))
+	assert.Error(t, ValidatePrefix("880881234"))
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"crypto/subtle"
 	"net/http"
 
 	"billing-engine/disbursement"
@@ -15,18 +16,24 @@ import (
 type DisbursementHandler struct {
 	disbursementService disbursement.DisbursementServiceInterface
 	middleware          middlewares.GoMiddlewareInterface
+	payoutWebhookToken  string // expected X-Callback-Token of payout confirmations and notifications
 }
 
 // NewDisbursementHandler creates a new disbursement handler instance
-func NewDisbursementHandler(e *echo.Echo, disbursementService disbursement.DisbursementServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+func NewDisbursementHandler(e *echo.Echo, disbursementService disbursement.DisbursementServiceInterface, middleware middlewares.GoMiddlewareInterface, payoutWebhookToken string) {
 	handler := &DisbursementHandler{
 		disbursementService: disbursementService,
 		middleware:          middleware,
+		payoutWebhookToken:  payoutWebhookToken,
 	}
 
 	// Register routes
 	v1 := e.Group("/v1")
 	v1.POST("/disbursement", handler.CreateDisbursement)
+	v1.POST("/loans/simulate", handler.SimulateLoan)
+	v1.GET("/loans/:loan_id/disbursement", handler.GetDisbursementStatus)
+	v1.POST("/loans/:loan_id/disbursement/callback", handler.UpdateDisbursementStatus)
+	v1.POST("/payouts/webhook", handler.HandlePayoutWebhook)
 }
 
 func (h *DisbursementHandler) CreateDisbursement(c echo.Context) error {
@@ -61,3 +68,151 @@ func (h *DisbursementHandler) CreateDisbursement(c echo.Context) error {
 		Data:   response,
 	})
 }
+
+func (h *DisbursementHandler) SimulateLoan(c echo.Context) error {
+	var req models.DisbursementRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	// Simulate the loan without booking it
+	response, err := h.disbursementService.SimulateLoan(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.LoanSimulationSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *DisbursementHandler) GetDisbursementStatus(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	response, err := h.disbursementService.GetDisbursementStatus(c.Request().Context(), loanID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DisbursementStatusSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// UpdateDisbursementStatus receives the payout confirmation for a loan's disbursement
+func (h *DisbursementHandler) UpdateDisbursementStatus(c echo.Context) error {
+	if !h.validCallbackToken(c) {
+		return c.JSON(http.StatusUnauthorized, global.BadResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "Invalid callback token",
+		})
+	}
+
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	var req models.DisbursementCallbackRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.disbursementService.UpdateDisbursementStatus(c.Request().Context(), loanID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DisbursementStatusSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// HandlePayoutWebhook receives payout settlement notifications from the payout gateway
+func (h *DisbursementHandler) HandlePayoutWebhook(c echo.Context) error {
+	if !h.validCallbackToken(c) {
+		return c.JSON(http.StatusUnauthorized, global.BadResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "Invalid callback token",
+		})
+	}
+
+	var req models.PayoutWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.disbursementService.HandlePayoutWebhook(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.DisbursementStatusSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// validCallbackToken checks the X-Callback-Token header; nothing passes while no token is configured
+func (h *DisbursementHandler) validCallbackToken(c echo.Context) bool {
+	token := c.Request().Header.Get("X-Callback-Token")
+	return h.payoutWebhookToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.payoutWebhookToken)) == 1
+}
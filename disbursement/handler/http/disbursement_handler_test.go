@@ -40,6 +40,7 @@ func TestDisbursementHandler_CreateDisbursement_Success(t *testing.T) {
 	handler := &DisbursementHandler{
 		disbursementService: mockService,
 		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
 	}
 
 	req := models.DisbursementRequest{
@@ -49,6 +50,8 @@ func TestDisbursementHandler_CreateDisbursement_Success(t *testing.T) {
 		NumberOfInstallment: 50,
 		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 		CustomerID:          "12312312",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
 	}
 
 	expectedResponse := &models.DisbursementResponse{
@@ -97,6 +100,7 @@ func TestDisbursementHandler_CreateDisbursement_InvalidJSON(t *testing.T) {
 	handler := &DisbursementHandler{
 		disbursementService: mockService,
 		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
 	}
 
 	// Create invalid JSON request
@@ -133,6 +137,8 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 				NumberOfInstallment: 50,
 				StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 				CustomerID:          "12312312",
+				BankCode:            "BCA",
+				AccountNumber:       "1234567890",
 			},
 			expectedError: "principalamount must be greater than 0",
 		},
@@ -145,6 +151,8 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 				NumberOfInstallment: 50,
 				StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 				CustomerID:          "12312312",
+				BankCode:            "BCA",
+				AccountNumber:       "1234567890",
 			},
 			expectedError: "interestrate must be greater than 0",
 		},
@@ -157,6 +165,8 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 				NumberOfInstallment: 0,
 				StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 				CustomerID:          "12312312",
+				BankCode:            "BCA",
+				AccountNumber:       "1234567890",
 			},
 			expectedError: "numberofinstallment must be greater than 0",
 		},
@@ -169,6 +179,8 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 				NumberOfInstallment: 50,
 				StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 				CustomerID:          "12312312",
+				BankCode:            "BCA",
+				AccountNumber:       "1234567890",
 			},
 			expectedError: "installmentunit must be one of: week, month",
 		},
@@ -181,9 +193,24 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 				NumberOfInstallment: 50,
 				StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 				CustomerID:          "",
+				BankCode:            "BCA",
+				AccountNumber:       "1234567890",
 			},
 			expectedError: "customerid is required",
 		},
+		{
+			name: "Empty account number",
+			request: models.DisbursementRequest{
+				PrincipalAmount:     5000000.00,
+				InterestRate:        0.10, // 10% interest rate
+				InstallmentUnit:     "week",
+				NumberOfInstallment: 50,
+				StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+				CustomerID:          "12312312",
+				BankCode:            "BCA",
+			},
+			expectedError: "accountnumber is required",
+		},
 		{
 			name: "Empty start date",
 			request: models.DisbursementRequest{
@@ -193,6 +220,8 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 				NumberOfInstallment: 50,
 				StartDate:           time.Time{},
 				CustomerID:          "12312312",
+				BankCode:            "BCA",
+				AccountNumber:       "1234567890",
 			},
 			expectedError: "startdate is required",
 		},
@@ -208,6 +237,7 @@ func TestDisbursementHandler_CreateDisbursement_ValidationErrors(t *testing.T) {
 			handler := &DisbursementHandler{
 				disbursementService: mockService,
 				middleware:          mockMiddleware,
+				payoutWebhookToken:  "test-token",
 			}
 
 			// Create request
@@ -241,6 +271,7 @@ func TestDisbursementHandler_CreateDisbursement_ServiceError(t *testing.T) {
 	handler := &DisbursementHandler{
 		disbursementService: mockService,
 		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
 	}
 
 	req := models.DisbursementRequest{
@@ -250,6 +281,8 @@ func TestDisbursementHandler_CreateDisbursement_ServiceError(t *testing.T) {
 		NumberOfInstallment: 50,
 		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 		CustomerID:          "12312312",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
 	}
 
 	mockService.On("CreateDisbursement", mock.Anything, &req).Return(nil, errors.New("service error"))
@@ -275,3 +308,352 @@ func TestDisbursementHandler_CreateDisbursement_ServiceError(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestDisbursementHandler_UpdateDisbursementStatus_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
+	}
+
+	req := models.DisbursementCallbackRequest{
+		Status:    models.DisbursementStatusDisbursed,
+		Reference: "PAYOUT-001",
+	}
+	expectedResponse := &models.DisbursementStatusResponse{
+		LoanID:     "loan_123",
+		Status:     models.DisbursementStatusDisbursed,
+		LoanStatus: models.StatusPending,
+		Reference:  "PAYOUT-001",
+	}
+
+	mockService.On("UpdateDisbursementStatus", mock.Anything, "loan_123", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/disbursement/callback", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("X-Callback-Token", "test-token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.UpdateDisbursementStatus(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.DisbursementStatusSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, models.StatusPending, response.Data.LoanStatus)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDisbursementHandler_UpdateDisbursementStatus_FailedWithoutReason(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(models.DisbursementCallbackRequest{Status: models.DisbursementStatusFailed})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/disbursement/callback", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("X-Callback-Token", "test-token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.UpdateDisbursementStatus(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Contains(t, response.Message, "failurereason is required")
+}
+
+func TestDisbursementHandler_UpdateDisbursementStatus_InvalidToken(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
+	}
+
+	// Create request without the callback token
+	reqBody, _ := json.Marshal(models.DisbursementCallbackRequest{Status: models.DisbursementStatusDisbursed, Reference: "PAYOUT-001"})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/disbursement/callback", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.UpdateDisbursementStatus(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockService.AssertNotCalled(t, "UpdateDisbursementStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDisbursementHandler_GetDisbursementStatus_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
+	}
+
+	mockService.On("GetDisbursementStatus", mock.Anything, "loan_123").Return(&models.DisbursementStatusResponse{
+		LoanID:     "loan_123",
+		Status:     models.DisbursementStatusRequested,
+		LoanStatus: models.StatusInactive,
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans/loan_123/disbursement", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.GetDisbursementStatus(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.DisbursementStatusSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, models.DisbursementStatusRequested, response.Data.Status)
+}
+
+func TestDisbursementHandler_HandlePayoutWebhook_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
+	}
+
+	req := models.PayoutWebhookRequest{
+		PayoutID:   "po_001",
+		ExternalID: "loan_123",
+		Status:     models.PayoutStatusSuccess,
+	}
+	mockService.On("HandlePayoutWebhook", mock.Anything, &req).Return(&models.DisbursementStatusResponse{
+		LoanID: "loan_123",
+		Status: models.DisbursementStatusDisbursed,
+	}, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/payouts/webhook", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("X-Callback-Token", "test-token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.HandlePayoutWebhook(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDisbursementHandler_HandlePayoutWebhook_InvalidToken(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+		payoutWebhookToken:  "test-token",
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(models.PayoutWebhookRequest{PayoutID: "po_001", ExternalID: "loan_123", Status: models.PayoutStatusSuccess})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/payouts/webhook", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("X-Callback-Token", "wrong-token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.HandlePayoutWebhook(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestDisbursementHandler_HandlePayoutWebhook_TokenNotConfigured(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+	}
+
+	// Create request; an empty token must not match an unconfigured one
+	reqBody, _ := json.Marshal(models.PayoutWebhookRequest{PayoutID: "po_001", ExternalID: "loan_123", Status: models.PayoutStatusSuccess})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/payouts/webhook", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("X-Callback-Token", "")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.HandlePayoutWebhook(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockService.AssertNotCalled(t, "HandlePayoutWebhook", mock.Anything, mock.Anything)
+}
+
+func TestDisbursementHandler_SimulateLoan_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+	}
+
+	req := models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10, // 10% interest rate
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 2,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
+	}
+
+	expectedResponse := &models.LoanSimulationResponse{
+		CustomerID:           "12312312",
+		Currency:             models.CurrencyIDR,
+		PrincipalAmount:      5000000.00,
+		InterestAmount:       500000.00,
+		NetDisbursedAmount:   5000000.00,
+		InstallmentAmount:    2750000.00,
+		TotalRepaymentAmount: 5500000.00,
+		Schedule: []models.PaymentScheduleResponse{
+			{InstallmentNumber: 1, InstallmentAmount: 2750000.00, DueDate: time.Date(2025, 9, 7, 11, 43, 0, 0, time.UTC)},
+			{InstallmentNumber: 2, InstallmentAmount: 2750000.00, DueDate: time.Date(2025, 9, 14, 11, 43, 0, 0, time.UTC)},
+		},
+	}
+
+	mockService.On("SimulateLoan", mock.Anything, mock.AnythingOfType("*models.DisbursementRequest")).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/simulate", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.SimulateLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.LoanSimulationSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Schedule, 2)
+	assert.Equal(t, 5500000.00, response.Data.TotalRepaymentAmount)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDisbursementHandler_SimulateLoan_ValidationError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewDisbursementServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &DisbursementHandler{
+		disbursementService: mockService,
+		middleware:          mockMiddleware,
+	}
+
+	req := models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10, // 10% interest rate
+		InstallmentUnit:     "day",
+		NumberOfInstallment: 2,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/simulate", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.SimulateLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Contains(t, response.Message, "installmentunit must be one of")
+}
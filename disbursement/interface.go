@@ -10,11 +10,38 @@ type DisbursementMySQLRepositoryInterface interface {
 	CreateDisbursement(ctx context.Context, disbursement *models.DisbursementDetail) error
 	CreateLoanSummary(ctx context.Context, loanSummary *models.LoanSummary) error
 	CreatePaymentSchedules(ctx context.Context, paymentSchedules []*models.PaymentSchedule) error
+	CreateLoanFees(ctx context.Context, loanFees []*models.LoanFee) error
+	CreateTaxLines(ctx context.Context, taxLines []*models.TaxLine) error
 	GetDisbursementByLoanID(ctx context.Context, loanID string) (*models.DisbursementDetail, error)
 	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	UpdateDisbursement(ctx context.Context, disbursement *models.DisbursementDetail) (bool, error)
+	ActivateLoan(ctx context.Context, disbursement *models.DisbursementDetail, loanSummary *models.LoanSummary, schedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) (bool, error)
+	FailDisbursement(ctx context.Context, disbursement *models.DisbursementDetail, loanSummary *models.LoanSummary) (bool, error)
+	GetUnsettledDisbursements(ctx context.Context) ([]*models.DisbursementDetail, error)
+	GetTaxLinesByLoanID(ctx context.Context, loanID string) ([]*models.TaxLine, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// PayoutGatewayInterface defines the interface for the payout gateway that moves disbursed funds to the borrower
+type PayoutGatewayInterface interface {
+	CreatePayout(ctx context.Context, req *models.PayoutRequest) (*models.PayoutResult, error)
+	GetPayout(ctx context.Context, externalID string) (*models.PayoutResult, error)
+}
+
+// VirtualAccountProviderInterface defines the interface for the provider that issues the virtual
+// account a borrower repays the loan into
+type VirtualAccountProviderInterface interface {
+	CreateVirtualAccount(ctx context.Context, req *models.VirtualAccountRequest) (*models.VirtualAccountResult, error)
+	CloseVirtualAccount(ctx context.Context, accountNumber string) error
 }
 
 // DisbursementServiceInterface defines the interface for disbursement service
 type DisbursementServiceInterface interface {
 	CreateDisbursement(ctx context.Context, req *models.DisbursementRequest) (*models.DisbursementResponse, error)
+	SimulateLoan(ctx context.Context, req *models.DisbursementRequest) (*models.LoanSimulationResponse, error)
+	GetDisbursementStatus(ctx context.Context, loanID string) (*models.DisbursementStatusResponse, error)
+	UpdateDisbursementStatus(ctx context.Context, loanID string, req *models.DisbursementCallbackRequest) (*models.DisbursementStatusResponse, error)
+	HandlePayoutWebhook(ctx context.Context, req *models.PayoutWebhookRequest) (*models.DisbursementStatusResponse, error)
+	SyncPayouts(ctx context.Context) (*models.PayoutSyncResponse, error)
 }
@@ -6,6 +6,7 @@ import (
 
 	"billing-engine/disbursement"
 	"billing-engine/models"
+	"billing-engine/utils/transaction"
 
 	"gorm.io/gorm"
 )
@@ -14,26 +15,37 @@ type disbursementMySQLRepository struct {
 	db *gorm.DB
 }
 
+// unsettledDisbursementStatuses are the statuses a disbursement can still be settled from
+var unsettledDisbursementStatuses = []string{models.DisbursementStatusRequested, models.DisbursementStatusProcessing}
+
 // NewDisbursementMySQLRepository creates a new disbursement repository instance
 func NewDisbursementMySQLRepository(db *gorm.DB) disbursement.DisbursementMySQLRepositoryInterface {
 	return &disbursementMySQLRepository{db: db}
 }
 
 func (r *disbursementMySQLRepository) CreateDisbursement(ctx context.Context, disbursementDetail *models.DisbursementDetail) error {
-	return r.db.WithContext(ctx).Create(disbursementDetail).Error
+	return transaction.DB(ctx, r.db).Create(disbursementDetail).Error
 }
 
 func (r *disbursementMySQLRepository) CreateLoanSummary(ctx context.Context, loanSummary *models.LoanSummary) error {
-	return r.db.WithContext(ctx).Create(loanSummary).Error
+	return transaction.DB(ctx, r.db).Create(loanSummary).Error
 }
 
 func (r *disbursementMySQLRepository) CreatePaymentSchedules(ctx context.Context, paymentSchedules []*models.PaymentSchedule) error {
-	return r.db.WithContext(ctx).Create(&paymentSchedules).Error
+	return transaction.DB(ctx, r.db).Create(&paymentSchedules).Error
+}
+
+func (r *disbursementMySQLRepository) CreateLoanFees(ctx context.Context, loanFees []*models.LoanFee) error {
+	return transaction.DB(ctx, r.db).Create(&loanFees).Error
+}
+
+func (r *disbursementMySQLRepository) CreateTaxLines(ctx context.Context, taxLines []*models.TaxLine) error {
+	return transaction.DB(ctx, r.db).Create(&taxLines).Error
 }
 
 func (r *disbursementMySQLRepository) GetDisbursementByLoanID(ctx context.Context, loanID string) (*models.DisbursementDetail, error) {
 	var disbursementDetail models.DisbursementDetail
-	err := r.db.WithContext(ctx).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&disbursementDetail).Error
+	err := transaction.DB(ctx, r.db).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&disbursementDetail).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -45,7 +57,7 @@ func (r *disbursementMySQLRepository) GetDisbursementByLoanID(ctx context.Contex
 
 func (r *disbursementMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
 	var loanSummary models.LoanSummary
-	err := r.db.WithContext(ctx).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	err := transaction.DB(ctx, r.db).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -54,3 +66,186 @@ func (r *disbursementMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context
 	}
 	return &loanSummary, nil
 }
+
+func (r *disbursementMySQLRepository) GetPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND deleted_at IS NULL", loanID).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// UpdateDisbursement moves a REQUESTED disbursement to its new status. It reports false when the
+// disbursement has left REQUESTED in the meantime, in which case nothing is written.
+func (r *disbursementMySQLRepository) UpdateDisbursement(ctx context.Context, disbursementDetail *models.DisbursementDetail) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(disbursementDetail).
+		Where("status = ?", models.DisbursementStatusRequested).
+		Updates(map[string]interface{}{
+			"status":     disbursementDetail.Status,
+			"reference":  disbursementDetail.Reference,
+			"updated_by": disbursementDetail.UpdatedBy,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ActivateLoan marks the disbursement as DISBURSED and activates the loan and its installments,
+// with their (possibly recalculated) due dates and history rows, in one transaction. The tax on
+// fees withheld from the payout is collected at the same time. It reports false, writing nothing,
+// when the disbursement is no longer REQUESTED or PROCESSING.
+func (r *disbursementMySQLRepository) ActivateLoan(ctx context.Context, disbursementDetail *models.DisbursementDetail, loanSummary *models.LoanSummary, schedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) (bool, error) {
+	claimed := false
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(disbursementDetail).
+			Where("status IN ?", unsettledDisbursementStatuses).
+			Updates(map[string]interface{}{
+				"status":       disbursementDetail.Status,
+				"disbursed_at": disbursementDetail.DisbursedAt,
+				"reference":    disbursementDetail.Reference,
+				"updated_by":   disbursementDetail.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		claimed = true
+
+		for _, schedule := range schedules {
+			if err := tx.Model(schedule).Updates(map[string]interface{}{
+				"status":               schedule.Status,
+				"installment_due_date": schedule.InstallmentDueDate,
+				"updated_by":           schedule.UpdatedBy,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.TaxLine{}).
+			Where("loan_id = ? AND installment_number = 0 AND status = ?", disbursementDetail.LoanID, models.StatusPending).
+			Updates(map[string]interface{}{
+				"status":       models.TaxStatusCollected,
+				"collected_at": disbursementDetail.DisbursedAt,
+				"updated_by":   "system",
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(loanSummary).Updates(map[string]interface{}{
+			"status":          loanSummary.Status,
+			"loan_start_date": loanSummary.LoanStartDate,
+			"updated_by":      loanSummary.UpdatedBy,
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// FailDisbursement marks the disbursement as FAILED and cancels the loan, its installments, its fees and its tax lines in one transaction.
+// It reports false, writing nothing, when the disbursement is no longer REQUESTED or PROCESSING.
+func (r *disbursementMySQLRepository) FailDisbursement(ctx context.Context, disbursementDetail *models.DisbursementDetail, loanSummary *models.LoanSummary) (bool, error) {
+	claimed := false
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(disbursementDetail).
+			Where("status IN ?", unsettledDisbursementStatuses).
+			Updates(map[string]interface{}{
+				"status":         disbursementDetail.Status,
+				"reference":      disbursementDetail.Reference,
+				"failure_reason": disbursementDetail.FailureReason,
+				"updated_by":     disbursementDetail.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		claimed = true
+
+		if err := tx.Model(&models.PaymentSchedule{}).
+			Where("loan_id = ? AND deleted_at IS NULL", loanSummary.LoanID).
+			Updates(map[string]interface{}{
+				"status":     models.StatusCancelled,
+				"deleted_at": gorm.Expr("CURRENT_TIMESTAMP"),
+				"deleted_by": "system",
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.LoanFee{}).
+			Where("loan_id = ? AND deleted_at IS NULL", loanSummary.LoanID).
+			Updates(map[string]interface{}{
+				"deleted_at": gorm.Expr("CURRENT_TIMESTAMP"),
+				"deleted_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.TaxLine{}).
+			Where("loan_id = ? AND status = ?", loanSummary.LoanID, models.StatusPending).
+			Updates(map[string]interface{}{
+				"status":     models.StatusCancelled,
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(loanSummary).Updates(map[string]interface{}{
+			"status":             loanSummary.Status,
+			"outstanding_amount": loanSummary.OutstandingAmount,
+			"updated_by":         loanSummary.UpdatedBy,
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// GetTaxLinesByLoanID returns the loan's tax lines, the ones withheld from the payout first
+func (r *disbursementMySQLRepository) GetTaxLinesByLoanID(ctx context.Context, loanID string) ([]*models.TaxLine, error) {
+	var taxLines []*models.TaxLine
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status <> ?", loanID, models.StatusCancelled).
+		Order("installment_number ASC, id ASC").
+		Find(&taxLines).Error
+	if err != nil {
+		return nil, err
+	}
+	return taxLines, nil
+}
+
+// GetUnsettledDisbursements returns the disbursements whose payout has not completed or failed yet
+func (r *disbursementMySQLRepository) GetUnsettledDisbursements(ctx context.Context) ([]*models.DisbursementDetail, error) {
+	var disbursementDetails []*models.DisbursementDetail
+	err := transaction.DB(ctx, r.db).
+		Where("status IN ? AND deleted_at IS NULL", unsettledDisbursementStatuses).
+		Order("id ASC").
+		Find(&disbursementDetails).Error
+	if err != nil {
+		return nil, err
+	}
+	return disbursementDetails, nil
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *disbursementMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
@@ -2,52 +2,290 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"billing-engine/disbursement"
+	"billing-engine/fee"
+	"billing-engine/ledger"
 	"billing-engine/models"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+	"billing-engine/utils/rate"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
 type disbursementService struct {
-	disbursementRepo disbursement.DisbursementMySQLRepositoryInterface
+	disbursementRepo       disbursement.DisbursementMySQLRepositoryInterface
+	feeService             fee.FeeServiceInterface
+	taxService             tax.TaxServiceInterface
+	ledgerService          ledger.LedgerServiceInterface
+	payoutGateway          disbursement.PayoutGatewayInterface
+	virtualAccountProvider disbursement.VirtualAccountProviderInterface
 }
 
 // NewDisbursementService creates a new disbursement service instance
-func NewDisbursementService(disbursementRepo disbursement.DisbursementMySQLRepositoryInterface) disbursement.DisbursementServiceInterface {
+func NewDisbursementService(disbursementRepo disbursement.DisbursementMySQLRepositoryInterface, feeService fee.FeeServiceInterface, taxService tax.TaxServiceInterface, ledgerService ledger.LedgerServiceInterface, payoutGateway disbursement.PayoutGatewayInterface, virtualAccountProvider disbursement.VirtualAccountProviderInterface) disbursement.DisbursementServiceInterface {
 	return &disbursementService{
-		disbursementRepo: disbursementRepo,
+		disbursementRepo:       disbursementRepo,
+		feeService:             feeService,
+		taxService:             taxService,
+		ledgerService:          ledgerService,
+		payoutGateway:          payoutGateway,
+		virtualAccountProvider: virtualAccountProvider,
 	}
 }
 
 func (s *disbursementService) CreateDisbursement(ctx context.Context, req *models.DisbursementRequest) (*models.DisbursementResponse, error) {
 	loanID := fmt.Sprintf("loan_%s", uuid.New().String())
+	plan, err := s.planLoan(ctx, loanID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Issue the virtual account the borrower repays into before anything is stored, so every
+	// booked loan can be paid. The account is closed again if the loan cannot be stored.
+	virtualAccount, err := s.virtualAccountProvider.CreateVirtualAccount(ctx, &models.VirtualAccountRequest{
+		ExternalID: loanID,
+		CustomerID: req.CustomerID,
+		Currency:   plan.loanSummary.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue virtual account: %v", err)
+	}
+	plan.loanSummary.VirtualAccountNumber = virtualAccount.AccountNumber
+	plan.loanSummary.VirtualAccountBank = virtualAccount.BankCode
+
+	// The loan is stored whole or not at all, so a payout is never submitted for half a loan
+	err = s.disbursementRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.disbursementRepo.CreateDisbursement(ctx, plan.disbursementDetail); err != nil {
+			return fmt.Errorf("failed to create disbursement: %v", err)
+		}
+		if err := s.disbursementRepo.CreateLoanSummary(ctx, plan.loanSummary); err != nil {
+			return fmt.Errorf("failed to create loan summary: %v", err)
+		}
+		if err := s.disbursementRepo.CreatePaymentSchedules(ctx, plan.paymentSchedules); err != nil {
+			return fmt.Errorf("failed to create payment schedules: %v", err)
+		}
+		if len(plan.loanFees) > 0 {
+			if err := s.disbursementRepo.CreateLoanFees(ctx, plan.loanFees); err != nil {
+				return fmt.Errorf("failed to create loan fees: %v", err)
+			}
+		}
+		if len(plan.taxLines) > 0 {
+			if err := s.disbursementRepo.CreateTaxLines(ctx, plan.taxLines); err != nil {
+				return fmt.Errorf("failed to create tax lines: %v", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if closeErr := s.virtualAccountProvider.CloseVirtualAccount(ctx, virtualAccount.AccountNumber); closeErr != nil {
+			return nil, fmt.Errorf("%v; failed to close virtual account %s: %v", err, virtualAccount.AccountNumber, closeErr)
+		}
+		return nil, err
+	}
+
+	// Hand the money over to the payout gateway. A failed submission leaves the disbursement
+	// REQUESTED; it is submitted again by the payout sync.
+	status := plan.disbursementDetail.Status
+	if payoutStatus, err := s.submitPayout(ctx, plan.disbursementDetail); err == nil {
+		status = payoutStatus.Status
+	}
+
+	paymentSchedules := plan.paymentSchedules
+	return &models.DisbursementResponse{
+		LoanID:                loanID,
+		CustomerID:            req.CustomerID,
+		Currency:              plan.loanSummary.Currency,
+		PrincipalAmount:       plan.loanSummary.PrincipalAmount,
+		Fees:                  buildLoanFeeResponses(plan.loanFees),
+		TotalFeeAmount:        plan.deductedFees.Add(plan.installmentFees).InexactFloat64(),
+		Taxes:                 plan.taxResponses,
+		TotalTaxAmount:        plan.deductedTax.Add(plan.installmentTax).InexactFloat64(),
+		NetDisbursedAmount:    plan.disbursementDetail.DisbursedAmount,
+		DisbursedAmount:       plan.disbursementDetail.DisbursedAmount,
+		InstallmentAmount:     plan.loanSummary.InstallmentAmount,
+		OutstandingAmount:     plan.loanSummary.OutstandingAmount,
+		AnnualPercentageRate:  plan.loanSummary.AnnualPercentageRate,
+		EffectiveInterestRate: plan.loanSummary.EffectiveInterestRate,
+		InstallmentUnit:       req.InstallmentUnit,
+		NumberOfInstallment:   req.NumberOfInstallment,
+		VirtualAccountNumber:  virtualAccount.AccountNumber,
+		VirtualAccountBank:    virtualAccount.BankCode,
+		DisbursementDate:      plan.loanSummary.LoanStartDate,
+		FirstDueDate:          paymentSchedules[0].InstallmentDueDate,
+		FinalDueDate:          paymentSchedules[len(paymentSchedules)-1].InstallmentDueDate,
+		Status:                status,
+	}, nil
+}
+
+// SimulateLoan returns the loan a disbursement request would book, with its full schedule, without
+// storing anything or paying out
+func (s *disbursementService) SimulateLoan(ctx context.Context, req *models.DisbursementRequest) (*models.LoanSimulationResponse, error) {
+	plan, err := s.planLoan(ctx, "", req)
+	if err != nil {
+		return nil, err
+	}
+
+	loanSummary := plan.loanSummary
+	schedules := make([]models.PaymentScheduleResponse, 0, len(plan.paymentSchedules))
+	for _, schedule := range plan.paymentSchedules {
+		schedules = append(schedules, models.PaymentScheduleResponse{
+			InstallmentNumber: schedule.InstallmentNumber,
+			DueDate:           schedule.InstallmentDueDate,
+			InstallmentAmount: schedule.InstallmentAmount,
+			TaxAmount:         schedule.TaxAmount,
+			Currency:          schedule.Currency,
+			Status:            schedule.Status,
+		})
+	}
+
+	return &models.LoanSimulationResponse{
+		CustomerID:            req.CustomerID,
+		ProductCode:           loanSummary.ProductCode,
+		Currency:              loanSummary.Currency,
+		PrincipalAmount:       loanSummary.PrincipalAmount,
+		InterestAmount:        loanSummary.InterestAmount,
+		Fees:                  buildLoanFeeResponses(plan.loanFees),
+		TotalFeeAmount:        plan.deductedFees.Add(plan.installmentFees).InexactFloat64(),
+		Taxes:                 plan.taxResponses,
+		TotalTaxAmount:        plan.deductedTax.Add(plan.installmentTax).InexactFloat64(),
+		NetDisbursedAmount:    plan.disbursementDetail.DisbursedAmount,
+		InstallmentAmount:     loanSummary.InstallmentAmount,
+		TotalRepaymentAmount:  loanSummary.OutstandingAmount,
+		InstallmentUnit:       loanSummary.InstallmentUnit,
+		NumberOfInstallment:   loanSummary.NoOfInstallment,
+		AnnualPercentageRate:  loanSummary.AnnualPercentageRate,
+		EffectiveInterestRate: loanSummary.EffectiveInterestRate,
+		StartDate:             loanSummary.LoanStartDate,
+		FirstDueDate:          schedules[0].DueDate,
+		FinalDueDate:          schedules[len(schedules)-1].DueDate,
+		Schedule:              schedules,
+	}, nil
+}
+
+// loanPlan is everything a disbursement books: the loan, its schedule, fees and taxes
+type loanPlan struct {
+	disbursementDetail *models.DisbursementDetail
+	loanSummary        *models.LoanSummary
+	paymentSchedules   []*models.PaymentSchedule
+	loanFees           []*models.LoanFee
+	taxLines           []*models.TaxLine
+	taxResponses       []models.TaxLineResponse
+	deductedFees       decimal.Decimal
+	installmentFees    decimal.Decimal
+	deductedTax        decimal.Decimal
+	installmentTax     decimal.Decimal
+}
+
+// planLoan works out the loan a disbursement request books without storing anything; it is shared
+// by CreateDisbursement and SimulateLoan so a simulation matches the booked loan exactly
+func (s *disbursementService) planLoan(ctx context.Context, loanID string, req *models.DisbursementRequest) (*loanPlan, error) {
 	startDate := req.StartDate
 	if startDate.IsZero() {
 		return nil, fmt.Errorf("start date cannot be zero")
 	}
+	currencyCode := currency.Normalize(req.Currency)
+	if !currency.IsSupported(currencyCode) {
+		return nil, fmt.Errorf("currency %s is not supported", currencyCode)
+	}
 	principal := decimal.NewFromFloat(req.PrincipalAmount)
+	if !currency.IsRounded(principal, currencyCode) {
+		return nil, fmt.Errorf("principal amount has more decimal places than %s allows", currencyCode)
+	}
 	interestRate := decimal.NewFromFloat(req.InterestRate)
 	numberOfInstallments := decimal.NewFromInt(int64(req.NumberOfInstallment))
+	productCode := req.ProductCode
+	if productCode == "" {
+		productCode = models.DefaultProductCode
+	}
+	// Apply the product's fee schedule: deducted fees reduce the amount paid out,
+	// installment fees are repaid with the installments
+	loanFees, err := s.feeService.CalculateFees(ctx, productCode, req.PrincipalAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	deductedFees := decimal.Zero
+	installmentFees := decimal.Zero
+	for _, loanFee := range loanFees {
+		loanFee.LoanID = loanID
+		if loanFee.ChargeMethod == models.FeeChargeDeducted {
+			deductedFees = deductedFees.Add(decimal.NewFromFloat(loanFee.Amount))
+		} else {
+			installmentFees = installmentFees.Add(decimal.NewFromFloat(loanFee.Amount))
+		}
+	}
+	// Tax the fees: the tax on deducted fees is withheld from the payout too, the tax on
+	// installment fees is spread over the installments together with the fee
+	var taxLines []*models.TaxLine
+	taxResponses := make([]models.TaxLineResponse, 0, len(loanFees))
+	deductedTax := decimal.Zero
+	installmentTax := decimal.Zero
+	installmentTaxes := make([]decimal.Decimal, req.NumberOfInstallment)
+	for _, loanFee := range loanFees {
+		taxLine := s.taxService.TaxCharge(loanFee.FeeType, loanFee.Amount, currencyCode)
+		if taxLine == nil {
+			continue
+		}
+		taxLine.LoanID = loanID
+		taxResponses = append(taxResponses, models.TaxLineResponse{
+			TaxType:       taxLine.TaxType,
+			ChargeType:    taxLine.ChargeType,
+			TaxableAmount: taxLine.TaxableAmount,
+			TaxRate:       taxLine.TaxRate,
+			TaxAmount:     taxLine.TaxAmount,
+			ChargeMethod:  loanFee.ChargeMethod,
+		})
+
+		if loanFee.ChargeMethod == models.FeeChargeDeducted {
+			deductedTax = deductedTax.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+			taxLines = append(taxLines, taxLine)
+			continue
+		}
+		installmentTax = installmentTax.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+		for i, installmentLine := range splitTaxLine(taxLine, req.NumberOfInstallment, currencyCode) {
+			installmentTaxes[i] = installmentTaxes[i].Add(decimal.NewFromFloat(installmentLine.TaxAmount))
+			taxLines = append(taxLines, installmentLine)
+		}
+	}
+	netDisbursedAmount := principal.Sub(deductedFees).Sub(deductedTax)
+	if !netDisbursedAmount.IsPositive() {
+		return nil, fmt.Errorf("deducted fees exceed principal amount")
+	}
 	// Calculate interest amount: principal * interest_rate (total interest for the loan)
-	interestAmount := principal.Mul(interestRate)
-	// Calculate total amount: principal + interest_amount
-	totalAmount := principal.Add(interestAmount)
-	// Calculate installment amount: total_amount / number_of_installments
-	installmentAmount := totalAmount.Div(numberOfInstallments)
-	// The effective interest rate is the same as the input interest rate
-	effectiveInterestRate := interestRate
+	interestAmount := currency.Round(principal.Mul(interestRate), currencyCode)
+	// Calculate installment amount: (principal + interest_amount + installment fees) / number_of_installments,
+	// rounded to the currency's minor unit with the remainder on the last installment. Each installment
+	// also carries its part of the tax on the installment fees
+	scheduledAmount := principal.Add(interestAmount).Add(installmentFees)
+	installmentAmount := currency.Round(scheduledAmount.Div(numberOfInstallments), currencyCode)
+	// Calculate total amount: principal + interest_amount + installment fees + their tax
+	totalAmount := scheduledAmount.Add(installmentTax)
+	firstInstallmentAmount := installmentAmount.Add(installmentTaxes[0])
+	// Generate payment schedules
+	paymentSchedules := s.generatePaymentSchedules(loanID, req, installmentAmount, scheduledAmount, currencyCode, startDate)
+	applyInstallmentTaxes(paymentSchedules, installmentTaxes)
+	// The APR and effective interest rate come from the IRR of the amount paid out against the
+	// installments, so fees and their tax count towards the cost of the loan
+	annualPercentageRate, effectiveInterestRate, err := loanRates(netDisbursedAmount, paymentSchedules, req.InstallmentUnit)
+	if err != nil {
+		return nil, err
+	}
 	// Create disbursement detail
 	disbursementDetail := &models.DisbursementDetail{
 		LoanID:            loanID,
 		CustomerID:        req.CustomerID,
 		DisbursementDate:  startDate,
-		DisbursedAmount:   principal.InexactFloat64(),
-		DisbursedCurrency: models.CurrencyIDR,
-		Status:            models.StatusPending,
+		DisbursedAmount:   netDisbursedAmount.InexactFloat64(),
+		DisbursedCurrency: currencyCode,
+		BankCode:          req.BankCode,
+		AccountNumber:     req.AccountNumber,
+		Status:            models.DisbursementStatusRequested,
 		CreatedBy:         "system",
 		UpdatedBy:         "system",
 	}
@@ -56,67 +294,74 @@ func (s *disbursementService) CreateDisbursement(ctx context.Context, req *model
 	loanSummary := &models.LoanSummary{
 		LoanID:                loanID,
 		CustomerID:            req.CustomerID,
+		ProductCode:           productCode,
+		Currency:              currencyCode,
 		PrincipalAmount:       principal.InexactFloat64(),
 		InterestAmount:        interestAmount.InexactFloat64(),
 		OutstandingAmount:     totalAmount.InexactFloat64(),
 		NoOfInstallment:       req.NumberOfInstallment,
 		InstallmentUnit:       req.InstallmentUnit,
-		InstallmentAmount:     installmentAmount.InexactFloat64(),
+		InstallmentAmount:     firstInstallmentAmount.InexactFloat64(),
+		FeeAmount:             installmentFees.InexactFloat64(),
+		TaxAmount:             installmentTax.InexactFloat64(),
+		AnnualPercentageRate:  annualPercentageRate.InexactFloat64(),
 		EffectiveInterestRate: effectiveInterestRate.InexactFloat64(),
 
-		Status:        models.StatusPending,
+		// The loan becomes active once the disbursement is confirmed
+		Status:        models.StatusInactive,
 		LoanStartDate: startDate,
 		CreatedBy:     "system",
 		UpdatedBy:     "system",
 	}
 
-	// Generate payment schedules
-	paymentSchedules := s.generatePaymentSchedules(loanID, req, installmentAmount, totalAmount, startDate)
-	if err := s.disbursementRepo.CreateDisbursement(ctx, disbursementDetail); err != nil {
-		return nil, fmt.Errorf("failed to create disbursement: %v", err)
-	}
-	if err := s.disbursementRepo.CreateLoanSummary(ctx, loanSummary); err != nil {
-		return nil, fmt.Errorf("failed to create loan summary: %v", err)
-	}
-	if err := s.disbursementRepo.CreatePaymentSchedules(ctx, paymentSchedules); err != nil {
-		return nil, fmt.Errorf("failed to create payment schedules: %v", err)
-	}
-
-	firstDueDate := paymentSchedules[0].InstallmentDueDate
-	finalDueDate := paymentSchedules[len(paymentSchedules)-1].InstallmentDueDate
-
-	return &models.DisbursementResponse{
-		LoanID:              loanID,
-		CustomerID:          req.CustomerID,
-		DisbursedAmount:     principal.InexactFloat64(),
-		InstallmentAmount:   installmentAmount.InexactFloat64(),
-		OutstandingAmount:   totalAmount.InexactFloat64(),
-		InstallmentUnit:     req.InstallmentUnit,
-		NumberOfInstallment: req.NumberOfInstallment,
-		DisbursementDate:    startDate,
-		FirstDueDate:        firstDueDate,
-		FinalDueDate:        finalDueDate,
+	return &loanPlan{
+		disbursementDetail: disbursementDetail,
+		loanSummary:        loanSummary,
+		paymentSchedules:   paymentSchedules,
+		loanFees:           loanFees,
+		taxLines:           taxLines,
+		taxResponses:       taxResponses,
+		deductedFees:       deductedFees,
+		installmentFees:    installmentFees,
+		deductedTax:        deductedTax,
+		installmentTax:     installmentTax,
 	}, nil
 }
 
-func (s *disbursementService) generatePaymentSchedules(loanID string, req *models.DisbursementRequest, installmentAmount, totalAmount decimal.Decimal, startDate time.Time) []*models.PaymentSchedule {
+func buildLoanFeeResponses(loanFees []*models.LoanFee) []models.LoanFeeResponse {
+	feeResponses := make([]models.LoanFeeResponse, 0, len(loanFees))
+	for _, loanFee := range loanFees {
+		feeResponses = append(feeResponses, models.LoanFeeResponse{
+			FeeType:         loanFee.FeeType,
+			CalculationType: loanFee.CalculationType,
+			Value:           loanFee.Value,
+			Amount:          loanFee.Amount,
+			ChargeMethod:    loanFee.ChargeMethod,
+		})
+	}
+	return feeResponses
+}
+
+// generatePaymentSchedules spreads the scheduled amount over the installments; the last installment
+// absorbs the rounding remainder
+func (s *disbursementService) generatePaymentSchedules(loanID string, req *models.DisbursementRequest, installmentAmount, scheduledAmount decimal.Decimal, currencyCode string, startDate time.Time) []*models.PaymentSchedule {
+	lastInstallmentAmount := scheduledAmount.Sub(installmentAmount.Mul(decimal.NewFromInt(int64(req.NumberOfInstallment - 1))))
+
 	schedules := make([]*models.PaymentSchedule, 0, req.NumberOfInstallment)
 	for i := 1; i <= req.NumberOfInstallment; i++ {
-		var dueDate time.Time
-		if req.InstallmentUnit == models.InstallmentUnitWeek {
-			dueDate = startDate.AddDate(0, 0, 7*i)
-		} else { // month
-			dueDate = startDate.AddDate(0, i, 0)
+		amount := installmentAmount
+		if i == req.NumberOfInstallment {
+			amount = lastInstallmentAmount
 		}
 
 		schedule := &models.PaymentSchedule{
 			LoanID:             loanID,
 			InstallmentNumber:  i,
-			InstallmentAmount:  installmentAmount.InexactFloat64(),
-			InstallmentDueDate: dueDate,
+			InstallmentAmount:  amount.InexactFloat64(),
+			InstallmentDueDate: installmentDueDate(startDate, req.InstallmentUnit, i),
 			InstallmentPaid:    0,
-			Status:             models.StatusPending,
-			Currency:           models.CurrencyIDR,
+			Status:             models.StatusInactive,
+			Currency:           currencyCode,
 			CreatedBy:          "system",
 			UpdatedBy:          "system",
 		}
@@ -126,3 +371,377 @@ func (s *disbursementService) generatePaymentSchedules(loanID string, req *model
 
 	return schedules
 }
+
+// loanRates returns the APR (nominal yearly rate) and the effective yearly interest rate of a loan
+// paying out netDisbursedAmount and repaid by the schedules, rounded to 6 decimals
+func loanRates(netDisbursedAmount decimal.Decimal, schedules []*models.PaymentSchedule, installmentUnit string) (decimal.Decimal, decimal.Decimal, error) {
+	payments := make([]float64, 0, len(schedules))
+	for _, schedule := range schedules {
+		payments = append(payments, schedule.InstallmentAmount)
+	}
+	periodicRate, err := rate.PeriodicIRR(netDisbursedAmount.InexactFloat64(), payments)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("failed to calculate APR: %v", err)
+	}
+
+	periodsPerYear := rate.PeriodsPerYear(installmentUnit)
+	annualPercentageRate := decimal.NewFromFloat(rate.AnnualPercentageRate(periodicRate, periodsPerYear)).Round(6)
+	effectiveInterestRate := decimal.NewFromFloat(rate.EffectiveAnnualRate(periodicRate, periodsPerYear)).Round(6)
+	return annualPercentageRate, effectiveInterestRate, nil
+}
+
+// applyInstallmentTaxes adds the tax due with each installment to its amount
+func applyInstallmentTaxes(schedules []*models.PaymentSchedule, installmentTaxes []decimal.Decimal) {
+	for i, schedule := range schedules {
+		if installmentTaxes[i].IsZero() {
+			continue
+		}
+		schedule.TaxAmount = installmentTaxes[i].InexactFloat64()
+		schedule.InstallmentAmount = decimal.NewFromFloat(schedule.InstallmentAmount).Add(installmentTaxes[i]).InexactFloat64()
+	}
+}
+
+// splitTaxLine spreads the tax on an installment fee over the installments. Each part is rounded
+// down to the currency's minor unit and the remainder is due with the last installment.
+func splitTaxLine(taxLine *models.TaxLine, numberOfInstallments int, currencyCode string) []*models.TaxLine {
+	taxableParts := currency.Split(decimal.NewFromFloat(taxLine.TaxableAmount), numberOfInstallments, currencyCode)
+	taxParts := currency.Split(decimal.NewFromFloat(taxLine.TaxAmount), numberOfInstallments, currencyCode)
+
+	lines := make([]*models.TaxLine, 0, numberOfInstallments)
+	for i := 0; i < numberOfInstallments; i++ {
+		line := *taxLine
+		line.InstallmentNumber = i + 1
+		line.TaxableAmount = taxableParts[i].InexactFloat64()
+		line.TaxAmount = taxParts[i].InexactFloat64()
+		lines = append(lines, &line)
+	}
+	return lines
+}
+
+// installmentDueDate returns the due date of the given installment number counted from the start date
+func installmentDueDate(startDate time.Time, installmentUnit string, installmentNumber int) time.Time {
+	if installmentUnit == models.InstallmentUnitWeek {
+		return startDate.AddDate(0, 0, 7*installmentNumber)
+	}
+	return startDate.AddDate(0, installmentNumber, 0) // month
+}
+
+// allowedDisbursementTransitions lists the statuses a disbursement can move to from its current status
+var allowedDisbursementTransitions = map[string][]string{
+	models.DisbursementStatusRequested:  {models.DisbursementStatusProcessing, models.DisbursementStatusDisbursed, models.DisbursementStatusFailed},
+	models.DisbursementStatusProcessing: {models.DisbursementStatusDisbursed, models.DisbursementStatusFailed},
+}
+
+func canTransition(from, to string) bool {
+	for _, status := range allowedDisbursementTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *disbursementService) GetDisbursementStatus(ctx context.Context, loanID string) (*models.DisbursementStatusResponse, error) {
+	disbursementDetail, err := s.disbursementRepo.GetDisbursementByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disbursement: %v", err)
+	}
+	if disbursementDetail == nil {
+		return nil, fmt.Errorf("disbursement not found")
+	}
+
+	loanSummary, err := s.disbursementRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	schedules, err := s.disbursementRepo.GetPaymentSchedulesByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment schedules: %v", err)
+	}
+
+	return buildDisbursementStatusResponse(disbursementDetail, loanSummary, schedules), nil
+}
+
+func (s *disbursementService) UpdateDisbursementStatus(ctx context.Context, loanID string, req *models.DisbursementCallbackRequest) (*models.DisbursementStatusResponse, error) {
+	disbursementDetail, err := s.disbursementRepo.GetDisbursementByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disbursement: %v", err)
+	}
+	if disbursementDetail == nil {
+		return nil, fmt.Errorf("disbursement not found")
+	}
+	if !canTransition(disbursementDetail.Status, req.Status) {
+		return nil, fmt.Errorf("disbursement cannot move from %s to %s", disbursementDetail.Status, req.Status)
+	}
+	fromStatus := disbursementDetail.Status
+
+	loanSummary, err := s.disbursementRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	schedules, err := s.disbursementRepo.GetPaymentSchedulesByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment schedules: %v", err)
+	}
+
+	disbursementDetail.Status = req.Status
+	if req.Reference != "" {
+		disbursementDetail.Reference = req.Reference
+	}
+	disbursementDetail.UpdatedBy = "system"
+
+	switch req.Status {
+	case models.DisbursementStatusProcessing:
+		claimed, err := s.disbursementRepo.UpdateDisbursement(ctx, disbursementDetail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update disbursement: %v", err)
+		}
+		if !claimed {
+			return nil, fmt.Errorf("disbursement is no longer %s", fromStatus)
+		}
+
+	case models.DisbursementStatusDisbursed:
+		disbursedAt := req.DisbursedAt
+		if disbursedAt.IsZero() {
+			disbursedAt = time.Now()
+		}
+		disbursementDetail.DisbursedAt = &disbursedAt
+
+		// Optionally count the installments from the date the money actually reached the borrower
+		if req.RecalculateSchedule {
+			loanSummary.LoanStartDate = disbursedAt
+		}
+
+		histories := make([]*models.PaymentScheduleHistory, 0, len(schedules))
+		for _, schedule := range schedules {
+			if req.RecalculateSchedule {
+				schedule.InstallmentDueDate = installmentDueDate(disbursedAt, loanSummary.InstallmentUnit, schedule.InstallmentNumber)
+			}
+			schedule.Status = models.StatusPending
+			schedule.UpdatedBy = "system"
+
+			histories = append(histories, &models.PaymentScheduleHistory{
+				ScheduleID:         schedule.ID,
+				LoanID:             schedule.LoanID,
+				Action:             models.ActionActivate,
+				InstallmentNumber:  schedule.InstallmentNumber,
+				InstallmentAmount:  schedule.InstallmentAmount,
+				InstallmentDueDate: schedule.InstallmentDueDate,
+				Status:             schedule.Status,
+				Currency:           schedule.Currency,
+				CreatedBy:          "system",
+			})
+		}
+
+		loanSummary.Status = models.StatusPending
+		loanSummary.UpdatedBy = "system"
+
+		deductedTax, err := s.deductedTaxAmount(ctx, loanID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The loan is booked in the ledger together with its activation, once, by whoever settles the disbursement first
+		err = s.disbursementRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+			claimed, err := s.disbursementRepo.ActivateLoan(ctx, disbursementDetail, loanSummary, schedules, histories)
+			if err != nil {
+				return fmt.Errorf("failed to activate loan: %v", err)
+			}
+			if !claimed {
+				return fmt.Errorf("disbursement is no longer %s", fromStatus)
+			}
+			if err := s.ledgerService.PostDisbursement(ctx, loanSummary, disbursementDetail, deductedTax.InexactFloat64()); err != nil {
+				return fmt.Errorf("failed to post disbursement: %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case models.DisbursementStatusFailed:
+		disbursementDetail.FailureReason = req.FailureReason
+
+		// The money never reached the borrower, so nothing is owed on the loan
+		loanSummary.Status = models.StatusCancelled
+		loanSummary.OutstandingAmount = 0
+		loanSummary.UpdatedBy = "system"
+		schedules = nil
+
+		claimed, err := s.disbursementRepo.FailDisbursement(ctx, disbursementDetail, loanSummary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update disbursement: %v", err)
+		}
+		if !claimed {
+			return nil, fmt.Errorf("disbursement is no longer %s", fromStatus)
+		}
+
+		// The cancelled loan takes no repayments, so its virtual account is closed once the loan is
+		// stored. The loan stays cancelled if the provider fails; the account is left for ops to close.
+		s.closeVirtualAccount(ctx, loanSummary)
+	}
+
+	return buildDisbursementStatusResponse(disbursementDetail, loanSummary, schedules), nil
+}
+
+// closeVirtualAccount closes the virtual account of a loan that no longer takes repayments
+func (s *disbursementService) closeVirtualAccount(ctx context.Context, loanSummary *models.LoanSummary) {
+	if loanSummary.VirtualAccountNumber == "" {
+		return
+	}
+	if err := s.virtualAccountProvider.CloseVirtualAccount(ctx, loanSummary.VirtualAccountNumber); err != nil {
+		log.Printf("failed to close virtual account %s of loan %s: %v", loanSummary.VirtualAccountNumber, loanSummary.LoanID, err)
+	}
+}
+
+// deductedTaxAmount adds up the tax on the fees withheld from the payout (installment number 0)
+func (s *disbursementService) deductedTaxAmount(ctx context.Context, loanID string) (decimal.Decimal, error) {
+	taxLines, err := s.disbursementRepo.GetTaxLinesByLoanID(ctx, loanID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get tax lines: %v", err)
+	}
+
+	total := decimal.Zero
+	for _, taxLine := range taxLines {
+		if taxLine.InstallmentNumber == 0 {
+			total = total.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+		}
+	}
+	return total, nil
+}
+
+func (s *disbursementService) HandlePayoutWebhook(ctx context.Context, req *models.PayoutWebhookRequest) (*models.DisbursementStatusResponse, error) {
+	disbursementDetail, err := s.disbursementRepo.GetDisbursementByLoanID(ctx, req.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disbursement: %v", err)
+	}
+	if disbursementDetail == nil {
+		return nil, fmt.Errorf("disbursement not found")
+	}
+
+	return s.applyPayoutResult(ctx, disbursementDetail, &models.PayoutResult{
+		PayoutID:      req.PayoutID,
+		ExternalID:    req.ExternalID,
+		Status:        req.Status,
+		FailureReason: req.FailureReason,
+		SettledAt:     req.SettledAt,
+	})
+}
+
+// SyncPayouts submits the disbursements that never reached the payout gateway and polls the
+// ones still in flight, for payouts whose webhook was missed
+func (s *disbursementService) SyncPayouts(ctx context.Context) (*models.PayoutSyncResponse, error) {
+	disbursementDetails, err := s.disbursementRepo.GetUnsettledDisbursements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsettled disbursements: %v", err)
+	}
+
+	result := &models.PayoutSyncResponse{}
+	for _, disbursementDetail := range disbursementDetails {
+		result.CheckedDisbursements++
+
+		var statusResponse *models.DisbursementStatusResponse
+		if disbursementDetail.Status == models.DisbursementStatusRequested {
+			statusResponse, err = s.submitPayout(ctx, disbursementDetail)
+		} else {
+			statusResponse, err = s.pollPayout(ctx, disbursementDetail)
+		}
+		if err != nil {
+			result.Errors++
+			continue
+		}
+
+		switch statusResponse.Status {
+		case models.DisbursementStatusDisbursed:
+			result.DisbursedLoans++
+		case models.DisbursementStatusFailed:
+			result.FailedDisbursements++
+		}
+	}
+
+	return result, nil
+}
+
+// submitPayout sends the disbursement to the payout gateway; the loan ID makes resubmission idempotent.
+// A payout the gateway refuses outright fails the disbursement, as sending it again would never
+// succeed; any other error leaves it REQUESTED for the next payout sync.
+func (s *disbursementService) submitPayout(ctx context.Context, disbursementDetail *models.DisbursementDetail) (*models.DisbursementStatusResponse, error) {
+	payout, err := s.payoutGateway.CreatePayout(ctx, &models.PayoutRequest{
+		ExternalID:    disbursementDetail.LoanID,
+		BankCode:      disbursementDetail.BankCode,
+		AccountNumber: disbursementDetail.AccountNumber,
+		Amount:        disbursementDetail.DisbursedAmount,
+		Currency:      disbursementDetail.DisbursedCurrency,
+	})
+	var rejection *models.PayoutRejectionError
+	if errors.As(err, &rejection) {
+		payout = &models.PayoutResult{
+			ExternalID:    disbursementDetail.LoanID,
+			Status:        models.PayoutStatusFailed,
+			FailureReason: fmt.Sprintf("payout rejected by the gateway: %s", rejection.Reason),
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create payout: %v", err)
+	}
+	return s.applyPayoutResult(ctx, disbursementDetail, payout)
+}
+
+func (s *disbursementService) pollPayout(ctx context.Context, disbursementDetail *models.DisbursementDetail) (*models.DisbursementStatusResponse, error) {
+	payout, err := s.payoutGateway.GetPayout(ctx, disbursementDetail.LoanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payout: %v", err)
+	}
+	return s.applyPayoutResult(ctx, disbursementDetail, payout)
+}
+
+// applyPayoutResult moves the disbursement to the state matching the payout status.
+// Repeated notifications for a state the disbursement already has are ignored.
+func (s *disbursementService) applyPayoutResult(ctx context.Context, disbursementDetail *models.DisbursementDetail, payout *models.PayoutResult) (*models.DisbursementStatusResponse, error) {
+	req := &models.DisbursementCallbackRequest{Reference: payout.PayoutID}
+	switch payout.Status {
+	case models.PayoutStatusSuccess:
+		req.Status = models.DisbursementStatusDisbursed
+		if payout.SettledAt != nil {
+			req.DisbursedAt = *payout.SettledAt
+		}
+	case models.PayoutStatusFailed:
+		req.Status = models.DisbursementStatusFailed
+		req.FailureReason = payout.FailureReason
+	case models.PayoutStatusPending:
+		req.Status = models.DisbursementStatusProcessing
+	default:
+		return nil, fmt.Errorf("unknown payout status %s", payout.Status)
+	}
+
+	if disbursementDetail.Status == req.Status {
+		return s.GetDisbursementStatus(ctx, disbursementDetail.LoanID)
+	}
+	return s.UpdateDisbursementStatus(ctx, disbursementDetail.LoanID, req)
+}
+
+func buildDisbursementStatusResponse(disbursementDetail *models.DisbursementDetail, loanSummary *models.LoanSummary, schedules []*models.PaymentSchedule) *models.DisbursementStatusResponse {
+	response := &models.DisbursementStatusResponse{
+		LoanID:           disbursementDetail.LoanID,
+		Status:           disbursementDetail.Status,
+		LoanStatus:       loanSummary.Status,
+		DisbursedAmount:  disbursementDetail.DisbursedAmount,
+		Currency:         disbursementDetail.DisbursedCurrency,
+		DisbursementDate: disbursementDetail.DisbursementDate,
+		DisbursedAt:      disbursementDetail.DisbursedAt,
+		Reference:        disbursementDetail.Reference,
+		FailureReason:    disbursementDetail.FailureReason,
+	}
+	if len(schedules) > 0 {
+		firstDueDate := schedules[0].InstallmentDueDate
+		response.FirstDueDate = &firstDueDate
+	}
+	return response
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	mocks "billing-engine/disbursement/_mock"
+	feeMocks "billing-engine/fee/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
 	"billing-engine/models"
+	taxMocks "billing-engine/tax/_mock"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,7 +19,12 @@ import (
 
 func TestDisbursementService_CreateDisbursement_Success(t *testing.T) {
 	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
-	service := NewDisbursementService(mockRepo)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
 	ctx := context.Background()
 
 	req := &models.DisbursementRequest{
@@ -25,13 +34,35 @@ func TestDisbursementService_CreateDisbursement_Success(t *testing.T) {
 		NumberOfInstallment: 50,
 		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
 		CustomerID:          "12312312",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
 	}
 
 	// Mock repository calls
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{}, nil)
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.MatchedBy(func(vaReq *models.VirtualAccountRequest) bool {
+		return strings.HasPrefix(vaReq.ExternalID, "loan_") && vaReq.CustomerID == "12312312" && vaReq.Currency == models.CurrencyIDR
+	})).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("CreateDisbursement", ctx, mock.AnythingOfType("*models.DisbursementDetail")).Return(nil)
-	mockRepo.On("CreateLoanSummary", ctx, mock.AnythingOfType("*models.LoanSummary")).Return(nil)
+	mockRepo.On("CreateLoanSummary", ctx, mock.MatchedBy(func(loanSummary *models.LoanSummary) bool {
+		return loanSummary.VirtualAccountNumber == "8808812345678901" && loanSummary.VirtualAccountBank == "BCA"
+	})).Return(nil)
 	mockRepo.On("CreatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
 
+	// Mock payout accepted by the gateway, settlement pending
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.BankCode == "BCA" && payout.AccountNumber == "1234567890" && payout.Amount == 5000000.00
+	})).Return(&models.PayoutResult{PayoutID: "po_001", Status: models.PayoutStatusPending}, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, mock.AnythingOfType("string")).Return(&models.DisbursementDetail{Status: models.DisbursementStatusRequested}, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, mock.AnythingOfType("string")).Return(&models.LoanSummary{Status: models.StatusInactive}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, mock.AnythingOfType("string")).Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("UpdateDisbursement", ctx, mock.MatchedBy(func(disbursement *models.DisbursementDetail) bool {
+		return disbursement.Status == models.DisbursementStatusProcessing && disbursement.Reference == "po_001"
+	})).Return(true, nil)
+
 	// Execute
 	response, err := service.CreateDisbursement(ctx, req)
 
@@ -42,16 +73,61 @@ func TestDisbursementService_CreateDisbursement_Success(t *testing.T) {
 	assert.Equal(t, req.PrincipalAmount, response.DisbursedAmount) // only principal is disbursed
 	assert.Equal(t, 110000.00, response.InstallmentAmount)         // (5000000 + 500000) / 50
 	assert.Equal(t, 5500000.00, response.OutstandingAmount)        // 5000000 + 500000
+	assert.Equal(t, req.PrincipalAmount, response.PrincipalAmount)
+	assert.Equal(t, 0.197793, response.AnnualPercentageRate) // the 10% flat rate amortised over 50 weeks
+	assert.Equal(t, 0.218253, response.EffectiveInterestRate)
+	assert.Empty(t, response.Fees)
 	assert.Equal(t, req.InstallmentUnit, response.InstallmentUnit)
 	assert.Equal(t, req.NumberOfInstallment, response.NumberOfInstallment)
 	assert.Contains(t, response.LoanID, "loan_")
+	assert.Equal(t, "8808812345678901", response.VirtualAccountNumber)
+	assert.Equal(t, "BCA", response.VirtualAccountBank)
+	assert.Equal(t, models.DisbursementStatusProcessing, response.Status)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestDisbursementService_CreateDisbursement_VirtualAccountError(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10,
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 50,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+	}
+
+	// Mock the provider failing; nothing is stored
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{}, nil)
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(nil, errors.New("provider unavailable"))
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to issue virtual account")
+	mockRepo.AssertNotCalled(t, "CreateDisbursement", mock.Anything, mock.Anything)
+}
+
 func TestDisbursementService_CreateDisbursement_ZeroStartDate(t *testing.T) {
 	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
-	service := NewDisbursementService(mockRepo)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
 	ctx := context.Background()
 
 	req := &models.DisbursementRequest{
@@ -74,7 +150,12 @@ func TestDisbursementService_CreateDisbursement_ZeroStartDate(t *testing.T) {
 
 func TestDisbursementService_CreateDisbursement_RepositoryError(t *testing.T) {
 	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
-	service := NewDisbursementService(mockRepo)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
 	ctx := context.Background()
 
 	req := &models.DisbursementRequest{
@@ -87,7 +168,13 @@ func TestDisbursementService_CreateDisbursement_RepositoryError(t *testing.T) {
 	}
 
 	// Mock repository error
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{}, nil)
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("CreateDisbursement", ctx, mock.AnythingOfType("*models.DisbursementDetail")).Return(errors.New("database error"))
+	mockVAProvider.On("CloseVirtualAccount", ctx, "8808812345678901").Return(nil)
 
 	// Execute
 	response, err := service.CreateDisbursement(ctx, req)
@@ -97,12 +184,57 @@ func TestDisbursementService_CreateDisbursement_RepositoryError(t *testing.T) {
 	assert.Nil(t, response)
 	assert.Contains(t, err.Error(), "failed to create disbursement")
 
+	// The loan was not stored, so its virtual account is closed again
 	mockRepo.AssertExpectations(t)
+	mockVAProvider.AssertExpectations(t)
+}
+
+func TestDisbursementService_CreateDisbursement_CloseVirtualAccountError(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10,
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 50,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+	}
+
+	// Mock the loan failing to store and the provider failing to close the account
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{}, nil)
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateDisbursement", ctx, mock.AnythingOfType("*models.DisbursementDetail")).Return(errors.New("database error"))
+	mockVAProvider.On("CloseVirtualAccount", ctx, "8808812345678901").Return(errors.New("provider unavailable"))
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to create disbursement")
+	assert.Contains(t, err.Error(), "failed to close virtual account 8808812345678901")
 }
 
 func TestDisbursementService_CreateDisbursement_MonthlyInstallments(t *testing.T) {
 	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
-	service := NewDisbursementService(mockRepo)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
 	ctx := context.Background()
 
 	req := &models.DisbursementRequest{
@@ -115,10 +247,18 @@ func TestDisbursementService_CreateDisbursement_MonthlyInstallments(t *testing.T
 	}
 
 	// Mock repository calls
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 1000000.00, models.CurrencyIDR).Return([]*models.LoanFee{}, nil)
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("CreateDisbursement", ctx, mock.AnythingOfType("*models.DisbursementDetail")).Return(nil)
 	mockRepo.On("CreateLoanSummary", ctx, mock.AnythingOfType("*models.LoanSummary")).Return(nil)
 	mockRepo.On("CreatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
 
+	// Mock payout gateway unreachable, the payout sync submits it later
+	mockGateway.On("CreatePayout", ctx, mock.AnythingOfType("*models.PayoutRequest")).Return(nil, errors.New("connection refused"))
+
 	// Execute
 	response, err := service.CreateDisbursement(ctx, req)
 
@@ -127,10 +267,308 @@ func TestDisbursementService_CreateDisbursement_MonthlyInstallments(t *testing.T
 	assert.NotNil(t, response)
 	assert.InDelta(t, 91666.67, response.InstallmentAmount, 0.01) // (1000000 + 100000) / 12 rounded
 	assert.Equal(t, "month", response.InstallmentUnit)
+	assert.Equal(t, models.DisbursementStatusRequested, response.Status)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestDisbursementService_CreateDisbursement_WithFees(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10, // 10% interest rate
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 50,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+		ProductCode:         "WEEKLY_50",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
+	}
+
+	// Mock fee schedule: admin fee deducted upfront, provision fee added to the installments
+	mockFeeService.On("CalculateFees", ctx, "WEEKLY_50", 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{
+		{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, Amount: 150000.00, ChargeMethod: models.FeeChargeDeducted},
+		{FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationPercentage, Value: 0.01, Amount: 50000.00, ChargeMethod: models.FeeChargeInstallment},
+	}, nil)
+	mockTaxService.On("TaxCharge", models.FeeTypeAdmin, 150000.00, models.CurrencyIDR).Return(nil)
+	mockTaxService.On("TaxCharge", models.FeeTypeProvision, 50000.00, models.CurrencyIDR).Return(nil)
+
+	// Mock repository calls
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateDisbursement", ctx, mock.MatchedBy(func(disbursement *models.DisbursementDetail) bool {
+		return disbursement.DisbursedAmount == 4850000.00
+	})).Return(nil)
+	mockRepo.On("CreateLoanSummary", ctx, mock.MatchedBy(func(loanSummary *models.LoanSummary) bool {
+		return loanSummary.PrincipalAmount == 5000000.00 && loanSummary.FeeAmount == 50000.00 && loanSummary.OutstandingAmount == 5550000.00
+	})).Return(nil)
+	mockRepo.On("CreatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreateLoanFees", ctx, mock.MatchedBy(func(loanFees []*models.LoanFee) bool {
+		return len(loanFees) == 2 && loanFees[0].LoanID != "" && loanFees[0].LoanID == loanFees[1].LoanID
+	})).Return(nil)
+
+	// Mock payout of the net amount, rejected by the gateway
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.Amount == 4850000.00
+	})).Return(nil, errors.New("connection refused"))
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 5000000.00, response.PrincipalAmount)
+	assert.Len(t, response.Fees, 2)
+	assert.Equal(t, 200000.00, response.TotalFeeAmount)
+	assert.Equal(t, 4850000.00, response.NetDisbursedAmount) // 5000000 - 150000 admin fee
+	assert.Equal(t, 4850000.00, response.DisbursedAmount)
+	assert.Equal(t, 111000.00, response.InstallmentAmount) // (5000000 + 500000 + 50000) / 50
+	assert.Equal(t, 5550000.00, response.OutstandingAmount)
+	assert.Equal(t, 0.281889, response.AnnualPercentageRate) // fees raise the rate on the net amount paid out
+	assert.Equal(t, 0.324623, response.EffectiveInterestRate)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDisbursementService_CreateDisbursement_WithTaxedFees(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10, // 10% interest rate
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 3,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+		ProductCode:         "WEEKLY_3",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
+	}
+
+	// Mock fee schedule and 11% PPN on both fees
+	mockFeeService.On("CalculateFees", ctx, "WEEKLY_3", 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{
+		{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, Amount: 150000.00, ChargeMethod: models.FeeChargeDeducted},
+		{FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationFixed, Value: 30000, Amount: 30000.00, ChargeMethod: models.FeeChargeInstallment},
+	}, nil)
+	mockTaxService.On("TaxCharge", models.FeeTypeAdmin, 150000.00, models.CurrencyIDR).Return(&models.TaxLine{
+		TaxType: models.TaxTypePPN, ChargeType: models.FeeTypeAdmin, TaxableAmount: 150000.00, TaxRate: 0.11, TaxAmount: 16500.00, Status: models.StatusPending,
+	})
+	mockTaxService.On("TaxCharge", models.FeeTypeProvision, 30000.00, models.CurrencyIDR).Return(&models.TaxLine{
+		TaxType: models.TaxTypePPN, ChargeType: models.FeeTypeProvision, TaxableAmount: 30000.00, TaxRate: 0.11, TaxAmount: 3300.01, Status: models.StatusPending,
+	})
+
+	// Mock repository calls
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateDisbursement", ctx, mock.MatchedBy(func(disbursement *models.DisbursementDetail) bool {
+		return disbursement.DisbursedAmount == 4833500.00
+	})).Return(nil)
+	mockRepo.On("CreateLoanSummary", ctx, mock.MatchedBy(func(loanSummary *models.LoanSummary) bool {
+		return loanSummary.TaxAmount == 3300.01 && loanSummary.OutstandingAmount == 5533300.01
+	})).Return(nil)
+	mockRepo.On("CreatePaymentSchedules", ctx, mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+		// (5000000 + 500000 + 30000) / 3 = 1843333.33 plus the tax part; the last installment takes the remainder
+		return len(schedules) == 3 &&
+			schedules[0].TaxAmount == 1100.00 && schedules[1].TaxAmount == 1100.00 && schedules[2].TaxAmount == 1100.01 &&
+			decimal.NewFromFloat(schedules[0].InstallmentAmount).Round(2).Equal(decimal.RequireFromString("1844433.33"))
+	})).Return(nil)
+	mockRepo.On("CreateLoanFees", ctx, mock.AnythingOfType("[]*models.LoanFee")).Return(nil)
+	mockRepo.On("CreateTaxLines", ctx, mock.MatchedBy(func(taxLines []*models.TaxLine) bool {
+		// one line for the deducted fee, one per installment for the installment fee
+		return len(taxLines) == 4 &&
+			taxLines[0].ChargeType == models.FeeTypeAdmin && taxLines[0].InstallmentNumber == 0 &&
+			taxLines[3].InstallmentNumber == 3 && taxLines[3].TaxableAmount == 10000.00 && taxLines[3].TaxAmount == 1100.01
+	})).Return(nil)
+
+	// Mock payout of the net amount, rejected by the gateway
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.Amount == 4833500.00
+	})).Return(nil, errors.New("connection refused"))
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Taxes, 2)
+	assert.Equal(t, models.FeeChargeDeducted, response.Taxes[0].ChargeMethod)
+	assert.Equal(t, 19800.01, response.TotalTaxAmount)
+	assert.Equal(t, 4833500.00, response.NetDisbursedAmount) // 5000000 - 150000 admin fee - 16500 PPN
+	assert.Equal(t, 5533300.01, response.OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDisbursementService_CreateDisbursement_FeesExceedPrincipal(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     100000.00,
+		InterestRate:        0.10, // 10% interest rate
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 4,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+	}
+
+	// Mock fixed admin fee above the principal
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 100000.00, models.CurrencyIDR).Return([]*models.LoanFee{
+		{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, Amount: 150000.00, ChargeMethod: models.FeeChargeDeducted},
+	}, nil)
+	mockTaxService.On("TaxCharge", models.FeeTypeAdmin, 150000.00, models.CurrencyIDR).Return(nil)
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "deducted fees exceed principal amount")
+}
+
+func TestDisbursementService_CreateDisbursement_ZeroDecimalCurrency(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     1000000,
+		InterestRate:        0.10,
+		InstallmentUnit:     "month",
+		NumberOfInstallment: 3,
+		StartDate:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CustomerID:          "customer123",
+		BankCode:            "MUFG",
+		AccountNumber:       "1234567890",
+		Currency:            "jpy",
+	}
+
+	// Mock repository calls
+	mockFeeService.On("CalculateFees", ctx, models.DefaultProductCode, 1000000.00, "JPY").Return([]*models.LoanFee{}, nil)
+	mockVAProvider.On("CreateVirtualAccount", ctx, mock.AnythingOfType("*models.VirtualAccountRequest")).Return(&models.VirtualAccountResult{AccountNumber: "8808812345678901", BankCode: "BCA"}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateDisbursement", ctx, mock.MatchedBy(func(disbursement *models.DisbursementDetail) bool {
+		return disbursement.DisbursedCurrency == "JPY"
+	})).Return(nil)
+	mockRepo.On("CreateLoanSummary", ctx, mock.MatchedBy(func(loanSummary *models.LoanSummary) bool {
+		return loanSummary.Currency == "JPY" && loanSummary.OutstandingAmount == 1100000
+	})).Return(nil)
+	mockRepo.On("CreatePaymentSchedules", ctx, mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+		// 1100000 / 3 rounded to whole yen, the last installment takes the remainder
+		return len(schedules) == 3 && schedules[0].InstallmentAmount == 366667 && schedules[1].InstallmentAmount == 366667 &&
+			schedules[2].InstallmentAmount == 366666 && schedules[2].Currency == "JPY"
+	})).Return(nil)
+
+	// Mock payout gateway unavailable, the disbursement stays REQUESTED
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.Currency == "JPY" && payout.Amount == 1000000
+	})).Return(nil, errors.New("gateway unavailable"))
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "JPY", response.Currency)
+	assert.Equal(t, 366667.00, response.InstallmentAmount)
+	assert.Equal(t, 1100000.00, response.OutstandingAmount)
+	assert.Equal(t, models.DisbursementStatusRequested, response.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDisbursementService_CreateDisbursement_AmountExceedsMinorUnit(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     1000000.50,
+		InterestRate:        0.10,
+		InstallmentUnit:     "month",
+		NumberOfInstallment: 3,
+		StartDate:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CustomerID:          "customer123",
+		Currency:            "JPY",
+	}
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "principal amount has more decimal places than JPY allows")
+}
+
+func TestDisbursementService_CreateDisbursement_UnsupportedCurrency(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     1000.00,
+		InterestRate:        0.10,
+		InstallmentUnit:     "month",
+		NumberOfInstallment: 3,
+		StartDate:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CustomerID:          "customer123",
+		Currency:            "KWD",
+	}
+
+	// Execute
+	response, err := service.CreateDisbursement(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "currency KWD is not supported")
+}
+
 func TestGeneratePaymentSchedules_WeeklyInstallments(t *testing.T) {
 	service := &disbursementService{}
 
@@ -143,7 +581,7 @@ func TestGeneratePaymentSchedules_WeeklyInstallments(t *testing.T) {
 	installmentAmount := decimal.NewFromFloat(100000.00)
 	totalAmount := decimal.NewFromFloat(300000.00)
 
-	schedules := service.generatePaymentSchedules("loan123", req, installmentAmount, totalAmount, startDate)
+	schedules := service.generatePaymentSchedules("loan123", req, installmentAmount, totalAmount, models.CurrencyIDR, startDate)
 
 	assert.Len(t, schedules, 3)
 
@@ -175,7 +613,7 @@ func TestGeneratePaymentSchedules_MonthlyInstallments(t *testing.T) {
 	installmentAmount := decimal.NewFromFloat(150000.00)
 	totalAmount := decimal.NewFromFloat(300000.00)
 
-	schedules := service.generatePaymentSchedules("loan123", req, installmentAmount, totalAmount, startDate)
+	schedules := service.generatePaymentSchedules("loan123", req, installmentAmount, totalAmount, models.CurrencyIDR, startDate)
 
 	assert.Len(t, schedules, 2)
 
@@ -189,3 +627,526 @@ func TestGeneratePaymentSchedules_MonthlyInstallments(t *testing.T) {
 	assert.Equal(t, startDate.AddDate(0, 2, 0), schedules[1].InstallmentDueDate)
 	assert.Equal(t, 0.00, schedules[1].InstallmentPaid)
 }
+
+func TestDisbursementService_UpdateDisbursementStatus_Processing(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", Status: models.DisbursementStatusRequested}
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Status: models.StatusInactive}
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("UpdateDisbursement", ctx, disbursementDetail).Return(true, nil)
+
+	// Execute
+	response, err := service.UpdateDisbursementStatus(ctx, "loan_123", &models.DisbursementCallbackRequest{
+		Status:    models.DisbursementStatusProcessing,
+		Reference: "PAYOUT-001",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DisbursementStatusProcessing, response.Status)
+	assert.Equal(t, models.StatusInactive, response.LoanStatus)
+	assert.Equal(t, "PAYOUT-001", response.Reference)
+}
+
+func TestDisbursementService_UpdateDisbursementStatus_DisbursedRecalculatesSchedule(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	startDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	disbursedAt := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", DisbursementDate: startDate, Status: models.DisbursementStatusProcessing}
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", InstallmentUnit: models.InstallmentUnitWeek, LoanStartDate: startDate, Status: models.StatusInactive}
+	schedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentDueDate: startDate.AddDate(0, 0, 7), Status: models.StatusInactive},
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentDueDate: startDate.AddDate(0, 0, 14), Status: models.StatusInactive},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("GetTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{
+		{LoanID: "loan_123", ChargeType: models.FeeTypeAdmin, InstallmentNumber: 0, TaxAmount: 16500.00},
+		{LoanID: "loan_123", ChargeType: models.FeeTypeProvision, InstallmentNumber: 1, TaxAmount: 1100.00},
+	}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("ActivateLoan", ctx, disbursementDetail, loanSummary, schedules,
+		mock.MatchedBy(func(histories []*models.PaymentScheduleHistory) bool {
+			return len(histories) == 2 && histories[0].Action == models.ActionActivate && histories[0].Status == models.StatusPending
+		}),
+	).Return(true, nil)
+	// Only the tax withheld from the payout is posted with the disbursement
+	mockLedgerService.On("PostDisbursement", ctx, loanSummary, disbursementDetail, 16500.00).Return(nil)
+
+	// Execute
+	response, err := service.UpdateDisbursementStatus(ctx, "loan_123", &models.DisbursementCallbackRequest{
+		Status:              models.DisbursementStatusDisbursed,
+		DisbursedAt:         disbursedAt,
+		RecalculateSchedule: true,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DisbursementStatusDisbursed, response.Status)
+	assert.Equal(t, models.StatusPending, response.LoanStatus)
+	assert.Equal(t, disbursedAt, *response.DisbursedAt)
+	assert.Equal(t, disbursedAt.AddDate(0, 0, 7), *response.FirstDueDate)
+
+	// Installments are active and counted from the actual disbursement date
+	assert.Equal(t, models.StatusPending, schedules[1].Status)
+	assert.Equal(t, disbursedAt.AddDate(0, 0, 14), schedules[1].InstallmentDueDate)
+	assert.Equal(t, disbursedAt, loanSummary.LoanStartDate)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDisbursementService_UpdateDisbursementStatus_DisbursedKeepsSchedule(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	startDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", DisbursementDate: startDate, Status: models.DisbursementStatusRequested}
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", InstallmentUnit: models.InstallmentUnitMonth, LoanStartDate: startDate, Status: models.StatusInactive}
+	schedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentDueDate: startDate.AddDate(0, 1, 0), Status: models.StatusInactive},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("GetTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("ActivateLoan", ctx, disbursementDetail, loanSummary, schedules, mock.Anything).Return(true, nil)
+	mockLedgerService.On("PostDisbursement", ctx, loanSummary, disbursementDetail, 0.0).Return(nil)
+
+	// Execute
+	response, err := service.UpdateDisbursementStatus(ctx, "loan_123", &models.DisbursementCallbackRequest{
+		Status:      models.DisbursementStatusDisbursed,
+		DisbursedAt: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, startDate.AddDate(0, 1, 0), *response.FirstDueDate)
+	assert.Equal(t, startDate, loanSummary.LoanStartDate)
+	assert.Equal(t, models.StatusPending, schedules[0].Status)
+}
+
+func TestDisbursementService_UpdateDisbursementStatus_DisbursedConcurrently(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", Status: models.DisbursementStatusProcessing}
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", InstallmentUnit: models.InstallmentUnitMonth, Status: models.StatusInactive}
+
+	// Mock repository calls; the webhook settled the disbursement between the read and the update
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("GetTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("ActivateLoan", ctx, disbursementDetail, loanSummary, mock.Anything, mock.Anything).Return(false, nil)
+
+	// Execute
+	response, err := service.UpdateDisbursementStatus(ctx, "loan_123", &models.DisbursementCallbackRequest{
+		Status: models.DisbursementStatusDisbursed,
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "no longer PROCESSING")
+	mockLedgerService.AssertNotCalled(t, "PostDisbursement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDisbursementService_UpdateDisbursementStatus_Failed(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", Status: models.DisbursementStatusProcessing}
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 5500000.00, Status: models.StatusInactive, VirtualAccountNumber: "8808000000000123"}
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{{ID: 1, LoanID: "loan_123"}}, nil)
+	mockRepo.On("FailDisbursement", ctx, disbursementDetail, loanSummary).Return(true, nil)
+	mockVAProvider.On("CloseVirtualAccount", ctx, "8808000000000123").Return(nil).Once()
+
+	// Execute
+	response, err := service.UpdateDisbursementStatus(ctx, "loan_123", &models.DisbursementCallbackRequest{
+		Status:        models.DisbursementStatusFailed,
+		FailureReason: "Beneficiary account closed",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DisbursementStatusFailed, response.Status)
+	assert.Equal(t, models.StatusCancelled, response.LoanStatus)
+	assert.Equal(t, "Beneficiary account closed", response.FailureReason)
+	assert.Nil(t, response.FirstDueDate)
+	assert.Equal(t, 0.0, loanSummary.OutstandingAmount)
+}
+
+func TestDisbursementService_UpdateDisbursementStatus_InvalidTransition(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(&models.DisbursementDetail{
+		LoanID: "loan_123",
+		Status: models.DisbursementStatusDisbursed,
+	}, nil)
+
+	// Execute
+	response, err := service.UpdateDisbursementStatus(ctx, "loan_123", &models.DisbursementCallbackRequest{
+		Status: models.DisbursementStatusProcessing,
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "disbursement cannot move from DISBURSED to PROCESSING")
+}
+
+func TestDisbursementService_GetDisbursementStatus_NotFound(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(nil, nil)
+
+	// Execute
+	response, err := service.GetDisbursementStatus(ctx, "loan_123")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "disbursement not found")
+}
+
+func TestDisbursementService_HandlePayoutWebhook_Success(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	settledAt := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", Status: models.DisbursementStatusProcessing}
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Status: models.StatusInactive}
+
+	// Mock repository calls
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("GetTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("ActivateLoan", ctx, disbursementDetail, loanSummary, mock.Anything, mock.Anything).Return(true, nil)
+	mockLedgerService.On("PostDisbursement", ctx, loanSummary, disbursementDetail, 0.0).Return(nil)
+
+	// Execute
+	response, err := service.HandlePayoutWebhook(ctx, &models.PayoutWebhookRequest{
+		PayoutID:   "po_001",
+		ExternalID: "loan_123",
+		Status:     models.PayoutStatusSuccess,
+		SettledAt:  &settledAt,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DisbursementStatusDisbursed, response.Status)
+	assert.Equal(t, models.StatusPending, response.LoanStatus)
+	assert.Equal(t, settledAt, *response.DisbursedAt)
+	assert.Equal(t, "po_001", response.Reference)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDisbursementService_HandlePayoutWebhook_DuplicateNotification(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	disbursementDetail := &models.DisbursementDetail{LoanID: "loan_123", Status: models.DisbursementStatusDisbursed, Reference: "po_001"}
+
+	// Mock repository calls, no state change is stored
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_123").Return(disbursementDetail, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+
+	// Execute
+	response, err := service.HandlePayoutWebhook(ctx, &models.PayoutWebhookRequest{
+		PayoutID:   "po_001",
+		ExternalID: "loan_123",
+		Status:     models.PayoutStatusSuccess,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DisbursementStatusDisbursed, response.Status)
+}
+
+func TestDisbursementService_SyncPayouts(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	requested := &models.DisbursementDetail{LoanID: "loan_1", BankCode: "BCA", AccountNumber: "1230000", DisbursedAmount: 1000000.00, Status: models.DisbursementStatusRequested}
+	processing := &models.DisbursementDetail{LoanID: "loan_2", Status: models.DisbursementStatusProcessing}
+	unreachable := &models.DisbursementDetail{LoanID: "loan_3", Status: models.DisbursementStatusProcessing}
+
+	// Mock repository calls
+	mockRepo.On("GetUnsettledDisbursements", ctx).Return([]*models.DisbursementDetail{requested, processing, unreachable}, nil)
+
+	// loan_1 is resubmitted and fails
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.ExternalID == "loan_1" && payout.AccountNumber == "1230000"
+	})).Return(&models.PayoutResult{PayoutID: "po_1", ExternalID: "loan_1", Status: models.PayoutStatusFailed, FailureReason: "Beneficiary account not found"}, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_1").Return(requested, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_1").Return(&models.LoanSummary{LoanID: "loan_1", Status: models.StatusInactive}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_1").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("FailDisbursement", ctx, requested, mock.AnythingOfType("*models.LoanSummary")).Return(true, nil)
+
+	// loan_2 has settled at the gateway
+	mockGateway.On("GetPayout", ctx, "loan_2").Return(&models.PayoutResult{PayoutID: "po_2", ExternalID: "loan_2", Status: models.PayoutStatusSuccess}, nil)
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_2").Return(processing, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_2").Return(&models.LoanSummary{LoanID: "loan_2", Status: models.StatusInactive}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_2").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("GetTaxLinesByLoanID", ctx, "loan_2").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("ActivateLoan", ctx, processing, mock.AnythingOfType("*models.LoanSummary"), mock.Anything, mock.Anything).Return(true, nil)
+	mockLedgerService.On("PostDisbursement", ctx, mock.AnythingOfType("*models.LoanSummary"), processing, 0.0).Return(nil)
+
+	// loan_3 cannot be polled
+	mockGateway.On("GetPayout", ctx, "loan_3").Return(nil, errors.New("payout gateway unavailable"))
+
+	// Execute
+	result, err := service.SyncPayouts(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.CheckedDisbursements)
+	assert.Equal(t, 1, result.DisbursedLoans)
+	assert.Equal(t, 1, result.FailedDisbursements)
+	assert.Equal(t, 1, result.Errors)
+	assert.Equal(t, "Beneficiary account not found", requested.FailureReason)
+
+	mockRepo.AssertExpectations(t)
+	mockGateway.AssertExpectations(t)
+}
+
+func TestDisbursementService_SyncPayouts_RejectedPayoutFails(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	requested := &models.DisbursementDetail{LoanID: "loan_1", BankCode: "BCA", AccountNumber: "12-34", DisbursedAmount: 1000000.00, Status: models.DisbursementStatusRequested}
+	unavailable := &models.DisbursementDetail{LoanID: "loan_2", BankCode: "BCA", AccountNumber: "1234567890", DisbursedAmount: 1000000.00, Status: models.DisbursementStatusRequested}
+
+	// Mock repository calls
+	mockRepo.On("GetUnsettledDisbursements", ctx).Return([]*models.DisbursementDetail{requested, unavailable}, nil)
+
+	// loan_1 is refused by the gateway, so it fails instead of being submitted again on every sync
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.ExternalID == "loan_1"
+	})).Return(nil, &models.PayoutRejectionError{StatusCode: 422, Reason: "invalid beneficiary account", Message: "payout gateway rejected request with 422: invalid beneficiary account"})
+	mockRepo.On("GetDisbursementByLoanID", ctx, "loan_1").Return(requested, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_1").Return(&models.LoanSummary{LoanID: "loan_1", Status: models.StatusInactive, VirtualAccountNumber: "8808000000000001"}, nil)
+	mockRepo.On("GetPaymentSchedulesByLoanID", ctx, "loan_1").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("FailDisbursement", ctx, requested, mock.MatchedBy(func(loanSummary *models.LoanSummary) bool {
+		return loanSummary.Status == models.StatusCancelled
+	})).Return(true, nil)
+	// A virtual account that cannot be closed leaves the loan cancelled
+	mockVAProvider.On("CloseVirtualAccount", ctx, "8808000000000001").Return(errors.New("virtual account provider unavailable")).Once()
+
+	// loan_2 cannot reach the gateway and stays REQUESTED
+	mockGateway.On("CreatePayout", ctx, mock.MatchedBy(func(payout *models.PayoutRequest) bool {
+		return payout.ExternalID == "loan_2"
+	})).Return(nil, errors.New("payout gateway unavailable after 3 attempts"))
+
+	// Execute
+	result, err := service.SyncPayouts(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.CheckedDisbursements)
+	assert.Equal(t, 1, result.FailedDisbursements)
+	assert.Equal(t, 1, result.Errors)
+	assert.Equal(t, models.DisbursementStatusFailed, requested.Status)
+	assert.Equal(t, "payout rejected by the gateway: invalid beneficiary account", requested.FailureReason)
+	assert.Equal(t, models.DisbursementStatusRequested, unavailable.Status)
+
+	mockRepo.AssertExpectations(t)
+	mockGateway.AssertExpectations(t)
+}
+
+func TestDisbursementService_SimulateLoan_WithTaxedFees(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     5000000.00,
+		InterestRate:        0.10, // 10% interest rate
+		InstallmentUnit:     "week",
+		NumberOfInstallment: 3,
+		StartDate:           time.Date(2025, 8, 31, 11, 43, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+		ProductCode:         "WEEKLY_3",
+		BankCode:            "BCA",
+		AccountNumber:       "1234567890",
+	}
+
+	// Mock fee schedule and 11% PPN on both fees; no repository or gateway call is expected
+	mockFeeService.On("CalculateFees", ctx, "WEEKLY_3", 5000000.00, models.CurrencyIDR).Return([]*models.LoanFee{
+		{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, Amount: 150000.00, ChargeMethod: models.FeeChargeDeducted},
+		{FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationFixed, Value: 30000, Amount: 30000.00, ChargeMethod: models.FeeChargeInstallment},
+	}, nil)
+	mockTaxService.On("TaxCharge", models.FeeTypeAdmin, 150000.00, models.CurrencyIDR).Return(&models.TaxLine{
+		TaxType: models.TaxTypePPN, ChargeType: models.FeeTypeAdmin, TaxableAmount: 150000.00, TaxRate: 0.11, TaxAmount: 16500.00, Status: models.StatusPending,
+	})
+	mockTaxService.On("TaxCharge", models.FeeTypeProvision, 30000.00, models.CurrencyIDR).Return(&models.TaxLine{
+		TaxType: models.TaxTypePPN, ChargeType: models.FeeTypeProvision, TaxableAmount: 30000.00, TaxRate: 0.11, TaxAmount: 3300.01, Status: models.StatusPending,
+	})
+
+	// Execute
+	response, err := service.SimulateLoan(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "WEEKLY_3", response.ProductCode)
+	assert.Equal(t, models.CurrencyIDR, response.Currency)
+	assert.Equal(t, 500000.00, response.InterestAmount)
+	assert.Equal(t, 180000.00, response.TotalFeeAmount)
+	assert.Equal(t, 19800.01, response.TotalTaxAmount)
+	assert.Equal(t, 4833500.00, response.NetDisbursedAmount) // 5000000 - 150000 admin fee - 16500 PPN
+	assert.Equal(t, 5533300.01, response.TotalRepaymentAmount)
+	assert.Equal(t, 3.680479, response.AnnualPercentageRate)
+	assert.Equal(t, 34.025175, response.EffectiveInterestRate)
+	assert.Len(t, response.Schedule, 3)
+	assert.Equal(t, 1, response.Schedule[0].InstallmentNumber)
+	assert.Equal(t, 1100.00, response.Schedule[0].TaxAmount)
+	assert.Equal(t, 1100.01, response.Schedule[2].TaxAmount)
+	assert.Equal(t, time.Date(2025, 9, 7, 11, 43, 0, 0, time.UTC), response.FirstDueDate)
+	assert.Equal(t, time.Date(2025, 9, 21, 11, 43, 0, 0, time.UTC), response.FinalDueDate)
+
+	total := decimal.Zero
+	for _, schedule := range response.Schedule {
+		total = total.Add(decimal.NewFromFloat(schedule.InstallmentAmount))
+	}
+	assert.True(t, total.Equal(decimal.NewFromFloat(response.TotalRepaymentAmount)))
+}
+
+func TestDisbursementService_SimulateLoan_UnsupportedCurrency(t *testing.T) {
+	mockRepo := mocks.NewDisbursementMySQLRepositoryInterface(t)
+	mockFeeService := feeMocks.NewFeeServiceInterface(t)
+	mockTaxService := taxMocks.NewTaxServiceInterface(t)
+	mockLedgerService := ledgerMocks.NewLedgerServiceInterface(t)
+	mockGateway := mocks.NewPayoutGatewayInterface(t)
+	mockVAProvider := mocks.NewVirtualAccountProviderInterface(t)
+	service := NewDisbursementService(mockRepo, mockFeeService, mockTaxService, mockLedgerService, mockGateway, mockVAProvider)
+	ctx := context.Background()
+
+	req := &models.DisbursementRequest{
+		PrincipalAmount:     1000.000,
+		InterestRate:        0.10,
+		InstallmentUnit:     "month",
+		NumberOfInstallment: 12,
+		StartDate:           time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+		CustomerID:          "12312312",
+		BankCode:            "NBK",
+		AccountNumber:       "1234567890",
+		Currency:            "KWD",
+	}
+
+	// Execute
+	response, err := service.SimulateLoan(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "currency KWD is not supported", err.Error())
+}
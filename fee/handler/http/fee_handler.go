@@ -0,0 +1,93 @@
+package http
+
+import (
+	"net/http"
+
+	"billing-engine/fee"
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeeHandler struct {
+	feeService fee.FeeServiceInterface
+	middleware middlewares.GoMiddlewareInterface
+}
+
+// NewFeeHandler creates a new fee schedule handler instance
+func NewFeeHandler(e *echo.Echo, feeService fee.FeeServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &FeeHandler{
+		feeService: feeService,
+		middleware: middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/products/:product_code/fees", handler.GetSchedule)
+	v1.PUT("/products/:product_code/fees", handler.UpdateSchedule)
+}
+
+func (h *FeeHandler) GetSchedule(c echo.Context) error {
+	productCode := c.Param("product_code")
+	if productCode == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Product code is required",
+		})
+	}
+
+	response, err := h.feeService.GetSchedule(c.Request().Context(), productCode)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.FeeScheduleSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *FeeHandler) UpdateSchedule(c echo.Context) error {
+	productCode := c.Param("product_code")
+	if productCode == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Product code is required",
+		})
+	}
+
+	var req models.FeeScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.feeService.UpdateSchedule(c.Request().Context(), productCode, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.FeeScheduleSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
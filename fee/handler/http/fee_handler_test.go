@@ -0,0 +1,142 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "billing-engine/fee/_mock"
+	"billing-engine/global"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestFeeHandler_GetSchedule_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewFeeServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &FeeHandler{
+		feeService: mockService,
+		middleware: mockMiddleware,
+	}
+
+	expectedResponse := &models.FeeScheduleResponse{
+		ProductCode: "WEEKLY_50",
+		Fees: []models.FeeRuleResponse{
+			{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, ChargeMethod: models.FeeChargeDeducted},
+		},
+	}
+
+	mockService.On("GetSchedule", mock.Anything, "WEEKLY_50").Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/products/WEEKLY_50/fees", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("WEEKLY_50")
+
+	// Execute
+	err := handler.GetSchedule(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.FeeScheduleSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Fees, 1)
+	assert.Equal(t, models.FeeChargeDeducted, response.Data.Fees[0].ChargeMethod)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestFeeHandler_UpdateSchedule_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewFeeServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &FeeHandler{
+		feeService: mockService,
+		middleware: mockMiddleware,
+	}
+
+	req := models.FeeScheduleRequest{
+		Fees: []models.FeeRuleRequest{
+			{FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationPercentage, Value: 0.01, ChargeMethod: models.FeeChargeInstallment},
+		},
+	}
+	expectedResponse := &models.FeeScheduleResponse{
+		ProductCode: "WEEKLY_50",
+	}
+
+	mockService.On("UpdateSchedule", mock.Anything, "WEEKLY_50", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPut, "/v1/products/WEEKLY_50/fees", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("WEEKLY_50")
+
+	// Execute
+	err := handler.UpdateSchedule(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestFeeHandler_UpdateSchedule_InvalidChargeMethod(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewFeeServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &FeeHandler{
+		feeService: mockService,
+		middleware: mockMiddleware,
+	}
+
+	// Create request
+	reqBody := []byte(`{"fees":[{"fee_type":"ADMIN","calculation_type":"FIXED","value":150000,"charge_method":"LATER"}]}`)
+	httpReq := httptest.NewRequest(http.MethodPut, "/v1/products/WEEKLY_50/fees", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("WEEKLY_50")
+
+	// Execute
+	err := handler.UpdateSchedule(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
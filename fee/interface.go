@@ -0,0 +1,19 @@
+package fee
+
+import (
+	"billing-engine/models"
+	"context"
+)
+
+// FeeMySQLRepositoryInterface defines the interface for fee schedule repository
+type FeeMySQLRepositoryInterface interface {
+	GetRulesByProductCode(ctx context.Context, productCode string) ([]*models.FeeRule, error)
+	ReplaceRules(ctx context.Context, productCode string, rules []*models.FeeRule) error
+}
+
+// FeeServiceInterface defines the interface for fee schedule service
+type FeeServiceInterface interface {
+	CalculateFees(ctx context.Context, productCode string, principalAmount float64, currencyCode string) ([]*models.LoanFee, error)
+	GetSchedule(ctx context.Context, productCode string) (*models.FeeScheduleResponse, error)
+	UpdateSchedule(ctx context.Context, productCode string, req *models.FeeScheduleRequest) (*models.FeeScheduleResponse, error)
+}
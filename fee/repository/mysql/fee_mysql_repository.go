@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/fee"
+	"billing-engine/models"
+
+	"gorm.io/gorm"
+)
+
+type feeMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewFeeMySQLRepository creates a new fee schedule repository instance
+func NewFeeMySQLRepository(db *gorm.DB) fee.FeeMySQLRepositoryInterface {
+	return &feeMySQLRepository{db: db}
+}
+
+func (r *feeMySQLRepository) GetRulesByProductCode(ctx context.Context, productCode string) ([]*models.FeeRule, error) {
+	var rules []*models.FeeRule
+	err := r.db.WithContext(ctx).
+		Where("product_code = ? AND deleted_at IS NULL", productCode).
+		Order("id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceRules soft-deletes the current fee rules of the product and stores the new set in one transaction.
+// An empty set removes all fees of the product.
+func (r *feeMySQLRepository) ReplaceRules(ctx context.Context, productCode string, rules []*models.FeeRule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.FeeRule{}).
+			Where("product_code = ? AND deleted_at IS NULL", productCode).
+			Updates(map[string]interface{}{
+				"deleted_at": time.Now(),
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
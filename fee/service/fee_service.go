@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"billing-engine/fee"
+	"billing-engine/models"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+type feeService struct {
+	feeRepo fee.FeeMySQLRepositoryInterface
+}
+
+// NewFeeService creates a new fee schedule service instance
+func NewFeeService(feeRepo fee.FeeMySQLRepositoryInterface) fee.FeeServiceInterface {
+	return &feeService{
+		feeRepo: feeRepo,
+	}
+}
+
+// CalculateFees applies the fee schedule of the product to the principal, rounding to the minor unit
+// of the loan currency. The returned fee lines are not linked to a loan yet. A product without a fee
+// schedule charges no fees.
+func (s *feeService) CalculateFees(ctx context.Context, productCode string, principalAmount float64, currencyCode string) ([]*models.LoanFee, error) {
+	if productCode == "" {
+		productCode = models.DefaultProductCode
+	}
+
+	rules, err := s.feeRepo.GetRulesByProductCode(ctx, productCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee schedule: %v", err)
+	}
+
+	principal := decimal.NewFromFloat(principalAmount)
+	fees := make([]*models.LoanFee, 0, len(rules))
+	for _, rule := range rules {
+		fees = append(fees, &models.LoanFee{
+			FeeType:         rule.FeeType,
+			CalculationType: rule.CalculationType,
+			Value:           rule.Value,
+			Amount:          feeAmount(rule, principal, currencyCode).InexactFloat64(),
+			ChargeMethod:    rule.ChargeMethod,
+			CreatedBy:       "system",
+		})
+	}
+	return fees, nil
+}
+
+func (s *feeService) GetSchedule(ctx context.Context, productCode string) (*models.FeeScheduleResponse, error) {
+	rules, err := s.feeRepo.GetRulesByProductCode(ctx, productCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee schedule: %v", err)
+	}
+	return buildScheduleResponse(productCode, rules), nil
+}
+
+func (s *feeService) UpdateSchedule(ctx context.Context, productCode string, req *models.FeeScheduleRequest) (*models.FeeScheduleResponse, error) {
+	rules := make([]*models.FeeRule, 0, len(req.Fees))
+	seenFeeTypes := make(map[string]bool, len(req.Fees))
+	for _, feeReq := range req.Fees {
+		if seenFeeTypes[feeReq.FeeType] {
+			return nil, fmt.Errorf("fee type %s is configured more than once", feeReq.FeeType)
+		}
+		seenFeeTypes[feeReq.FeeType] = true
+
+		if feeReq.CalculationType == models.FeeCalculationPercentage && feeReq.Value >= 1 {
+			return nil, fmt.Errorf("%s fee percentage must be below 1", feeReq.FeeType)
+		}
+		rules = append(rules, &models.FeeRule{
+			ProductCode:     productCode,
+			FeeType:         feeReq.FeeType,
+			CalculationType: feeReq.CalculationType,
+			Value:           feeReq.Value,
+			ChargeMethod:    feeReq.ChargeMethod,
+			CreatedBy:       "system",
+			UpdatedBy:       "system",
+		})
+	}
+
+	if err := s.feeRepo.ReplaceRules(ctx, productCode, rules); err != nil {
+		return nil, fmt.Errorf("failed to update fee schedule: %v", err)
+	}
+
+	return buildScheduleResponse(productCode, rules), nil
+}
+
+// feeAmount returns the fee charged on the principal, rounded to the currency's minor unit
+func feeAmount(rule *models.FeeRule, principal decimal.Decimal, currencyCode string) decimal.Decimal {
+	value := decimal.NewFromFloat(rule.Value)
+	if rule.CalculationType == models.FeeCalculationPercentage {
+		return currency.Round(principal.Mul(value), currencyCode)
+	}
+	return currency.Round(value, currencyCode)
+}
+
+// buildScheduleResponse converts fee rules into the API response
+func buildScheduleResponse(productCode string, rules []*models.FeeRule) *models.FeeScheduleResponse {
+	feeResponses := make([]models.FeeRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		feeResponses = append(feeResponses, models.FeeRuleResponse{
+			FeeType:         rule.FeeType,
+			CalculationType: rule.CalculationType,
+			Value:           rule.Value,
+			ChargeMethod:    rule.ChargeMethod,
+		})
+	}
+
+	return &models.FeeScheduleResponse{
+		ProductCode: productCode,
+		Fees:        feeResponses,
+	}
+}
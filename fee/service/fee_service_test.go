@@ -0,0 +1,124 @@
+package service
+
+import (
+	mocks "billing-engine/fee/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeeService_CalculateFees_Success(t *testing.T) {
+	mockRepo := mocks.NewFeeMySQLRepositoryInterface(t)
+	service := NewFeeService(mockRepo)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetRulesByProductCode", ctx, "WEEKLY_50").Return([]*models.FeeRule{
+		{ProductCode: "WEEKLY_50", FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, ChargeMethod: models.FeeChargeDeducted},
+		{ProductCode: "WEEKLY_50", FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationPercentage, Value: 0.0125, ChargeMethod: models.FeeChargeInstallment},
+	}, nil)
+
+	// Execute
+	fees, err := service.CalculateFees(ctx, "WEEKLY_50", 3333333.00, models.CurrencyIDR)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, fees, 2)
+	assert.Equal(t, 150000.00, fees[0].Amount)
+	assert.Equal(t, models.FeeChargeDeducted, fees[0].ChargeMethod)
+	assert.Equal(t, 41666.66, fees[1].Amount) // 3333333 * 1.25% rounded
+	assert.Equal(t, models.FeeChargeInstallment, fees[1].ChargeMethod)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFeeService_CalculateFees_NoSchedule(t *testing.T) {
+	mockRepo := mocks.NewFeeMySQLRepositoryInterface(t)
+	service := NewFeeService(mockRepo)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetRulesByProductCode", ctx, models.DefaultProductCode).Return([]*models.FeeRule{}, nil)
+
+	// Execute
+	fees, err := service.CalculateFees(ctx, "", 5000000.00, models.CurrencyIDR)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, fees)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFeeService_CalculateFees_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewFeeMySQLRepositoryInterface(t)
+	service := NewFeeService(mockRepo)
+	ctx := context.Background()
+
+	// Mock repository error
+	mockRepo.On("GetRulesByProductCode", ctx, "WEEKLY_50").Return(nil, errors.New("database error"))
+
+	// Execute
+	fees, err := service.CalculateFees(ctx, "WEEKLY_50", 5000000.00, models.CurrencyIDR)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, fees)
+	assert.Contains(t, err.Error(), "failed to get fee schedule")
+}
+
+func TestFeeService_UpdateSchedule_Success(t *testing.T) {
+	mockRepo := mocks.NewFeeMySQLRepositoryInterface(t)
+	service := NewFeeService(mockRepo)
+	ctx := context.Background()
+
+	req := &models.FeeScheduleRequest{
+		Fees: []models.FeeRuleRequest{
+			{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, ChargeMethod: models.FeeChargeDeducted},
+			{FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationPercentage, Value: 0.01, ChargeMethod: models.FeeChargeInstallment},
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("ReplaceRules", ctx, "WEEKLY_50", mock.MatchedBy(func(rules []*models.FeeRule) bool {
+		return len(rules) == 2 && rules[0].ProductCode == "WEEKLY_50" && rules[1].Value == 0.01
+	})).Return(nil)
+
+	// Execute
+	response, err := service.UpdateSchedule(ctx, "WEEKLY_50", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "WEEKLY_50", response.ProductCode)
+	assert.Len(t, response.Fees, 2)
+	assert.Equal(t, models.FeeChargeInstallment, response.Fees[1].ChargeMethod)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFeeService_UpdateSchedule_InvalidFees(t *testing.T) {
+	mockRepo := mocks.NewFeeMySQLRepositoryInterface(t)
+	service := NewFeeService(mockRepo)
+	ctx := context.Background()
+
+	// Execute
+	_, duplicateErr := service.UpdateSchedule(ctx, "WEEKLY_50", &models.FeeScheduleRequest{
+		Fees: []models.FeeRuleRequest{
+			{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 150000, ChargeMethod: models.FeeChargeDeducted},
+			{FeeType: models.FeeTypeAdmin, CalculationType: models.FeeCalculationFixed, Value: 50000, ChargeMethod: models.FeeChargeInstallment},
+		},
+	})
+	_, percentageErr := service.UpdateSchedule(ctx, "WEEKLY_50", &models.FeeScheduleRequest{
+		Fees: []models.FeeRuleRequest{
+			{FeeType: models.FeeTypeProvision, CalculationType: models.FeeCalculationPercentage, Value: 1.5, ChargeMethod: models.FeeChargeDeducted},
+		},
+	})
+
+	// Assert
+	assert.EqualError(t, duplicateErr, "fee type ADMIN is configured more than once")
+	assert.EqualError(t, percentageErr, "PROVISION fee percentage must be below 1")
+}
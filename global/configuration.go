@@ -1,14 +1,38 @@
 package global
 
 type Configuration struct {
-	HostUrl                      string `mapstructure:"host_url"`
-	HostPort                     string `mapstructure:"host_port"`
-	DbHost                       string `mapstructure:"db_host"`
-	DbName                       string `mapstructure:"db_name"`
-	DbUser                       string `mapstructure:"db_user"`
-	DbPass                       string `mapstructure:"db_pass"`
-	DbPort                       string `mapstructure:"db_port"`
-	TimeoutDuration              int    `mapstructure:"timeout_duration"`
-	PrivateJWTAccessTokenSecret  string `mapstructure:"private_jwt_access_token_secret"`
-	PrivateJWTRefreshTokenSecret string `mapstructure:"private_jwt_refresh_token_secret"`
+	HostUrl                      string  `mapstructure:"host_url"`
+	HostPort                     string  `mapstructure:"host_port"`
+	DbHost                       string  `mapstructure:"db_host"`
+	DbName                       string  `mapstructure:"db_name"`
+	DbUser                       string  `mapstructure:"db_user"`
+	DbPass                       string  `mapstructure:"db_pass"`
+	DbPort                       string  `mapstructure:"db_port"`
+	TimeoutDuration              int     `mapstructure:"timeout_duration"`
+	PrivateJWTAccessTokenSecret  string  `mapstructure:"private_jwt_access_token_secret"`
+	PrivateJWTRefreshTokenSecret string  `mapstructure:"private_jwt_refresh_token_secret"`
+	DailyJobTime                 string  `mapstructure:"daily_job_time"`
+	CollectibilityDpdThresholds  string  `mapstructure:"collectibility_dpd_thresholds"`
+	WriteOffMinDpd               int     `mapstructure:"write_off_min_dpd"`
+	CoolingOffDays               int     `mapstructure:"cooling_off_days"`
+	PayoutGatewayURL             string  `mapstructure:"payout_gateway_url"`
+	PayoutMaxRetries             int     `mapstructure:"payout_max_retries"`
+	PayoutSyncInterval           string  `mapstructure:"payout_sync_interval"`
+	PayoutWebhookToken           string  `mapstructure:"payout_webhook_token"`
+	VirtualAccountBank           string  `mapstructure:"virtual_account_bank"`
+	VirtualAccountPrefix         string  `mapstructure:"virtual_account_prefix"`
+	PaymentAggregatorSecret      string  `mapstructure:"payment_aggregator_secret"`
+	PaymentSnapPublicKeyFile     string  `mapstructure:"payment_snap_public_key_file"`
+	PpnRate                      float64 `mapstructure:"ppn_rate"`
+	PpnTaxableCharges            string  `mapstructure:"ppn_taxable_charges"`
+	CustomerRepaymentPolicy      string  `mapstructure:"customer_repayment_policy"`
+	InterestAccrualMethod        string  `mapstructure:"interest_accrual_method"`
+	AccrualSuspendCollectibility int     `mapstructure:"accrual_suspend_collectibility"`
+	PenaltyDailyRate             float64 `mapstructure:"penalty_daily_rate"`
+	ReminderDaysBeforeDue        int     `mapstructure:"reminder_days_before_due"`
+	ReminderOverdueDays          string  `mapstructure:"reminder_overdue_days"`
+	BatchWorkers                 int     `mapstructure:"batch_workers"`
+	BatchChunkSize               int     `mapstructure:"batch_chunk_size"`
+	GlAccountMapping             string  `mapstructure:"gl_account_mapping"`
+	ProvisionStageDpdThresholds  string  `mapstructure:"provision_stage_dpd_thresholds"`
 }
@@ -35,18 +35,48 @@ type DisbursementSuccessResponse struct {
 	Data   *models.DisbursementResponse `json:"data"`
 }
 
+// LoanSimulationSuccessResponse represents a successful loan simulation response
+type LoanSimulationSuccessResponse struct {
+	Status string                         `json:"status"`
+	Data   *models.LoanSimulationResponse `json:"data"`
+}
+
+// DisbursementStatusSuccessResponse represents a successful disbursement status response
+type DisbursementStatusSuccessResponse struct {
+	Status string                             `json:"status"`
+	Data   *models.DisbursementStatusResponse `json:"data"`
+}
+
 // RepaymentSuccessResponse represents a successful repayment response
 type RepaymentSuccessResponse struct {
 	Status string                    `json:"status"`
 	Data   *models.RepaymentResponse `json:"data"`
 }
 
+// CustomerRepaymentSuccessResponse represents a successful customer-level repayment response
+type CustomerRepaymentSuccessResponse struct {
+	Status string                            `json:"status"`
+	Data   *models.CustomerRepaymentResponse `json:"data"`
+}
+
 // OutstandingBalanceSuccessResponse represents a successful outstanding balance query response
 type OutstandingBalanceSuccessResponse struct {
 	Status string                             `json:"status"`
 	Data   *models.OutstandingBalanceResponse `json:"data"`
 }
 
+// CustomerLoansSuccessResponse represents a successful customer loan listing response
+type CustomerLoansSuccessResponse struct {
+	Status string                        `json:"status"`
+	Data   *models.CustomerLoansResponse `json:"data"`
+}
+
+// LoanSearchSuccessResponse represents a successful loan search response
+type LoanSearchSuccessResponse struct {
+	Status string                     `json:"status"`
+	Data   *models.LoanSearchResponse `json:"data"`
+}
+
 // DelinquencySuccessResponse represents a successful delinquency query response
 type DelinquencySuccessResponse struct {
 	Status string                      `json:"status"`
@@ -58,3 +88,171 @@ type LoanScheduleSuccessResponse struct {
 	Status string                       `json:"status"`
 	Data   *models.LoanScheduleResponse `json:"data"`
 }
+
+// LoanCollectibilitySuccessResponse represents a successful loan collectibility query response
+type LoanCollectibilitySuccessResponse struct {
+	Status string                             `json:"status"`
+	Data   *models.LoanCollectibilityResponse `json:"data"`
+}
+
+// CollectibilityEvaluationSuccessResponse represents a successful collectibility evaluation response
+type CollectibilityEvaluationSuccessResponse struct {
+	Status string                                   `json:"status"`
+	Data   *models.CollectibilityEvaluationResponse `json:"data"`
+}
+
+// CollectibilityReportSuccessResponse represents a successful collectibility portfolio report response
+type CollectibilityReportSuccessResponse struct {
+	Status string                               `json:"status"`
+	Data   *models.CollectibilityReportResponse `json:"data"`
+}
+
+// DelinquencyPolicySuccessResponse represents a successful delinquency policy response
+type DelinquencyPolicySuccessResponse struct {
+	Status string                            `json:"status"`
+	Data   *models.DelinquencyPolicyResponse `json:"data"`
+}
+
+// FeeScheduleSuccessResponse represents a successful product fee schedule response
+type FeeScheduleSuccessResponse struct {
+	Status string                      `json:"status"`
+	Data   *models.FeeScheduleResponse `json:"data"`
+}
+
+// TaxReportSuccessResponse represents a successful tax collection report response
+type TaxReportSuccessResponse struct {
+	Status string                    `json:"status"`
+	Data   *models.TaxReportResponse `json:"data"`
+}
+
+// WriteOffSuccessResponse represents a successful loan write-off response
+type WriteOffSuccessResponse struct {
+	Status string                   `json:"status"`
+	Data   *models.WriteOffResponse `json:"data"`
+}
+
+// WriteOffReportSuccessResponse represents a successful write-off and recovery report response
+type WriteOffReportSuccessResponse struct {
+	Status string                         `json:"status"`
+	Data   *models.WriteOffReportResponse `json:"data"`
+}
+
+// RestructureSuccessResponse represents a successful loan restructure response
+type RestructureSuccessResponse struct {
+	Status string                      `json:"status"`
+	Data   *models.RestructureResponse `json:"data"`
+}
+
+// LoanRestructureHistorySuccessResponse represents a successful loan restructure history response
+type LoanRestructureHistorySuccessResponse struct {
+	Status string                                 `json:"status"`
+	Data   *models.LoanRestructureHistoryResponse `json:"data"`
+}
+
+// DeferralSuccessResponse represents a successful installment deferral response
+type DeferralSuccessResponse struct {
+	Status string                   `json:"status"`
+	Data   *models.DeferralResponse `json:"data"`
+}
+
+// CancellationSuccessResponse represents a successful loan cancellation response
+type CancellationSuccessResponse struct {
+	Status string                       `json:"status"`
+	Data   *models.CancellationResponse `json:"data"`
+}
+
+// TrialBalanceSuccessResponse represents a successful ledger trial balance response
+type TrialBalanceSuccessResponse struct {
+	Status string                       `json:"status"`
+	Data   *models.TrialBalanceResponse `json:"data"`
+}
+
+// LedgerReconciliationSuccessResponse represents a successful ledger reconciliation response
+type LedgerReconciliationSuccessResponse struct {
+	Status string                               `json:"status"`
+	Data   *models.LedgerReconciliationResponse `json:"data"`
+}
+
+// InterestAccrualRunSuccessResponse represents a successful interest accrual run response
+type InterestAccrualRunSuccessResponse struct {
+	Status string                             `json:"status"`
+	Data   *models.InterestAccrualRunResponse `json:"data"`
+}
+
+// LoanAccrualsSuccessResponse represents a successful loan interest accrual query response
+type LoanAccrualsSuccessResponse struct {
+	Status string                       `json:"status"`
+	Data   *models.LoanAccrualsResponse `json:"data"`
+}
+
+// DailyReportSuccessResponse represents a successful daily loan position report response
+type DailyReportSuccessResponse struct {
+	Status string                      `json:"status"`
+	Data   *models.DailyReportResponse `json:"data"`
+}
+
+// BatchRunSuccessResponse represents a successful end-of-day batch run response
+type BatchRunSuccessResponse struct {
+	Status string                   `json:"status"`
+	Data   *models.BatchRunResponse `json:"data"`
+}
+
+// BatchRunListSuccessResponse represents a successful end-of-day batch run list response
+type BatchRunListSuccessResponse struct {
+	Status string                       `json:"status"`
+	Data   *models.BatchRunListResponse `json:"data"`
+}
+
+// ProvisionParametersSuccessResponse represents a successful provision parameters response
+type ProvisionParametersSuccessResponse struct {
+	Status string                              `json:"status"`
+	Data   *models.ProvisionParametersResponse `json:"data"`
+}
+
+// ProvisionRunSuccessResponse represents a successful provision run response
+type ProvisionRunSuccessResponse struct {
+	Status string                       `json:"status"`
+	Data   *models.ProvisionRunResponse `json:"data"`
+}
+
+// ProvisionMovementSuccessResponse represents a successful provision movement report response
+type ProvisionMovementSuccessResponse struct {
+	Status string                            `json:"status"`
+	Data   *models.ProvisionMovementResponse `json:"data"`
+}
+
+// BankStatementImportSuccessResponse represents a successful bank statement import response
+type BankStatementImportSuccessResponse struct {
+	Status string                              `json:"status"`
+	Data   *models.BankStatementImportResponse `json:"data"`
+}
+
+// BankStatementLineListSuccessResponse represents a successful unmatched bank statement line list response
+type BankStatementLineListSuccessResponse struct {
+	Status string                                `json:"status"`
+	Data   *models.BankStatementLineListResponse `json:"data"`
+}
+
+// BankStatementLineSuccessResponse represents a successful bank statement line response
+type BankStatementLineSuccessResponse struct {
+	Status string                            `json:"status"`
+	Data   *models.BankStatementLineResponse `json:"data"`
+}
+
+// SuspenseItemListSuccessResponse represents a successful suspense item list response
+type SuspenseItemListSuccessResponse struct {
+	Status string                           `json:"status"`
+	Data   *models.SuspenseItemListResponse `json:"data"`
+}
+
+// SuspenseItemSuccessResponse represents a successful suspense item response
+type SuspenseItemSuccessResponse struct {
+	Status string                       `json:"status"`
+	Data   *models.SuspenseItemResponse `json:"data"`
+}
+
+// SuspenseAgingSuccessResponse represents a successful suspense aging report response
+type SuspenseAgingSuccessResponse struct {
+	Status string                        `json:"status"`
+	Data   *models.SuspenseAgingResponse `json:"data"`
+}
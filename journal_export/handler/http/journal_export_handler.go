@@ -0,0 +1,61 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"billing-engine/global"
+	"billing-engine/journal_export"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JournalExportHandler struct {
+	journalExportService journal_export.JournalExportServiceInterface
+	middleware           middlewares.GoMiddlewareInterface
+}
+
+// NewJournalExportHandler creates a new journal export handler instance
+func NewJournalExportHandler(e *echo.Echo, journalExportService journal_export.JournalExportServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &JournalExportHandler{
+		journalExportService: journalExportService,
+		middleware:           middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/ledger/export", handler.ExportJournal)
+}
+
+// ExportJournal downloads the day's journal lines as a file for the ERP
+func (h *JournalExportHandler) ExportJournal(c echo.Context) error {
+	var req models.JournalExportRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	file, err := h.journalExportService.ExportJournal(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.FileName))
+	return c.Blob(http.StatusOK, file.ContentType, file.Content)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "billing-engine/journal_export/_mock"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestJournalExportHandler_ExportJournal_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewJournalExportServiceInterface(t)
+	handler := &JournalExportHandler{journalExportService: mockService, middleware: new(MockMiddleware)}
+
+	content := "record_type,date,account_code,currency,debit,credit,loan_id,reference\nTRAILER,0,0.00,0.00,e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n"
+	mockService.On("ExportJournal", mock.Anything, &models.JournalExportRequest{Date: "2026-01-04", Format: "csv", Mode: "aggregated"}).Return(&models.JournalExportFile{
+		FileName:    "gl-export-20260104-aggregated.csv",
+		ContentType: "text/csv",
+		Content:     []byte(content),
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/export?date=2026-01-04&format=csv&mode=aggregated", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ExportJournal(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="gl-export-20260104-aggregated.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, content, rec.Body.String())
+}
+
+func TestJournalExportHandler_ExportJournal_InvalidFormat(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewJournalExportServiceInterface(t)
+	handler := &JournalExportHandler{journalExportService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/export?format=xml", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ExportJournal(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "ExportJournal", mock.Anything, mock.Anything)
+}
+
+func TestJournalExportHandler_ExportJournal_InvalidDate(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewJournalExportServiceInterface(t)
+	handler := &JournalExportHandler{journalExportService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/export?date=04-01-2026", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ExportJournal(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestJournalExportHandler_ExportJournal_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewJournalExportServiceInterface(t)
+	handler := &JournalExportHandler{journalExportService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ExportJournal", mock.Anything, mock.Anything).Return(nil, errors.New("account WRITE_OFF_EXPENSE has no ERP account mapping"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/export", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ExportJournal(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,17 @@
+package journal_export
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// JournalExportMySQLRepositoryInterface defines the interface for journal export repository
+type JournalExportMySQLRepositoryInterface interface {
+	GetPostings(ctx context.Context, from, to time.Time) ([]*models.JournalExportPosting, error)
+}
+
+// JournalExportServiceInterface defines the interface for journal export service
+type JournalExportServiceInterface interface {
+	ExportJournal(ctx context.Context, req *models.JournalExportRequest) (*models.JournalExportFile, error)
+}
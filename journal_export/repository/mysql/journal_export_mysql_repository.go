@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/journal_export"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type journalExportMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewJournalExportMySQLRepository creates a new journal export repository instance
+func NewJournalExportMySQLRepository(db *gorm.DB) journal_export.JournalExportMySQLRepositoryInterface {
+	return &journalExportMySQLRepository{db: db}
+}
+
+// GetPostings returns the postings of the journal entries dated from (inclusive) to (exclusive),
+// in entry and posting order
+func (r *journalExportMySQLRepository) GetPostings(ctx context.Context, from, to time.Time) ([]*models.JournalExportPosting, error) {
+	var postings []*models.JournalExportPosting
+	err := transaction.DB(ctx, r.db).
+		Table("journal_postings jp").
+		Select("je.id AS entry_id, je.entry_type, je.reference, je.entry_date, jp.account_code, jp.loan_id, jp.currency, jp.debit, jp.credit").
+		Joins("JOIN journal_entries je ON je.id = jp.journal_entry_id").
+		Where("je.entry_date >= ? AND je.entry_date < ?", from, to).
+		Order("je.id ASC, jp.id ASC").
+		Scan(&postings).Error
+	if err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
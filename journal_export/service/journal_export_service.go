@@ -0,0 +1,262 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"billing-engine/journal_export"
+	"billing-engine/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// csvHeader is the first record of a CSV export; line records follow it and the trailer record
+// (TRAILER, line count, total debit, total credit, checksum) closes the file
+var csvHeader = []string{"record_type", "date", "account_code", "currency", "debit", "credit", "loan_id", "reference"}
+
+type journalExportService struct {
+	journalExportRepo journal_export.JournalExportMySQLRepositoryInterface
+	accountMapping    map[string]string // ledger account code to ERP account code; empty exports the ledger codes
+}
+
+// NewJournalExportService creates a new journal export service instance
+func NewJournalExportService(journalExportRepo journal_export.JournalExportMySQLRepositoryInterface, accountMapping map[string]string) journal_export.JournalExportServiceInterface {
+	return &journalExportService{
+		journalExportRepo: journalExportRepo,
+		accountMapping:    accountMapping,
+	}
+}
+
+// ParseAccountMapping parses a comma separated chart-of-accounts mapping such as
+// "CASH=1101,LOAN_RECEIVABLE=1301". An empty value maps nothing.
+func ParseAccountMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid account mapping %q, expected ACCOUNT=ERP_ACCOUNT", part)
+		}
+		accountCode := strings.ToUpper(strings.TrimSpace(pair[0]))
+		erpCode := strings.TrimSpace(pair[1])
+		if accountCode == "" || erpCode == "" {
+			return nil, fmt.Errorf("invalid account mapping %q, expected ACCOUNT=ERP_ACCOUNT", part)
+		}
+		if _, exists := mapping[accountCode]; exists {
+			return nil, fmt.Errorf("account %s is mapped more than once", accountCode)
+		}
+		mapping[accountCode] = erpCode
+	}
+	return mapping, nil
+}
+
+// ExportJournal renders the postings of the journal entries dated on the export date, yesterday
+// by default. Once a mapping is configured every exported account must be mapped, so the ERP
+// never receives a ledger code it does not know.
+func (s *journalExportService) ExportJournal(ctx context.Context, req *models.JournalExportRequest) (*models.JournalExportFile, error) {
+	exportDate := startOfDay(time.Now().AddDate(0, 0, -1))
+	if req.Date != "" {
+		date, err := time.ParseInLocation("2006-01-02", req.Date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid export date %q", req.Date)
+		}
+		exportDate = date
+	}
+	format := strings.ToUpper(req.Format)
+	if format == "" {
+		format = models.JournalExportFormatCSV
+	}
+	mode := strings.ToUpper(req.Mode)
+	if mode == "" {
+		mode = models.JournalExportModeDetailed
+	}
+
+	postings, err := s.journalExportRepo.GetPostings(ctx, exportDate, exportDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journal postings: %v", err)
+	}
+
+	var lines []models.JournalExportLine
+	if mode == models.JournalExportModeAggregated {
+		lines, err = s.aggregatedLines(postings, exportDate)
+	} else {
+		lines, err = s.detailedLines(postings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.JournalExportResponse{
+		ExportDate:  exportDate.Format("2006-01-02"),
+		Mode:        mode,
+		GeneratedAt: time.Now(),
+		Lines:       lines,
+		Trailer:     trailerOf(lines),
+	}
+
+	fileName := fmt.Sprintf("gl-export-%s-%s", exportDate.Format("20060102"), strings.ToLower(mode))
+	if format == models.JournalExportFormatJSON {
+		content, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to render journal export: %v", err)
+		}
+		return &models.JournalExportFile{FileName: fileName + ".json", ContentType: "application/json", Content: content}, nil
+	}
+
+	content, err := renderCSV(export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render journal export: %v", err)
+	}
+	return &models.JournalExportFile{FileName: fileName + ".csv", ContentType: "text/csv", Content: content}, nil
+}
+
+// detailedLines exports one line per posting, referenced by its entry's reference or, when the
+// entry has none, by its type and ID
+func (s *journalExportService) detailedLines(postings []*models.JournalExportPosting) ([]models.JournalExportLine, error) {
+	lines := make([]models.JournalExportLine, 0, len(postings))
+	for _, posting := range postings {
+		accountCode, err := s.erpAccount(posting.AccountCode)
+		if err != nil {
+			return nil, err
+		}
+		reference := posting.Reference
+		if reference == "" {
+			reference = fmt.Sprintf("%s-%d", posting.EntryType, posting.EntryID)
+		}
+		lines = append(lines, models.JournalExportLine{
+			Date:        posting.EntryDate.In(time.Local).Format("2006-01-02"),
+			AccountCode: accountCode,
+			Currency:    posting.Currency,
+			Debit:       posting.Debit,
+			Credit:      posting.Credit,
+			LoanID:      posting.LoanID,
+			Reference:   reference,
+		})
+	}
+	return lines, nil
+}
+
+// aggregatedLines exports one line per ERP account and currency with the day's debits and credits
+func (s *journalExportService) aggregatedLines(postings []*models.JournalExportPosting, exportDate time.Time) ([]models.JournalExportLine, error) {
+	type lineKey struct{ accountCode, currency string }
+	debits := make(map[lineKey]decimal.Decimal)
+	credits := make(map[lineKey]decimal.Decimal)
+	keys := make([]lineKey, 0)
+	for _, posting := range postings {
+		accountCode, err := s.erpAccount(posting.AccountCode)
+		if err != nil {
+			return nil, err
+		}
+		key := lineKey{accountCode: accountCode, currency: posting.Currency}
+		if _, seen := debits[key]; !seen {
+			keys = append(keys, key)
+		}
+		debits[key] = debits[key].Add(decimal.NewFromFloat(posting.Debit))
+		credits[key] = credits[key].Add(decimal.NewFromFloat(posting.Credit))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].currency != keys[j].currency {
+			return keys[i].currency < keys[j].currency
+		}
+		return keys[i].accountCode < keys[j].accountCode
+	})
+
+	reference := "GL-" + exportDate.Format("20060102")
+	lines := make([]models.JournalExportLine, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, models.JournalExportLine{
+			Date:        exportDate.Format("2006-01-02"),
+			AccountCode: key.accountCode,
+			Currency:    key.currency,
+			Debit:       debits[key].InexactFloat64(),
+			Credit:      credits[key].InexactFloat64(),
+			Reference:   reference,
+		})
+	}
+	return lines, nil
+}
+
+// erpAccount maps a ledger account code to the ERP chart of accounts
+func (s *journalExportService) erpAccount(accountCode string) (string, error) {
+	if len(s.accountMapping) == 0 {
+		return accountCode, nil
+	}
+	erpCode, ok := s.accountMapping[accountCode]
+	if !ok {
+		return "", fmt.Errorf("account %s has no ERP account mapping", accountCode)
+	}
+	return erpCode, nil
+}
+
+// trailerOf computes the control totals of the lines. The checksum is the SHA-256 of every line
+// written as date|account_code|currency|debit|credit|loan_id|reference followed by a newline,
+// with amounts in two decimals, so the ERP can verify the file in either format.
+func trailerOf(lines []models.JournalExportLine) models.JournalExportTrailer {
+	hash := sha256.New()
+	totalDebit, totalCredit := decimal.Zero, decimal.Zero
+	for _, line := range lines {
+		debit, credit := decimal.NewFromFloat(line.Debit), decimal.NewFromFloat(line.Credit)
+		totalDebit = totalDebit.Add(debit)
+		totalCredit = totalCredit.Add(credit)
+		fmt.Fprintf(hash, "%s|%s|%s|%s|%s|%s|%s\n", line.Date, line.AccountCode, line.Currency,
+			debit.StringFixed(2), credit.StringFixed(2), line.LoanID, line.Reference)
+	}
+	return models.JournalExportTrailer{
+		LineCount:   len(lines),
+		TotalDebit:  totalDebit.InexactFloat64(),
+		TotalCredit: totalCredit.InexactFloat64(),
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}
+}
+
+func renderCSV(export *models.JournalExportResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, line := range export.Lines {
+		record := []string{
+			"LINE",
+			line.Date,
+			line.AccountCode,
+			line.Currency,
+			decimal.NewFromFloat(line.Debit).StringFixed(2),
+			decimal.NewFromFloat(line.Credit).StringFixed(2),
+			line.LoanID,
+			line.Reference,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	trailer := []string{
+		"TRAILER",
+		strconv.Itoa(export.Trailer.LineCount),
+		decimal.NewFromFloat(export.Trailer.TotalDebit).StringFixed(2),
+		decimal.NewFromFloat(export.Trailer.TotalCredit).StringFixed(2),
+		export.Trailer.Checksum,
+	}
+	if err := writer.Write(trailer); err != nil {
+		return nil, err
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// startOfDay returns midnight local time of the day t falls on
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	mocks "billing-engine/journal_export/_mock"
+	"billing-engine/models"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// repaymentPostings are the postings of two repayments: 550000 with a 10000 penalty taxed at 11%,
+// and 110000 paid with a payment reference
+func repaymentPostings() []*models.JournalExportPosting {
+	entryDate := time.Date(2026, 1, 4, 10, 30, 0, 0, time.Local)
+	return []*models.JournalExportPosting{
+		{EntryID: 42, EntryType: models.JournalEntryRepayment, EntryDate: entryDate, AccountCode: models.AccountCash, LoanID: "loan_123", Currency: models.CurrencyIDR, Debit: 561100.00},
+		{EntryID: 42, EntryType: models.JournalEntryRepayment, EntryDate: entryDate, AccountCode: models.AccountLoanReceivable, LoanID: "loan_123", Currency: models.CurrencyIDR, Credit: 550000.00},
+		{EntryID: 42, EntryType: models.JournalEntryRepayment, EntryDate: entryDate, AccountCode: models.AccountPenaltyIncome, LoanID: "loan_123", Currency: models.CurrencyIDR, Credit: 10000.00},
+		{EntryID: 42, EntryType: models.JournalEntryRepayment, EntryDate: entryDate, AccountCode: models.AccountTaxPayable, LoanID: "loan_123", Currency: models.CurrencyIDR, Credit: 1100.00},
+		{EntryID: 43, EntryType: models.JournalEntryRepayment, Reference: "PAY-0001", EntryDate: entryDate, AccountCode: models.AccountCash, LoanID: "loan_456", Currency: models.CurrencyIDR, Debit: 110000.00},
+		{EntryID: 43, EntryType: models.JournalEntryRepayment, Reference: "PAY-0001", EntryDate: entryDate, AccountCode: models.AccountLoanReceivable, LoanID: "loan_456", Currency: models.CurrencyIDR, Credit: 110000.00},
+	}
+}
+
+func TestParseAccountMapping(t *testing.T) {
+	mapping, err := ParseAccountMapping(" cash=1101, LOAN_RECEIVABLE = 1301 ,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{models.AccountCash: "1101", models.AccountLoanReceivable: "1301"}, mapping)
+
+	mapping, err = ParseAccountMapping("")
+	assert.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	_, err = ParseAccountMapping("CASH")
+	assert.Error(t, err)
+
+	_, err = ParseAccountMapping("CASH=1101,CASH=1102")
+	assert.Error(t, err)
+}
+
+func TestJournalExportService_ExportJournal_DetailedCSV(t *testing.T) {
+	mockRepo := mocks.NewJournalExportMySQLRepositoryInterface(t)
+	service := NewJournalExportService(mockRepo, nil)
+	ctx := context.Background()
+
+	from := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("GetPostings", ctx, from, from.AddDate(0, 0, 1)).Return(repaymentPostings(), nil)
+
+	// Execute
+	file, err := service.ExportJournal(ctx, &models.JournalExportRequest{Date: "2026-01-04"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "gl-export-20260104-detailed.csv", file.FileName)
+	assert.Equal(t, "text/csv", file.ContentType)
+
+	reader := csv.NewReader(strings.NewReader(string(file.Content)))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 8) // header, six lines and the trailer
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{"LINE", "2026-01-04", "CASH", "IDR", "561100.00", "0.00", "loan_123", "REPAYMENT-42"}, records[1])
+	assert.Equal(t, "PAY-0001", records[5][7])
+	trailer := records[7]
+	assert.Equal(t, []string{"TRAILER", "6", "671100.00", "671100.00"}, trailer[:4])
+	assert.Len(t, trailer[4], 64)
+}
+
+func TestJournalExportService_ExportJournal_AggregatedJSONWithMapping(t *testing.T) {
+	mockRepo := mocks.NewJournalExportMySQLRepositoryInterface(t)
+	mapping := map[string]string{
+		models.AccountCash:           "1101",
+		models.AccountLoanReceivable: "1301",
+		models.AccountPenaltyIncome:  "4201",
+		models.AccountTaxPayable:     "2301",
+	}
+	service := NewJournalExportService(mockRepo, mapping)
+	ctx := context.Background()
+
+	from := time.Date(2026, 1, 4, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("GetPostings", ctx, from, from.AddDate(0, 0, 1)).Return(repaymentPostings(), nil)
+
+	// Execute
+	file, err := service.ExportJournal(ctx, &models.JournalExportRequest{Date: "2026-01-04", Format: "json", Mode: "aggregated"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "gl-export-20260104-aggregated.json", file.FileName)
+
+	var export models.JournalExportResponse
+	assert.NoError(t, json.Unmarshal(file.Content, &export))
+	assert.Equal(t, models.JournalExportModeAggregated, export.Mode)
+	assert.Len(t, export.Lines, 4)
+	assert.Equal(t, models.JournalExportLine{Date: "2026-01-04", AccountCode: "1101", Currency: "IDR", Debit: 671100.00, LoanID: "", Reference: "GL-20260104"}, export.Lines[0])
+	assert.Equal(t, "1301", export.Lines[1].AccountCode)
+	assert.Equal(t, 660000.00, export.Lines[1].Credit)
+	assert.Equal(t, 4, export.Trailer.LineCount)
+	assert.Equal(t, export.Trailer.TotalDebit, export.Trailer.TotalCredit)
+}
+
+func TestJournalExportService_ExportJournal_ChecksumMatchesAcrossFormats(t *testing.T) {
+	mockRepo := mocks.NewJournalExportMySQLRepositoryInterface(t)
+	service := NewJournalExportService(mockRepo, nil)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetPostings", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(repaymentPostings(), nil)
+
+	// Execute
+	csvFile, err := service.ExportJournal(ctx, &models.JournalExportRequest{Date: "2026-01-04", Format: "csv"})
+	assert.NoError(t, err)
+	jsonFile, err := service.ExportJournal(ctx, &models.JournalExportRequest{Date: "2026-01-04", Format: "json"})
+	assert.NoError(t, err)
+
+	// Assert
+	var export models.JournalExportResponse
+	assert.NoError(t, json.Unmarshal(jsonFile.Content, &export))
+	assert.Contains(t, string(csvFile.Content), export.Trailer.Checksum)
+}
+
+func TestJournalExportService_ExportJournal_UnmappedAccount(t *testing.T) {
+	mockRepo := mocks.NewJournalExportMySQLRepositoryInterface(t)
+	service := NewJournalExportService(mockRepo, map[string]string{models.AccountCash: "1101"})
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetPostings", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(repaymentPostings(), nil)
+
+	// Execute
+	file, err := service.ExportJournal(ctx, &models.JournalExportRequest{Date: "2026-01-04"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, file)
+	assert.Equal(t, "account LOAN_RECEIVABLE has no ERP account mapping", err.Error())
+}
+
+func TestJournalExportService_ExportJournal_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewJournalExportMySQLRepositoryInterface(t)
+	service := NewJournalExportService(mockRepo, nil)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetPostings", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, errors.New("database error"))
+
+	// Execute
+	file, err := service.ExportJournal(ctx, &models.JournalExportRequest{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, file)
+	assert.Contains(t, err.Error(), "failed to get journal postings")
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"billing-engine/global"
+	"billing-engine/ledger"
+	"billing-engine/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LedgerHandler struct {
+	ledgerService ledger.LedgerServiceInterface
+	middleware    middlewares.GoMiddlewareInterface
+}
+
+// NewLedgerHandler creates a new ledger handler instance
+func NewLedgerHandler(e *echo.Echo, ledgerService ledger.LedgerServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &LedgerHandler{
+		ledgerService: ledgerService,
+		middleware:    middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/ledger/trial-balance", handler.GetTrialBalance)
+	v1.GET("/ledger/reconciliation", handler.ReconcileLoanBalances)
+}
+
+func (h *LedgerHandler) GetTrialBalance(c echo.Context) error {
+	now := time.Now()
+	asOf := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if c.QueryParam("as_of") != "" {
+		date, err := time.ParseInLocation("2006-01-02", c.QueryParam("as_of"), time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, global.BadResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Dates must use the YYYY-MM-DD format",
+			})
+		}
+		asOf = date
+	}
+
+	response, err := h.ledgerService.GetTrialBalance(c.Request().Context(), asOf)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.TrialBalanceSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *LedgerHandler) ReconcileLoanBalances(c echo.Context) error {
+	response, err := h.ledgerService.ReconcileLoanBalances(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.LedgerReconciliationSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
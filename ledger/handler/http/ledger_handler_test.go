@@ -0,0 +1,147 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"billing-engine/global"
+	mocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestLedgerHandler_GetTrialBalance_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLedgerServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LedgerHandler{
+		ledgerService: mockService,
+		middleware:    mockMiddleware,
+	}
+
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+	expectedResponse := &models.TrialBalanceResponse{
+		AsOf:     asOf,
+		Balanced: true,
+		Currencies: []models.TrialBalanceCurrencyResponse{
+			{Currency: models.CurrencyIDR, TotalDebit: 5720000.00, TotalCredit: 5720000.00, Balanced: true},
+		},
+	}
+
+	mockService.On("GetTrialBalance", mock.Anything, asOf).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/trial-balance?as_of=2026-03-31", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetTrialBalance(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.TrialBalanceSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.True(t, response.Data.Balanced)
+	assert.Equal(t, 5720000.00, response.Data.Currencies[0].TotalDebit)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_GetTrialBalance_InvalidDate(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLedgerServiceInterface(t)
+	handler := &LedgerHandler{ledgerService: mockService, middleware: new(MockMiddleware)}
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/trial-balance?as_of=31-03-2026", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetTrialBalance(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "GetTrialBalance", mock.Anything, mock.Anything)
+}
+
+func TestLedgerHandler_ReconcileLoanBalances_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLedgerServiceInterface(t)
+	handler := &LedgerHandler{ledgerService: mockService, middleware: new(MockMiddleware)}
+
+	expectedResponse := &models.LedgerReconciliationResponse{
+		CheckedLoans:    2,
+		MismatchedLoans: 1,
+		Mismatches: []models.LedgerMismatchResponse{
+			{LoanID: "loan_123", Status: models.StatusPending, OutstandingAmount: 330000.00, ExpectedBalance: 330000.00, LedgerBalance: 440000.00, Difference: 110000.00},
+		},
+	}
+
+	mockService.On("ReconcileLoanBalances", mock.Anything).Return(expectedResponse, nil)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/reconciliation", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ReconcileLoanBalances(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.LedgerReconciliationSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.False(t, response.Data.Balanced)
+	assert.Equal(t, "loan_123", response.Data.Mismatches[0].LoanID)
+}
+
+func TestLedgerHandler_ReconcileLoanBalances_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLedgerServiceInterface(t)
+	handler := &LedgerHandler{ledgerService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ReconcileLoanBalances", mock.Anything).Return(nil, errors.New("failed to get loan ledger balances: database error"))
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/ledger/reconciliation", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ReconcileLoanBalances(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
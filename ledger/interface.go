@@ -0,0 +1,32 @@
+package ledger
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// LedgerMySQLRepositoryInterface defines the interface for ledger repository
+type LedgerMySQLRepositoryInterface interface {
+	CreateJournalEntry(ctx context.Context, entry *models.JournalEntry) error
+	GetJournalEntriesByLoanID(ctx context.Context, loanID string) ([]*models.JournalEntry, error)
+	GetLoanAccountBalance(ctx context.Context, loanID string, accountCode string) (float64, error)
+	GetAccountTotals(ctx context.Context, before time.Time) ([]*models.LedgerAccountTotal, error)
+	GetLoanLedgerBalances(ctx context.Context) ([]*models.LoanLedgerBalance, error)
+}
+
+// LedgerServiceInterface defines the interface for ledger service
+type LedgerServiceInterface interface {
+	PostDisbursement(ctx context.Context, loanSummary *models.LoanSummary, disbursement *models.DisbursementDetail, deductedTaxAmount float64) error
+	PostRepayment(ctx context.Context, loanSummary *models.LoanSummary, installmentAmount, penaltyAmount, penaltyTaxAmount float64, paidAt time.Time) error
+	PostRecovery(ctx context.Context, loanSummary *models.LoanSummary, amount float64, recoveredAt time.Time) error
+	PostWriteOff(ctx context.Context, loanSummary *models.LoanSummary, writtenOffAt time.Time) error
+	PostDeferralInterest(ctx context.Context, loanSummary *models.LoanSummary, amount float64, deferredAt time.Time) error
+	PostCapitalisedPenalties(ctx context.Context, loanSummary *models.LoanSummary, penaltyAmount float64, penaltyTaxAmount float64, restructuredAt time.Time) error
+	PostInterestAccrual(ctx context.Context, loanSummary *models.LoanSummary, amount float64, accrualDate time.Time) error
+	PostSuspenseReceipt(ctx context.Context, item *models.SuspenseItem) error
+	PostSuspenseRelease(ctx context.Context, item *models.SuspenseItem, accountCode string, releasedAt time.Time) error
+	ReverseLoanEntries(ctx context.Context, loanID string, reason string, reversedAt time.Time) error
+	GetTrialBalance(ctx context.Context, asOf time.Time) (*models.TrialBalanceResponse, error)
+	ReconcileLoanBalances(ctx context.Context) (*models.LedgerReconciliationResponse, error)
+}
@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type ledgerMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewLedgerMySQLRepository creates a new ledger repository instance
+func NewLedgerMySQLRepository(db *gorm.DB) ledger.LedgerMySQLRepositoryInterface {
+	return &ledgerMySQLRepository{db: db}
+}
+
+// CreateJournalEntry stores the entry with its postings. It joins the transaction carried by ctx,
+// so the entry commits together with the money movement it records.
+func (r *ledgerMySQLRepository) CreateJournalEntry(ctx context.Context, entry *models.JournalEntry) error {
+	return transaction.DB(ctx, r.db).Create(entry).Error
+}
+
+func (r *ledgerMySQLRepository) GetJournalEntriesByLoanID(ctx context.Context, loanID string) ([]*models.JournalEntry, error) {
+	var entries []*models.JournalEntry
+	err := transaction.DB(ctx, r.db).
+		Preload("Postings").
+		Where("loan_id = ?", loanID).
+		Order("id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLoanAccountBalance returns debits minus credits posted on the account for the loan
+func (r *ledgerMySQLRepository) GetLoanAccountBalance(ctx context.Context, loanID string, accountCode string) (float64, error) {
+	var balance float64
+	err := transaction.DB(ctx, r.db).
+		Model(&models.JournalPosting{}).
+		Select("COALESCE(SUM(debit - credit), 0)").
+		Where("loan_id = ? AND account_code = ?", loanID, accountCode).
+		Scan(&balance).Error
+	if err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// GetAccountTotals sums the postings of entries dated before the given time per currency and account
+func (r *ledgerMySQLRepository) GetAccountTotals(ctx context.Context, before time.Time) ([]*models.LedgerAccountTotal, error) {
+	var totals []*models.LedgerAccountTotal
+	err := transaction.DB(ctx, r.db).
+		Table("journal_postings jp").
+		Select("jp.account_code, la.name AS account_name, la.type AS account_type, jp.currency, COALESCE(SUM(jp.debit), 0) AS debit, COALESCE(SUM(jp.credit), 0) AS credit").
+		Joins("JOIN journal_entries je ON je.id = jp.journal_entry_id").
+		Joins("JOIN ledger_accounts la ON la.code = jp.account_code").
+		Where("je.entry_date < ?", before).
+		Group("jp.currency, jp.account_code, la.name, la.type").
+		Order("jp.currency ASC, jp.account_code ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetLoanLedgerBalances returns every loan's outstanding amount with its loan receivable balance
+func (r *ledgerMySQLRepository) GetLoanLedgerBalances(ctx context.Context) ([]*models.LoanLedgerBalance, error) {
+	var balances []*models.LoanLedgerBalance
+	err := transaction.DB(ctx, r.db).
+		Table("loan_summaries ls").
+		Select("ls.loan_id, ls.status, ls.currency, ls.outstanding_amount, COALESCE(SUM(jp.debit - jp.credit), 0) AS ledger_balance").
+		Joins("LEFT JOIN journal_postings jp ON jp.loan_id = ls.loan_id AND jp.account_code = ?", models.AccountLoanReceivable).
+		Where("ls.deleted_at IS NULL").
+		Group("ls.id, ls.loan_id, ls.status, ls.currency, ls.outstanding_amount").
+		Order("ls.id ASC").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+type ledgerService struct {
+	ledgerRepo ledger.LedgerMySQLRepositoryInterface
+}
+
+// NewLedgerService creates a new ledger service instance
+func NewLedgerService(ledgerRepo ledger.LedgerMySQLRepositoryInterface) ledger.LedgerServiceInterface {
+	return &ledgerService{
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+// PostDisbursement books the loan once the payout reached the borrower: the amount owed against
+// the cash paid out, the interest still to be earned, the fees and the tax on them
+func (s *ledgerService) PostDisbursement(ctx context.Context, loanSummary *models.LoanSummary, disbursement *models.DisbursementDetail, deductedTaxAmount float64) error {
+	principal := decimal.NewFromFloat(loanSummary.PrincipalAmount)
+	paidOut := decimal.NewFromFloat(disbursement.DisbursedAmount)
+	deductedTax := decimal.NewFromFloat(deductedTaxAmount)
+	deductedFees := principal.Sub(paidOut).Sub(deductedTax)
+
+	entry := newJournalEntry(models.JournalEntryDisbursement, loanSummary, disbursedAt(disbursement), "Loan disbursement")
+	entry.Reference = disbursement.Reference
+	debit(entry, models.AccountLoanReceivable, decimal.NewFromFloat(loanSummary.OutstandingAmount))
+	credit(entry, models.AccountCash, paidOut)
+	credit(entry, models.AccountUnearnedInterest, decimal.NewFromFloat(loanSummary.InterestAmount))
+	credit(entry, models.AccountFeeIncome, deductedFees.Add(decimal.NewFromFloat(loanSummary.FeeAmount)))
+	credit(entry, models.AccountTaxPayable, deductedTax.Add(decimal.NewFromFloat(loanSummary.TaxAmount)))
+	return s.post(ctx, entry)
+}
+
+// PostRepayment books an installment payment. The installments reduce the loan receivable;
+// penalties and their tax are not part of it.
+func (s *ledgerService) PostRepayment(ctx context.Context, loanSummary *models.LoanSummary, installmentAmount, penaltyAmount, penaltyTaxAmount float64, paidAt time.Time) error {
+	installments := decimal.NewFromFloat(installmentAmount)
+	penalty := decimal.NewFromFloat(penaltyAmount)
+	penaltyTax := decimal.NewFromFloat(penaltyTaxAmount)
+
+	entry := newJournalEntry(models.JournalEntryRepayment, loanSummary, paidAt, "Installment repayment")
+	debit(entry, models.AccountCash, installments.Add(penalty).Add(penaltyTax))
+	credit(entry, models.AccountLoanReceivable, installments)
+	credit(entry, models.AccountPenaltyIncome, penalty)
+	credit(entry, models.AccountTaxPayable, penaltyTax)
+	return s.post(ctx, entry)
+}
+
+// PostRecovery books a payment on a written-off loan, whose receivable was already written off
+func (s *ledgerService) PostRecovery(ctx context.Context, loanSummary *models.LoanSummary, amount float64, recoveredAt time.Time) error {
+	recovered := decimal.NewFromFloat(amount)
+
+	entry := newJournalEntry(models.JournalEntryRecovery, loanSummary, recoveredAt, "Recovery on written-off loan")
+	debit(entry, models.AccountCash, recovered)
+	credit(entry, models.AccountRecoveryIncome, recovered)
+	return s.post(ctx, entry)
+}
+
+// PostWriteOff takes the outstanding amount off the loan receivable. The interest that was never
+// earned is released from unearned interest; the rest is a loss.
+func (s *ledgerService) PostWriteOff(ctx context.Context, loanSummary *models.LoanSummary, writtenOffAt time.Time) error {
+	outstanding := decimal.NewFromFloat(loanSummary.OutstandingAmount)
+	if !outstanding.IsPositive() {
+		return nil
+	}
+
+	balance, err := s.ledgerRepo.GetLoanAccountBalance(ctx, loanSummary.LoanID, models.AccountUnearnedInterest)
+	if err != nil {
+		return fmt.Errorf("failed to get unearned interest: %v", err)
+	}
+	unearnedInterest := decimal.Max(decimal.NewFromFloat(balance).Neg(), decimal.Zero)
+	unearnedInterest = decimal.Min(unearnedInterest, outstanding)
+
+	entry := newJournalEntry(models.JournalEntryWriteOff, loanSummary, writtenOffAt, "Loan write-off")
+	debit(entry, models.AccountUnearnedInterest, unearnedInterest)
+	debit(entry, models.AccountWriteOffExpense, outstanding.Sub(unearnedInterest))
+	credit(entry, models.AccountLoanReceivable, outstanding)
+	return s.post(ctx, entry)
+}
+
+// PostDeferralInterest books the interest charged for a payment holiday, earned over the rest of the loan
+func (s *ledgerService) PostDeferralInterest(ctx context.Context, loanSummary *models.LoanSummary, amount float64, deferredAt time.Time) error {
+	interest := decimal.NewFromFloat(amount)
+	if !interest.IsPositive() {
+		return nil
+	}
+
+	entry := newJournalEntry(models.JournalEntryDeferral, loanSummary, deferredAt, "Deferral interest")
+	debit(entry, models.AccountLoanReceivable, interest)
+	credit(entry, models.AccountUnearnedInterest, interest)
+	return s.post(ctx, entry)
+}
+
+// PostCapitalisedPenalties books the penalties a restructure added to the outstanding amount,
+// together with their tax
+func (s *ledgerService) PostCapitalisedPenalties(ctx context.Context, loanSummary *models.LoanSummary, penaltyAmount, penaltyTaxAmount float64, restructuredAt time.Time) error {
+	penalty := decimal.NewFromFloat(penaltyAmount)
+	penaltyTax := decimal.NewFromFloat(penaltyTaxAmount)
+	if !penalty.IsPositive() {
+		return nil
+	}
+
+	entry := newJournalEntry(models.JournalEntryRestructure, loanSummary, restructuredAt, "Penalties capitalised by restructure")
+	debit(entry, models.AccountLoanReceivable, penalty.Add(penaltyTax))
+	credit(entry, models.AccountPenaltyIncome, penalty)
+	credit(entry, models.AccountTaxPayable, penaltyTax)
+	return s.post(ctx, entry)
+}
+
+// PostInterestAccrual recognises a day of interest: it moves from unearned interest to interest income
+func (s *ledgerService) PostInterestAccrual(ctx context.Context, loanSummary *models.LoanSummary, amount float64, accrualDate time.Time) error {
+	interest := decimal.NewFromFloat(amount)
+
+	entry := newJournalEntry(models.JournalEntryAccrual, loanSummary, accrualDate, "Daily interest accrual")
+	entry.Reference = accrualDate.Format("2006-01-02")
+	debit(entry, models.AccountUnearnedInterest, interest)
+	credit(entry, models.AccountInterestIncome, interest)
+	return s.post(ctx, entry)
+}
+
+// PostSuspenseReceipt books a payment that could not be applied to a loan on the suspense account.
+// The entry is kept off the loan so a cancellation of the loan does not reverse it.
+func (s *ledgerService) PostSuspenseReceipt(ctx context.Context, item *models.SuspenseItem) error {
+	amount := decimal.NewFromFloat(item.Amount)
+
+	entry := newSuspenseEntry(models.JournalEntrySuspense, item, item.ReceivedAt, "Payment held in suspense")
+	debit(entry, models.AccountCash, amount)
+	credit(entry, models.AccountSuspense, amount)
+	return s.post(ctx, entry)
+}
+
+// PostSuspenseRelease takes a resolved item off the suspense account into the given account: cash
+// when it is refunded or applied to a loan, whose repayment books the cash again
+func (s *ledgerService) PostSuspenseRelease(ctx context.Context, item *models.SuspenseItem, accountCode string, releasedAt time.Time) error {
+	amount := decimal.NewFromFloat(item.Amount)
+
+	entry := newSuspenseEntry(models.JournalEntrySuspenseRelease, item, releasedAt, fmt.Sprintf("Suspense item resolved by %s", strings.ToLower(item.Resolution)))
+	debit(entry, models.AccountSuspense, amount)
+	credit(entry, accountCode, amount)
+	return s.post(ctx, entry)
+}
+
+// ReverseLoanEntries posts a reversal of every entry of the loan that is not reversed yet, so the
+// loan's accounts are back to zero. Entries are never changed or deleted. Cash paid out to the
+// borrower has really left, so instead of returning to cash it is owed on the cancellation
+// receivable until the borrower pays it back.
+func (s *ledgerService) ReverseLoanEntries(ctx context.Context, loanID string, reason string, reversedAt time.Time) error {
+	entries, err := s.ledgerRepo.GetJournalEntriesByLoanID(ctx, loanID)
+	if err != nil {
+		return fmt.Errorf("failed to get journal entries: %v", err)
+	}
+
+	reversed := make(map[uint]bool)
+	for _, entry := range entries {
+		if entry.ReversalOfID != nil {
+			reversed[*entry.ReversalOfID] = true
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.ReversalOfID != nil || reversed[entry.ID] {
+			continue
+		}
+
+		originalID := entry.ID
+		reversal := &models.JournalEntry{
+			EntryType:    models.JournalEntryReversal,
+			LoanID:       entry.LoanID,
+			Currency:     entry.Currency,
+			Reference:    entry.Reference,
+			Description:  fmt.Sprintf("Reversal of %s: %s", entry.EntryType, reason),
+			ReversalOfID: &originalID,
+			EntryDate:    reversedAt,
+			CreatedBy:    "system",
+		}
+		for _, posting := range entry.Postings {
+			accountCode := posting.AccountCode
+			if accountCode == models.AccountCash && posting.Credit > 0 {
+				accountCode = models.AccountCancellationReceivable
+			}
+			reversal.Postings = append(reversal.Postings, &models.JournalPosting{
+				AccountCode: accountCode,
+				LoanID:      posting.LoanID,
+				Currency:    posting.Currency,
+				Debit:       posting.Credit,
+				Credit:      posting.Debit,
+			})
+		}
+		if err := s.post(ctx, reversal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTrialBalance lists the debits, credits and balance of every account per currency, over the
+// entries dated up to and including asOf
+func (s *ledgerService) GetTrialBalance(ctx context.Context, asOf time.Time) (*models.TrialBalanceResponse, error) {
+	totals, err := s.ledgerRepo.GetAccountTotals(ctx, asOf.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account totals: %v", err)
+	}
+
+	response := &models.TrialBalanceResponse{
+		AsOf:        asOf,
+		GeneratedAt: time.Now(),
+		Balanced:    true,
+		Currencies:  make([]models.TrialBalanceCurrencyResponse, 0),
+	}
+
+	// Totals are ordered by currency, so each currency's accounts are contiguous
+	var totalDebit, totalCredit decimal.Decimal
+	for _, total := range totals {
+		if len(response.Currencies) == 0 || response.Currencies[len(response.Currencies)-1].Currency != total.Currency {
+			response.Currencies = append(response.Currencies, models.TrialBalanceCurrencyResponse{Currency: total.Currency})
+			totalDebit, totalCredit = decimal.Zero, decimal.Zero
+		}
+		group := &response.Currencies[len(response.Currencies)-1]
+
+		accountDebit := decimal.NewFromFloat(total.Debit)
+		accountCredit := decimal.NewFromFloat(total.Credit)
+		balance := accountCredit.Sub(accountDebit)
+		if debitNormal(total.AccountType) {
+			balance = accountDebit.Sub(accountCredit)
+		}
+		group.Accounts = append(group.Accounts, models.TrialBalanceAccountResponse{
+			AccountCode: total.AccountCode,
+			AccountName: total.AccountName,
+			AccountType: total.AccountType,
+			Debit:       total.Debit,
+			Credit:      total.Credit,
+			Balance:     balance.InexactFloat64(),
+		})
+
+		totalDebit = totalDebit.Add(accountDebit)
+		totalCredit = totalCredit.Add(accountCredit)
+		group.TotalDebit = totalDebit.InexactFloat64()
+		group.TotalCredit = totalCredit.InexactFloat64()
+		group.Balanced = totalDebit.Equal(totalCredit)
+	}
+	for _, group := range response.Currencies {
+		if !group.Balanced {
+			response.Balanced = false
+		}
+	}
+
+	return response, nil
+}
+
+// ReconcileLoanBalances checks the invariant that each loan's loan receivable balance equals its
+// outstanding amount. Loans that are not disbursed, cancelled or written off must have none.
+func (s *ledgerService) ReconcileLoanBalances(ctx context.Context) (*models.LedgerReconciliationResponse, error) {
+	balances, err := s.ledgerRepo.GetLoanLedgerBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan ledger balances: %v", err)
+	}
+
+	response := &models.LedgerReconciliationResponse{
+		GeneratedAt:  time.Now(),
+		CheckedLoans: len(balances),
+		Mismatches:   make([]models.LedgerMismatchResponse, 0),
+	}
+	for _, balance := range balances {
+		expected := decimal.NewFromFloat(balance.OutstandingAmount)
+		switch balance.Status {
+		case models.StatusInactive, models.StatusCancelled, models.StatusWrittenOff:
+			expected = decimal.Zero
+		}
+
+		ledgerBalance := decimal.NewFromFloat(balance.LedgerBalance)
+		if ledgerBalance.Equal(expected) {
+			continue
+		}
+		response.Mismatches = append(response.Mismatches, models.LedgerMismatchResponse{
+			LoanID:            balance.LoanID,
+			Status:            balance.Status,
+			Currency:          currency.Normalize(balance.Currency),
+			OutstandingAmount: balance.OutstandingAmount,
+			ExpectedBalance:   expected.InexactFloat64(),
+			LedgerBalance:     balance.LedgerBalance,
+			Difference:        ledgerBalance.Sub(expected).InexactFloat64(),
+		})
+	}
+	response.MismatchedLoans = len(response.Mismatches)
+	response.Balanced = response.MismatchedLoans == 0
+
+	return response, nil
+}
+
+// post checks that the entry balances before storing it
+func (s *ledgerService) post(ctx context.Context, entry *models.JournalEntry) error {
+	totalDebit, totalCredit := decimal.Zero, decimal.Zero
+	for _, posting := range entry.Postings {
+		if posting.Debit < 0 || posting.Credit < 0 {
+			return fmt.Errorf("journal entry %s for loan %s has a negative posting on %s", entry.EntryType, entry.LoanID, posting.AccountCode)
+		}
+		totalDebit = totalDebit.Add(decimal.NewFromFloat(posting.Debit))
+		totalCredit = totalCredit.Add(decimal.NewFromFloat(posting.Credit))
+	}
+	if len(entry.Postings) < 2 || !totalDebit.IsPositive() || !totalDebit.Equal(totalCredit) {
+		return fmt.Errorf("journal entry %s for loan %s does not balance: debit %s, credit %s",
+			entry.EntryType, entry.LoanID, totalDebit.StringFixed(2), totalCredit.StringFixed(2))
+	}
+
+	if err := s.ledgerRepo.CreateJournalEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to post journal entry: %v", err)
+	}
+	return nil
+}
+
+// newJournalEntry starts an entry for the loan in the loan's currency
+func newJournalEntry(entryType string, loanSummary *models.LoanSummary, entryDate time.Time, description string) *models.JournalEntry {
+	return &models.JournalEntry{
+		EntryType:   entryType,
+		LoanID:      loanSummary.LoanID,
+		Currency:    currency.Normalize(loanSummary.Currency),
+		Description: description,
+		EntryDate:   entryDate,
+		CreatedBy:   "system",
+	}
+}
+
+// newSuspenseEntry starts an entry for the suspense item, referenced by the item ID
+func newSuspenseEntry(entryType string, item *models.SuspenseItem, entryDate time.Time, description string) *models.JournalEntry {
+	return &models.JournalEntry{
+		EntryType:   entryType,
+		Currency:    currency.Normalize(item.Currency),
+		Reference:   fmt.Sprintf("SUSPENSE-%d", item.ID),
+		Description: description,
+		EntryDate:   entryDate,
+		CreatedBy:   "system",
+	}
+}
+
+// debit adds a debit posting to the entry; zero amounts are left out
+func debit(entry *models.JournalEntry, accountCode string, amount decimal.Decimal) {
+	addPosting(entry, accountCode, amount, decimal.Zero)
+}
+
+// credit adds a credit posting to the entry; zero amounts are left out
+func credit(entry *models.JournalEntry, accountCode string, amount decimal.Decimal) {
+	addPosting(entry, accountCode, decimal.Zero, amount)
+}
+
+func addPosting(entry *models.JournalEntry, accountCode string, debitAmount, creditAmount decimal.Decimal) {
+	if debitAmount.IsZero() && creditAmount.IsZero() {
+		return
+	}
+	entry.Postings = append(entry.Postings, &models.JournalPosting{
+		AccountCode: accountCode,
+		LoanID:      entry.LoanID,
+		Currency:    entry.Currency,
+		Debit:       debitAmount.InexactFloat64(),
+		Credit:      creditAmount.InexactFloat64(),
+	})
+}
+
+// debitNormal reports whether accounts of the type carry a debit balance
+func debitNormal(accountType string) bool {
+	return accountType == models.AccountTypeAsset || accountType == models.AccountTypeExpense
+}
+
+// disbursedAt is when the payout reached the borrower, falling back to the disbursement date
+func disbursedAt(disbursement *models.DisbursementDetail) time.Time {
+	if disbursement.DisbursedAt != nil {
+		return *disbursement.DisbursedAt
+	}
+	return disbursement.DisbursementDate
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	mocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// postingsByAccount indexes the postings of an entry by account code
+func postingsByAccount(entry *models.JournalEntry) map[string]*models.JournalPosting {
+	postings := make(map[string]*models.JournalPosting, len(entry.Postings))
+	for _, posting := range entry.Postings {
+		postings[posting.AccountCode] = posting
+	}
+	return postings
+}
+
+func TestLedgerService_PostDisbursement_Success(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		PrincipalAmount:   5000000.00,
+		InterestAmount:    500000.00,
+		FeeAmount:         10000.00,
+		TaxAmount:         1100.00,
+		OutstandingAmount: 5511100.00,
+		Currency:          models.CurrencyIDR,
+	}
+	disbursedAt := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	disbursement := &models.DisbursementDetail{
+		LoanID:          "loan_123",
+		DisbursedAmount: 4833500.00, // 150000 admin fee and 16500 PPN on it are deducted
+		Reference:       "PAYOUT-123",
+		DisbursedAt:     &disbursedAt,
+	}
+
+	var posted *models.JournalEntry
+	// Mock repository calls
+	mockRepo.On("CreateJournalEntry", ctx, mock.AnythingOfType("*models.JournalEntry")).Run(func(args mock.Arguments) {
+		posted = args.Get(1).(*models.JournalEntry)
+	}).Return(nil)
+
+	// Execute
+	err := service.PostDisbursement(ctx, loanSummary, disbursement, 16500.00)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.JournalEntryDisbursement, posted.EntryType)
+	assert.Equal(t, "PAYOUT-123", posted.Reference)
+	assert.Equal(t, disbursedAt, posted.EntryDate)
+
+	postings := postingsByAccount(posted)
+	assert.Len(t, postings, 5)
+	assert.Equal(t, 5511100.00, postings[models.AccountLoanReceivable].Debit)
+	assert.Equal(t, 4833500.00, postings[models.AccountCash].Credit)
+	assert.Equal(t, 500000.00, postings[models.AccountUnearnedInterest].Credit)
+	assert.Equal(t, 160000.00, postings[models.AccountFeeIncome].Credit)
+	assert.Equal(t, 17600.00, postings[models.AccountTaxPayable].Credit)
+}
+
+func TestLedgerService_PostDisbursement_Unbalanced(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	// Outstanding amount does not match the principal and interest
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		PrincipalAmount:   5000000.00,
+		InterestAmount:    500000.00,
+		OutstandingAmount: 5400000.00,
+	}
+	disbursement := &models.DisbursementDetail{LoanID: "loan_123", DisbursedAmount: 5000000.00, DisbursementDate: time.Now()}
+
+	// Execute
+	err := service.PostDisbursement(ctx, loanSummary, disbursement, 0)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not balance: debit 5400000.00, credit 5500000.00")
+	mockRepo.AssertNotCalled(t, "CreateJournalEntry", mock.Anything, mock.Anything)
+}
+
+func TestLedgerService_PostRepayment_WithPenalty(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Currency: models.CurrencyIDR}
+
+	// Mock repository calls
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntryRepayment && len(postings) == 4 &&
+			postings[models.AccountCash].Debit == 116660.00 &&
+			postings[models.AccountLoanReceivable].Credit == 111110.00 &&
+			postings[models.AccountPenaltyIncome].Credit == 5000.00 &&
+			postings[models.AccountTaxPayable].Credit == 550.00
+	})).Return(nil)
+
+	// Execute
+	err := service.PostRepayment(ctx, loanSummary, 111110.00, 5000.00, 550.00, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLedgerService_PostCapitalisedPenalties_WithTax(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Currency: models.CurrencyIDR}
+
+	// Mock repository calls
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntryRestructure && len(postings) == 3 &&
+			postings[models.AccountLoanReceivable].Debit == 11100.00 &&
+			postings[models.AccountPenaltyIncome].Credit == 10000.00 &&
+			postings[models.AccountTaxPayable].Credit == 1100.00
+	})).Return(nil)
+
+	// Execute
+	err := service.PostCapitalisedPenalties(ctx, loanSummary, 10000.00, 1100.00, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLedgerService_PostWriteOff_ReleasesUnearnedInterest(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 3300000.00, Status: models.StatusWrittenOff}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanAccountBalance", ctx, "loan_123", models.AccountUnearnedInterest).Return(-300000.00, nil)
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntryWriteOff && len(postings) == 3 &&
+			postings[models.AccountUnearnedInterest].Debit == 300000.00 &&
+			postings[models.AccountWriteOffExpense].Debit == 3000000.00 &&
+			postings[models.AccountLoanReceivable].Credit == 3300000.00
+	})).Return(nil)
+
+	// Execute
+	err := service.PostWriteOff(ctx, loanSummary, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLedgerService_PostDeferralInterest_ZeroAmount(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+
+	// Execute
+	err := service.PostDeferralInterest(context.Background(), &models.LoanSummary{LoanID: "loan_123"}, 0, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "CreateJournalEntry", mock.Anything, mock.Anything)
+}
+
+func TestLedgerService_PostInterestAccrual(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Currency: models.CurrencyIDR}
+	accrualDate := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntryAccrual && entry.Reference == "2026-01-02" &&
+			postings[models.AccountUnearnedInterest].Debit == 10000.00 &&
+			postings[models.AccountInterestIncome].Credit == 10000.00
+	})).Return(nil)
+
+	// Execute
+	err := service.PostInterestAccrual(ctx, loanSummary, 10000.00, accrualDate)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLedgerService_PostSuspenseReceipt(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	item := &models.SuspenseItem{ID: 7, LoanID: "loan_123", Amount: 100000.00, ReceivedAt: time.Now()}
+
+	// Mock repository calls
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntrySuspense && entry.LoanID == "" &&
+			entry.Reference == "SUSPENSE-7" && entry.Currency == models.CurrencyIDR &&
+			postings[models.AccountCash].Debit == 100000.00 &&
+			postings[models.AccountSuspense].Credit == 100000.00
+	})).Return(nil)
+
+	// Execute
+	err := service.PostSuspenseReceipt(ctx, item)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLedgerService_PostSuspenseRelease(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	item := &models.SuspenseItem{ID: 7, Amount: 100000.00, Currency: models.CurrencyIDR, Resolution: models.SuspenseResolutionRefund}
+
+	// Mock repository calls
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntrySuspenseRelease && entry.Description == "Suspense item resolved by refund" &&
+			postings[models.AccountSuspense].Debit == 100000.00 &&
+			postings[models.AccountCash].Credit == 100000.00
+	})).Return(nil)
+
+	// Execute
+	err := service.PostSuspenseRelease(ctx, item, models.AccountCash, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLedgerService_ReverseLoanEntries_SkipsReversedEntries(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	reversedID := uint(1)
+	entries := []*models.JournalEntry{
+		{ID: 1, EntryType: models.JournalEntryDeferral, LoanID: "loan_123", Currency: models.CurrencyIDR, Postings: []*models.JournalPosting{
+			{AccountCode: models.AccountLoanReceivable, Debit: 3300.00},
+			{AccountCode: models.AccountUnearnedInterest, Credit: 3300.00},
+		}},
+		{ID: 2, EntryType: models.JournalEntryReversal, LoanID: "loan_123", Currency: models.CurrencyIDR, ReversalOfID: &reversedID, Postings: []*models.JournalPosting{
+			{AccountCode: models.AccountLoanReceivable, Credit: 3300.00},
+			{AccountCode: models.AccountUnearnedInterest, Debit: 3300.00},
+		}},
+		{ID: 3, EntryType: models.JournalEntryDisbursement, LoanID: "loan_123", Currency: models.CurrencyIDR, Postings: []*models.JournalPosting{
+			{AccountCode: models.AccountLoanReceivable, Debit: 5500000.00},
+			{AccountCode: models.AccountCash, Credit: 5000000.00},
+			{AccountCode: models.AccountUnearnedInterest, Credit: 500000.00},
+		}},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetJournalEntriesByLoanID", ctx, "loan_123").Return(entries, nil)
+	mockRepo.On("CreateJournalEntry", ctx, mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		postings := postingsByAccount(entry)
+		return entry.EntryType == models.JournalEntryReversal && *entry.ReversalOfID == 3 &&
+			postings[models.AccountLoanReceivable].Credit == 5500000.00 &&
+			postings[models.AccountCancellationReceivable].Debit == 5000000.00 &&
+			postings[models.AccountCash] == nil &&
+			postings[models.AccountUnearnedInterest].Debit == 500000.00
+	})).Return(nil).Once()
+
+	// Execute
+	err := service.ReverseLoanEntries(ctx, "loan_123", "Borrower changed their mind", time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "CreateJournalEntry", 1)
+}
+
+func TestLedgerService_ReverseLoanEntries_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	entries := []*models.JournalEntry{
+		{ID: 1, EntryType: models.JournalEntryDeferral, LoanID: "loan_123", Postings: []*models.JournalPosting{
+			{AccountCode: models.AccountLoanReceivable, Debit: 3300.00},
+			{AccountCode: models.AccountUnearnedInterest, Credit: 3300.00},
+		}},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetJournalEntriesByLoanID", ctx, "loan_123").Return(entries, nil)
+	mockRepo.On("CreateJournalEntry", ctx, mock.Anything).Return(errors.New("database error"))
+
+	// Execute
+	err := service.ReverseLoanEntries(ctx, "loan_123", "Changed mind", time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to post journal entry")
+}
+
+func TestLedgerService_GetTrialBalance(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	totals := []*models.LedgerAccountTotal{
+		{AccountCode: models.AccountCash, AccountName: "Cash", AccountType: models.AccountTypeAsset, Currency: models.CurrencyIDR, Debit: 220000.00, Credit: 5000000.00},
+		{AccountCode: models.AccountLoanReceivable, AccountName: "Loan Receivable", AccountType: models.AccountTypeAsset, Currency: models.CurrencyIDR, Debit: 5500000.00, Credit: 220000.00},
+		{AccountCode: models.AccountUnearnedInterest, AccountName: "Unearned Interest", AccountType: models.AccountTypeLiability, Currency: models.CurrencyIDR, Credit: 500000.00},
+		{AccountCode: models.AccountCash, AccountName: "Cash", AccountType: models.AccountTypeAsset, Currency: "USD", Credit: 1000.00},
+		{AccountCode: models.AccountLoanReceivable, AccountName: "Loan Receivable", AccountType: models.AccountTypeAsset, Currency: "USD", Debit: 1000.00},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetAccountTotals", ctx, asOf.AddDate(0, 0, 1)).Return(totals, nil)
+
+	// Execute
+	response, err := service.GetTrialBalance(ctx, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Balanced)
+	assert.Len(t, response.Currencies, 2)
+
+	idr := response.Currencies[0]
+	assert.Equal(t, models.CurrencyIDR, idr.Currency)
+	assert.Equal(t, 5720000.00, idr.TotalDebit)
+	assert.Equal(t, 5720000.00, idr.TotalCredit)
+	assert.Equal(t, -4780000.00, idr.Accounts[0].Balance)
+	assert.Equal(t, 5280000.00, idr.Accounts[1].Balance)
+	assert.Equal(t, 500000.00, idr.Accounts[2].Balance) // credit balance for liabilities
+
+	assert.Equal(t, "USD", response.Currencies[1].Currency)
+	assert.True(t, response.Currencies[1].Balanced)
+}
+
+func TestLedgerService_ReconcileLoanBalances(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	balances := []*models.LoanLedgerBalance{
+		{LoanID: "loan_1", Status: models.StatusPending, Currency: models.CurrencyIDR, OutstandingAmount: 5280000.00, LedgerBalance: 5280000.00},
+		{LoanID: "loan_2", Status: models.StatusWrittenOff, Currency: models.CurrencyIDR, OutstandingAmount: 3300000.00, LedgerBalance: 0},
+		{LoanID: "loan_3", Status: models.StatusDelinquent, Currency: models.CurrencyIDR, OutstandingAmount: 330000.00, LedgerBalance: 440000.00},
+		{LoanID: "loan_4", Status: models.StatusCancelled, Currency: models.CurrencyIDR, OutstandingAmount: 0, LedgerBalance: 5500000.00},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanLedgerBalances", ctx).Return(balances, nil)
+
+	// Execute
+	response, err := service.ReconcileLoanBalances(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, response.Balanced)
+	assert.Equal(t, 4, response.CheckedLoans)
+	assert.Equal(t, 2, response.MismatchedLoans)
+	assert.Equal(t, "loan_3", response.Mismatches[0].LoanID)
+	assert.Equal(t, 110000.00, response.Mismatches[0].Difference)
+	assert.Equal(t, "loan_4", response.Mismatches[1].LoanID)
+	assert.Equal(t, 0.0, response.Mismatches[1].ExpectedBalance)
+}
+
+func TestLedgerService_ReconcileLoanBalances_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewLedgerMySQLRepositoryInterface(t)
+	service := NewLedgerService(mockRepo)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetLoanLedgerBalances", ctx).Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.ReconcileLoanBalances(ctx)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to get loan ledger balances")
+}
@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "billing-engine/models"
+
+	time "time"
 )
 
 // LoanQueryMySQLRepositoryInterface is an autogenerated mock type for the LoanQueryMySQLRepositoryInterface type
@@ -15,6 +17,96 @@ type LoanQueryMySQLRepositoryInterface struct {
 	mock.Mock
 }
 
+// GetCollectibilityHistoryByLoanID provides a mock function with given fields: ctx, loanID
+func (_m *LoanQueryMySQLRepositoryInterface) GetCollectibilityHistoryByLoanID(ctx context.Context, loanID string) ([]*models.LoanCollectibility, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollectibilityHistoryByLoanID")
+	}
+
+	var r0 []*models.LoanCollectibility
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.LoanCollectibility, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.LoanCollectibility); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoanCollectibility)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomerLoanTotals provides a mock function with given fields: ctx, customerID
+func (_m *LoanQueryMySQLRepositoryInterface) GetCustomerLoanTotals(ctx context.Context, customerID string) ([]*models.CustomerCurrencyTotal, error) {
+	ret := _m.Called(ctx, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerLoanTotals")
+	}
+
+	var r0 []*models.CustomerCurrencyTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.CustomerCurrencyTotal, error)); ok {
+		return rf(ctx, customerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.CustomerCurrencyTotal); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CustomerCurrencyTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanSummariesByCustomerID provides a mock function with given fields: ctx, customerID, statuses, beforeID, limit
+func (_m *LoanQueryMySQLRepositoryInterface) GetLoanSummariesByCustomerID(ctx context.Context, customerID string, statuses []string, beforeID uint, limit int) ([]*models.LoanSummary, error) {
+	ret := _m.Called(ctx, customerID, statuses, beforeID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanSummariesByCustomerID")
+	}
+
+	var r0 []*models.LoanSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, uint, int) ([]*models.LoanSummary, error)); ok {
+		return rf(ctx, customerID, statuses, beforeID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, uint, int) []*models.LoanSummary); ok {
+		r0 = rf(ctx, customerID, statuses, beforeID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoanSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, uint, int) error); ok {
+		r1 = rf(ctx, customerID, statuses, beforeID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLoanSummaryByLoanID provides a mock function with given fields: ctx, loanID
 func (_m *LoanQueryMySQLRepositoryInterface) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
 	ret := _m.Called(ctx, loanID)
@@ -45,6 +137,36 @@ func (_m *LoanQueryMySQLRepositoryInterface) GetLoanSummaryByLoanID(ctx context.
 	return r0, r1
 }
 
+// GetOverduePaymentSchedulesByCustomerID provides a mock function with given fields: ctx, customerID, asOf
+func (_m *LoanQueryMySQLRepositoryInterface) GetOverduePaymentSchedulesByCustomerID(ctx context.Context, customerID string, asOf time.Time) ([]*models.PaymentSchedule, error) {
+	ret := _m.Called(ctx, customerID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverduePaymentSchedulesByCustomerID")
+	}
+
+	var r0 []*models.PaymentSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*models.PaymentSchedule, error)); ok {
+		return rf(ctx, customerID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []*models.PaymentSchedule); ok {
+		r0 = rf(ctx, customerID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PaymentSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, customerID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOverduePaymentSchedulesByLoanID provides a mock function with given fields: ctx, loanID
 func (_m *LoanQueryMySQLRepositoryInterface) GetOverduePaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
 	ret := _m.Called(ctx, loanID)
@@ -165,6 +287,36 @@ func (_m *LoanQueryMySQLRepositoryInterface) GetPendingPaymentSchedulesByLoanID(
 	return r0, r1
 }
 
+// SearchLoanSummaries provides a mock function with given fields: ctx, filter, limit
+func (_m *LoanQueryMySQLRepositoryInterface) SearchLoanSummaries(ctx context.Context, filter *models.LoanSearchFilter, limit int) ([]*models.LoanSummary, error) {
+	ret := _m.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchLoanSummaries")
+	}
+
+	var r0 []*models.LoanSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LoanSearchFilter, int) ([]*models.LoanSummary, error)); ok {
+		return rf(ctx, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LoanSearchFilter, int) []*models.LoanSummary); ok {
+		r0 = rf(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoanSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.LoanSearchFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewLoanQueryMySQLRepositoryInterface creates a new instance of LoanQueryMySQLRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLoanQueryMySQLRepositoryInterface(t interface {
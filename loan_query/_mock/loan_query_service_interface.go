@@ -45,6 +45,36 @@ func (_m *LoanQueryServiceInterface) GetDelinquencyStatus(ctx context.Context, l
 	return r0, r1
 }
 
+// GetLoanCollectibility provides a mock function with given fields: ctx, loanID
+func (_m *LoanQueryServiceInterface) GetLoanCollectibility(ctx context.Context, loanID string) (*models.LoanCollectibilityResponse, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanCollectibility")
+	}
+
+	var r0 *models.LoanCollectibilityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.LoanCollectibilityResponse, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.LoanCollectibilityResponse); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanCollectibilityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLoanSchedule provides a mock function with given fields: ctx, loanID
 func (_m *LoanQueryServiceInterface) GetLoanSchedule(ctx context.Context, loanID string) (*models.LoanScheduleResponse, error) {
 	ret := _m.Called(ctx, loanID)
@@ -105,6 +135,66 @@ func (_m *LoanQueryServiceInterface) GetOutstandingBalance(ctx context.Context,
 	return r0, r1
 }
 
+// ListCustomerLoans provides a mock function with given fields: ctx, customerID, req
+func (_m *LoanQueryServiceInterface) ListCustomerLoans(ctx context.Context, customerID string, req *models.CustomerLoanListRequest) (*models.CustomerLoansResponse, error) {
+	ret := _m.Called(ctx, customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCustomerLoans")
+	}
+
+	var r0 *models.CustomerLoansResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CustomerLoanListRequest) (*models.CustomerLoansResponse, error)); ok {
+		return rf(ctx, customerID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CustomerLoanListRequest) *models.CustomerLoansResponse); ok {
+		r0 = rf(ctx, customerID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CustomerLoansResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.CustomerLoanListRequest) error); ok {
+		r1 = rf(ctx, customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchLoans provides a mock function with given fields: ctx, req
+func (_m *LoanQueryServiceInterface) SearchLoans(ctx context.Context, req *models.LoanSearchRequest) (*models.LoanSearchResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchLoans")
+	}
+
+	var r0 *models.LoanSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LoanSearchRequest) (*models.LoanSearchResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LoanSearchRequest) *models.LoanSearchResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.LoanSearchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewLoanQueryServiceInterface creates a new instance of LoanQueryServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLoanQueryServiceInterface(t interface {
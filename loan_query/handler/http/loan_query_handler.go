@@ -6,6 +6,8 @@ import (
 	"billing-engine/global"
 	"billing-engine/loan_query"
 	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
 
 	"github.com/labstack/echo/v4"
 )
@@ -24,9 +26,12 @@ func NewLoanQueryHandler(e *echo.Echo, loanQueryService loan_query.LoanQueryServ
 
 	// Register routes
 	v1 := e.Group("/v1")
+	v1.GET("/loans", handler.SearchLoans)
 	v1.GET("/loans/:loan_id/outstanding", handler.GetOutstandingBalance)
 	v1.GET("/loans/:loan_id/delinquency", handler.GetDelinquencyStatus)
 	v1.GET("/loans/:loan_id/schedule", handler.GetLoanSchedule)
+	v1.GET("/loans/:loan_id/collectibility", handler.GetLoanCollectibility)
+	v1.GET("/customers/:customer_id/loans", handler.ListCustomerLoans)
 }
 
 func (h *LoanQueryHandler) GetOutstandingBalance(c echo.Context) error {
@@ -97,3 +102,96 @@ func (h *LoanQueryHandler) GetLoanSchedule(c echo.Context) error {
 		Data:   response,
 	})
 }
+
+func (h *LoanQueryHandler) GetLoanCollectibility(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	response, err := h.loanQueryService.GetLoanCollectibility(c.Request().Context(), loanID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.LoanCollectibilitySuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *LoanQueryHandler) ListCustomerLoans(c echo.Context) error {
+	customerID := c.Param("customer_id")
+	if customerID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Customer ID is required",
+		})
+	}
+
+	var req models.CustomerLoanListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.loanQueryService.ListCustomerLoans(c.Request().Context(), customerID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.CustomerLoansSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *LoanQueryHandler) SearchLoans(c echo.Context) error {
+	var req models.LoanSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.loanQueryService.SearchLoans(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.LoanSearchSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -320,3 +320,267 @@ func TestLoanQueryHandler_GetLoanSchedule_EmptyLoanID(t *testing.T) {
 	assert.Equal(t, float64(http.StatusBadRequest), response["code"])
 	assert.Equal(t, "Loan ID is required", response["message"])
 }
+
+func TestLoanQueryHandler_GetLoanCollectibility_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	effectiveDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	expectedResponse := &models.LoanCollectibilityResponse{
+		LoanID: "loan_123456789",
+		Current: models.CollectibilityResponse{
+			Collectibility: 3,
+			Label:          "Kurang Lancar",
+			Dpd:            98,
+			EffectiveDate:  &effectiveDate,
+		},
+		History: []models.CollectibilityHistoryResponse{
+			{Collectibility: 3, Label: "Kurang Lancar", Dpd: 98, EffectiveFrom: effectiveDate},
+		},
+	}
+
+	mockService.On("GetLoanCollectibility", mock.Anything, "loan_123456789").Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans/loan_123456789/collectibility", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123456789")
+
+	// Execute
+	err := handler.GetLoanCollectibility(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.LoanCollectibilitySuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 3, response.Data.Current.Collectibility)
+	assert.Len(t, response.Data.History, 1)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLoanQueryHandler_GetLoanCollectibility_EmptyLoanID(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	// Create request with empty loan_id
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans//collectibility", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("")
+
+	// Execute
+	err := handler.GetLoanCollectibility(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "Loan ID is required", response["message"])
+}
+
+func TestLoanQueryHandler_ListCustomerLoans_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	expectedResponse := &models.CustomerLoansResponse{
+		CustomerID: "12312312",
+		Summary: models.CustomerLoanSummaryResponse{
+			ActiveLoans: 1,
+			WorstDpd:    14,
+			Totals: []models.CustomerCurrencyTotal{
+				{Currency: models.CurrencyIDR, ActiveLoans: 1, WorstDpd: 14, OutstandingAmount: 3300000.00, OverdueAmount: 220000.00},
+			},
+		},
+		Loans: []models.LoanListItemResponse{
+			{LoanID: "loan_123456789", CustomerID: "12312312", Status: models.StatusPending, Currency: models.CurrencyIDR, OutstandingAmount: 3300000.00},
+		},
+	}
+
+	mockService.On("ListCustomerLoans", mock.Anything, "12312312", mock.MatchedBy(func(req *models.CustomerLoanListRequest) bool {
+		return len(req.Status) == 2 && req.Status[0] == models.StatusPending && req.Status[1] == models.StatusDelinquent && req.Limit == 10
+	})).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/customers/12312312/loans?status=PENDING&status=DELINQUENT&limit=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("12312312")
+
+	// Execute
+	err := handler.ListCustomerLoans(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.CustomerLoansSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Loans, 1)
+	assert.Equal(t, 14, response.Data.Summary.WorstDpd)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLoanQueryHandler_ListCustomerLoans_InvalidStatus(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/customers/12312312/loans?status=OPEN", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("12312312")
+
+	// Execute
+	err := handler.ListCustomerLoans(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Contains(t, response.Message, "status[0] must be one of")
+}
+
+func TestLoanQueryHandler_ListCustomerLoans_InvalidCursor(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/customers/12312312/loans?cursor=garbage", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("12312312")
+
+	// Execute
+	err := handler.ListCustomerLoans(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "cursor is invalid", response.Message)
+}
+
+func TestLoanQueryHandler_SearchLoans_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	expectedResponse := &models.LoanSearchResponse{
+		Loans: []models.LoanListItemResponse{
+			{LoanID: "loan_123456789", CustomerID: "12312312", ProductCode: "CONSUMER", Status: models.StatusDelinquent, Currency: models.CurrencyIDR, OutstandingAmount: 3300000.00, Dpd: 45},
+		},
+	}
+
+	mockService.On("SearchLoans", mock.Anything, mock.MatchedBy(func(req *models.LoanSearchRequest) bool {
+		return len(req.Status) == 1 && req.Status[0] == models.StatusDelinquent && req.ProductCode == "CONSUMER" &&
+			req.DpdBucket == models.DpdBucket31To60 && req.MinOutstanding == 1000000 && req.SortBy == models.LoanSortDpd
+	})).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans?status=DELINQUENT&product_code=CONSUMER&dpd_bucket=DPD_31_60&min_outstanding=1000000&sort_by=dpd", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.SearchLoans(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.LoanSearchSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Loans, 1)
+	assert.Equal(t, 45, response.Data.Loans[0].Dpd)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLoanQueryHandler_SearchLoans_InvalidFilters(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewLoanQueryServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &LoanQueryHandler{
+		loanQueryService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans?start_date_from=01-01-2025&min_outstanding=500&max_outstanding=100", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.SearchLoans(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Contains(t, response.Message, "startdatefrom must be a date in 2006-01-02 format")
+	assert.Contains(t, response.Message, "maxoutstanding must be greater than or equal to minoutstanding")
+}
@@ -3,6 +3,7 @@ package loan_query
 import (
 	"billing-engine/models"
 	"context"
+	"time"
 )
 
 // LoanQueryMySQLRepositoryInterface defines the interface for loan query repository
@@ -12,6 +13,11 @@ type LoanQueryMySQLRepositoryInterface interface {
 	GetOverduePaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
 	GetPaidPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
 	GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	GetCollectibilityHistoryByLoanID(ctx context.Context, loanID string) ([]*models.LoanCollectibility, error)
+	GetLoanSummariesByCustomerID(ctx context.Context, customerID string, statuses []string, beforeID uint, limit int) ([]*models.LoanSummary, error)
+	GetCustomerLoanTotals(ctx context.Context, customerID string) ([]*models.CustomerCurrencyTotal, error)
+	GetOverduePaymentSchedulesByCustomerID(ctx context.Context, customerID string, asOf time.Time) ([]*models.PaymentSchedule, error)
+	SearchLoanSummaries(ctx context.Context, filter *models.LoanSearchFilter, limit int) ([]*models.LoanSummary, error)
 }
 
 // LoanQueryServiceInterface defines the interface for loan query service
@@ -19,4 +25,7 @@ type LoanQueryServiceInterface interface {
 	GetOutstandingBalance(ctx context.Context, loanID string) (*models.OutstandingBalanceResponse, error)
 	GetDelinquencyStatus(ctx context.Context, loanID string) (*models.DelinquencyResponse, error)
 	GetLoanSchedule(ctx context.Context, loanID string) (*models.LoanScheduleResponse, error)
+	GetLoanCollectibility(ctx context.Context, loanID string) (*models.LoanCollectibilityResponse, error)
+	ListCustomerLoans(ctx context.Context, customerID string, req *models.CustomerLoanListRequest) (*models.CustomerLoansResponse, error)
+	SearchLoans(ctx context.Context, req *models.LoanSearchRequest) (*models.LoanSearchResponse, error)
 }
@@ -80,3 +80,137 @@ func (r *loanQueryMySQLRepository) GetPendingPaymentSchedulesByLoanID(ctx contex
 	}
 	return schedules, nil
 }
+
+func (r *loanQueryMySQLRepository) GetCollectibilityHistoryByLoanID(ctx context.Context, loanID string) ([]*models.LoanCollectibility, error) {
+	var history []*models.LoanCollectibility
+	err := r.db.WithContext(ctx).
+		Where("loan_id = ?", loanID).
+		Order("effective_from DESC, id DESC").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// GetLoanSummariesByCustomerID returns the customer's loans newest first, starting below beforeID when it is set
+func (r *loanQueryMySQLRepository) GetLoanSummariesByCustomerID(ctx context.Context, customerID string, statuses []string, beforeID uint, limit int) ([]*models.LoanSummary, error) {
+	query := r.db.WithContext(ctx).Where("customer_id = ? AND deleted_at IS NULL", customerID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var loanSummaries []*models.LoanSummary
+	err := query.Order("id DESC").Limit(limit).Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+// GetCustomerLoanTotals aggregates the customer's active loans per currency. The overdue amount is
+// left to the caller, which adds the penalties and their tax to the installments.
+func (r *loanQueryMySQLRepository) GetCustomerLoanTotals(ctx context.Context, customerID string) ([]*models.CustomerCurrencyTotal, error) {
+	var totals []*models.CustomerCurrencyTotal
+	err := r.db.WithContext(ctx).
+		Model(&models.LoanSummary{}).
+		Select("currency, COUNT(*) AS active_loans, MAX(dpd) AS worst_dpd, COALESCE(SUM(outstanding_amount), 0) AS outstanding_amount").
+		Where("customer_id = ? AND status IN ? AND deleted_at IS NULL",
+			customerID, []string{models.StatusPending, models.StatusDelinquent}).
+		Group("currency").
+		Order("currency ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetOverduePaymentSchedulesByCustomerID returns the installments of the customer's active loans
+// overdue as of the given time
+func (r *loanQueryMySQLRepository) GetOverduePaymentSchedulesByCustomerID(ctx context.Context, customerID string, asOf time.Time) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := r.db.WithContext(ctx).
+		Joins("JOIN loan_summaries ON loan_summaries.loan_id = payment_schedules.loan_id").
+		Where("loan_summaries.customer_id = ? AND loan_summaries.status IN ? AND loan_summaries.deleted_at IS NULL",
+			customerID, []string{models.StatusPending, models.StatusDelinquent}).
+		Where("payment_schedules.status = ? AND payment_schedules.installment_due_date < ? AND payment_schedules.deleted_at IS NULL",
+			models.StatusPending, asOf).
+		Order("payment_schedules.loan_id ASC, payment_schedules.installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// loanSearchColumns maps the loan search sort keys to their loan_summaries columns
+var loanSearchColumns = map[string]string{
+	models.LoanSortID:                "id",
+	models.LoanSortLoanStartDate:     "loan_start_date",
+	models.LoanSortOutstandingAmount: "outstanding_amount",
+	models.LoanSortDpd:               "dpd",
+}
+
+// SearchLoanSummaries returns the loans matching the filter in the filter's sort order, keyset
+// paginated on the sort column and ID
+func (r *loanQueryMySQLRepository) SearchLoanSummaries(ctx context.Context, filter *models.LoanSearchFilter, limit int) ([]*models.LoanSummary, error) {
+	query := r.db.WithContext(ctx).Where("deleted_at IS NULL")
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.InstallmentUnit != "" {
+		query = query.Where("installment_unit = ?", filter.InstallmentUnit)
+	}
+	if filter.ProductCode != "" {
+		query = query.Where("product_code = ?", filter.ProductCode)
+	}
+	if filter.StartDateFrom != nil {
+		query = query.Where("loan_start_date >= ?", *filter.StartDateFrom)
+	}
+	if filter.StartDateTo != nil {
+		query = query.Where("loan_start_date <= ?", *filter.StartDateTo)
+	}
+	if filter.MinDpd != nil {
+		query = query.Where("dpd >= ?", *filter.MinDpd)
+	}
+	if filter.MaxDpd != nil {
+		query = query.Where("dpd <= ?", *filter.MaxDpd)
+	}
+	if filter.MinOutstanding > 0 {
+		query = query.Where("outstanding_amount >= ?", filter.MinOutstanding)
+	}
+	if filter.MaxOutstanding > 0 {
+		query = query.Where("outstanding_amount <= ?", filter.MaxOutstanding)
+	}
+
+	column, ok := loanSearchColumns[filter.SortBy]
+	if !ok {
+		column = "id"
+	}
+	comparison, direction := ">", "ASC"
+	if filter.SortDesc {
+		comparison, direction = "<", "DESC"
+	}
+	if filter.AfterID > 0 {
+		if column == "id" {
+			query = query.Where("id "+comparison+" ?", filter.AfterID)
+		} else {
+			query = query.Where("("+column+" "+comparison+" ? OR ("+column+" = ? AND id "+comparison+" ?))",
+				filter.AfterValue, filter.AfterValue, filter.AfterID)
+		}
+	}
+	if column != "id" {
+		query = query.Order(column + " " + direction)
+	}
+
+	var loanSummaries []*models.LoanSummary
+	err := query.Order("id " + direction).Limit(limit).Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
@@ -3,23 +3,49 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	"billing-engine/delinquency"
 	"billing-engine/loan_query"
 	"billing-engine/models"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+	"billing-engine/utils/pagination"
+
+	"github.com/shopspring/decimal"
 )
 
 type loanQueryService struct {
-	loanQueryRepo loan_query.LoanQueryMySQLRepositoryInterface
+	loanQueryRepo      loan_query.LoanQueryMySQLRepositoryInterface
+	delinquencyService delinquency.DelinquencyServiceInterface
+	taxService         tax.TaxServiceInterface
 }
 
 // NewLoanQueryService creates a new loan query service instance
-func NewLoanQueryService(loanQueryRepo loan_query.LoanQueryMySQLRepositoryInterface) loan_query.LoanQueryServiceInterface {
+func NewLoanQueryService(loanQueryRepo loan_query.LoanQueryMySQLRepositoryInterface, delinquencyService delinquency.DelinquencyServiceInterface, taxService tax.TaxServiceInterface) loan_query.LoanQueryServiceInterface {
 	return &loanQueryService{
-		loanQueryRepo: loanQueryRepo,
+		loanQueryRepo:      loanQueryRepo,
+		delinquencyService: delinquencyService,
+		taxService:         taxService,
 	}
 }
 
+// amountDue is what paying the installment takes, as the repayment requires it: the installment,
+// its penalty and the tax on that penalty
+func (s *loanQueryService) amountDue(schedule *models.PaymentSchedule, currencyCode string) decimal.Decimal {
+	amount := decimal.NewFromFloat(schedule.InstallmentAmount)
+	if schedule.PenaltyAmount <= 0 {
+		return amount
+	}
+
+	amount = amount.Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+	if taxLine := s.taxService.TaxCharge(models.ChargeTypePenalty, schedule.PenaltyAmount, currencyCode); taxLine != nil {
+		amount = amount.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+	}
+	return amount
+}
+
 func (s *loanQueryService) GetOutstandingBalance(ctx context.Context, loanID string) (*models.OutstandingBalanceResponse, error) {
 	// Get loan summary
 	loanSummary, err := s.loanQueryRepo.GetLoanSummaryByLoanID(ctx, loanID)
@@ -48,26 +74,30 @@ func (s *loanQueryService) GetOutstandingBalance(ctx context.Context, loanID str
 		return nil, fmt.Errorf("failed to get pending schedules: %v", err)
 	}
 
-	// Calculate overdue amount
-	var overdueAmount float64
+	// Calculate overdue amount, penalties and their tax included
+	loanCurrency := currency.Normalize(loanSummary.Currency)
+	overdueAmount := decimal.Zero
 	for _, schedule := range overdueSchedules {
-		overdueAmount += schedule.InstallmentAmount
+		overdueAmount = overdueAmount.Add(s.amountDue(schedule, loanCurrency))
 	}
 
 	return &models.OutstandingBalanceResponse{
 		LoanID:     loanID,
 		CustomerID: loanSummary.CustomerID,
+		Currency:   loanCurrency,
 		LoanDetails: models.LoanDetailsResponse{
 			InstallmentUnit:   loanSummary.InstallmentUnit,
 			InstallmentAmount: loanSummary.InstallmentAmount,
 			TotalInstallments: loanSummary.NoOfInstallment,
+			Currency:          loanCurrency,
 		},
 		OutstandingAmount: loanSummary.OutstandingAmount,
-		OverdueAmount:     overdueAmount,
+		OverdueAmount:     overdueAmount.InexactFloat64(),
 
 		OverdueInstallments:   len(overdueSchedules),
 		PaidInstallments:      len(paidSchedules),
 		RemainingInstallments: len(pendingSchedules),
+		Collectibility:        toCollectibilityResponse(loanSummary),
 	}, nil
 }
 
@@ -87,25 +117,38 @@ func (s *loanQueryService) GetDelinquencyStatus(ctx context.Context, loanID stri
 		return nil, fmt.Errorf("failed to get overdue schedules: %v", err)
 	}
 
-	// Calculate overdue amount and required payment
-	var overdueAmount float64
+	// Calculate overdue amount and required payment, penalties and their tax included
+	loanCurrency := currency.Normalize(loanSummary.Currency)
+	overdueAmount := decimal.Zero
 	for _, schedule := range overdueSchedules {
-		overdueAmount += schedule.InstallmentAmount
+		overdueAmount = overdueAmount.Add(s.amountDue(schedule, loanCurrency))
 	}
 
-	// Determine if delinquent (has overdue installments)
-	isDelinquent := len(overdueSchedules) > 1
+	// Determine if delinquent using the product's delinquency policy
+	evaluation, err := s.delinquencyService.EvaluateLoan(ctx, loanSummary, overdueSchedules, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate delinquency: %v", err)
+	}
+
+	// Delinquent loans must pay all overdue amounts, otherwise only the oldest overdue installment
+	requiredPaymentAmount := overdueAmount
+	if !evaluation.IsDelinquent && len(overdueSchedules) > 0 {
+		requiredPaymentAmount = s.amountDue(overdueSchedules[0], loanCurrency)
+	}
 
 	return &models.DelinquencyResponse{
 		LoanID:       loanID,
 		CustomerID:   loanSummary.CustomerID,
-		IsDelinquent: isDelinquent,
+		IsDelinquent: evaluation.IsDelinquent,
+		Currency:     loanCurrency,
+		MatchedRules: evaluation.MatchedRules,
 
 		InstallmentUnit:       loanSummary.InstallmentUnit,
 		OverdueInstallments:   len(overdueSchedules),
-		OverdueAmount:         overdueAmount,
+		OverdueAmount:         overdueAmount.InexactFloat64(),
 		OutstandingAmount:     loanSummary.OutstandingAmount,
-		RequiredPaymentAmount: overdueAmount, // Must pay all overdue amounts
+		RequiredPaymentAmount: requiredPaymentAmount.InexactFloat64(),
+		Collectibility:        toCollectibilityResponse(loanSummary),
 	}, nil
 }
 
@@ -126,6 +169,7 @@ func (s *loanQueryService) GetLoanSchedule(ctx context.Context, loanID string) (
 	}
 
 	// Convert to response format
+	loanCurrency := currency.Normalize(loanSummary.Currency)
 	scheduleResponses := make([]models.PaymentScheduleResponse, 0, len(paymentSchedules))
 	for _, schedule := range paymentSchedules {
 		var paidDate *time.Time
@@ -138,6 +182,9 @@ func (s *loanQueryService) GetLoanSchedule(ctx context.Context, loanID string) (
 			DueDate:           schedule.InstallmentDueDate,
 			InstallmentAmount: schedule.InstallmentAmount,
 			InstallmentPaid:   schedule.InstallmentPaid,
+			PenaltyAmount:     schedule.PenaltyAmount,
+			TaxAmount:         schedule.TaxAmount,
+			Currency:          loanCurrency,
 			Status:            schedule.Status,
 			PaidDate:          paidDate,
 		}
@@ -145,14 +192,270 @@ func (s *loanQueryService) GetLoanSchedule(ctx context.Context, loanID string) (
 	}
 
 	return &models.LoanScheduleResponse{
-		LoanID: loanID,
+		LoanID:   loanID,
+		Currency: loanCurrency,
 		LoanSummary: models.LoanSummaryScheduleResponse{
-			InstallmentUnit:   loanSummary.InstallmentUnit,
-			TotalInstallments: loanSummary.NoOfInstallment,
-			InstallmentAmount: loanSummary.InstallmentAmount,
-			DisbursedAmount:   loanSummary.PrincipalAmount,
-			OutstandingAmount: loanSummary.OutstandingAmount,
+			InstallmentUnit:       loanSummary.InstallmentUnit,
+			TotalInstallments:     loanSummary.NoOfInstallment,
+			InstallmentAmount:     loanSummary.InstallmentAmount,
+			DisbursedAmount:       loanSummary.PrincipalAmount,
+			OutstandingAmount:     loanSummary.OutstandingAmount,
+			Currency:              loanCurrency,
+			AnnualPercentageRate:  loanSummary.AnnualPercentageRate,
+			EffectiveInterestRate: loanSummary.EffectiveInterestRate,
 		},
 		Schedule: scheduleResponses,
 	}, nil
 }
+
+func (s *loanQueryService) GetLoanCollectibility(ctx context.Context, loanID string) (*models.LoanCollectibilityResponse, error) {
+	// Get loan summary
+	loanSummary, err := s.loanQueryRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	// Get collectibility history, most recent first
+	collectibilities, err := s.loanQueryRepo.GetCollectibilityHistoryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collectibility history: %v", err)
+	}
+
+	loanCurrency := currency.Normalize(loanSummary.Currency)
+	history := make([]models.CollectibilityHistoryResponse, 0, len(collectibilities))
+	for _, collectibility := range collectibilities {
+		history = append(history, models.CollectibilityHistoryResponse{
+			Collectibility:    collectibility.Collectibility,
+			Label:             models.CollectibilityLabels[collectibility.Collectibility],
+			Dpd:               collectibility.Dpd,
+			OutstandingAmount: collectibility.OutstandingAmount,
+			Currency:          loanCurrency,
+			EffectiveFrom:     collectibility.EffectiveFrom,
+			EffectiveTo:       collectibility.EffectiveTo,
+		})
+	}
+
+	return &models.LoanCollectibilityResponse{
+		LoanID:   loanID,
+		Currency: loanCurrency,
+		Current:  toCollectibilityResponse(loanSummary),
+		History:  history,
+	}, nil
+}
+
+// ListCustomerLoans lists the customer's loans newest first, with a summary of all their active loans
+func (s *loanQueryService) ListCustomerLoans(ctx context.Context, customerID string, req *models.CustomerLoanListRequest) (*models.CustomerLoansResponse, error) {
+	cursor, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	var beforeID uint
+	if cursor != nil {
+		beforeID = cursor.ID
+	}
+
+	// Fetch one loan more than the page size to know whether another page follows
+	limit := pagination.Limit(req.Limit)
+	loanSummaries, err := s.loanQueryRepo.GetLoanSummariesByCustomerID(ctx, customerID, req.Status, beforeID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer loans: %v", err)
+	}
+
+	totals, err := s.loanQueryRepo.GetCustomerLoanTotals(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer loan totals: %v", err)
+	}
+
+	// The overdue amount is what paying the overdue installments takes, as on each loan's balance
+	overdueSchedules, err := s.loanQueryRepo.GetOverduePaymentSchedulesByCustomerID(ctx, customerID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue payment schedules: %v", err)
+	}
+	overdueAmounts := make(map[string]decimal.Decimal)
+	for _, schedule := range overdueSchedules {
+		currencyCode := currency.Normalize(schedule.Currency)
+		overdueAmounts[currencyCode] = overdueAmounts[currencyCode].Add(s.amountDue(schedule, currencyCode))
+	}
+
+	response := &models.CustomerLoansResponse{
+		CustomerID: customerID,
+		Summary: models.CustomerLoanSummaryResponse{
+			Totals: make([]models.CustomerCurrencyTotal, 0, len(totals)),
+		},
+		Loans: make([]models.LoanListItemResponse, 0, len(loanSummaries)),
+	}
+	for _, total := range totals {
+		total.OverdueAmount = overdueAmounts[currency.Normalize(total.Currency)].InexactFloat64()
+		response.Summary.ActiveLoans += total.ActiveLoans
+		if total.WorstDpd > response.Summary.WorstDpd {
+			response.Summary.WorstDpd = total.WorstDpd
+		}
+		response.Summary.Totals = append(response.Summary.Totals, *total)
+	}
+
+	if len(loanSummaries) > limit {
+		loanSummaries = loanSummaries[:limit]
+		response.NextCursor = pagination.EncodeCursor(pagination.Cursor{ID: loanSummaries[limit-1].ID})
+	}
+	for _, loanSummary := range loanSummaries {
+		response.Loans = append(response.Loans, toLoanListItemResponse(loanSummary))
+	}
+
+	return response, nil
+}
+
+// SearchLoans lists the loans matching the operations search filters, paginated with a cursor
+// bound to the requested sort order
+func (s *loanQueryService) SearchLoans(ctx context.Context, req *models.LoanSearchRequest) (*models.LoanSearchResponse, error) {
+	filter, err := toLoanSearchFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	sortKey := filter.SortBy + ":desc"
+	if !filter.SortDesc {
+		sortKey = filter.SortBy + ":asc"
+	}
+
+	cursor, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil {
+		if cursor.Sort != sortKey {
+			return nil, fmt.Errorf("cursor does not match the requested sort order")
+		}
+		filter.AfterID = cursor.ID
+		filter.AfterValue = cursor.Value
+	}
+
+	// Fetch one loan more than the page size to know whether another page follows
+	limit := pagination.Limit(req.Limit)
+	loanSummaries, err := s.loanQueryRepo.SearchLoanSummaries(ctx, filter, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search loans: %v", err)
+	}
+
+	response := &models.LoanSearchResponse{
+		Loans: make([]models.LoanListItemResponse, 0, len(loanSummaries)),
+	}
+	if len(loanSummaries) > limit {
+		loanSummaries = loanSummaries[:limit]
+		last := loanSummaries[limit-1]
+		response.NextCursor = pagination.EncodeCursor(pagination.Cursor{
+			ID:    last.ID,
+			Value: loanSortValue(last, filter.SortBy),
+			Sort:  sortKey,
+		})
+	}
+	for _, loanSummary := range loanSummaries {
+		response.Loans = append(response.Loans, toLoanListItemResponse(loanSummary))
+	}
+
+	return response, nil
+}
+
+// toLoanSearchFilter turns the search query parameters into the repository filter
+func toLoanSearchFilter(req *models.LoanSearchRequest) (*models.LoanSearchFilter, error) {
+	filter := &models.LoanSearchFilter{
+		Statuses:        req.Status,
+		InstallmentUnit: req.InstallmentUnit,
+		ProductCode:     req.ProductCode,
+		MinOutstanding:  req.MinOutstanding,
+		MaxOutstanding:  req.MaxOutstanding,
+		SortBy:          req.SortBy,
+		SortDesc:        req.SortOrder != "asc",
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = models.LoanSortID
+	}
+
+	if req.StartDateFrom != "" {
+		startDateFrom, err := time.Parse("2006-01-02", req.StartDateFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date_from: %v", err)
+		}
+		filter.StartDateFrom = &startDateFrom
+	}
+	if req.StartDateTo != "" {
+		startDateTo, err := time.Parse("2006-01-02", req.StartDateTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date_to: %v", err)
+		}
+		filter.StartDateTo = &startDateTo
+	}
+	if filter.StartDateFrom != nil && filter.StartDateTo != nil && filter.StartDateTo.Before(*filter.StartDateFrom) {
+		return nil, fmt.Errorf("start_date_to must not be before start_date_from")
+	}
+
+	if req.DpdBucket != "" {
+		minDpd, maxDpd := dpdBucketRange(req.DpdBucket)
+		filter.MinDpd = &minDpd
+		if maxDpd >= 0 {
+			filter.MaxDpd = &maxDpd
+		}
+	}
+
+	return filter, nil
+}
+
+// dpdBucketRange returns the inclusive DPD range of a bucket; a max of -1 means no upper bound
+func dpdBucketRange(bucket string) (int, int) {
+	switch bucket {
+	case models.DpdBucket1To30:
+		return 1, 30
+	case models.DpdBucket31To60:
+		return 31, 60
+	case models.DpdBucket61To90:
+		return 61, 90
+	case models.DpdBucketOver90:
+		return 91, -1
+	default:
+		return 0, 0
+	}
+}
+
+// loanSortValue returns the sort key of the loan as stored in the cursor
+func loanSortValue(loanSummary *models.LoanSummary, sortBy string) string {
+	switch sortBy {
+	case models.LoanSortLoanStartDate:
+		return loanSummary.LoanStartDate.Format("2006-01-02")
+	case models.LoanSortOutstandingAmount:
+		return strconv.FormatFloat(loanSummary.OutstandingAmount, 'f', 2, 64)
+	case models.LoanSortDpd:
+		return strconv.Itoa(loanSummary.Dpd)
+	default:
+		return ""
+	}
+}
+
+// toLoanListItemResponse converts a loan summary into a loan listing item
+func toLoanListItemResponse(loanSummary *models.LoanSummary) models.LoanListItemResponse {
+	return models.LoanListItemResponse{
+		LoanID:              loanSummary.LoanID,
+		CustomerID:          loanSummary.CustomerID,
+		ProductCode:         loanSummary.ProductCode,
+		Status:              loanSummary.Status,
+		Currency:            currency.Normalize(loanSummary.Currency),
+		PrincipalAmount:     loanSummary.PrincipalAmount,
+		OutstandingAmount:   loanSummary.OutstandingAmount,
+		InstallmentAmount:   loanSummary.InstallmentAmount,
+		InstallmentUnit:     loanSummary.InstallmentUnit,
+		NumberOfInstallment: loanSummary.NoOfInstallment,
+		Dpd:                 loanSummary.Dpd,
+		Collectibility:      loanSummary.Collectibility,
+		LoanStartDate:       loanSummary.LoanStartDate,
+	}
+}
+
+// toCollectibilityResponse builds the current collectibility grade stored on the loan summary
+func toCollectibilityResponse(loanSummary *models.LoanSummary) models.CollectibilityResponse {
+	return models.CollectibilityResponse{
+		Collectibility: loanSummary.Collectibility,
+		Label:          models.CollectibilityLabels[loanSummary.Collectibility],
+		Dpd:            loanSummary.Dpd,
+		EffectiveDate:  loanSummary.CollectibilityDate,
+	}
+}
@@ -1,19 +1,24 @@
 package service
 
 import (
+	delinquencyMocks "billing-engine/delinquency/_mock"
 	mocks "billing-engine/loan_query/_mock"
 	"billing-engine/models"
+	taxMocks "billing-engine/tax/_mock"
+	"billing-engine/utils/pagination"
 	"context"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestLoanQueryService_GetOutstandingBalance_Success(t *testing.T) {
 	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
-	service := NewLoanQueryService(mockRepo)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
 	ctx := context.Background()
 
 	loanSummary := &models.LoanSummary{
@@ -23,6 +28,8 @@ func TestLoanQueryService_GetOutstandingBalance_Success(t *testing.T) {
 		InstallmentAmount: 110000.00,
 		NoOfInstallment:   50,
 		InstallmentUnit:   "week",
+		Dpd:               14,
+		Collectibility:    models.CollectibilitySpecialMention,
 	}
 
 	overdueSchedules := []*models.PaymentSchedule{
@@ -75,7 +82,9 @@ func TestLoanQueryService_GetOutstandingBalance_Success(t *testing.T) {
 	assert.Equal(t, "loan_123", response.LoanID)
 	assert.Equal(t, "customer_123", response.CustomerID)
 	assert.Equal(t, 5280000.00, response.OutstandingAmount)
-	assert.Equal(t, 220000.00, response.OverdueAmount) // 2 * 110000
+	assert.Equal(t, 220000.00, response.OverdueAmount)     // 2 * 110000
+	assert.Equal(t, models.CurrencyIDR, response.Currency) // loans without a currency are in IDR
+	assert.Equal(t, models.CurrencyIDR, response.LoanDetails.Currency)
 
 	assert.Equal(t, 2, response.OverdueInstallments)
 	assert.Equal(t, 2, response.PaidInstallments)
@@ -83,13 +92,17 @@ func TestLoanQueryService_GetOutstandingBalance_Success(t *testing.T) {
 	assert.Equal(t, "week", response.LoanDetails.InstallmentUnit)
 	assert.Equal(t, 110000.00, response.LoanDetails.InstallmentAmount)
 	assert.Equal(t, 50, response.LoanDetails.TotalInstallments)
+	assert.Equal(t, models.CollectibilitySpecialMention, response.Collectibility.Collectibility)
+	assert.Equal(t, "Dalam Perhatian Khusus", response.Collectibility.Label)
+	assert.Equal(t, 14, response.Collectibility.Dpd)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestLoanQueryService_GetOutstandingBalance_LoanNotFound(t *testing.T) {
 	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
-	service := NewLoanQueryService(mockRepo)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
 	ctx := context.Background()
 
 	// Mock repository calls
@@ -106,9 +119,50 @@ func TestLoanQueryService_GetOutstandingBalance_LoanNotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestLoanQueryService_GetOutstandingBalance_WithPenalty(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, mockTax)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		CustomerID:        "customer_123",
+		OutstandingAmount: 5390000.00,
+		InstallmentAmount: 110000.00,
+		NoOfInstallment:   50,
+	}
+
+	overdueSchedules := []*models.PaymentSchedule{
+		{
+			ID:                1,
+			InstallmentAmount: 110000.00,
+			PenaltyAmount:     5500.00,
+			Status:            models.StatusPending,
+		},
+	}
+
+	// Mock repository and service calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockRepo.On("GetPaidPaymentSchedulesByLoanID", ctx, "loan_123").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 5500.00, models.CurrencyIDR).Return(&models.TaxLine{TaxAmount: 605.00})
+
+	// Execute
+	response, err := service.GetOutstandingBalance(ctx, "loan_123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 116105.00, response.OverdueAmount)
+	assert.Equal(t, 5390000.00, response.OutstandingAmount)
+}
+
 func TestLoanQueryService_GetDelinquencyStatus_Success(t *testing.T) {
 	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
-	service := NewLoanQueryService(mockRepo)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
 	ctx := context.Background()
 
 	loanSummary := &models.LoanSummary{
@@ -135,6 +189,12 @@ func TestLoanQueryService_GetDelinquencyStatus_Success(t *testing.T) {
 	// Mock repository calls
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
 	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{
+		IsDelinquent: true,
+		Dpd:          14,
+		PolicySource: models.DelinquencyPolicySourceBuiltIn,
+		MatchedRules: []string{"more than 1 overdue installment(s)"},
+	}, nil)
 
 	// Execute
 	response, err := service.GetDelinquencyStatus(ctx, "loan_123")
@@ -145,6 +205,7 @@ func TestLoanQueryService_GetDelinquencyStatus_Success(t *testing.T) {
 	assert.Equal(t, "loan_123", response.LoanID)
 	assert.Equal(t, "customer_123", response.CustomerID)
 	assert.True(t, response.IsDelinquent)
+	assert.Equal(t, []string{"more than 1 overdue installment(s)"}, response.MatchedRules)
 
 	assert.Equal(t, "week", response.InstallmentUnit)
 	assert.Equal(t, 2, response.OverdueInstallments)
@@ -155,9 +216,59 @@ func TestLoanQueryService_GetDelinquencyStatus_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestLoanQueryService_GetDelinquencyStatus_WithPenalty(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, mockTax)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		CustomerID:        "customer_123",
+		OutstandingAmount: 5280000.00,
+		InstallmentAmount: 110000.00,
+		InstallmentUnit:   "week",
+	}
+
+	overdueSchedules := []*models.PaymentSchedule{
+		{
+			ID:                1,
+			InstallmentAmount: 110000.00,
+			PenaltyAmount:     5500.00,
+			Status:            models.StatusPending,
+		},
+		{
+			ID:                2,
+			InstallmentAmount: 110000.00,
+			Status:            models.StatusPending,
+		},
+	}
+
+	// Mock repository and service calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 5500.00, models.CurrencyIDR).Return(&models.TaxLine{TaxAmount: 605.00})
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{
+		IsDelinquent: false,
+		PolicySource: models.DelinquencyPolicySourceBuiltIn,
+		MatchedRules: []string{},
+	}, nil)
+
+	// Execute
+	response, err := service.GetDelinquencyStatus(ctx, "loan_123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, response.IsDelinquent)
+	assert.Equal(t, 226105.00, response.OverdueAmount)
+	assert.Equal(t, 116105.00, response.RequiredPaymentAmount)
+}
+
 func TestLoanQueryService_GetDelinquencyStatus_NotDelinquent(t *testing.T) {
 	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
-	service := NewLoanQueryService(mockRepo)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
 	ctx := context.Background()
 
 	loanSummary := &models.LoanSummary{
@@ -173,6 +284,11 @@ func TestLoanQueryService_GetDelinquencyStatus_NotDelinquent(t *testing.T) {
 	// Mock repository calls
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
 	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{
+		IsDelinquent: false,
+		PolicySource: models.DelinquencyPolicySourceBuiltIn,
+		MatchedRules: []string{},
+	}, nil)
 
 	// Execute
 	response, err := service.GetDelinquencyStatus(ctx, "loan_123")
@@ -191,21 +307,80 @@ func TestLoanQueryService_GetDelinquencyStatus_NotDelinquent(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-func TestLoanQueryService_GetLoanSchedule_Success(t *testing.T) {
+func TestLoanQueryService_GetDelinquencyStatus_OverdueButNotDelinquent(t *testing.T) {
 	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
-	service := NewLoanQueryService(mockRepo)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
 	ctx := context.Background()
 
 	loanSummary := &models.LoanSummary{
 		LoanID:            "loan_123",
 		CustomerID:        "customer_123",
-		PrincipalAmount:   5000000.00,
+		ProductCode:       "WEEKLY_50",
 		OutstandingAmount: 5280000.00,
 		InstallmentAmount: 110000.00,
-		NoOfInstallment:   50,
 		InstallmentUnit:   "week",
 	}
 
+	overdueSchedules := []*models.PaymentSchedule{
+		{
+			ID:                1,
+			InstallmentNumber: 1,
+			InstallmentAmount: 110000.00,
+			Status:            models.StatusPending,
+		},
+		{
+			ID:                3,
+			InstallmentNumber: 3,
+			InstallmentAmount: 110000.00,
+			Status:            models.StatusPending,
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{
+		IsDelinquent: false,
+		Dpd:          14,
+		PolicySource: models.DelinquencyPolicySourceProduct,
+		MatchedRules: []string{},
+	}, nil)
+
+	// Execute
+	response, err := service.GetDelinquencyStatus(ctx, "loan_123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.False(t, response.IsDelinquent)
+	assert.Equal(t, 2, response.OverdueInstallments)
+	assert.Equal(t, 220000.00, response.OverdueAmount)
+	assert.Equal(t, 110000.00, response.RequiredPaymentAmount) // Only the oldest overdue installment
+
+	mockRepo.AssertExpectations(t)
+	mockDelinquency.AssertExpectations(t)
+}
+
+func TestLoanQueryService_GetLoanSchedule_Success(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{
+		LoanID:                "loan_123",
+		CustomerID:            "customer_123",
+		PrincipalAmount:       5000000.00,
+		OutstandingAmount:     5280000.00,
+		InstallmentAmount:     110000.00,
+		NoOfInstallment:       50,
+		InstallmentUnit:       "week",
+		Currency:              "USD",
+		AnnualPercentageRate:  0.197793,
+		EffectiveInterestRate: 0.218253,
+	}
+
 	paidDate := time.Now().AddDate(0, 0, -7)
 	paymentSchedules := []*models.PaymentSchedule{
 		{
@@ -243,8 +418,14 @@ func TestLoanQueryService_GetLoanSchedule_Success(t *testing.T) {
 	assert.Equal(t, 110000.00, response.LoanSummary.InstallmentAmount)
 	assert.Equal(t, 5000000.00, response.LoanSummary.DisbursedAmount)
 	assert.Equal(t, 5280000.00, response.LoanSummary.OutstandingAmount)
+	assert.Equal(t, "USD", response.Currency)
+	assert.Equal(t, "USD", response.LoanSummary.Currency)
+	assert.Equal(t, 0.197793, response.LoanSummary.AnnualPercentageRate)
+	assert.Equal(t, 0.218253, response.LoanSummary.EffectiveInterestRate)
 
 	assert.Len(t, response.Schedule, 2)
+	assert.Equal(t, "USD", response.Schedule[0].Currency)
+	assert.Equal(t, "USD", response.Schedule[1].Currency)
 
 	// Check first schedule (paid)
 	assert.Equal(t, 1, response.Schedule[0].InstallmentNumber)
@@ -265,7 +446,8 @@ func TestLoanQueryService_GetLoanSchedule_Success(t *testing.T) {
 
 func TestLoanQueryService_GetLoanSchedule_RepositoryError(t *testing.T) {
 	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
-	service := NewLoanQueryService(mockRepo)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
 	ctx := context.Background()
 
 	// Mock repository error
@@ -281,3 +463,275 @@ func TestLoanQueryService_GetLoanSchedule_RepositoryError(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestLoanQueryService_GetLoanCollectibility_Success(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	currentDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
+	previousDate := time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{
+		LoanID:             "loan_123",
+		Dpd:                98,
+		Collectibility:     models.CollectibilitySubstandard,
+		CollectibilityDate: &currentDate,
+	}
+
+	history := []*models.LoanCollectibility{
+		{
+			LoanID:            "loan_123",
+			Collectibility:    models.CollectibilitySubstandard,
+			Dpd:               98,
+			OutstandingAmount: 3300000.00,
+			EffectiveFrom:     currentDate,
+		},
+		{
+			LoanID:            "loan_123",
+			Collectibility:    models.CollectibilityCurrent,
+			OutstandingAmount: 5500000.00,
+			EffectiveFrom:     previousDate,
+			EffectiveTo:       &currentDate,
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetCollectibilityHistoryByLoanID", ctx, "loan_123").Return(history, nil)
+
+	// Execute
+	response, err := service.GetLoanCollectibility(ctx, "loan_123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "loan_123", response.LoanID)
+	assert.Equal(t, models.CollectibilitySubstandard, response.Current.Collectibility)
+	assert.Equal(t, "Kurang Lancar", response.Current.Label)
+	assert.Equal(t, 98, response.Current.Dpd)
+	assert.Len(t, response.History, 2)
+	assert.Nil(t, response.History[0].EffectiveTo)
+	assert.Equal(t, "Lancar", response.History[1].Label)
+	assert.Equal(t, currentDate, *response.History[1].EffectiveTo)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoanQueryService_ListCustomerLoans_FirstPage(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, mockTax)
+	ctx := context.Background()
+
+	req := &models.CustomerLoanListRequest{
+		Status: []string{models.StatusPending, models.StatusDelinquent},
+		Limit:  2,
+	}
+
+	// Mock repository calls, one loan more than the page size
+	mockRepo.On("GetLoanSummariesByCustomerID", ctx, "customer_123", req.Status, uint(0), 3).Return([]*models.LoanSummary{
+		{ID: 30, LoanID: "loan_3", CustomerID: "customer_123", Status: models.StatusPending, OutstandingAmount: 1100000.00},
+		{ID: 20, LoanID: "loan_2", CustomerID: "customer_123", Status: models.StatusDelinquent, OutstandingAmount: 550000.00, Dpd: 45, Currency: "USD"},
+		{ID: 10, LoanID: "loan_1", CustomerID: "customer_123", Status: models.StatusPending, OutstandingAmount: 220000.00},
+	}, nil)
+	mockRepo.On("GetCustomerLoanTotals", ctx, "customer_123").Return([]*models.CustomerCurrencyTotal{
+		{Currency: models.CurrencyIDR, ActiveLoans: 2, WorstDpd: 3, OutstandingAmount: 1320000.00},
+		{Currency: "USD", ActiveLoans: 1, WorstDpd: 45, OutstandingAmount: 550000.00},
+	}, nil)
+	// The USD installments carry a penalty, taxed like the repayment charges it
+	mockRepo.On("GetOverduePaymentSchedulesByCustomerID", ctx, "customer_123", mock.AnythingOfType("time.Time")).Return([]*models.PaymentSchedule{
+		{LoanID: "loan_1", InstallmentNumber: 1, InstallmentAmount: 110000.00, Currency: models.CurrencyIDR},
+		{LoanID: "loan_2", InstallmentNumber: 1, InstallmentAmount: 50000.00, PenaltyAmount: 500.00, Currency: "USD"},
+		{LoanID: "loan_2", InstallmentNumber: 2, InstallmentAmount: 50000.00, PenaltyAmount: 500.00, Currency: "USD"},
+	}, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 500.00, "USD").Return(&models.TaxLine{TaxAmount: 55.00})
+
+	// Execute
+	response, err := service.ListCustomerLoans(ctx, "customer_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "customer_123", response.CustomerID)
+	assert.Len(t, response.Loans, 2)
+	assert.Equal(t, "loan_3", response.Loans[0].LoanID)
+	assert.Equal(t, models.CurrencyIDR, response.Loans[0].Currency)
+	assert.Equal(t, "USD", response.Loans[1].Currency)
+	assert.NotEmpty(t, response.NextCursor)
+
+	assert.Equal(t, 3, response.Summary.ActiveLoans)
+	assert.Equal(t, 45, response.Summary.WorstDpd)
+	assert.Len(t, response.Summary.Totals, 2)
+	assert.Equal(t, 110000.00, response.Summary.Totals[0].OverdueAmount)
+	assert.Equal(t, 101110.00, response.Summary.Totals[1].OverdueAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoanQueryService_ListCustomerLoans_NextPage(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	req := &models.CustomerLoanListRequest{
+		Limit:  2,
+		Cursor: pagination.EncodeCursor(pagination.Cursor{ID: 20}),
+	}
+
+	// Mock repository calls, the last page
+	mockRepo.On("GetLoanSummariesByCustomerID", ctx, "customer_123", []string(nil), uint(20), 3).Return([]*models.LoanSummary{
+		{ID: 10, LoanID: "loan_1", CustomerID: "customer_123", Status: models.StatusPaid},
+	}, nil)
+	mockRepo.On("GetCustomerLoanTotals", ctx, "customer_123").Return([]*models.CustomerCurrencyTotal{}, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByCustomerID", ctx, "customer_123", mock.AnythingOfType("time.Time")).Return([]*models.PaymentSchedule{}, nil)
+
+	// Execute
+	response, err := service.ListCustomerLoans(ctx, "customer_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Loans, 1)
+	assert.Empty(t, response.NextCursor)
+	assert.Equal(t, 0, response.Summary.ActiveLoans)
+	assert.Empty(t, response.Summary.Totals)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoanQueryService_ListCustomerLoans_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	// Mock repository error
+	mockRepo.On("GetLoanSummariesByCustomerID", ctx, "customer_123", []string(nil), uint(0), pagination.DefaultLimit+1).Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.ListCustomerLoans(ctx, "customer_123", &models.CustomerLoanListRequest{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to get customer loans")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoanQueryService_SearchLoans_FirstPage(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	req := &models.LoanSearchRequest{
+		Status:          []string{models.StatusDelinquent},
+		InstallmentUnit: "week",
+		StartDateFrom:   "2025-01-01",
+		StartDateTo:     "2025-06-30",
+		DpdBucket:       models.DpdBucket31To60,
+		SortBy:          models.LoanSortOutstandingAmount,
+		Limit:           2,
+	}
+
+	// Mock repository calls, one loan more than the page size
+	mockRepo.On("SearchLoanSummaries", ctx, mock.MatchedBy(func(filter *models.LoanSearchFilter) bool {
+		return filter.InstallmentUnit == "week" && filter.SortBy == models.LoanSortOutstandingAmount && filter.SortDesc &&
+			*filter.MinDpd == 31 && *filter.MaxDpd == 60 &&
+			filter.StartDateFrom.Format("2006-01-02") == "2025-01-01" && filter.StartDateTo.Format("2006-01-02") == "2025-06-30" &&
+			filter.AfterID == 0
+	}), 3).Return([]*models.LoanSummary{
+		{ID: 7, LoanID: "loan_7", Status: models.StatusDelinquent, OutstandingAmount: 5000000.00, Dpd: 40},
+		{ID: 3, LoanID: "loan_3", Status: models.StatusDelinquent, OutstandingAmount: 2500000.00, Dpd: 35},
+		{ID: 9, LoanID: "loan_9", Status: models.StatusDelinquent, OutstandingAmount: 2500000.00, Dpd: 52},
+	}, nil)
+
+	// Execute
+	response, err := service.SearchLoans(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Loans, 2)
+	assert.Equal(t, "loan_7", response.Loans[0].LoanID)
+
+	cursor, err := pagination.DecodeCursor(response.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), cursor.ID)
+	assert.Equal(t, "2500000.00", cursor.Value)
+	assert.Equal(t, "outstanding_amount:desc", cursor.Sort)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoanQueryService_SearchLoans_NextPage(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	req := &models.LoanSearchRequest{
+		DpdBucket: models.DpdBucketOver90,
+		SortBy:    models.LoanSortLoanStartDate,
+		SortOrder: "asc",
+		Cursor:    pagination.EncodeCursor(pagination.Cursor{ID: 12, Value: "2025-03-01", Sort: "loan_start_date:asc"}),
+	}
+
+	// Mock repository calls, the last page
+	mockRepo.On("SearchLoanSummaries", ctx, mock.MatchedBy(func(filter *models.LoanSearchFilter) bool {
+		return !filter.SortDesc && *filter.MinDpd == 91 && filter.MaxDpd == nil &&
+			filter.AfterID == 12 && filter.AfterValue == "2025-03-01"
+	}), pagination.DefaultLimit+1).Return([]*models.LoanSummary{
+		{ID: 15, LoanID: "loan_15", Status: models.StatusDelinquent, Dpd: 120},
+	}, nil)
+
+	// Execute
+	response, err := service.SearchLoans(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Loans, 1)
+	assert.Empty(t, response.NextCursor)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoanQueryService_SearchLoans_CursorSortMismatch(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	req := &models.LoanSearchRequest{
+		SortBy: models.LoanSortDpd,
+		Cursor: pagination.EncodeCursor(pagination.Cursor{ID: 12, Value: "2025-03-01", Sort: "loan_start_date:asc"}),
+	}
+
+	// Execute
+	response, err := service.SearchLoans(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "cursor does not match")
+}
+
+func TestLoanQueryService_SearchLoans_InvalidDateRange(t *testing.T) {
+	mockRepo := mocks.NewLoanQueryMySQLRepositoryInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	service := NewLoanQueryService(mockRepo, mockDelinquency, taxMocks.NewTaxServiceInterface(t))
+	ctx := context.Background()
+
+	req := &models.LoanSearchRequest{
+		StartDateFrom: "2025-06-30",
+		StartDateTo:   "2025-01-01",
+	}
+
+	// Execute
+	response, err := service.SearchLoans(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "start_date_to must not be before start_date_from")
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"time"
 
+	disbursementGateway "billing-engine/disbursement/gateway/payout"
+	virtualAccountProvider "billing-engine/disbursement/gateway/virtualaccount"
 	disbursementHTTPHandler "billing-engine/disbursement/handler/http"
 	disbursementRepository "billing-engine/disbursement/repository/mysql"
 	disbursementService "billing-engine/disbursement/service"
@@ -18,8 +22,73 @@ import (
 	loanQueryRepository "billing-engine/loan_query/repository/mysql"
 	loanQueryService "billing-engine/loan_query/service"
 
+	accrualHTTPHandler "billing-engine/accrual/handler/http"
+	accrualRepository "billing-engine/accrual/repository/mysql"
+	accrualService "billing-engine/accrual/service"
+	bankReconciliationHTTPHandler "billing-engine/bank_reconciliation/handler/http"
+	bankReconciliationRepository "billing-engine/bank_reconciliation/repository/mysql"
+	bankReconciliationService "billing-engine/bank_reconciliation/service"
+	batchHTTPHandler "billing-engine/batch/handler/http"
+	batchRepository "billing-engine/batch/repository/mysql"
+	batchService "billing-engine/batch/service"
+	batchStep "billing-engine/batch/step"
+	cancellationHTTPHandler "billing-engine/cancellation/handler/http"
+	cancellationRepository "billing-engine/cancellation/repository/mysql"
+	cancellationService "billing-engine/cancellation/service"
+	collectibilityHTTPHandler "billing-engine/collectibility/handler/http"
+	collectibilityRepository "billing-engine/collectibility/repository/mysql"
+	collectibilityService "billing-engine/collectibility/service"
+	dailyReportHTTPHandler "billing-engine/daily_report/handler/http"
+	dailyReportRepository "billing-engine/daily_report/repository/mysql"
+	dailyReportService "billing-engine/daily_report/service"
+	deferralHTTPHandler "billing-engine/deferral/handler/http"
+	deferralRepository "billing-engine/deferral/repository/mysql"
+	deferralService "billing-engine/deferral/service"
+	delinquencyHTTPHandler "billing-engine/delinquency/handler/http"
+	delinquencyRepository "billing-engine/delinquency/repository/mysql"
+	delinquencyService "billing-engine/delinquency/service"
+	feeHTTPHandler "billing-engine/fee/handler/http"
+	feeRepository "billing-engine/fee/repository/mysql"
+	feeService "billing-engine/fee/service"
+	journalExportHTTPHandler "billing-engine/journal_export/handler/http"
+	journalExportRepository "billing-engine/journal_export/repository/mysql"
+	journalExportService "billing-engine/journal_export/service"
+	ledgerHTTPHandler "billing-engine/ledger/handler/http"
+	ledgerRepository "billing-engine/ledger/repository/mysql"
+	ledgerService "billing-engine/ledger/service"
+	paymentNotificationHTTPHandler "billing-engine/payment_notification/handler/http"
+	paymentNotificationProvider "billing-engine/payment_notification/provider"
+	paymentNotificationRepository "billing-engine/payment_notification/repository/mysql"
+	paymentNotificationService "billing-engine/payment_notification/service"
+	penaltyRepository "billing-engine/penalty/repository/mysql"
+	penaltyService "billing-engine/penalty/service"
+	provisioningHTTPHandler "billing-engine/provisioning/handler/http"
+	provisioningRepository "billing-engine/provisioning/repository/mysql"
+	provisioningService "billing-engine/provisioning/service"
+	reminderSender "billing-engine/reminder/gateway/sender"
+	reminderRepository "billing-engine/reminder/repository/mysql"
+	reminderService "billing-engine/reminder/service"
+	restructureHTTPHandler "billing-engine/restructure/handler/http"
+	restructureRepository "billing-engine/restructure/repository/mysql"
+	restructureService "billing-engine/restructure/service"
+	suspenseHTTPHandler "billing-engine/suspense/handler/http"
+	suspenseRepository "billing-engine/suspense/repository/mysql"
+	suspenseService "billing-engine/suspense/service"
+	taxHTTPHandler "billing-engine/tax/handler/http"
+	taxRepository "billing-engine/tax/repository/mysql"
+	taxService "billing-engine/tax/service"
+	virtualAccountRepository "billing-engine/virtual_account/repository/mysql"
+	virtualAccountService "billing-engine/virtual_account/service"
+	writeOffHTTPHandler "billing-engine/write_off/handler/http"
+	writeOffRepository "billing-engine/write_off/repository/mysql"
+	writeOffService "billing-engine/write_off/service"
+
+	"billing-engine/batch"
 	"billing-engine/global"
 	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/payment_notification"
+	"billing-engine/utils/scheduler"
 
 	"github.com/labstack/echo/v4"
 	"github.com/spf13/viper"
@@ -54,6 +123,30 @@ func main() {
 	viper.SetDefault("db_pass", getEnv("DB_PASSWORD", "billing_password"))
 	viper.SetDefault("private_jwt_access_token_secret", getEnv("JWT_SECRET", "default-secret-key"))
 	viper.SetDefault("private_jwt_refresh_token_secret", getEnv("JWT_REFRESH_SECRET", "default-refresh-secret-key"))
+	viper.SetDefault("daily_job_time", getEnv("DAILY_JOB_TIME", "00:30"))
+	viper.SetDefault("collectibility_dpd_thresholds", getEnv("COLLECTIBILITY_DPD_THRESHOLDS", "0,90,120,180"))
+	viper.SetDefault("write_off_min_dpd", getEnv("WRITE_OFF_MIN_DPD", "180"))
+	viper.SetDefault("cooling_off_days", getEnv("COOLING_OFF_DAYS", "14"))
+	viper.SetDefault("payout_gateway_url", getEnv("PAYOUT_GATEWAY_URL", "http://localhost:9010"))
+	viper.SetDefault("payout_max_retries", getEnv("PAYOUT_MAX_RETRIES", "3"))
+	viper.SetDefault("payout_sync_interval", getEnv("PAYOUT_SYNC_INTERVAL", "1m"))
+	viper.SetDefault("payout_webhook_token", getEnv("PAYOUT_WEBHOOK_TOKEN", ""))
+	viper.SetDefault("virtual_account_bank", getEnv("VIRTUAL_ACCOUNT_BANK", "BCA"))
+	viper.SetDefault("virtual_account_prefix", getEnv("VIRTUAL_ACCOUNT_PREFIX", "88088"))
+	viper.SetDefault("payment_aggregator_secret", getEnv("PAYMENT_AGGREGATOR_SECRET", ""))
+	viper.SetDefault("payment_snap_public_key_file", getEnv("PAYMENT_SNAP_PUBLIC_KEY_FILE", ""))
+	viper.SetDefault("ppn_rate", getEnv("PPN_RATE", "0.11"))
+	viper.SetDefault("ppn_taxable_charges", getEnv("PPN_TAXABLE_CHARGES", "ADMIN,PROVISION,PENALTY"))
+	viper.SetDefault("customer_repayment_policy", getEnv("CUSTOMER_REPAYMENT_POLICY", "OLDEST_OVERDUE_FIRST"))
+	viper.SetDefault("interest_accrual_method", getEnv("INTEREST_ACCRUAL_METHOD", "STRAIGHT_LINE"))
+	viper.SetDefault("accrual_suspend_collectibility", getEnv("ACCRUAL_SUSPEND_COLLECTIBILITY", "3"))
+	viper.SetDefault("penalty_daily_rate", getEnv("PENALTY_DAILY_RATE", "0.001"))
+	viper.SetDefault("reminder_days_before_due", getEnv("REMINDER_DAYS_BEFORE_DUE", "3"))
+	viper.SetDefault("reminder_overdue_days", getEnv("REMINDER_OVERDUE_DAYS", "1,7,30"))
+	viper.SetDefault("batch_workers", getEnv("BATCH_WORKERS", "4"))
+	viper.SetDefault("batch_chunk_size", getEnv("BATCH_CHUNK_SIZE", "100"))
+	viper.SetDefault("gl_account_mapping", getEnv("GL_ACCOUNT_MAPPING", ""))
+	viper.SetDefault("provision_stage_dpd_thresholds", getEnv("PROVISION_STAGE_DPD_THRESHOLDS", "30,90"))
 
 	if err := viper.Unmarshal(&configuration); err != nil {
 		panic("Unable to decode configuration into struct")
@@ -81,21 +174,245 @@ func main() {
 		return ec.JSON(http.StatusOK, map[string]interface{}{"message": "Billing Engine is live"})
 	})
 
+	// Initialize fee schedule module
+	feeRepo := feeRepository.NewFeeMySQLRepository(mysqlDb)
+	feeSvc := feeService.NewFeeService(feeRepo)
+	feeHTTPHandler.NewFeeHandler(newEcho, feeSvc, middlewares)
+
+	// Initialize tax module
+	if configuration.PpnRate < 0 || configuration.PpnRate >= 1 {
+		panic("Invalid tax configuration: ppn rate must be between 0 and 1")
+	}
+	taxableCharges, err := taxService.ParseTaxableChargeTypes(configuration.PpnTaxableCharges)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid tax configuration: %v", err))
+	}
+	taxRepo := taxRepository.NewTaxMySQLRepository(mysqlDb)
+	taxSvc := taxService.NewTaxService(taxRepo, configuration.PpnRate, taxableCharges)
+	taxHTTPHandler.NewTaxHandler(newEcho, taxSvc, middlewares)
+
+	// Initialize ledger module
+	ledgerRepo := ledgerRepository.NewLedgerMySQLRepository(mysqlDb)
+	ledgerSvc := ledgerService.NewLedgerService(ledgerRepo)
+	ledgerHTTPHandler.NewLedgerHandler(newEcho, ledgerSvc, middlewares)
+
+	// Initialize journal export module
+	glAccountMapping, err := journalExportService.ParseAccountMapping(configuration.GlAccountMapping)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid journal export configuration: %v", err))
+	}
+	journalExportRepo := journalExportRepository.NewJournalExportMySQLRepository(mysqlDb)
+	journalExportSvc := journalExportService.NewJournalExportService(journalExportRepo, glAccountMapping)
+	journalExportHTTPHandler.NewJournalExportHandler(newEcho, journalExportSvc, middlewares)
+
+	// Initialize interest accrual module
+	accrualMethod, err := accrualService.ParseAccrualMethod(configuration.InterestAccrualMethod)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid accrual configuration: %v", err))
+	}
+	if configuration.AccrualSuspendCollectibility < models.CollectibilitySpecialMention || configuration.AccrualSuspendCollectibility > models.CollectibilityLoss {
+		panic("Invalid accrual configuration: suspend collectibility must be between 2 and 5")
+	}
+	accrualRepo := accrualRepository.NewAccrualMySQLRepository(mysqlDb)
+	accrualSvc := accrualService.NewAccrualService(accrualRepo, ledgerSvc, accrualMethod, configuration.AccrualSuspendCollectibility)
+	accrualHTTPHandler.NewAccrualHandler(newEcho, accrualSvc, middlewares)
+
 	// Initialize disbursement module
 	disbursementRepo := disbursementRepository.NewDisbursementMySQLRepository(mysqlDb)
-	disbursementSvc := disbursementService.NewDisbursementService(disbursementRepo)
-	disbursementHTTPHandler.NewDisbursementHandler(newEcho, disbursementSvc, middlewares)
+	payoutGw := disbursementGateway.NewHTTPPayoutGateway(configuration.PayoutGatewayURL, 10*time.Second, configuration.PayoutMaxRetries, 500*time.Millisecond)
+	if err := virtualAccountProvider.ValidatePrefix(configuration.VirtualAccountPrefix); err != nil {
+		panic(fmt.Sprintf("Invalid virtual account configuration: %v", err))
+	}
+	vaProvider := virtualAccountProvider.NewFakeVirtualAccountProvider(configuration.VirtualAccountBank, configuration.VirtualAccountPrefix)
+	disbursementSvc := disbursementService.NewDisbursementService(disbursementRepo, feeSvc, taxSvc, ledgerSvc, payoutGw, vaProvider)
+	disbursementHTTPHandler.NewDisbursementHandler(newEcho, disbursementSvc, middlewares, configuration.PayoutWebhookToken)
+
+	// Initialize collectibility module
+	dpdThresholds, err := collectibilityService.ParseDpdThresholds(configuration.CollectibilityDpdThresholds)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid collectibility configuration: %v", err))
+	}
+	collectibilityClassifier, err := collectibilityService.NewClassifier(dpdThresholds)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid collectibility configuration: %v", err))
+	}
+	collectibilityRepo := collectibilityRepository.NewCollectibilityMySQLRepository(mysqlDb)
+	collectibilitySvc := collectibilityService.NewCollectibilityService(collectibilityRepo, collectibilityClassifier)
+	collectibilityHTTPHandler.NewCollectibilityHandler(newEcho, collectibilitySvc, middlewares)
+
+	// Initialize delinquency policy module
+	delinquencyRepo := delinquencyRepository.NewDelinquencyMySQLRepository(mysqlDb)
+	delinquencySvc := delinquencyService.NewDelinquencyService(delinquencyRepo)
+	delinquencyHTTPHandler.NewDelinquencyHandler(newEcho, delinquencySvc, middlewares)
+
+	// Initialize write-off module
+	writeOffRepo := writeOffRepository.NewWriteOffMySQLRepository(mysqlDb)
+	writeOffSvc := writeOffService.NewWriteOffService(writeOffRepo, collectibilitySvc, ledgerSvc, configuration.WriteOffMinDpd)
+	writeOffHTTPHandler.NewWriteOffHandler(newEcho, writeOffSvc, middlewares)
+
+	// Initialize restructure module
+	restructureRepo := restructureRepository.NewRestructureMySQLRepository(mysqlDb)
+	restructureSvc := restructureService.NewRestructureService(restructureRepo, collectibilitySvc, taxSvc, ledgerSvc)
+	restructureHTTPHandler.NewRestructureHandler(newEcho, restructureSvc, middlewares)
+
+	// Initialize deferral module
+	deferralRepo := deferralRepository.NewDeferralMySQLRepository(mysqlDb)
+	deferralSvc := deferralService.NewDeferralService(deferralRepo, collectibilitySvc, ledgerSvc)
+	deferralHTTPHandler.NewDeferralHandler(newEcho, deferralSvc, middlewares)
+
+	// Initialize cancellation module
+	cancellationRepo := cancellationRepository.NewCancellationMySQLRepository(mysqlDb)
+	cancellationSvc := cancellationService.NewCancellationService(cancellationRepo, ledgerSvc, vaProvider, configuration.CoolingOffDays)
+	cancellationHTTPHandler.NewCancellationHandler(newEcho, cancellationSvc, middlewares)
 
 	// Initialize repayment module
 	repaymentRepo := repaymentRepository.NewRepaymentMySQLRepository(mysqlDb)
-	repaymentSvc := repaymentService.NewRepaymentService(repaymentRepo)
-	repaymentHTTPHandler.NewRepaymentHandler(newEcho, repaymentSvc, middlewares)
+	allocationPolicy, err := repaymentService.ParseAllocationPolicy(configuration.CustomerRepaymentPolicy)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid repayment configuration: %v", err))
+	}
+	repaymentSvc := repaymentService.NewRepaymentService(repaymentRepo, collectibilitySvc, delinquencySvc, writeOffSvc, taxSvc, ledgerSvc, allocationPolicy)
+
+	// Initialize suspense module; payments the repayment API rejects are held in suspense
+	suspenseRepo := suspenseRepository.NewSuspenseMySQLRepository(mysqlDb)
+	suspenseSvc := suspenseService.NewSuspenseService(suspenseRepo, repaymentSvc, ledgerSvc)
+	suspenseHTTPHandler.NewSuspenseHandler(newEcho, suspenseSvc, middlewares)
+	repaymentHTTPHandler.NewRepaymentHandler(newEcho, suspenseService.NewSuspenseRepaymentService(repaymentSvc, suspenseSvc, suspenseRepo, models.SuspenseSourceRepaymentAPI), middlewares)
+
+	// Initialize payment notification module; providers without credentials are not accepted
+	virtualAccountRepo := virtualAccountRepository.NewVirtualAccountMySQLRepository(mysqlDb)
+	virtualAccountSvc := virtualAccountService.NewVirtualAccountService(virtualAccountRepo, repaymentSvc, suspenseSvc)
+	var paymentProviders []payment_notification.PaymentProviderInterface
+	if configuration.PaymentAggregatorSecret != "" {
+		paymentProviders = append(paymentProviders, paymentNotificationProvider.NewAggregatorProvider(configuration.PaymentAggregatorSecret))
+	}
+	if configuration.PaymentSnapPublicKeyFile != "" {
+		pemBytes, err := os.ReadFile(configuration.PaymentSnapPublicKeyFile)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid payment notification configuration: %v", err))
+		}
+		snapPublicKey, err := paymentNotificationProvider.ParseRSAPublicKey(pemBytes)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid payment notification configuration: %v", err))
+		}
+		paymentProviders = append(paymentProviders, paymentNotificationProvider.NewSNAPProvider(snapPublicKey))
+	}
+	paymentNotificationRepo := paymentNotificationRepository.NewPaymentNotificationMySQLRepository(mysqlDb)
+	paymentNotificationSvc := paymentNotificationService.NewPaymentNotificationService(paymentNotificationRepo, virtualAccountSvc, repaymentSvc, suspenseSvc)
+	paymentNotificationHTTPHandler.NewPaymentNotificationHandler(newEcho, paymentNotificationSvc, middlewares, paymentProviders)
+
+	// Initialize provisioning module
+	stageDpdThresholds, err := provisioningService.ParseStageDpdThresholds(configuration.ProvisionStageDpdThresholds)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid provisioning configuration: %v", err))
+	}
+	provisionStager, err := provisioningService.NewStager(stageDpdThresholds)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid provisioning configuration: %v", err))
+	}
+	provisioningRepo := provisioningRepository.NewProvisioningMySQLRepository(mysqlDb)
+	provisioningSvc := provisioningService.NewProvisioningService(provisioningRepo, provisionStager)
+	provisioningHTTPHandler.NewProvisioningHandler(newEcho, provisioningSvc, middlewares)
+
+	// Initialize bank statement reconciliation module
+	bankReconciliationRepo := bankReconciliationRepository.NewBankReconciliationMySQLRepository(mysqlDb)
+	bankReconciliationSvc := bankReconciliationService.NewBankReconciliationService(bankReconciliationRepo, repaymentSvc)
+	bankReconciliationHTTPHandler.NewBankReconciliationHandler(newEcho, bankReconciliationSvc, middlewares)
 
 	// Initialize loan query module
 	loanQueryRepo := loanQueryRepository.NewLoanQueryMySQLRepository(mysqlDb)
-	loanQuerySvc := loanQueryService.NewLoanQueryService(loanQueryRepo)
+	loanQuerySvc := loanQueryService.NewLoanQueryService(loanQueryRepo, delinquencySvc, taxSvc)
 	loanQueryHTTPHandler.NewLoanQueryHandler(newEcho, loanQuerySvc, middlewares)
 
+	// Initialize late payment penalty module
+	if configuration.PenaltyDailyRate < 0 || configuration.PenaltyDailyRate >= 1 {
+		panic("Invalid penalty configuration: daily rate must be between 0 and 1")
+	}
+	penaltyRepo := penaltyRepository.NewPenaltyMySQLRepository(mysqlDb)
+	penaltySvc := penaltyService.NewPenaltyService(penaltyRepo, configuration.PenaltyDailyRate)
+
+	// Initialize payment reminder module
+	if configuration.ReminderDaysBeforeDue < 0 {
+		panic("Invalid reminder configuration: days before due must not be negative")
+	}
+	reminderOverdueDays, err := reminderService.ParseOverdueDays(configuration.ReminderOverdueDays)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid reminder configuration: %v", err))
+	}
+	reminderRepo := reminderRepository.NewReminderMySQLRepository(mysqlDb)
+	reminderSvc := reminderService.NewReminderService(reminderRepo, reminderSender.NewLogReminderSender(), taxSvc, configuration.ReminderDaysBeforeDue, reminderOverdueDays)
+
+	// Initialize daily report module
+	dailyReportRepo := dailyReportRepository.NewDailyReportMySQLRepository(mysqlDb)
+	dailyReportSvc := dailyReportService.NewDailyReportService(dailyReportRepo, taxSvc)
+	dailyReportHTTPHandler.NewDailyReportHandler(newEcho, dailyReportSvc, middlewares)
+
+	// Initialize end-of-day batch module
+	if configuration.BatchWorkers < 1 || configuration.BatchChunkSize < 1 {
+		panic("Invalid batch configuration: workers and chunk size must be at least 1")
+	}
+	batchRepo := batchRepository.NewBatchMySQLRepository(mysqlDb)
+	// Collectibility runs first so accrual suspension follows the grades of the business date.
+	// Reminders go out once the penalties are assessed, and the daily report records the day last.
+	batchSteps := []batch.BatchStepInterface{
+		batchStep.NewCollectibilityStep(collectibilitySvc),
+		batchStep.NewDelinquencyStep(delinquencySvc),
+		batchStep.NewPenaltyStep(penaltySvc),
+		batchStep.NewInterestAccrualStep(accrualSvc),
+		batchStep.NewReminderStep(reminderSvc),
+		batchStep.NewDailyReportStep(dailyReportSvc),
+	}
+	// Provisions are run monthly, once the last day of the month is closed
+	batchHooks := []batch.BatchRunHookInterface{
+		batchStep.NewProvisioningHook(provisioningSvc),
+	}
+	batchSvc := batchService.NewBatchService(batchRepo, batchSteps, batchHooks, configuration.BatchWorkers, configuration.BatchChunkSize)
+	batchHTTPHandler.NewBatchHandler(newEcho, batchSvc, middlewares)
+
+	// Resume a run interrupted by a crash or restart
+	go func() {
+		if err := batchSvc.ResumeUnfinishedRuns(context.Background()); err != nil {
+			log.Printf("resuming batch runs failed: %v", err)
+		}
+	}()
+
+	// Start daily jobs
+	jobHour, jobMinute, err := scheduler.ParseTimeOfDay(configuration.DailyJobTime)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid daily job configuration: %v", err))
+	}
+	go scheduler.RunDaily(context.Background(), jobHour, jobMinute, func(ctx context.Context, runAt time.Time) {
+		if err := batchSvc.ResumeUnfinishedRuns(ctx); err != nil {
+			log.Printf("resuming batch runs failed: %v", err)
+		}
+
+		// The job runs after midnight, so it closes the previous day
+		result, err := batchSvc.RunEndOfDay(ctx, runAt.AddDate(0, 0, -1), "system")
+		if err != nil {
+			log.Printf("end-of-day batch failed: %v", err)
+			return
+		}
+		log.Printf("end-of-day batch %d for %s done: status=%s",
+			result.ID, result.BusinessDate.Format("2006-01-02"), result.Status)
+	})
+
+	// Submit and poll payouts that have not settled yet
+	payoutSyncInterval, err := time.ParseDuration(configuration.PayoutSyncInterval)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid payout sync configuration: %v", err))
+	}
+	go scheduler.RunEvery(context.Background(), payoutSyncInterval, func(ctx context.Context, runAt time.Time) {
+		result, err := disbursementSvc.SyncPayouts(ctx)
+		if err != nil {
+			log.Printf("payout sync failed: %v", err)
+			return
+		}
+		if result.CheckedDisbursements > 0 {
+			log.Printf("payout sync done: checked=%d disbursed=%d failed=%d errors=%d",
+				result.CheckedDisbursements, result.DisbursedLoans, result.FailedDisbursements, result.Errors)
+		}
+	})
+
 	newEcho.Logger.Fatal(newEcho.Start(fmt.Sprintf(":%s", configuration.HostPort)))
 }
 
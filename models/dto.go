@@ -10,78 +10,411 @@ type DisbursementRequest struct {
 	NumberOfInstallment int       `json:"number_of_installment" validate:"gt=0"`
 	StartDate           time.Time `json:"start_date" validate:"required"`
 	CustomerID          string    `json:"customer_id" validate:"required"`
+	ProductCode         string    `json:"product_code" validate:"omitempty,max=50"`
+	BankCode            string    `json:"bank_code" validate:"required,max=20"`
+	AccountNumber       string    `json:"account_number" validate:"required,max=50"`
+	Currency            string    `json:"currency" validate:"omitempty,iso4217"`
 }
 
+type DisbursementCallbackRequest struct {
+	Status              string    `json:"status" validate:"required,oneof=PROCESSING DISBURSED FAILED"`
+	Reference           string    `json:"reference" validate:"max=100"`
+	DisbursedAt         time.Time `json:"disbursed_at"`
+	FailureReason       string    `json:"failure_reason" validate:"required_if=Status FAILED,max=500"`
+	RecalculateSchedule bool      `json:"recalculate_schedule"`
+}
+
+// PayoutRequest is sent to the payout gateway to move the disbursed amount to the borrower.
+// ExternalID is the loan ID, which the gateway uses as idempotency key.
+type PayoutRequest struct {
+	ExternalID    string  `json:"external_id"`
+	BankCode      string  `json:"bank_code"`
+	AccountNumber string  `json:"account_number"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+}
+
+// VirtualAccountRequest asks the virtual account provider for an account number the borrower pays
+// the loan into. ExternalID is the loan ID, which the provider uses as idempotency key.
+type VirtualAccountRequest struct {
+	ExternalID string `json:"external_id"`
+	CustomerID string `json:"customer_id"`
+	Currency   string `json:"currency"`
+}
+
+// VirtualAccountPaymentRequest is a payment received into a virtual account. TransactionID is the
+// provider's reference of the transfer.
+type VirtualAccountPaymentRequest struct {
+	AccountNumber string  `json:"account_number" validate:"required,numeric,max=30"`
+	TransactionID string  `json:"transaction_id" validate:"required,max=100"`
+	Amount        float64 `json:"amount" validate:"gt=0"`
+	Currency      string  `json:"currency" validate:"omitempty,iso4217"`
+}
+
+// PaymentNotificationRequest is a payment callback after the provider's signature is verified and
+// its format parsed. A VIRTUAL_ACCOUNT payment names the account paid into, a QRIS payment the loan
+// in its reference. Payload keeps the raw callback.
+type PaymentNotificationRequest struct {
+	Provider      string  `json:"provider" validate:"required,max=30"`
+	TransactionID string  `json:"transaction_id" validate:"required,max=100"`
+	PaymentMethod string  `json:"payment_method" validate:"required,oneof=VIRTUAL_ACCOUNT QRIS"`
+	AccountNumber string  `json:"account_number" validate:"required_if=PaymentMethod VIRTUAL_ACCOUNT,max=30"`
+	LoanID        string  `json:"loan_id" validate:"max=50"`
+	Amount        float64 `json:"amount" validate:"gt=0"`
+	Currency      string  `json:"currency" validate:"omitempty,iso4217"`
+	Paid          bool    `json:"paid"`
+	Payload       string  `json:"-"`
+}
+
+// PayoutWebhookRequest is the settlement notification pushed by the payout gateway
+type PayoutWebhookRequest struct {
+	PayoutID      string     `json:"payout_id" validate:"required,max=100"`
+	ExternalID    string     `json:"external_id" validate:"required,max=50"`
+	Status        string     `json:"status" validate:"required,oneof=PENDING SUCCESS FAILED"`
+	FailureReason string     `json:"failure_reason" validate:"max=500"`
+	SettledAt     *time.Time `json:"settled_at"`
+}
+
+// RepaymentRequest is a payment on a loan. Reference identifies the transfer at the payer's side
+// and is kept on the suspense item when the payment cannot be applied.
 type RepaymentRequest struct {
 	LoanID        string  `json:"loan_id" validate:"required"`
 	PaymentAmount float64 `json:"payment_amount" validate:"gt=0"`
+	Currency      string  `json:"currency" validate:"omitempty,iso4217"`
+	Reference     string  `json:"reference" validate:"max=255"`
+}
+
+// PaymentRejectionError is returned when a payment cannot be applied to the loan as it is, so the
+// funds have to be held in suspense. ReasonCode is one of the suspense reason codes and Currency
+// the loan's currency when the loan is known.
+type PaymentRejectionError struct {
+	ReasonCode string
+	Currency   string
+	Message    string
+}
+
+func (e *PaymentRejectionError) Error() string {
+	return e.Message
+}
+
+// CustomerRepaymentRequest is a single transfer spread across the customer's active loans in its
+// currency. Policy defaults to the configured allocation policy. Reference identifies the transfer
+// and is kept on the suspense item holding what the transfer leaves unallocated.
+type CustomerRepaymentRequest struct {
+	PaymentAmount float64 `json:"payment_amount" validate:"gt=0"`
+	Currency      string  `json:"currency" validate:"omitempty,iso4217"`
+	Policy        string  `json:"policy" validate:"omitempty,oneof=OLDEST_OVERDUE_FIRST HIGHEST_PENALTY_FIRST PRO_RATA"`
+	Reference     string  `json:"reference" validate:"max=255"`
+}
+
+// CustomerLoanListRequest holds the query parameters of the customer loan listing.
+// Statuses are passed as repeated status parameters.
+type CustomerLoanListRequest struct {
+	Status []string `query:"status" validate:"dive,oneof=INACTIVE PENDING PAID DELINQUENT WRITTEN_OFF CANCELLED"`
+	Limit  int      `query:"limit" validate:"gte=0,lte=100"`
+	Cursor string   `query:"cursor" validate:"omitempty,cursor"`
+}
+
+// LoanSearchRequest holds the query parameters of the loan search. Dates use the YYYY-MM-DD format
+// and a zero max_outstanding means no upper bound.
+type LoanSearchRequest struct {
+	Status          []string `query:"status" validate:"dive,oneof=INACTIVE PENDING PAID DELINQUENT WRITTEN_OFF CANCELLED"`
+	InstallmentUnit string   `query:"installment_unit" validate:"omitempty,oneof=week month"`
+	ProductCode     string   `query:"product_code" validate:"max=50"`
+	StartDateFrom   string   `query:"start_date_from" validate:"omitempty,datetime=2006-01-02"`
+	StartDateTo     string   `query:"start_date_to" validate:"omitempty,datetime=2006-01-02"`
+	DpdBucket       string   `query:"dpd_bucket" validate:"omitempty,oneof=DPD_0 DPD_1_30 DPD_31_60 DPD_61_90 DPD_90_PLUS"`
+	MinOutstanding  float64  `query:"min_outstanding" validate:"gte=0"`
+	MaxOutstanding  float64  `query:"max_outstanding" validate:"omitempty,gtefield=MinOutstanding"`
+	SortBy          string   `query:"sort_by" validate:"omitempty,oneof=id loan_start_date outstanding_amount dpd"`
+	SortOrder       string   `query:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Limit           int      `query:"limit" validate:"gte=0,lte=100"`
+	Cursor          string   `query:"cursor" validate:"omitempty,cursor"`
+}
+
+type CollectibilityEvaluationRequest struct {
+	AsOf time.Time `json:"as_of"`
+}
+
+type DelinquencyPolicyRequest struct {
+	Rules []DelinquencyRuleRequest `json:"rules" validate:"dive"`
+}
+
+type DelinquencyRuleRequest struct {
+	RuleType  string `json:"rule_type" validate:"required,oneof=OVERDUE_INSTALLMENTS CONSECUTIVE_MISSED DPD_ABOVE"`
+	Threshold int    `json:"threshold" validate:"gte=0"`
+}
+
+type FeeScheduleRequest struct {
+	Fees []FeeRuleRequest `json:"fees" validate:"dive"`
+}
+
+type FeeRuleRequest struct {
+	FeeType         string  `json:"fee_type" validate:"required,oneof=ADMIN PROVISION"`
+	CalculationType string  `json:"calculation_type" validate:"required,oneof=FIXED PERCENTAGE"`
+	Value           float64 `json:"value" validate:"gte=0"`
+	ChargeMethod    string  `json:"charge_method" validate:"required,oneof=DEDUCTED INSTALLMENT"`
+}
+
+type WriteOffRequest struct {
+	Reason     string `json:"reason" validate:"required,max=500"`
+	ApprovedBy string `json:"approved_by" validate:"required,max=255"`
+}
+
+type RestructureRequest struct {
+	InstallmentUnit     string    `json:"installment_unit" validate:"required,oneof=week month"`
+	NumberOfInstallment int       `json:"number_of_installment" validate:"gt=0"`
+	StartDate           time.Time `json:"start_date"`
+	CapitalisePenalties bool      `json:"capitalise_penalties"`
+	Reason              string    `json:"reason" validate:"required,max=500"`
+}
+
+type CancellationRequest struct {
+	Reason      string `json:"reason" validate:"required,max=500"`
+	CancelledBy string `json:"cancelled_by" validate:"required,max=255"`
+}
+
+type DeferralRequest struct {
+	Periods              int     `json:"periods" validate:"gt=0"`
+	FromInstallment      int     `json:"from_installment" validate:"gte=0"`
+	DeferralInterestRate float64 `json:"deferral_interest_rate" validate:"gte=0,lte=1"`
+	Reason               string  `json:"reason" validate:"required,max=500"`
 }
 
 // Response DTOs
 type DisbursementResponse struct {
-	LoanID              string    `json:"loan_id"`
-	CustomerID          string    `json:"customer_id"`
-	DisbursedAmount     float64   `json:"disbursed_amount"`
-	InstallmentAmount   float64   `json:"installment_amount"`
-	OutstandingAmount   float64   `json:"outstanding_amount"`
-	InstallmentUnit     string    `json:"installment_unit"`
-	NumberOfInstallment int       `json:"number_of_installment"`
-	DisbursementDate    time.Time `json:"disbursement_date"`
-	FirstDueDate        time.Time `json:"first_due_date"`
-	FinalDueDate        time.Time `json:"final_due_date"`
+	LoanID                string            `json:"loan_id"`
+	CustomerID            string            `json:"customer_id"`
+	Currency              string            `json:"currency"`
+	PrincipalAmount       float64           `json:"principal_amount"`
+	Fees                  []LoanFeeResponse `json:"fees"`
+	TotalFeeAmount        float64           `json:"total_fee_amount"`
+	Taxes                 []TaxLineResponse `json:"taxes"`
+	TotalTaxAmount        float64           `json:"total_tax_amount"`
+	NetDisbursedAmount    float64           `json:"net_disbursed_amount"`
+	DisbursedAmount       float64           `json:"disbursed_amount"`
+	InstallmentAmount     float64           `json:"installment_amount"`
+	OutstandingAmount     float64           `json:"outstanding_amount"`
+	AnnualPercentageRate  float64           `json:"annual_percentage_rate"`
+	EffectiveInterestRate float64           `json:"effective_interest_rate"`
+	InstallmentUnit       string            `json:"installment_unit"`
+	NumberOfInstallment   int               `json:"number_of_installment"`
+	VirtualAccountNumber  string            `json:"virtual_account_number"`
+	VirtualAccountBank    string            `json:"virtual_account_bank"`
+	DisbursementDate      time.Time         `json:"disbursement_date"`
+	FirstDueDate          time.Time         `json:"first_due_date"`
+	FinalDueDate          time.Time         `json:"final_due_date"`
+	Status                string            `json:"status"`
+}
+
+// LoanSimulationResponse is the loan a disbursement request would book; nothing is stored
+type LoanSimulationResponse struct {
+	CustomerID            string                    `json:"customer_id"`
+	ProductCode           string                    `json:"product_code"`
+	Currency              string                    `json:"currency"`
+	PrincipalAmount       float64                   `json:"principal_amount"`
+	InterestAmount        float64                   `json:"interest_amount"`
+	Fees                  []LoanFeeResponse         `json:"fees"`
+	TotalFeeAmount        float64                   `json:"total_fee_amount"`
+	Taxes                 []TaxLineResponse         `json:"taxes"`
+	TotalTaxAmount        float64                   `json:"total_tax_amount"`
+	NetDisbursedAmount    float64                   `json:"net_disbursed_amount"`
+	InstallmentAmount     float64                   `json:"installment_amount"`
+	TotalRepaymentAmount  float64                   `json:"total_repayment_amount"`
+	InstallmentUnit       string                    `json:"installment_unit"`
+	NumberOfInstallment   int                       `json:"number_of_installment"`
+	AnnualPercentageRate  float64                   `json:"annual_percentage_rate"`
+	EffectiveInterestRate float64                   `json:"effective_interest_rate"`
+	StartDate             time.Time                 `json:"start_date"`
+	FirstDueDate          time.Time                 `json:"first_due_date"`
+	FinalDueDate          time.Time                 `json:"final_due_date"`
+	Schedule              []PaymentScheduleResponse `json:"schedule"`
+}
+
+type TaxLineResponse struct {
+	TaxType       string  `json:"tax_type"`
+	ChargeType    string  `json:"charge_type"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxRate       float64 `json:"tax_rate"`
+	TaxAmount     float64 `json:"tax_amount"`
+	ChargeMethod  string  `json:"charge_method"`
+}
+
+type LoanFeeResponse struct {
+	FeeType         string  `json:"fee_type"`
+	CalculationType string  `json:"calculation_type"`
+	Value           float64 `json:"value"`
+	Amount          float64 `json:"amount"`
+	ChargeMethod    string  `json:"charge_method"`
+}
+
+// PayoutResult is the payout state returned by the payout gateway
+type PayoutResult struct {
+	PayoutID      string     `json:"payout_id"`
+	ExternalID    string     `json:"external_id"`
+	Status        string     `json:"status"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	SettledAt     *time.Time `json:"settled_at"`
+}
+
+// PayoutRejectionError is a payout the payout gateway refused for what the request holds, such as
+// an invalid beneficiary account. Submitting the same payout again gets the same answer.
+type PayoutRejectionError struct {
+	StatusCode int
+	Reason     string // the gateway's explanation
+	Message    string
+}
+
+func (e *PayoutRejectionError) Error() string {
+	return e.Message
+}
+
+// VirtualAccountResult is the virtual account issued by the virtual account provider
+type VirtualAccountResult struct {
+	AccountNumber string `json:"account_number"`
+	BankCode      string `json:"bank_code"`
+}
+
+// VirtualAccountPaymentResponse tells where a virtual account payment went: APPLIED to the loan, or
+// HELD in suspense when the loan could not take it
+type VirtualAccountPaymentResponse struct {
+	AccountNumber  string             `json:"account_number"`
+	TransactionID  string             `json:"transaction_id"`
+	LoanID         string             `json:"loan_id"`
+	Status         string             `json:"status"`
+	Repayment      *RepaymentResponse `json:"repayment,omitempty"`
+	SuspenseItemID uint               `json:"suspense_item_id,omitempty"`
+	Message        string             `json:"message,omitempty"`
+}
+
+// PaymentNotificationResponse is the outcome of a payment notification. Duplicate is set when the
+// provider transaction was processed before and the stored outcome is returned.
+type PaymentNotificationResponse struct {
+	Provider       string `json:"provider"`
+	TransactionID  string `json:"transaction_id"`
+	LoanID         string `json:"loan_id,omitempty"`
+	Status         string `json:"status"`
+	SuspenseItemID uint   `json:"suspense_item_id,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Duplicate      bool   `json:"duplicate"`
+}
+
+type PayoutSyncResponse struct {
+	CheckedDisbursements int `json:"checked_disbursements"`
+	DisbursedLoans       int `json:"disbursed_loans"`
+	FailedDisbursements  int `json:"failed_disbursements"`
+	Errors               int `json:"errors"`
+}
+
+type DisbursementStatusResponse struct {
+	LoanID           string     `json:"loan_id"`
+	Status           string     `json:"status"`
+	LoanStatus       string     `json:"loan_status"`
+	DisbursedAmount  float64    `json:"disbursed_amount"`
+	Currency         string     `json:"currency"`
+	DisbursementDate time.Time  `json:"disbursement_date"`
+	DisbursedAt      *time.Time `json:"disbursed_at"`
+	Reference        string     `json:"reference"`
+	FailureReason    string     `json:"failure_reason,omitempty"`
+	FirstDueDate     *time.Time `json:"first_due_date"`
 }
 
 type RepaymentResponse struct {
-	LoanID                string    `json:"loan_id"`
-	PaymentAmount         float64   `json:"payment_amount"`
-	InstallmentsPaid      int       `json:"installments_paid"`
-	InstallmentAmount     float64   `json:"installment_amount"`
-	RemainingInstallments int       `json:"remaining_installments"`
-	OutstandingAmount     float64   `json:"outstanding_amount"`
-	NextDueDate           time.Time `json:"next_due_date"`
-	PaymentDate           time.Time `json:"payment_date"`
+	LoanID                string                 `json:"loan_id"`
+	PaymentAmount         float64                `json:"payment_amount"`
+	Currency              string                 `json:"currency"`
+	PenaltyPaid           float64                `json:"penalty_paid"`
+	TaxPaid               float64                `json:"tax_paid"`
+	InstallmentsPaid      int                    `json:"installments_paid"`
+	InstallmentAmount     float64                `json:"installment_amount"`
+	RemainingInstallments int                    `json:"remaining_installments"`
+	OutstandingAmount     float64                `json:"outstanding_amount"`
+	NextDueDate           time.Time              `json:"next_due_date"`
+	PaymentDate           time.Time              `json:"payment_date"`
+	PaymentType           string                 `json:"payment_type"`
+	Collectibility        CollectibilityResponse `json:"collectibility"`
+}
+
+// CustomerRepaymentResponse breaks a customer-level repayment down per loan. The unallocated amount
+// is the part of the transfer that did not cover a whole installment on any loan. Duplicate is set
+// when the reference was applied before; the allocations are then the stored amounts, without the
+// balances the loans had after the payment.
+type CustomerRepaymentResponse struct {
+	CustomerID        string                        `json:"customer_id"`
+	PaymentAmount     float64                       `json:"payment_amount"`
+	Currency          string                        `json:"currency"`
+	Policy            string                        `json:"policy"`
+	AllocatedAmount   float64                       `json:"allocated_amount"`
+	UnallocatedAmount float64                       `json:"unallocated_amount"`
+	SuspenseItemID    *uint                         `json:"suspense_item_id,omitempty"` // item holding the unallocated amount
+	PaymentDate       time.Time                     `json:"payment_date"`
+	Duplicate         bool                          `json:"duplicate"`
+	Allocations       []CustomerRepaymentAllocation `json:"allocations"`
+}
+
+// CustomerRepaymentAllocation is what one loan received; the balances are those after the payment
+type CustomerRepaymentAllocation struct {
+	LoanID                string                 `json:"loan_id"`
+	AllocatedAmount       float64                `json:"allocated_amount"`
+	PenaltyPaid           float64                `json:"penalty_paid"`
+	TaxPaid               float64                `json:"tax_paid"`
+	InstallmentsPaid      int                    `json:"installments_paid"`
+	RemainingInstallments int                    `json:"remaining_installments"`
+	OutstandingAmount     float64                `json:"outstanding_amount"`
+	NextDueDate           time.Time              `json:"next_due_date"`
+	Collectibility        CollectibilityResponse `json:"collectibility"`
 }
 
 type OutstandingBalanceResponse struct {
-	LoanID                string              `json:"loan_id"`
-	CustomerID            string              `json:"customer_id"`
-	LoanDetails           LoanDetailsResponse `json:"loan_details"`
-	OutstandingAmount     float64             `json:"outstanding_amount"`
-	OverdueAmount         float64             `json:"overdue_amount"`
-	OverdueInstallments   int                 `json:"overdue_installments"`
-	PaidInstallments      int                 `json:"paid_installments"`
-	RemainingInstallments int                 `json:"remaining_installments"`
+	LoanID                string                 `json:"loan_id"`
+	CustomerID            string                 `json:"customer_id"`
+	Currency              string                 `json:"currency"`
+	LoanDetails           LoanDetailsResponse    `json:"loan_details"`
+	OutstandingAmount     float64                `json:"outstanding_amount"`
+	OverdueAmount         float64                `json:"overdue_amount"`
+	OverdueInstallments   int                    `json:"overdue_installments"`
+	PaidInstallments      int                    `json:"paid_installments"`
+	RemainingInstallments int                    `json:"remaining_installments"`
+	Collectibility        CollectibilityResponse `json:"collectibility"`
 }
 
 type LoanDetailsResponse struct {
 	InstallmentUnit   string  `json:"installment_unit"`
 	InstallmentAmount float64 `json:"installment_amount"`
 	TotalInstallments int     `json:"total_installments"`
+	Currency          string  `json:"currency"`
 }
 
 type DelinquencyResponse struct {
-	LoanID                string  `json:"loan_id"`
-	CustomerID            string  `json:"customer_id"`
-	IsDelinquent          bool    `json:"is_delinquent"`
-	InstallmentUnit       string  `json:"installment_unit"`
-	OverdueInstallments   int     `json:"overdue_installments"`
-	OverdueAmount         float64 `json:"overdue_amount"`
-	OutstandingAmount     float64 `json:"outstanding_amount"`
-	RequiredPaymentAmount float64 `json:"required_payment_amount"`
+	LoanID                string                 `json:"loan_id"`
+	CustomerID            string                 `json:"customer_id"`
+	IsDelinquent          bool                   `json:"is_delinquent"`
+	Currency              string                 `json:"currency"`
+	InstallmentUnit       string                 `json:"installment_unit"`
+	OverdueInstallments   int                    `json:"overdue_installments"`
+	OverdueAmount         float64                `json:"overdue_amount"`
+	OutstandingAmount     float64                `json:"outstanding_amount"`
+	RequiredPaymentAmount float64                `json:"required_payment_amount"`
+	MatchedRules          []string               `json:"matched_rules"`
+	Collectibility        CollectibilityResponse `json:"collectibility"`
 }
 
 type LoanScheduleResponse struct {
 	LoanID      string                      `json:"loan_id"`
+	Currency    string                      `json:"currency"`
 	LoanSummary LoanSummaryScheduleResponse `json:"loan_summary"`
 	Schedule    []PaymentScheduleResponse   `json:"schedule"`
 }
 
 type LoanSummaryScheduleResponse struct {
-	InstallmentUnit   string  `json:"installment_unit"`
-	TotalInstallments int     `json:"total_installments"`
-	InstallmentAmount float64 `json:"installment_amount"`
-	DisbursedAmount   float64 `json:"disbursed_amount"`
-	OutstandingAmount float64 `json:"outstanding_amount"`
+	InstallmentUnit       string  `json:"installment_unit"`
+	TotalInstallments     int     `json:"total_installments"`
+	InstallmentAmount     float64 `json:"installment_amount"`
+	DisbursedAmount       float64 `json:"disbursed_amount"`
+	OutstandingAmount     float64 `json:"outstanding_amount"`
+	Currency              string  `json:"currency"`
+	AnnualPercentageRate  float64 `json:"annual_percentage_rate"`
+	EffectiveInterestRate float64 `json:"effective_interest_rate"`
 }
 
 type PaymentScheduleResponse struct {
@@ -89,6 +422,714 @@ type PaymentScheduleResponse struct {
 	DueDate           time.Time  `json:"due_date"`
 	InstallmentAmount float64    `json:"installment_amount"`
 	InstallmentPaid   float64    `json:"installment_paid"`
+	PenaltyAmount     float64    `json:"penalty_amount"`
+	TaxAmount         float64    `json:"tax_amount"`
+	Currency          string     `json:"currency"`
 	Status            string     `json:"status"`
 	PaidDate          *time.Time `json:"paid_date"`
 }
+
+type CustomerLoansResponse struct {
+	CustomerID string                      `json:"customer_id"`
+	Summary    CustomerLoanSummaryResponse `json:"summary"`
+	Loans      []LoanListItemResponse      `json:"loans"`
+	NextCursor string                      `json:"next_cursor"`
+}
+
+type LoanSearchResponse struct {
+	Loans      []LoanListItemResponse `json:"loans"`
+	NextCursor string                 `json:"next_cursor"`
+}
+
+// LoanSearchFilter is the loan search passed to the repository. Rows are sorted on SortBy then ID and
+// start after the (AfterValue, AfterID) position when AfterID is set.
+type LoanSearchFilter struct {
+	Statuses        []string
+	InstallmentUnit string
+	ProductCode     string
+	StartDateFrom   *time.Time
+	StartDateTo     *time.Time
+	MinDpd          *int
+	MaxDpd          *int
+	MinOutstanding  float64
+	MaxOutstanding  float64
+	SortBy          string
+	SortDesc        bool
+	AfterID         uint
+	AfterValue      string
+}
+
+// CustomerLoanSummaryResponse covers the customer's active loans, whatever page or filter is requested
+type CustomerLoanSummaryResponse struct {
+	ActiveLoans int                     `json:"active_loans"`
+	WorstDpd    int                     `json:"worst_dpd"`
+	Totals      []CustomerCurrencyTotal `json:"totals"`
+}
+
+// CustomerCurrencyTotal holds the totals of a customer's active loans in one currency, aggregated from loan_summaries
+type CustomerCurrencyTotal struct {
+	Currency          string  `json:"currency"`
+	ActiveLoans       int     `json:"active_loans"`
+	WorstDpd          int     `json:"worst_dpd"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	OverdueAmount     float64 `json:"overdue_amount"`
+}
+
+type LoanListItemResponse struct {
+	LoanID              string    `json:"loan_id"`
+	CustomerID          string    `json:"customer_id"`
+	ProductCode         string    `json:"product_code"`
+	Status              string    `json:"status"`
+	Currency            string    `json:"currency"`
+	PrincipalAmount     float64   `json:"principal_amount"`
+	OutstandingAmount   float64   `json:"outstanding_amount"`
+	InstallmentAmount   float64   `json:"installment_amount"`
+	InstallmentUnit     string    `json:"installment_unit"`
+	NumberOfInstallment int       `json:"number_of_installment"`
+	Dpd                 int       `json:"dpd"`
+	Collectibility      int       `json:"collectibility"`
+	LoanStartDate       time.Time `json:"loan_start_date"`
+}
+
+type CollectibilityResponse struct {
+	Collectibility int        `json:"collectibility"`
+	Label          string     `json:"label"`
+	Dpd            int        `json:"dpd"`
+	EffectiveDate  *time.Time `json:"effective_date"`
+}
+
+type LoanCollectibilityResponse struct {
+	LoanID   string                          `json:"loan_id"`
+	Currency string                          `json:"currency"`
+	Current  CollectibilityResponse          `json:"current"`
+	History  []CollectibilityHistoryResponse `json:"history"`
+}
+
+type CollectibilityHistoryResponse struct {
+	Collectibility    int        `json:"collectibility"`
+	Label             string     `json:"label"`
+	Dpd               int        `json:"dpd"`
+	OutstandingAmount float64    `json:"outstanding_amount"`
+	Currency          string     `json:"currency"`
+	EffectiveFrom     time.Time  `json:"effective_from"`
+	EffectiveTo       *time.Time `json:"effective_to"`
+}
+
+type CollectibilityEvaluationResponse struct {
+	AsOf              time.Time `json:"as_of"`
+	EvaluatedLoans    int       `json:"evaluated_loans"`
+	ReclassifiedLoans int       `json:"reclassified_loans"`
+	FailedLoans       int       `json:"failed_loans"`
+}
+
+type CollectibilityReportResponse struct {
+	GeneratedAt time.Time                      `json:"generated_at"`
+	TotalLoans  int                            `json:"total_loans"`
+	Currencies  []CollectibilityCurrencyReport `json:"currencies"`
+}
+
+// CollectibilityCurrencyReport is the portfolio of one currency; outstanding amounts and their
+// ratios are only added up within a currency
+type CollectibilityCurrencyReport struct {
+	Currency          string                      `json:"currency"`
+	TotalLoans        int                         `json:"total_loans"`
+	OutstandingAmount float64                     `json:"outstanding_amount"`
+	Grades            []CollectibilityGradeReport `json:"grades"`
+}
+
+type CollectibilityGradeReport struct {
+	Currency          string  `json:"currency"`
+	Collectibility    int     `json:"collectibility"`
+	Label             string  `json:"label"`
+	LoanCount         int     `json:"loan_count"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	OutstandingRatio  float64 `json:"outstanding_ratio"`
+}
+
+type DelinquencyPolicyResponse struct {
+	ProductCode string                    `json:"product_code"`
+	Source      string                    `json:"source"`
+	Rules       []DelinquencyRuleResponse `json:"rules"`
+}
+
+type DelinquencyRuleResponse struct {
+	RuleType    string `json:"rule_type"`
+	Threshold   int    `json:"threshold"`
+	Description string `json:"description"`
+}
+
+type FeeScheduleResponse struct {
+	ProductCode string            `json:"product_code"`
+	Fees        []FeeRuleResponse `json:"fees"`
+}
+
+type FeeRuleResponse struct {
+	FeeType         string  `json:"fee_type"`
+	CalculationType string  `json:"calculation_type"`
+	Value           float64 `json:"value"`
+	ChargeMethod    string  `json:"charge_method"`
+}
+
+// DelinquencyEvaluation is the outcome of running a loan through its product's delinquency policy
+type DelinquencyEvaluation struct {
+	IsDelinquent bool
+	Dpd          int
+	PolicySource string
+	MatchedRules []string
+}
+
+type WriteOffResponse struct {
+	LoanID            string             `json:"loan_id"`
+	Status            string             `json:"status"`
+	WrittenOffAmount  float64            `json:"written_off_amount"`
+	RecoveredAmount   float64            `json:"recovered_amount"`
+	OutstandingAmount float64            `json:"outstanding_amount"`
+	Dpd               int                `json:"dpd"`
+	Reason            string             `json:"reason"`
+	ApprovedBy        string             `json:"approved_by"`
+	WrittenOffAt      time.Time          `json:"written_off_at"`
+	Recoveries        []RecoveryResponse `json:"recoveries"`
+}
+
+type RecoveryResponse struct {
+	Amount      float64   `json:"amount"`
+	RecoveredAt time.Time `json:"recovered_at"`
+}
+
+type WriteOffReportResponse struct {
+	GeneratedAt     time.Time                `json:"generated_at"`
+	WrittenOffLoans int                      `json:"written_off_loans"`
+	Currencies      []WriteOffCurrencyReport `json:"currencies"`
+}
+
+// WriteOffCurrencyReport holds the write-off totals of the loans in one currency
+type WriteOffCurrencyReport struct {
+	Currency          string  `json:"currency"`
+	WrittenOffLoans   int     `json:"written_off_loans"`
+	WrittenOffAmount  float64 `json:"written_off_amount"`
+	RecoveredAmount   float64 `json:"recovered_amount"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	RecoveryRate      float64 `json:"recovery_rate"`
+}
+
+// WriteOffTotals holds the portfolio totals of one currency aggregated from loan_write_offs
+type WriteOffTotals struct {
+	Currency         string  `json:"currency"`
+	WrittenOffLoans  int     `json:"written_off_loans"`
+	WrittenOffAmount float64 `json:"written_off_amount"`
+	RecoveredAmount  float64 `json:"recovered_amount"`
+}
+
+type TaxReportResponse struct {
+	TaxType     string                `json:"tax_type"`
+	From        time.Time             `json:"from"`
+	To          time.Time             `json:"to"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	Currencies  []TaxCurrencyResponse `json:"currencies"`
+}
+
+// TaxCurrencyResponse is the tax collected in one currency; amounts are only added up within a currency
+type TaxCurrencyResponse struct {
+	Currency      string              `json:"currency"`
+	TaxableAmount float64             `json:"taxable_amount"`
+	TaxAmount     float64             `json:"tax_amount"`
+	Periods       []TaxPeriodResponse `json:"periods"`
+}
+
+type TaxPeriodResponse struct {
+	Period        string              `json:"period"`
+	TaxableAmount float64             `json:"taxable_amount"`
+	TaxAmount     float64             `json:"tax_amount"`
+	Charges       []TaxChargeResponse `json:"charges"`
+}
+
+type TaxChargeResponse struct {
+	ChargeType    string  `json:"charge_type"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+type DailyReportResponse struct {
+	BusinessDate time.Time                   `json:"business_date"`
+	GeneratedAt  time.Time                   `json:"generated_at"`
+	TotalLoans   int                         `json:"total_loans"`
+	Currencies   []DailyReportCurrencyReport `json:"currencies"`
+}
+
+// DailyReportCurrencyReport holds the end-of-day positions of the loans in one currency
+type DailyReportCurrencyReport struct {
+	Currency          string                    `json:"currency"`
+	TotalLoans        int                       `json:"total_loans"`
+	OutstandingAmount float64                   `json:"outstanding_amount"`
+	OverdueAmount     float64                   `json:"overdue_amount"`
+	Buckets           []DailyReportBucketReport `json:"buckets"`
+}
+
+type DailyReportBucketReport struct {
+	DpdBucket         string  `json:"dpd_bucket"`
+	Loans             int     `json:"loans"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	OverdueAmount     float64 `json:"overdue_amount"`
+}
+
+// DailyPositionTotals holds the positions of one currency and DPD bucket on a business date, aggregated from loan_daily_positions
+type DailyPositionTotals struct {
+	Currency          string  `json:"currency"`
+	DpdBucket         string  `json:"dpd_bucket"`
+	Loans             int     `json:"loans"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	OverdueAmount     float64 `json:"overdue_amount"`
+}
+
+// TaxCollectionTotal holds the collected tax of one charge type in one currency and month, aggregated from tax_lines
+type TaxCollectionTotal struct {
+	Currency      string  `json:"currency"`
+	Period        string  `json:"period"`
+	ChargeType    string  `json:"charge_type"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+type RestructureResponse struct {
+	LoanID                    string    `json:"loan_id"`
+	RestructureID             uint      `json:"restructure_id"`
+	PreviousOutstandingAmount float64   `json:"previous_outstanding_amount"`
+	CapitalisedPenaltyAmount  float64   `json:"capitalised_penalty_amount"`
+	OutstandingAmount         float64   `json:"outstanding_amount"`
+	ReplacedInstallments      int       `json:"replaced_installments"`
+	InstallmentUnit           string    `json:"installment_unit"`
+	InstallmentAmount         float64   `json:"installment_amount"`
+	NumberOfInstallment       int       `json:"number_of_installment"`
+	FirstDueDate              time.Time `json:"first_due_date"`
+	FinalDueDate              time.Time `json:"final_due_date"`
+	RestructuredAt            time.Time `json:"restructured_at"`
+}
+
+type LoanRestructureHistoryResponse struct {
+	LoanID       string                       `json:"loan_id"`
+	Restructures []RestructureHistoryResponse `json:"restructures"`
+}
+
+type RestructureHistoryResponse struct {
+	RestructureID             uint                      `json:"restructure_id"`
+	Reason                    string                    `json:"reason"`
+	PreviousOutstandingAmount float64                   `json:"previous_outstanding_amount"`
+	CapitalisedPenaltyAmount  float64                   `json:"capitalised_penalty_amount"`
+	NewOutstandingAmount      float64                   `json:"new_outstanding_amount"`
+	RestructuredAt            time.Time                 `json:"restructured_at"`
+	OldSchedule               []PaymentScheduleResponse `json:"old_schedule"`
+	NewSchedule               []PaymentScheduleResponse `json:"new_schedule"`
+}
+
+type DeferralResponse struct {
+	LoanID                 string                        `json:"loan_id"`
+	DeferralID             uint                          `json:"deferral_id"`
+	Periods                int                           `json:"periods"`
+	InstallmentUnit        string                        `json:"installment_unit"`
+	DeferredInstallments   int                           `json:"deferred_installments"`
+	DeferralInterestAmount float64                       `json:"deferral_interest_amount"`
+	OutstandingAmount      float64                       `json:"outstanding_amount"`
+	DeferredAt             time.Time                     `json:"deferred_at"`
+	Installments           []DeferredInstallmentResponse `json:"installments"`
+}
+
+type DeferredInstallmentResponse struct {
+	InstallmentNumber int       `json:"installment_number"`
+	PreviousDueDate   time.Time `json:"previous_due_date"`
+	DueDate           time.Time `json:"due_date"`
+	InstallmentAmount float64   `json:"installment_amount"`
+}
+
+type CancellationResponse struct {
+	LoanID                string    `json:"loan_id"`
+	Status                string    `json:"status"`
+	PrincipalAmount       float64   `json:"principal_amount"`
+	WaivedInterestAmount  float64   `json:"waived_interest_amount"`
+	CollectableAmount     float64   `json:"collectable_amount"`
+	CancelledInstallments int       `json:"cancelled_installments"`
+	Reason                string    `json:"reason"`
+	CancelledBy           string    `json:"cancelled_by"`
+	DisbursementDate      time.Time `json:"disbursement_date"`
+	CancelledAt           time.Time `json:"cancelled_at"`
+}
+
+type TrialBalanceResponse struct {
+	AsOf        time.Time                      `json:"as_of"`
+	GeneratedAt time.Time                      `json:"generated_at"`
+	Balanced    bool                           `json:"balanced"`
+	Currencies  []TrialBalanceCurrencyResponse `json:"currencies"`
+}
+
+// TrialBalanceCurrencyResponse is the trial balance of one currency; debits and credits only
+// balance within a currency
+type TrialBalanceCurrencyResponse struct {
+	Currency    string                        `json:"currency"`
+	TotalDebit  float64                       `json:"total_debit"`
+	TotalCredit float64                       `json:"total_credit"`
+	Balanced    bool                          `json:"balanced"`
+	Accounts    []TrialBalanceAccountResponse `json:"accounts"`
+}
+
+type TrialBalanceAccountResponse struct {
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	AccountType string  `json:"account_type"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	Balance     float64 `json:"balance"` // on the account's normal side
+}
+
+// LedgerAccountTotal holds the debits and credits of one account in one currency, aggregated from journal_postings
+type LedgerAccountTotal struct {
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	AccountType string  `json:"account_type"`
+	Currency    string  `json:"currency"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+type LedgerReconciliationResponse struct {
+	GeneratedAt     time.Time                `json:"generated_at"`
+	CheckedLoans    int                      `json:"checked_loans"`
+	MismatchedLoans int                      `json:"mismatched_loans"`
+	Balanced        bool                     `json:"balanced"`
+	Mismatches      []LedgerMismatchResponse `json:"mismatches"`
+}
+
+type LedgerMismatchResponse struct {
+	LoanID            string  `json:"loan_id"`
+	Status            string  `json:"status"`
+	Currency          string  `json:"currency"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	ExpectedBalance   float64 `json:"expected_balance"`
+	LedgerBalance     float64 `json:"ledger_balance"`
+	Difference        float64 `json:"difference"`
+}
+
+// LoanLedgerBalance holds a loan's outstanding amount next to its loan receivable balance in the ledger
+type LoanLedgerBalance struct {
+	LoanID            string  `json:"loan_id"`
+	Status            string  `json:"status"`
+	Currency          string  `json:"currency"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	LedgerBalance     float64 `json:"ledger_balance"`
+}
+
+type InterestAccrualRunRequest struct {
+	AsOf time.Time `json:"as_of"`
+}
+
+type InterestAccrualRunResponse struct {
+	AsOf           time.Time `json:"as_of"`
+	Method         string    `json:"method"`
+	ProcessedLoans int       `json:"processed_loans"`
+	AccruedLoans   int       `json:"accrued_loans"`
+	SuspendedLoans int       `json:"suspended_loans"`
+	FailedLoans    int       `json:"failed_loans"`
+	AccruedDays    int       `json:"accrued_days"`
+	SuspendedDays  int       `json:"suspended_days"`
+}
+
+type LoanAccrualsResponse struct {
+	LoanID                  string                    `json:"loan_id"`
+	Currency                string                    `json:"currency"`
+	InterestAmount          float64                   `json:"interest_amount"`
+	AccruedInterestAmount   float64                   `json:"accrued_interest_amount"`
+	SuspendedInterestAmount float64                   `json:"suspended_interest_amount"`
+	UnearnedInterestAmount  float64                   `json:"unearned_interest_amount"`
+	LastAccrualDate         *time.Time                `json:"last_accrual_date"`
+	Accruals                []InterestAccrualResponse `json:"accruals"`
+}
+
+type InterestAccrualResponse struct {
+	AccrualDate time.Time `json:"accrual_date"`
+	Method      string    `json:"method"`
+	Amount      float64   `json:"amount"`
+	Status      string    `json:"status"`
+}
+
+// AccrualPosition holds the interest accrued and suspended on a loan so far, aggregated from interest_accruals
+type AccrualPosition struct {
+	LoanID          string     `json:"loan_id"`
+	AccruedAmount   float64    `json:"accrued_amount"`
+	SuspendedAmount float64    `json:"suspended_amount"`
+	LastAccrualDate *time.Time `json:"last_accrual_date"`
+}
+
+type BatchRunRequest struct {
+	BusinessDate time.Time `json:"business_date"`
+}
+
+type BatchRunResponse struct {
+	ID           uint                   `json:"id"`
+	BusinessDate time.Time              `json:"business_date"`
+	Status       string                 `json:"status"`
+	TriggeredBy  string                 `json:"triggered_by"`
+	ErrorMessage string                 `json:"error_message,omitempty"`
+	StartedAt    time.Time              `json:"started_at"`
+	FinishedAt   *time.Time             `json:"finished_at"`
+	Steps        []BatchStepRunResponse `json:"steps"`
+}
+
+type BatchStepRunResponse struct {
+	StepName         string     `json:"step_name"`
+	Sequence         int        `json:"sequence"`
+	Status           string     `json:"status"`
+	CheckpointLoanID uint       `json:"checkpoint_loan_id"`
+	ProcessedLoans   int        `json:"processed_loans"`
+	FailedLoans      int        `json:"failed_loans"`
+	ErrorMessage     string     `json:"error_message,omitempty"`
+	StartedAt        *time.Time `json:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at"`
+}
+
+type BatchRunListResponse struct {
+	Runs []BatchRunResponse `json:"runs"`
+}
+
+// JournalExportPosting is one posting of a journal entry with the entry fields the export needs
+type JournalExportPosting struct {
+	EntryID     uint      `json:"entry_id"`
+	EntryType   string    `json:"entry_type"`
+	Reference   string    `json:"reference"`
+	EntryDate   time.Time `json:"entry_date"`
+	AccountCode string    `json:"account_code"`
+	LoanID      string    `json:"loan_id"`
+	Currency    string    `json:"currency"`
+	Debit       float64   `json:"debit"`
+	Credit      float64   `json:"credit"`
+}
+
+type JournalExportRequest struct {
+	Date   string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+	Format string `query:"format" validate:"omitempty,oneof=csv json CSV JSON"`
+	Mode   string `query:"mode" validate:"omitempty,oneof=detailed aggregated DETAILED AGGREGATED"`
+}
+
+type JournalExportResponse struct {
+	ExportDate  string               `json:"export_date"`
+	Mode        string               `json:"mode"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Lines       []JournalExportLine  `json:"lines"`
+	Trailer     JournalExportTrailer `json:"trailer"`
+}
+
+type JournalExportLine struct {
+	Date        string  `json:"date"`
+	AccountCode string  `json:"account_code"`
+	Currency    string  `json:"currency"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	LoanID      string  `json:"loan_id"`
+	Reference   string  `json:"reference"`
+}
+
+// JournalExportTrailer holds the control totals of an export; Checksum is the SHA-256 of its lines
+type JournalExportTrailer struct {
+	LineCount   int     `json:"line_count"`
+	TotalDebit  float64 `json:"total_debit"`
+	TotalCredit float64 `json:"total_credit"`
+	Checksum    string  `json:"checksum"`
+}
+
+// JournalExportFile is a rendered journal export ready to be downloaded
+type JournalExportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type ProvisionParametersRequest struct {
+	Stages []ProvisionParameterRequest `json:"stages" validate:"dive"`
+}
+
+type ProvisionParameterRequest struct {
+	Stage                int     `json:"stage" validate:"required,min=1,max=3"`
+	ProbabilityOfDefault float64 `json:"probability_of_default" validate:"gte=0,lte=1"`
+	LossGivenDefault     float64 `json:"loss_given_default" validate:"gte=0,lte=1"`
+}
+
+type ProvisionParametersResponse struct {
+	ProductCode string                       `json:"product_code"`
+	Source      string                       `json:"source"`
+	Stages      []ProvisionParameterResponse `json:"stages"`
+}
+
+type ProvisionParameterResponse struct {
+	Stage                int     `json:"stage"`
+	ProbabilityOfDefault float64 `json:"probability_of_default"`
+	LossGivenDefault     float64 `json:"loss_given_default"`
+}
+
+type ProvisionRunRequest struct {
+	Period string `json:"period" validate:"omitempty,datetime=2006-01"`
+}
+
+type ProvisionRunResponse struct {
+	ID        uint                    `json:"id"`
+	Period    string                  `json:"period"`
+	PeriodEnd time.Time               `json:"period_end"`
+	LoanCount int                     `json:"loan_count"`
+	CreatedAt time.Time               `json:"created_at"`
+	Stages    []ProvisionStageSummary `json:"stages"`
+}
+
+// ProvisionStageSummary totals the loans of one currency and stage in a provision run
+type ProvisionStageSummary struct {
+	Currency        string  `json:"currency"`
+	Stage           int     `json:"stage"`
+	LoanCount       int     `json:"loan_count"`
+	ExposureAmount  float64 `json:"exposure_amount"`
+	ProvisionAmount float64 `json:"provision_amount"`
+}
+
+type ProvisionMovementRequest struct {
+	From string `query:"from" validate:"required,datetime=2006-01"`
+	To   string `query:"to" validate:"required,datetime=2006-01"`
+}
+
+type ProvisionMovementResponse struct {
+	FromPeriod  string                   `json:"from_period"`
+	ToPeriod    string                   `json:"to_period"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Movements   []ProvisionStageMovement `json:"movements"`
+}
+
+// ProvisionStageMovement reconciles the provision of one currency and stage between two runs:
+// closing = opening + new loans - derecognised + transfers in - transfers out + remeasurement
+type ProvisionStageMovement struct {
+	Currency         string  `json:"currency"`
+	Stage            int     `json:"stage"`
+	OpeningProvision float64 `json:"opening_provision"`
+	NewLoans         float64 `json:"new_loans"`
+	Derecognised     float64 `json:"derecognised"`
+	TransfersIn      float64 `json:"transfers_in"`
+	TransfersOut     float64 `json:"transfers_out"`
+	Remeasurement    float64 `json:"remeasurement"`
+	ClosingProvision float64 `json:"closing_provision"`
+}
+
+// BankStatementImportRequest is an uploaded statement file. AccountNumber is the receiving account
+// of CSV statements; MT940 statements carry their own.
+type BankStatementImportRequest struct {
+	Format        string `form:"format" validate:"required,oneof=mt940 csv MT940 CSV"`
+	AccountNumber string `form:"account_number" validate:"max=50"`
+	FileName      string `form:"-"`
+	Content       []byte `form:"-"`
+}
+
+type BankStatementImportResponse struct {
+	StatementID        uint                        `json:"statement_id"`
+	FileName           string                      `json:"file_name"`
+	Format             string                      `json:"format"`
+	AccountNumber      string                      `json:"account_number"`
+	StatementReference string                      `json:"statement_reference"`
+	TotalLines         int                         `json:"total_lines"`
+	CreditLines        int                         `json:"credit_lines"`
+	DuplicateLines     int                         `json:"duplicate_lines"`
+	MatchedLines       int                         `json:"matched_lines"`
+	UnmatchedLines     int                         `json:"unmatched_lines"`
+	Lines              []BankStatementLineResponse `json:"lines"`
+}
+
+type BankStatementLineResponse struct {
+	ID             uint       `json:"id"`
+	StatementID    uint       `json:"statement_id"`
+	ValueDate      time.Time  `json:"value_date"`
+	Amount         float64    `json:"amount"`
+	Currency       string     `json:"currency"`
+	VirtualAccount string     `json:"virtual_account,omitempty"`
+	Reference      string     `json:"reference"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	MatchMethod    string     `json:"match_method,omitempty"`
+	LoanID         string     `json:"loan_id,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	MatchedAt      *time.Time `json:"matched_at,omitempty"`
+	MatchedBy      string     `json:"matched_by,omitempty"`
+}
+
+type BankStatementLineListRequest struct {
+	Limit  int    `query:"limit" validate:"gte=0,lte=100"`
+	Cursor string `query:"cursor" validate:"omitempty,cursor"`
+}
+
+type BankStatementLineListResponse struct {
+	Lines      []BankStatementLineResponse `json:"lines"`
+	NextCursor string                      `json:"next_cursor"`
+}
+
+type BankStatementLineAssignRequest struct {
+	LoanID string `json:"loan_id" validate:"required,max=50"`
+}
+
+// SuspenseItemListRequest filters the suspense items; items are listed oldest first
+type SuspenseItemListRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=OPEN RESOLVED"`
+	ReasonCode string `query:"reason_code" validate:"omitempty,oneof=WRONG_AMOUNT UNKNOWN_LOAN CLOSED_LOAN LOAN_NOT_DISBURSED CURRENCY_MISMATCH"`
+	Limit      int    `query:"limit" validate:"gte=0,lte=100"`
+	Cursor     string `query:"cursor" validate:"omitempty,cursor"`
+}
+
+type SuspenseItemListResponse struct {
+	Items      []SuspenseItemResponse `json:"items"`
+	NextCursor string                 `json:"next_cursor"`
+}
+
+type SuspenseItemResponse struct {
+	ID              uint       `json:"id"`
+	Source          string     `json:"source"`
+	SourceReference string     `json:"source_reference"`
+	LoanID          string     `json:"loan_id"`
+	Amount          float64    `json:"amount"`
+	Currency        string     `json:"currency"`
+	ReasonCode      string     `json:"reason_code"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status"`
+	ReceivedAt      time.Time  `json:"received_at"`
+	AgeDays         int        `json:"age_days"`
+	Resolution      string     `json:"resolution,omitempty"`
+	ResolvedLoanID  string     `json:"resolved_loan_id,omitempty"`
+	AccountCode     string     `json:"account_code,omitempty"`
+	RefundReference string     `json:"refund_reference,omitempty"`
+	Note            string     `json:"note,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy      string     `json:"resolved_by,omitempty"`
+}
+
+// SuspenseResolveRequest resolves an open suspense item. APPLY pays LoanID, defaulting to the loan
+// the payment was meant for; REFUND needs the refund transfer reference and MOVE the ledger account
+// the funds move to.
+type SuspenseResolveRequest struct {
+	Action          string `json:"action" validate:"required,oneof=APPLY REFUND MOVE"`
+	LoanID          string `json:"loan_id" validate:"max=50"`
+	RefundReference string `json:"refund_reference" validate:"required_if=Action REFUND,max=255"`
+	AccountCode     string `json:"account_code" validate:"required_if=Action MOVE,max=50"`
+	Note            string `json:"note" validate:"max=500"`
+	ResolvedBy      string `json:"resolved_by" validate:"required,max=255"`
+}
+
+type SuspenseAgingResponse struct {
+	AsOf        time.Time               `json:"as_of"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Currencies  []SuspenseAgingCurrency `json:"currencies"`
+}
+
+type SuspenseAgingCurrency struct {
+	Currency      string                `json:"currency"`
+	ItemCount     int                   `json:"item_count"`
+	Amount        float64               `json:"amount"`
+	OldestAgeDays int                   `json:"oldest_age_days"`
+	Buckets       []SuspenseAgingBucket `json:"buckets"`
+}
+
+// SuspenseAgingBucket totals the items whose age in days is between MinDays and MaxDays; the last
+// bucket has no MaxDays
+type SuspenseAgingBucket struct {
+	Bucket    string  `json:"bucket"`
+	MinDays   int     `json:"min_days"`
+	MaxDays   *int    `json:"max_days"`
+	ItemCount int     `json:"item_count"`
+	Amount    float64 `json:"amount"`
+}
@@ -24,7 +24,12 @@ type DisbursementDetail struct {
 	DisbursementDate  time.Time  `json:"disbursement_date" gorm:"not null"`
 	DisbursedAmount   float64    `json:"disbursed_amount" gorm:"not null;type:decimal(15,2)"`
 	DisbursedCurrency string     `json:"disbursed_currency" gorm:"default:'IDR';type:char(3)"`
-	Status            string     `json:"status" gorm:"not null;type:varchar(100)"`
+	BankCode          string     `json:"bank_code" gorm:"type:varchar(20)"`
+	AccountNumber     string     `json:"account_number" gorm:"type:varchar(50)"`
+	Status            string     `json:"status" gorm:"not null;type:varchar(100);index"`
+	DisbursedAt       *time.Time `json:"disbursed_at"`
+	Reference         string     `json:"reference" gorm:"type:varchar(100)"`
+	FailureReason     string     `json:"failure_reason" gorm:"type:varchar(500)"`
 	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	CreatedBy         string     `json:"created_by" gorm:"type:varchar(255)"`
 	UpdatedAt         time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
@@ -37,13 +42,23 @@ type LoanSummary struct {
 	ID                    uint       `json:"id" gorm:"primaryKey;autoIncrement"`
 	LoanID                string     `json:"loan_id" gorm:"uniqueIndex;not null;type:varchar(50)"`
 	CustomerID            string     `json:"customer_id" gorm:"not null;type:varchar(36);index"`
+	ProductCode           string     `json:"product_code" gorm:"not null;default:'DEFAULT';type:varchar(50);index"`
+	Currency              string     `json:"currency" gorm:"not null;default:'IDR';type:char(3)"`
 	PrincipalAmount       float64    `json:"principal_amount" gorm:"not null;type:decimal(15,2)"`
 	InterestAmount        float64    `json:"interest_amount" gorm:"not null;type:decimal(15,2)"`
 	OutstandingAmount     float64    `json:"outstanding_amount" gorm:"not null;type:decimal(15,2)"`
 	NoOfInstallment       int        `json:"no_of_installment" gorm:"not null"`
 	InstallmentUnit       string     `json:"installment_unit" gorm:"not null;type:varchar(100)"`
 	InstallmentAmount     float64    `json:"installment_amount" gorm:"not null;type:decimal(15,2)"`
-	EffectiveInterestRate float64    `json:"effective_interest_rate" gorm:"not null;type:decimal(5,4)"`
+	FeeAmount             float64    `json:"fee_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	TaxAmount             float64    `json:"tax_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	AnnualPercentageRate  float64    `json:"annual_percentage_rate" gorm:"not null;type:decimal(16,6);default:0"`
+	EffectiveInterestRate float64    `json:"effective_interest_rate" gorm:"not null;type:decimal(16,6)"`
+	VirtualAccountNumber  string     `json:"virtual_account_number" gorm:"type:varchar(30);index"`
+	VirtualAccountBank    string     `json:"virtual_account_bank" gorm:"type:varchar(20)"`
+	Dpd                   int        `json:"dpd" gorm:"not null;default:0;index"`
+	Collectibility        int        `json:"collectibility" gorm:"not null;default:1;index"`
+	CollectibilityDate    *time.Time `json:"collectibility_date" gorm:"type:date"`
 	Status                string     `json:"status" gorm:"not null;type:varchar(100);index"`
 	LoanStartDate         time.Time  `json:"loan_start_date" gorm:"not null;type:date"`
 	CreatedAt             time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
@@ -61,6 +76,8 @@ type PaymentSchedule struct {
 	InstallmentAmount  float64    `json:"installment_amount" gorm:"not null;type:decimal(15,2)"`
 	InstallmentDueDate time.Time  `json:"installment_due_date" gorm:"not null;type:date;index"`
 	InstallmentPaid    float64    `json:"installment_paid" gorm:"not null;type:decimal(15,2);default:0"`
+	PenaltyAmount      float64    `json:"penalty_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	TaxAmount          float64    `json:"tax_amount" gorm:"not null;type:decimal(15,2);default:0"`
 	Status             string     `json:"status" gorm:"default:'PENDING';type:varchar(100);index"`
 	Currency           string     `json:"currency" gorm:"default:'IDR';type:char(3)"`
 	CreatedAt          time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
@@ -86,16 +103,700 @@ type PaymentScheduleHistory struct {
 	CreatedBy          string    `json:"created_by" gorm:"type:varchar(255)"`
 }
 
+// LoanCollectibility represents the loan_collectibilities table. Each row is one
+// OJK collectibility grade held by a loan between EffectiveFrom and EffectiveTo;
+// the open row (EffectiveTo is nil) is the current grade.
+type LoanCollectibility struct {
+	ID                uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID            string     `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	Collectibility    int        `json:"collectibility" gorm:"not null"`
+	Dpd               int        `json:"dpd" gorm:"not null"`
+	OutstandingAmount float64    `json:"outstanding_amount" gorm:"not null;type:decimal(15,2)"`
+	EffectiveFrom     time.Time  `json:"effective_from" gorm:"not null;type:date"`
+	EffectiveTo       *time.Time `json:"effective_to" gorm:"type:date"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy         string     `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// DelinquencyRule represents the delinquency_rules table. The rules of a product
+// form its delinquency policy; a loan is delinquent when any of them matches.
+type DelinquencyRule struct {
+	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProductCode string     `json:"product_code" gorm:"not null;type:varchar(50);index"`
+	RuleType    string     `json:"rule_type" gorm:"not null;type:varchar(50)"`
+	Threshold   int        `json:"threshold" gorm:"not null"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy   string     `json:"created_by" gorm:"type:varchar(255)"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	UpdatedBy   string     `json:"updated_by" gorm:"type:varchar(255)"`
+	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// LoanWriteOff represents the loan_write_offs table. A loan is written off at most
+// once; RecoveredAmount accumulates the recoveries collected afterwards.
+type LoanWriteOff struct {
+	ID               uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID           string    `json:"loan_id" gorm:"uniqueIndex;not null;type:varchar(50)"`
+	WrittenOffAmount float64   `json:"written_off_amount" gorm:"not null;type:decimal(15,2)"`
+	RecoveredAmount  float64   `json:"recovered_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	Dpd              int       `json:"dpd" gorm:"not null"`
+	Reason           string    `json:"reason" gorm:"not null;type:varchar(500)"`
+	ApprovedBy       string    `json:"approved_by" gorm:"not null;type:varchar(255)"`
+	WrittenOffAt     time.Time `json:"written_off_at" gorm:"not null;index"`
+	CreatedAt        time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy        string    `json:"created_by" gorm:"type:varchar(255)"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	UpdatedBy        string    `json:"updated_by" gorm:"type:varchar(255)"`
+}
+
+// LoanRecovery represents the loan_recoveries table, one row per payment
+// received on a written-off loan
+type LoanRecovery struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID      string    `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	WriteOffID  uint      `json:"write_off_id" gorm:"not null;index"`
+	Amount      float64   `json:"amount" gorm:"not null;type:decimal(15,2)"`
+	RecoveredAt time.Time `json:"recovered_at" gorm:"not null;index"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy   string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// LoanRestructure represents the loan_restructures table. The replaced installments
+// (soft-deleted) and the installments generated by the restructure are identified by
+// their installment number ranges, which are unique per loan.
+type LoanRestructure struct {
+	ID                        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID                    string    `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	PreviousOutstandingAmount float64   `json:"previous_outstanding_amount" gorm:"not null;type:decimal(15,2)"`
+	CapitalisedPenaltyAmount  float64   `json:"capitalised_penalty_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	NewOutstandingAmount      float64   `json:"new_outstanding_amount" gorm:"not null;type:decimal(15,2)"`
+	PreviousInstallmentUnit   string    `json:"previous_installment_unit" gorm:"not null;type:varchar(100)"`
+	PreviousInstallmentAmount float64   `json:"previous_installment_amount" gorm:"not null;type:decimal(15,2)"`
+	OldFromInstallment        int       `json:"old_from_installment" gorm:"not null"`
+	OldToInstallment          int       `json:"old_to_installment" gorm:"not null"`
+	NewInstallmentUnit        string    `json:"new_installment_unit" gorm:"not null;type:varchar(100)"`
+	NewInstallmentAmount      float64   `json:"new_installment_amount" gorm:"not null;type:decimal(15,2)"`
+	NewFromInstallment        int       `json:"new_from_installment" gorm:"not null"`
+	NewToInstallment          int       `json:"new_to_installment" gorm:"not null"`
+	Reason                    string    `json:"reason" gorm:"not null;type:varchar(500)"`
+	RestructuredAt            time.Time `json:"restructured_at" gorm:"not null"`
+	CreatedAt                 time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy                 string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// LoanDeferral represents the loan_deferrals table, one row per payment holiday granted.
+// The pending installments from FromInstallment onwards were shifted by Periods.
+type LoanDeferral struct {
+	ID                     uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID                 string    `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	FromInstallment        int       `json:"from_installment" gorm:"not null"`
+	Periods                int       `json:"periods" gorm:"not null"`
+	DeferredInstallments   int       `json:"deferred_installments" gorm:"not null"`
+	DeferralInterestRate   float64   `json:"deferral_interest_rate" gorm:"not null;type:decimal(5,4);default:0"`
+	DeferralInterestAmount float64   `json:"deferral_interest_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	Reason                 string    `json:"reason" gorm:"not null;type:varchar(500)"`
+	DeferredAt             time.Time `json:"deferred_at" gorm:"not null"`
+	CreatedAt              time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy              string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// LoanCancellation represents the loan_cancellations table. A loan is cancelled at most
+// once, within the cooling-off window and before any repayment.
+type LoanCancellation struct {
+	ID                   uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID               string    `json:"loan_id" gorm:"uniqueIndex;not null;type:varchar(50)"`
+	PrincipalAmount      float64   `json:"principal_amount" gorm:"not null;type:decimal(15,2)"`
+	WaivedInterestAmount float64   `json:"waived_interest_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	CollectableAmount    float64   `json:"collectable_amount" gorm:"not null;type:decimal(15,2)"`
+	Reason               string    `json:"reason" gorm:"not null;type:varchar(500)"`
+	CancelledBy          string    `json:"cancelled_by" gorm:"not null;type:varchar(255)"`
+	CancelledAt          time.Time `json:"cancelled_at" gorm:"not null;index"`
+	CreatedAt            time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy            string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// FeeRule represents the fee_rules table. The rules of a product form its fee schedule,
+// applied to every loan of the product at disbursement.
+type FeeRule struct {
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProductCode     string     `json:"product_code" gorm:"not null;type:varchar(50);index"`
+	FeeType         string     `json:"fee_type" gorm:"not null;type:varchar(50)"`
+	CalculationType string     `json:"calculation_type" gorm:"not null;type:varchar(50)"`
+	Value           float64    `json:"value" gorm:"not null;type:decimal(15,4)"`
+	ChargeMethod    string     `json:"charge_method" gorm:"not null;type:varchar(50)"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy       string     `json:"created_by" gorm:"type:varchar(255)"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	UpdatedBy       string     `json:"updated_by" gorm:"type:varchar(255)"`
+	DeletedAt       *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// LoanFee represents the loan_fees table, one row per fee charged on a loan at disbursement
+type LoanFee struct {
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID          string     `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	FeeType         string     `json:"fee_type" gorm:"not null;type:varchar(50)"`
+	CalculationType string     `json:"calculation_type" gorm:"not null;type:varchar(50)"`
+	Value           float64    `json:"value" gorm:"not null;type:decimal(15,4)"`
+	Amount          float64    `json:"amount" gorm:"not null;type:decimal(15,2)"`
+	ChargeMethod    string     `json:"charge_method" gorm:"not null;type:varchar(50)"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy       string     `json:"created_by" gorm:"type:varchar(255)"`
+	DeletedAt       *time.Time `json:"deleted_at" gorm:"index"`
+	DeletedBy       string     `json:"deleted_by" gorm:"type:varchar(255)"`
+}
+
+// TaxLine represents the tax_lines table. Each line is the tax on one charge: a fee deducted
+// at disbursement (InstallmentNumber 0), the part of an installment fee due with an installment,
+// or a penalty paid with an installment.
+type TaxLine struct {
+	ID                uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID            string     `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	TaxType           string     `json:"tax_type" gorm:"not null;type:varchar(20)"`
+	ChargeType        string     `json:"charge_type" gorm:"not null;type:varchar(50)"`
+	InstallmentNumber int        `json:"installment_number" gorm:"not null;default:0"`
+	TaxableAmount     float64    `json:"taxable_amount" gorm:"not null;type:decimal(15,2)"`
+	TaxRate           float64    `json:"tax_rate" gorm:"not null;type:decimal(5,4)"`
+	TaxAmount         float64    `json:"tax_amount" gorm:"not null;type:decimal(15,2)"`
+	Currency          string     `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Status            string     `json:"status" gorm:"not null;type:varchar(100);index"`
+	CollectedAt       *time.Time `json:"collected_at" gorm:"index"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy         string     `json:"created_by" gorm:"type:varchar(255)"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	UpdatedBy         string     `json:"updated_by" gorm:"type:varchar(255)"`
+}
+
+// LedgerAccount represents the ledger_accounts table, the chart of accounts of the general ledger
+type LedgerAccount struct {
+	Code      string    `json:"code" gorm:"primaryKey;type:varchar(50)"`
+	Name      string    `json:"name" gorm:"not null;type:varchar(255)"`
+	Type      string    `json:"type" gorm:"not null;type:varchar(20)"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// JournalEntry represents the journal_entries table, one balanced entry per money movement.
+// A reversal points at the entry it cancels through ReversalOfID.
+type JournalEntry struct {
+	ID           uint              `json:"id" gorm:"primaryKey;autoIncrement"`
+	EntryType    string            `json:"entry_type" gorm:"not null;type:varchar(50);index"`
+	LoanID       string            `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	Currency     string            `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Reference    string            `json:"reference" gorm:"type:varchar(255)"`
+	Description  string            `json:"description" gorm:"type:varchar(500)"`
+	ReversalOfID *uint             `json:"reversal_of_id" gorm:"index"`
+	EntryDate    time.Time         `json:"entry_date" gorm:"not null;index"`
+	Postings     []*JournalPosting `json:"postings" gorm:"foreignKey:JournalEntryID"`
+	CreatedAt    time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy    string            `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// JournalPosting represents the journal_postings table, one debit or credit of a journal entry
+// on a ledger account
+type JournalPosting struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	JournalEntryID uint      `json:"journal_entry_id" gorm:"not null;index"`
+	AccountCode    string    `json:"account_code" gorm:"not null;type:varchar(50)"`
+	LoanID         string    `json:"loan_id" gorm:"not null;type:varchar(50)"`
+	Currency       string    `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Debit          float64   `json:"debit" gorm:"not null;type:decimal(15,2);default:0"`
+	Credit         float64   `json:"credit" gorm:"not null;type:decimal(15,2);default:0"`
+	CreatedAt      time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// InterestAccrual represents the interest_accruals table, the interest recognised on a loan for
+// one day. Suspended days record the interest that was not recognised while the loan was
+// non-performing.
+type InterestAccrual struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LoanID      string    `json:"loan_id" gorm:"not null;type:varchar(50);uniqueIndex:idx_loan_accrual_date"`
+	AccrualDate time.Time `json:"accrual_date" gorm:"not null;type:date;uniqueIndex:idx_loan_accrual_date"`
+	Method      string    `json:"method" gorm:"not null;type:varchar(50)"`
+	Amount      float64   `json:"amount" gorm:"not null;type:decimal(15,2)"`
+	Currency    string    `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Status      string    `json:"status" gorm:"not null;type:varchar(20);index"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy   string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// BatchRun represents the batch_runs table, one end-of-day run per business date. A run that
+// did not complete is resumed rather than started again.
+type BatchRun struct {
+	ID           uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	BusinessDate time.Time       `json:"business_date" gorm:"not null;type:date;uniqueIndex"`
+	Status       string          `json:"status" gorm:"not null;type:varchar(20);index"`
+	TriggeredBy  string          `json:"triggered_by" gorm:"type:varchar(255)"`
+	ErrorMessage string          `json:"error_message" gorm:"type:varchar(1000)"`
+	StartedAt    time.Time       `json:"started_at" gorm:"not null"`
+	FinishedAt   *time.Time      `json:"finished_at"`
+	Steps        []*BatchStepRun `json:"steps" gorm:"foreignKey:BatchRunID"`
+	CreatedAt    time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// BatchStepRun represents the batch_step_runs table, the progress of one step of a run.
+// CheckpointLoanID is the ID of the last loan of the last chunk page the step finished; a
+// resumed step continues after it.
+type BatchStepRun struct {
+	ID               uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	BatchRunID       uint       `json:"batch_run_id" gorm:"not null;uniqueIndex:idx_batch_run_step"`
+	StepName         string     `json:"step_name" gorm:"not null;type:varchar(50);uniqueIndex:idx_batch_run_step"`
+	Sequence         int        `json:"sequence" gorm:"not null"`
+	Status           string     `json:"status" gorm:"not null;type:varchar(20)"`
+	CheckpointLoanID uint       `json:"checkpoint_loan_id" gorm:"not null;default:0"`
+	ProcessedLoans   int        `json:"processed_loans" gorm:"not null;default:0"`
+	FailedLoans      int        `json:"failed_loans" gorm:"not null;default:0"`
+	ErrorMessage     string     `json:"error_message" gorm:"type:varchar(1000)"`
+	StartedAt        *time.Time `json:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// PaymentReminder represents the payment_reminders table, the reminder of one installment sent by
+// the end-of-day run of a business date. A reminder that failed to send is sent again when the run
+// is resumed; one that was sent is not sent twice.
+type PaymentReminder struct {
+	ID                 uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ScheduleID         uint       `json:"schedule_id" gorm:"not null;uniqueIndex:idx_schedule_business_date"`
+	BusinessDate       time.Time  `json:"business_date" gorm:"not null;type:date;uniqueIndex:idx_schedule_business_date"`
+	LoanID             string     `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	CustomerID         string     `json:"customer_id" gorm:"not null;type:varchar(36)"`
+	ReminderType       string     `json:"reminder_type" gorm:"not null;type:varchar(20)"`
+	InstallmentNumber  int        `json:"installment_number" gorm:"not null"`
+	InstallmentDueDate time.Time  `json:"installment_due_date" gorm:"not null;type:date"`
+	DaysPastDue        int        `json:"days_past_due" gorm:"not null;default:0"`
+	AmountDue          float64    `json:"amount_due" gorm:"not null;type:decimal(15,2)"`
+	Currency           string     `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Status             string     `json:"status" gorm:"not null;type:varchar(20)"`
+	ErrorMessage       string     `json:"error_message" gorm:"type:varchar(1000)"`
+	SentAt             *time.Time `json:"sent_at"`
+	CreatedAt          time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt          time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// LoanDailyPosition represents the loan_daily_positions table, the position of one active loan at
+// the end of a business date as recorded by the end-of-day run
+type LoanDailyPosition struct {
+	ID                uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	BusinessDate      time.Time `json:"business_date" gorm:"not null;type:date;uniqueIndex:idx_business_date_loan"`
+	LoanID            string    `json:"loan_id" gorm:"not null;type:varchar(50);uniqueIndex:idx_business_date_loan;index"`
+	CustomerID        string    `json:"customer_id" gorm:"not null;type:varchar(36)"`
+	ProductCode       string    `json:"product_code" gorm:"not null;type:varchar(50)"`
+	Currency          string    `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Status            string    `json:"status" gorm:"not null;type:varchar(100)"`
+	OutstandingAmount float64   `json:"outstanding_amount" gorm:"not null;type:decimal(15,2)"`
+	OverdueAmount     float64   `json:"overdue_amount" gorm:"not null;type:decimal(15,2);default:0"`
+	Dpd               int       `json:"dpd" gorm:"not null;default:0"`
+	Collectibility    int       `json:"collectibility" gorm:"not null;default:1"`
+	CreatedAt         time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// ProvisionParameter represents the provision_parameters table. The parameters of a product
+// hold the probability of default and loss given default of each IFRS 9 stage.
+type ProvisionParameter struct {
+	ID                   uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProductCode          string     `json:"product_code" gorm:"not null;type:varchar(50);index"`
+	Stage                int        `json:"stage" gorm:"not null"`
+	ProbabilityOfDefault float64    `json:"probability_of_default" gorm:"not null;type:decimal(7,6)"`
+	LossGivenDefault     float64    `json:"loss_given_default" gorm:"not null;type:decimal(7,6)"`
+	CreatedAt            time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy            string     `json:"created_by" gorm:"type:varchar(255)"`
+	UpdatedAt            time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	UpdatedBy            string     `json:"updated_by" gorm:"type:varchar(255)"`
+	DeletedAt            *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// ProvisionRun represents the provision_runs table, one expected credit loss run per month.
+// Running a month again replaces its snapshot.
+type ProvisionRun struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Period    string    `json:"period" gorm:"not null;type:char(7);uniqueIndex"`
+	PeriodEnd time.Time `json:"period_end" gorm:"not null;type:date"`
+	LoanCount int       `json:"loan_count" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// LoanProvision represents the loan_provisions table, the stage and expected credit loss of
+// one loan in a provision run
+type LoanProvision struct {
+	ID                   uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProvisionRunID       uint      `json:"provision_run_id" gorm:"not null;uniqueIndex:idx_run_loan"`
+	LoanID               string    `json:"loan_id" gorm:"not null;type:varchar(50);uniqueIndex:idx_run_loan;index"`
+	ProductCode          string    `json:"product_code" gorm:"not null;type:varchar(50)"`
+	Currency             string    `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Dpd                  int       `json:"dpd" gorm:"not null"`
+	Stage                int       `json:"stage" gorm:"not null"`
+	ExposureAmount       float64   `json:"exposure_amount" gorm:"not null;type:decimal(15,2)"`
+	ProbabilityOfDefault float64   `json:"probability_of_default" gorm:"not null;type:decimal(7,6)"`
+	LossGivenDefault     float64   `json:"loss_given_default" gorm:"not null;type:decimal(7,6)"`
+	ProvisionAmount      float64   `json:"provision_amount" gorm:"not null;type:decimal(15,2)"`
+	CreatedAt            time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// BankStatement represents the bank_statements table, one imported MT940 or CSV statement file
+type BankStatement struct {
+	ID                 uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	FileName           string    `json:"file_name" gorm:"type:varchar(255)"`
+	Format             string    `json:"format" gorm:"not null;type:varchar(10)"`
+	AccountNumber      string    `json:"account_number" gorm:"type:varchar(50)"`
+	StatementReference string    `json:"statement_reference" gorm:"type:varchar(50)"`
+	TotalLines         int       `json:"total_lines" gorm:"not null;default:0"`
+	CreditLines        int       `json:"credit_lines" gorm:"not null;default:0"`
+	DuplicateLines     int       `json:"duplicate_lines" gorm:"not null;default:0"`
+	MatchedLines       int       `json:"matched_lines" gorm:"not null;default:0"`
+	UnmatchedLines     int       `json:"unmatched_lines" gorm:"not null;default:0"`
+	CreatedAt          time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedBy          string    `json:"created_by" gorm:"type:varchar(255)"`
+}
+
+// BankStatementLine represents the bank_statement_lines table, one incoming transfer of a
+// statement. LineKey identifies the transfer across imports so a statement imported twice does
+// not pay a loan twice; unmatched lines wait for a manual assignment.
+type BankStatementLine struct {
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	BankStatementID uint       `json:"bank_statement_id" gorm:"not null;index"`
+	LineKey         string     `json:"line_key" gorm:"not null;type:char(64);uniqueIndex"`
+	AccountNumber   string     `json:"account_number" gorm:"type:varchar(50)"`
+	VirtualAccount  string     `json:"virtual_account" gorm:"type:varchar(30)"` // virtual account the transfer was paid into, when the bank reports it
+	ValueDate       time.Time  `json:"value_date" gorm:"not null;type:date"`
+	Amount          float64    `json:"amount" gorm:"not null;type:decimal(15,2)"`
+	Currency        string     `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	Reference       string     `json:"reference" gorm:"type:varchar(255)"`
+	Description     string     `json:"description" gorm:"type:varchar(1000)"`
+	Status          string     `json:"status" gorm:"not null;type:varchar(20);index"`
+	MatchMethod     string     `json:"match_method" gorm:"type:varchar(20)"`
+	LoanID          string     `json:"loan_id" gorm:"type:varchar(50);index"`
+	Reason          string     `json:"reason" gorm:"type:varchar(1000)"`
+	MatchedAt       *time.Time `json:"matched_at"`
+	MatchedBy       string     `json:"matched_by" gorm:"type:varchar(255)"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// SuspenseItem represents the suspense_items table, a payment received that could not be applied
+// to a loan. The funds sit on the SUSPENSE ledger account until the item is resolved by applying
+// it to a loan, refunding it or moving it to another account. An item without a source reference
+// stores NULL, so only referenced payments are unique per source.
+type SuspenseItem struct {
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Source          string     `json:"source" gorm:"not null;type:varchar(30);uniqueIndex:idx_source_reference"`
+	SourceReference string     `json:"source_reference" gorm:"type:varchar(255);uniqueIndex:idx_source_reference;default:null"`
+	LoanID          string     `json:"loan_id" gorm:"type:varchar(50);index"` // loan the payment was meant for
+	Amount          float64    `json:"amount" gorm:"not null;type:decimal(15,2)"`
+	Currency        string     `json:"currency" gorm:"not null;type:char(3);default:IDR"`
+	ReasonCode      string     `json:"reason_code" gorm:"not null;type:varchar(30);index"`
+	Reason          string     `json:"reason" gorm:"type:varchar(1000)"`
+	Status          string     `json:"status" gorm:"not null;type:varchar(20);index"`
+	ReceivedAt      time.Time  `json:"received_at" gorm:"not null"`
+	Resolution      string     `json:"resolution" gorm:"type:varchar(20)"`
+	ResolvedLoanID  string     `json:"resolved_loan_id" gorm:"type:varchar(50)"`
+	AccountCode     string     `json:"account_code" gorm:"type:varchar(50)"` // account a moved item went to
+	RefundReference string     `json:"refund_reference" gorm:"type:varchar(255)"`
+	Note            string     `json:"note" gorm:"type:varchar(500)"`
+	ResolvedAt      *time.Time `json:"resolved_at"`
+	ResolvedBy      string     `json:"resolved_by" gorm:"type:varchar(255)"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// PaymentNotification represents the payment_notifications table, one payment callback of a payment
+// provider. A provider transaction is processed once; repeated callbacks get the stored outcome.
+type PaymentNotification struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Provider       string    `json:"provider" gorm:"not null;type:varchar(30);uniqueIndex:idx_provider_transaction"`
+	TransactionID  string    `json:"transaction_id" gorm:"not null;type:varchar(100);uniqueIndex:idx_provider_transaction"`
+	PaymentMethod  string    `json:"payment_method" gorm:"not null;type:varchar(30)"`
+	AccountNumber  string    `json:"account_number" gorm:"type:varchar(30)"` // virtual account paid into
+	LoanID         string    `json:"loan_id" gorm:"type:varchar(50);index"`
+	Amount         float64   `json:"amount" gorm:"not null;type:decimal(15,2)"`
+	Currency       string    `json:"currency" gorm:"type:char(3)"`
+	Status         string    `json:"status" gorm:"not null;type:varchar(20)"`
+	SuspenseItemID *uint     `json:"suspense_item_id"`
+	Message        string    `json:"message" gorm:"type:varchar(1000)"`
+	Payload        string    `json:"payload" gorm:"type:text"` // raw callback body as signed by the provider
+	ReceivedAt     time.Time `json:"received_at" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// CustomerRepayment represents the customer_repayments table, a customer-level repayment made with a
+// reference. A transfer is applied once per customer and reference; a repeated call gets the stored
+// outcome back instead of paying the loans again.
+type CustomerRepayment struct {
+	ID                uint                     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerID        string                   `json:"customer_id" gorm:"not null;type:varchar(36);uniqueIndex:idx_customer_reference"`
+	Reference         string                   `json:"reference" gorm:"not null;type:varchar(255);uniqueIndex:idx_customer_reference"`
+	PaymentAmount     float64                  `json:"payment_amount" gorm:"not null;type:decimal(15,2)"`
+	Currency          string                   `json:"currency" gorm:"not null;type:char(3)"`
+	Policy            string                   `json:"policy" gorm:"not null;type:varchar(30)"`
+	AllocatedAmount   float64                  `json:"allocated_amount" gorm:"not null;type:decimal(15,2)"`
+	UnallocatedAmount float64                  `json:"unallocated_amount" gorm:"not null;type:decimal(15,2)"`
+	PaymentDate       time.Time                `json:"payment_date" gorm:"not null"`
+	Loans             []*CustomerRepaymentLoan `json:"loans" gorm:"foreignKey:CustomerRepaymentID"`
+	CreatedAt         time.Time                `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// CustomerRepaymentLoan represents the customer_repayment_loans table, what one loan received from
+// a customer repayment
+type CustomerRepaymentLoan struct {
+	ID                  uint    `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerRepaymentID uint    `json:"customer_repayment_id" gorm:"not null;index"`
+	LoanID              string  `json:"loan_id" gorm:"not null;type:varchar(50);index"`
+	AllocatedAmount     float64 `json:"allocated_amount" gorm:"not null;type:decimal(15,2)"`
+	PenaltyPaid         float64 `json:"penalty_paid" gorm:"not null;type:decimal(15,2)"`
+	TaxPaid             float64 `json:"tax_paid" gorm:"not null;type:decimal(15,2)"`
+	InstallmentsPaid    int     `json:"installments_paid" gorm:"not null"`
+}
+
+// DaysPastDue returns how many whole days the schedule is overdue as of the given date
+func (s *PaymentSchedule) DaysPastDue(asOf time.Time) int {
+	dueDate := time.Date(s.InstallmentDueDate.Year(), s.InstallmentDueDate.Month(), s.InstallmentDueDate.Day(), 0, 0, 0, 0, time.Local)
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.Local)
+	days := int(today.Sub(dueDate).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
 // Constants
 const (
-	StatusPending    = "PENDING"
-	StatusPaid       = "PAID"
-	StatusDelinquent = "DELINQUENT"
+	StatusPending      = "PENDING"
+	StatusPaid         = "PAID"
+	StatusDelinquent   = "DELINQUENT"
+	StatusWrittenOff   = "WRITTEN_OFF"
+	StatusRestructured = "RESTRUCTURED"
+	StatusCancelled    = "CANCELLED"
+	StatusInactive     = "INACTIVE" // loan and installments waiting for the disbursement to complete
+
+	// Disbursement lifecycle: REQUESTED -> PROCESSING -> DISBURSED or FAILED; a DISBURSED loan
+	// cancelled in its cooling-off period ends as CANCELLED
+	DisbursementStatusRequested  = "REQUESTED"
+	DisbursementStatusProcessing = "PROCESSING"
+	DisbursementStatusDisbursed  = "DISBURSED"
+	DisbursementStatusFailed     = "FAILED"
+	DisbursementStatusCancelled  = "CANCELLED"
+
+	// Payout statuses reported by the payout gateway
+	PayoutStatusPending = "PENDING"
+	PayoutStatusSuccess = "SUCCESS"
+	PayoutStatusFailed  = "FAILED"
 
 	InstallmentUnitWeek  = "week"
 	InstallmentUnitMonth = "month"
 
 	CurrencyIDR = "IDR"
 
-	ActionPayment = "PAYMENT"
+	DefaultProductCode = "DEFAULT"
+
+	ActionPayment     = "PAYMENT"
+	ActionWriteOff    = "WRITE_OFF"
+	ActionRestructure = "RESTRUCTURE"
+	ActionReschedule  = "RESCHEDULE"
+	ActionCancel      = "CANCEL"
+	ActionActivate    = "ACTIVATE"
+
+	// Fees charged at disbursement
+	FeeTypeAdmin     = "ADMIN"
+	FeeTypeProvision = "PROVISION"
+
+	FeeCalculationFixed      = "FIXED"      // value is an amount
+	FeeCalculationPercentage = "PERCENTAGE" // value is a fraction of the principal
+
+	FeeChargeDeducted    = "DEDUCTED"    // taken from the disbursed amount
+	FeeChargeInstallment = "INSTALLMENT" // spread over the installments
+
+	// Charge types that can be taxed besides the fee types
+	ChargeTypePenalty = "PENALTY"
+
+	// Indonesian value added tax (Pajak Pertambahan Nilai)
+	TaxTypePPN = "PPN"
+
+	// Tax line statuses besides PENDING and CANCELLED
+	TaxStatusCollected = "COLLECTED"
+
+	// DPD buckets of the loan search
+	DpdBucketCurrent = "DPD_0"
+	DpdBucket1To30   = "DPD_1_30"
+	DpdBucket31To60  = "DPD_31_60"
+	DpdBucket61To90  = "DPD_61_90"
+	DpdBucketOver90  = "DPD_90_PLUS"
+
+	// Loan search sort keys
+	LoanSortID                = "id"
+	LoanSortLoanStartDate     = "loan_start_date"
+	LoanSortOutstandingAmount = "outstanding_amount"
+	LoanSortDpd               = "dpd"
+
+	// Kind of payment recorded by the repayment API
+	PaymentTypeInstallment = "INSTALLMENT"
+	PaymentTypeRecovery    = "RECOVERY"
+
+	// Policies spreading a customer-level repayment across the customer's loans
+	AllocationPolicyOldestOverdueFirst  = "OLDEST_OVERDUE_FIRST"
+	AllocationPolicyHighestPenaltyFirst = "HIGHEST_PENALTY_FIRST"
+	AllocationPolicyProRata             = "PRO_RATA"
+
+	// Ledger account types; assets and expenses have a debit balance, the others a credit balance
+	AccountTypeAsset     = "ASSET"
+	AccountTypeLiability = "LIABILITY"
+	AccountTypeEquity    = "EQUITY"
+	AccountTypeIncome    = "INCOME"
+	AccountTypeExpense   = "EXPENSE"
+
+	// Ledger accounts of the chart of accounts
+	AccountCash             = "CASH"
+	AccountLoanReceivable   = "LOAN_RECEIVABLE"   // what borrowers owe, equal to the loans' outstanding amount
+	AccountUnearnedInterest = "UNEARNED_INTEREST" // interest booked at disbursement and not earned yet
+	AccountTaxPayable       = "TAX_PAYABLE"
+	AccountOpeningBalance   = "OPENING_BALANCE" // balances of loans booked before the ledger existed
+	AccountInterestIncome   = "INTEREST_INCOME"
+	AccountFeeIncome        = "FEE_INCOME"
+	AccountPenaltyIncome    = "PENALTY_INCOME"
+	AccountRecoveryIncome   = "RECOVERY_INCOME"
+	AccountWriteOffExpense  = "WRITE_OFF_EXPENSE"
+	AccountSuspense         = "SUSPENSE" // payments received that could not be applied to a loan yet
+
+	AccountCancellationReceivable = "CANCELLATION_RECEIVABLE" // payouts of cancelled loans the borrower has not returned yet
+
+	// Journal entry types, one per kind of money movement
+	JournalEntryDisbursement    = "DISBURSEMENT"
+	JournalEntryRepayment       = "REPAYMENT"
+	JournalEntryRecovery        = "RECOVERY"
+	JournalEntryWriteOff        = "WRITE_OFF"
+	JournalEntryDeferral        = "DEFERRAL"
+	JournalEntryRestructure     = "RESTRUCTURE"
+	JournalEntryReversal        = "REVERSAL"
+	JournalEntryOpeningBalance  = "OPENING_BALANCE"
+	JournalEntryAccrual         = "INTEREST_ACCRUAL"
+	JournalEntrySuspense        = "SUSPENSE"
+	JournalEntrySuspenseRelease = "SUSPENSE_RELEASE"
+
+	// Interest accrual methods; opening balance rows mark the interest of loans booked before accrual existed
+	AccrualMethodStraightLine      = "STRAIGHT_LINE"
+	AccrualMethodEffectiveInterest = "EFFECTIVE_INTEREST"
+	AccrualMethodOpeningBalance    = "OPENING_BALANCE"
+
+	// Interest accrual statuses; suspended interest is not posted to the ledger
+	AccrualStatusAccrued   = "ACCRUED"
+	AccrualStatusSuspended = "SUSPENDED"
+
+	// End-of-day batch run and step statuses; a step is PENDING until the run reaches it
+	BatchStatusPending   = "PENDING"
+	BatchStatusRunning   = "RUNNING"
+	BatchStatusCompleted = "COMPLETED"
+	BatchStatusFailed    = "FAILED"
+
+	// End-of-day batch steps, run in this order
+	BatchStepCollectibility  = "COLLECTIBILITY"
+	BatchStepDelinquency     = "DELINQUENCY"
+	BatchStepPenalty         = "PENALTY"
+	BatchStepInterestAccrual = "INTEREST_ACCRUAL"
+	BatchStepReminder        = "REMINDER"
+	BatchStepDailyReport     = "DAILY_REPORT"
+
+	// Payment reminders sent by the end-of-day run
+	ReminderTypeUpcoming = "UPCOMING" // the installment falls due in a few days
+	ReminderTypeOverdue  = "OVERDUE"  // the installment is past due
+	ReminderStatusSent   = "SENT"
+	ReminderStatusFailed = "FAILED"
+
+	// End-of-day batch hooks, run once a run completes
+	BatchHookProvisioning = "PROVISIONING"
+
+	// Bank statement file formats
+	StatementFormatMT940 = "MT940"
+	StatementFormatCSV   = "CSV"
+
+	// Bank statement line statuses; matched lines were paid automatically, assigned lines by hand
+	StatementLineStatusUnmatched = "UNMATCHED"
+	StatementLineStatusMatched   = "MATCHED"
+	StatementLineStatusAssigned  = "ASSIGNED"
+
+	// How a bank statement line was tied to its loan
+	StatementMatchVirtualAccount = "VIRTUAL_ACCOUNT"
+	StatementMatchReference      = "REFERENCE"
+	StatementMatchManual         = "MANUAL"
+
+	// Why a payment was held in suspense
+	SuspenseReasonWrongAmount      = "WRONG_AMOUNT"       // not the exact amount due
+	SuspenseReasonUnknownLoan      = "UNKNOWN_LOAN"       // no loan with the given ID
+	SuspenseReasonClosedLoan       = "CLOSED_LOAN"        // loan paid off or cancelled
+	SuspenseReasonLoanNotDisbursed = "LOAN_NOT_DISBURSED" // loan still waiting for its disbursement
+	SuspenseReasonCurrencyMismatch = "CURRENCY_MISMATCH"  // paid in another currency than the loan's
+	SuspenseReasonUnallocated      = "UNALLOCATED"        // customer repayment no loan payment fits
+
+	// Suspense item statuses; only open items hold funds on the suspense account
+	SuspenseStatusOpen     = "OPEN"
+	SuspenseStatusResolved = "RESOLVED"
+
+	// How a suspense item was resolved
+	SuspenseResolutionApply  = "APPLY"  // applied as a repayment of a loan
+	SuspenseResolutionRefund = "REFUND" // paid back to the payer
+	SuspenseResolutionMove   = "MOVE"   // moved to another ledger account
+
+	// Where a suspense item was received from
+	SuspenseSourceRepaymentAPI   = "REPAYMENT_API"
+	SuspenseSourceVirtualAccount = "VIRTUAL_ACCOUNT"
+	SuspenseSourceQRIS           = "QRIS"
+
+	// Outcome of a payment into a virtual account
+	VirtualAccountPaymentApplied = "APPLIED"
+	VirtualAccountPaymentHeld    = "HELD"
+
+	// Payment providers pushing payment notifications
+	PaymentProviderAggregator = "AGGREGATOR" // JSON callbacks signed with HMAC-SHA256
+	PaymentProviderSNAP       = "SNAP"       // Bank Indonesia SNAP callbacks signed with RSA
+
+	// Payment methods of a payment notification
+	PaymentMethodVirtualAccount = "VIRTUAL_ACCOUNT"
+	PaymentMethodQRIS           = "QRIS"
+
+	// Outcome of a payment notification
+	PaymentNotificationApplied = "APPLIED"
+	PaymentNotificationHeld    = "HELD"
+	PaymentNotificationIgnored = "IGNORED" // not a successful payment, nothing received
+
+	// IFRS 9 impairment stages
+	ProvisionStage1 = 1 // performing, 12-month expected credit loss
+	ProvisionStage2 = 2 // significant increase in credit risk, lifetime expected credit loss
+	ProvisionStage3 = 3 // credit-impaired, lifetime expected credit loss
+
+	// Where the provision parameters applied to a loan came from
+	ProvisionParameterSourceProduct        = "PRODUCT"
+	ProvisionParameterSourceDefaultProduct = "DEFAULT_PRODUCT"
+	ProvisionParameterSourceBuiltIn        = "BUILT_IN"
+
+	// Journal export file formats and line granularity
+	JournalExportFormatCSV      = "CSV"
+	JournalExportFormatJSON     = "JSON"
+	JournalExportModeDetailed   = "DETAILED"   // one line per posting
+	JournalExportModeAggregated = "AGGREGATED" // one line per account and currency
+
+	// OJK collectibility grades (Kolektibilitas)
+	CollectibilityCurrent        = 1 // Kol 1 - Lancar
+	CollectibilitySpecialMention = 2 // Kol 2 - Dalam Perhatian Khusus
+	CollectibilitySubstandard    = 3 // Kol 3 - Kurang Lancar
+	CollectibilityDoubtful       = 4 // Kol 4 - Diragukan
+	CollectibilityLoss           = 5 // Kol 5 - Macet
+
+	// Delinquency rule types, each compared against the rule threshold
+	DelinquencyRuleOverdueInstallments = "OVERDUE_INSTALLMENTS" // overdue installments > threshold
+	DelinquencyRuleConsecutiveMissed   = "CONSECUTIVE_MISSED"   // consecutive overdue installments >= threshold
+	DelinquencyRuleDpdAbove            = "DPD_ABOVE"            // days past due > threshold
+
+	// Where the delinquency policy applied to a loan came from
+	DelinquencyPolicySourceProduct        = "PRODUCT"
+	DelinquencyPolicySourceDefaultProduct = "DEFAULT_PRODUCT"
+	DelinquencyPolicySourceBuiltIn        = "BUILT_IN"
 )
+
+// CollectibilityLabels maps each OJK collectibility grade to its official name
+var CollectibilityLabels = map[int]string{
+	CollectibilityCurrent:        "Lancar",
+	CollectibilitySpecialMention: "Dalam Perhatian Khusus",
+	CollectibilitySubstandard:    "Kurang Lancar",
+	CollectibilityDoubtful:       "Diragukan",
+	CollectibilityLoss:           "Macet",
+}
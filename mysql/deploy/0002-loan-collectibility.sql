@@ -0,0 +1,28 @@
+-- Deploy billing_engine:0002-loan-collectibility to mysql
+-- requires: 0001-create-all-tables
+BEGIN;
+
+-- Current DPD and OJK collectibility grade on each loan
+ALTER TABLE loan_summaries
+    ADD COLUMN dpd INT NOT NULL DEFAULT 0 AFTER effective_interest_rate,
+    ADD COLUMN collectibility INT NOT NULL DEFAULT 1 AFTER dpd,
+    ADD COLUMN collectibility_date DATE NULL AFTER collectibility,
+    ADD INDEX idx_dpd (dpd),
+    ADD INDEX idx_collectibility (collectibility);
+
+-- Create loan_collectibilities table (grade history with effective dates)
+CREATE TABLE IF NOT EXISTS loan_collectibilities (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    collectibility INT NOT NULL,
+    dpd INT NOT NULL,
+    outstanding_amount DECIMAL(15,2) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_to DATE NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_loan_id (loan_id),
+    INDEX idx_loan_effective (loan_id, effective_from)
+);
+
+COMMIT;
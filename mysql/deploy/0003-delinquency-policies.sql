@@ -0,0 +1,29 @@
+-- Deploy billing_engine:0003-delinquency-policies to mysql
+-- requires: 0002-loan-collectibility
+BEGIN;
+
+-- Product each loan belongs to, used to look up its delinquency policy
+ALTER TABLE loan_summaries
+    ADD COLUMN product_code VARCHAR(50) NOT NULL DEFAULT 'DEFAULT' AFTER customer_id,
+    ADD INDEX idx_product_code (product_code);
+
+-- Create delinquency_rules table (delinquency policy per product)
+CREATE TABLE IF NOT EXISTS delinquency_rules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    product_code VARCHAR(50) NOT NULL,
+    rule_type VARCHAR(50) NOT NULL,
+    threshold INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by VARCHAR(255),
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_product_code (product_code),
+    INDEX idx_deleted_at (deleted_at)
+);
+
+-- Default policy keeps the original behaviour: more than one overdue installment
+INSERT INTO delinquency_rules (product_code, rule_type, threshold, created_by, updated_by)
+VALUES ('DEFAULT', 'OVERDUE_INSTALLMENTS', 1, 'system', 'system');
+
+COMMIT;
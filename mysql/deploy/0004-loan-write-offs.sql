@@ -0,0 +1,37 @@
+-- Deploy billing_engine:0004-loan-write-offs to mysql
+-- requires: 0003-delinquency-policies
+BEGIN;
+
+-- Create loan_write_offs table (one row per written-off loan)
+CREATE TABLE IF NOT EXISTS loan_write_offs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL UNIQUE,
+    written_off_amount DECIMAL(15,2) NOT NULL,
+    recovered_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    dpd INT NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    approved_by VARCHAR(255) NOT NULL,
+    written_off_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by VARCHAR(255),
+    INDEX idx_written_off_at (written_off_at)
+);
+
+-- Create loan_recoveries table (payments received after write-off)
+CREATE TABLE IF NOT EXISTS loan_recoveries (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    write_off_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    recovered_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_loan_id (loan_id),
+    INDEX idx_write_off_id (write_off_id),
+    INDEX idx_recovered_at (recovered_at),
+    FOREIGN KEY (write_off_id) REFERENCES loan_write_offs(id)
+);
+
+COMMIT;
@@ -0,0 +1,31 @@
+-- Deploy billing_engine:0005-loan-restructures to mysql
+-- requires: 0004-loan-write-offs
+BEGIN;
+
+-- Penalty accrued on an installment, can be capitalised on restructure
+ALTER TABLE payment_schedules
+    ADD COLUMN penalty_amount DECIMAL(15,2) NOT NULL DEFAULT 0 AFTER installment_paid;
+
+-- Create loan_restructures table (links replaced and new installments by number range)
+CREATE TABLE IF NOT EXISTS loan_restructures (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    previous_outstanding_amount DECIMAL(15,2) NOT NULL,
+    capitalised_penalty_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    new_outstanding_amount DECIMAL(15,2) NOT NULL,
+    previous_installment_unit VARCHAR(100) NOT NULL,
+    previous_installment_amount DECIMAL(15,2) NOT NULL,
+    old_from_installment INT NOT NULL,
+    old_to_installment INT NOT NULL,
+    new_installment_unit VARCHAR(100) NOT NULL,
+    new_installment_amount DECIMAL(15,2) NOT NULL,
+    new_from_installment INT NOT NULL,
+    new_to_installment INT NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    restructured_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_loan_id (loan_id)
+);
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Deploy billing_engine:0006-loan-deferrals to mysql
+-- requires: 0005-loan-restructures
+BEGIN;
+
+-- Create loan_deferrals table (one row per payment holiday granted)
+CREATE TABLE IF NOT EXISTS loan_deferrals (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    from_installment INT NOT NULL,
+    periods INT NOT NULL,
+    deferred_installments INT NOT NULL,
+    deferral_interest_rate DECIMAL(5,4) NOT NULL DEFAULT 0,
+    deferral_interest_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    reason VARCHAR(500) NOT NULL,
+    deferred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_loan_id (loan_id)
+);
+
+COMMIT;
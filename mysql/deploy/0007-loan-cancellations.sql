@@ -0,0 +1,20 @@
+-- Deploy billing_engine:0007-loan-cancellations to mysql
+-- requires: 0006-loan-deferrals
+BEGIN;
+
+-- Create loan_cancellations table (cooling-off cancellations, at most one per loan)
+CREATE TABLE IF NOT EXISTS loan_cancellations (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL UNIQUE,
+    principal_amount DECIMAL(15,2) NOT NULL,
+    waived_interest_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    collectable_amount DECIMAL(15,2) NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    cancelled_by VARCHAR(255) NOT NULL,
+    cancelled_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_cancelled_at (cancelled_at)
+);
+
+COMMIT;
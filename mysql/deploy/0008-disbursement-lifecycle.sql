@@ -0,0 +1,16 @@
+-- Deploy billing_engine:0008-disbursement-lifecycle to mysql
+-- requires: 0007-loan-cancellations
+BEGIN;
+
+-- Actual payout date, payout reference and failure reason of a disbursement
+ALTER TABLE disbursement_details
+    ADD COLUMN disbursed_at TIMESTAMP NULL AFTER status,
+    ADD COLUMN reference VARCHAR(100) NULL AFTER disbursed_at,
+    ADD COLUMN failure_reason VARCHAR(500) NULL AFTER reference;
+
+-- Existing loans were treated as live immediately, so their disbursements count as completed
+UPDATE disbursement_details
+SET status = 'DISBURSED', disbursed_at = disbursement_date
+WHERE status = 'PENDING';
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Deploy billing_engine:0009-disbursement-payout to mysql
+-- requires: 0008-disbursement-lifecycle
+BEGIN;
+
+-- Beneficiary account the payout is sent to
+ALTER TABLE disbursement_details
+    ADD COLUMN bank_code VARCHAR(20) NULL AFTER disbursed_currency,
+    ADD COLUMN account_number VARCHAR(50) NULL AFTER bank_code;
+
+COMMIT;
@@ -0,0 +1,40 @@
+-- Deploy billing_engine:0010-loan-fees to mysql
+-- requires: 0009-disbursement-payout
+BEGIN;
+
+-- Fees repaid with the installments, included in the outstanding amount
+ALTER TABLE loan_summaries
+    ADD COLUMN fee_amount DECIMAL(15,2) NOT NULL DEFAULT 0 AFTER installment_amount;
+
+-- Create fee_rules table (fee schedule per product)
+CREATE TABLE IF NOT EXISTS fee_rules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    product_code VARCHAR(50) NOT NULL,
+    fee_type VARCHAR(50) NOT NULL,
+    calculation_type VARCHAR(50) NOT NULL,
+    value DECIMAL(15,4) NOT NULL,
+    charge_method VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by VARCHAR(255),
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_product_code (product_code),
+    INDEX idx_deleted_at (deleted_at)
+);
+
+-- Create loan_fees table (fees charged on each loan at disbursement)
+CREATE TABLE IF NOT EXISTS loan_fees (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    fee_type VARCHAR(50) NOT NULL,
+    calculation_type VARCHAR(50) NOT NULL,
+    value DECIMAL(15,4) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    charge_method VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_loan_id (loan_id)
+);
+
+COMMIT;
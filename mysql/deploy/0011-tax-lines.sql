@@ -0,0 +1,33 @@
+-- Deploy billing_engine:0011-tax-lines to mysql
+-- requires: 0010-loan-fees
+BEGIN;
+
+-- PPN on installment fees, repaid with the installments
+ALTER TABLE loan_summaries
+    ADD COLUMN tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0 AFTER fee_amount;
+
+ALTER TABLE payment_schedules
+    ADD COLUMN tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0 AFTER installment_amount;
+
+-- Create tax_lines table (tax charged on fees and penalties, kept apart from the charge)
+CREATE TABLE IF NOT EXISTS tax_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    tax_type VARCHAR(20) NOT NULL,
+    charge_type VARCHAR(50) NOT NULL,
+    installment_number INT NOT NULL DEFAULT 0,
+    taxable_amount DECIMAL(15,2) NOT NULL,
+    tax_rate DECIMAL(5,4) NOT NULL,
+    tax_amount DECIMAL(15,2) NOT NULL,
+    status VARCHAR(100) NOT NULL,
+    collected_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by VARCHAR(255),
+    INDEX idx_loan_id (loan_id),
+    INDEX idx_status (status),
+    INDEX idx_collected_at (collected_at)
+);
+
+COMMIT;
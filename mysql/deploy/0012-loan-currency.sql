@@ -0,0 +1,14 @@
+-- Deploy billing_engine:0012-loan-currency to mysql
+-- requires: 0011-tax-lines
+BEGIN;
+
+-- ISO 4217 currency every amount of the loan is kept in
+ALTER TABLE loan_summaries
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER product_code;
+
+UPDATE loan_summaries ls
+    JOIN disbursement_details dd ON dd.loan_id = ls.loan_id
+    SET ls.currency = dd.disbursed_currency
+    WHERE dd.disbursed_currency IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Deploy billing_engine:0013-loan-search-indexes to mysql
+-- requires: 0012-loan-currency
+BEGIN;
+
+-- Composite indexes backing the loan search filters, each ending on id for keyset pagination
+CREATE INDEX idx_loan_summaries_status_id ON loan_summaries (status, id);
+CREATE INDEX idx_loan_summaries_status_start_date ON loan_summaries (status, loan_start_date, id);
+CREATE INDEX idx_loan_summaries_status_outstanding ON loan_summaries (status, outstanding_amount, id);
+CREATE INDEX idx_loan_summaries_status_dpd ON loan_summaries (status, dpd, id);
+CREATE INDEX idx_loan_summaries_product_status ON loan_summaries (product_code, status, id);
+CREATE INDEX idx_loan_summaries_unit_status ON loan_summaries (installment_unit, status, id);
+
+COMMIT;
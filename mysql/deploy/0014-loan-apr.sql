@@ -0,0 +1,11 @@
+-- Deploy billing_engine:0014-loan-apr to mysql
+-- requires: 0013-loan-search-indexes
+BEGIN;
+
+-- APR and effective interest rate are solved from the loan cash flows at disbursement. Loans
+-- disbursed before this change keep an APR of 0 and their flat rate as effective interest rate.
+ALTER TABLE loan_summaries
+    MODIFY effective_interest_rate DECIMAL(16,6) NOT NULL,
+    ADD COLUMN annual_percentage_rate DECIMAL(16,6) NOT NULL DEFAULT 0 AFTER tax_amount;
+
+COMMIT;
@@ -0,0 +1,79 @@
+-- Deploy billing_engine:0015-general-ledger to mysql
+-- requires: 0014-loan-apr
+BEGIN;
+
+-- Create ledger_accounts table (chart of accounts of the double-entry ledger)
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO ledger_accounts (code, name, type) VALUES
+    ('CASH', 'Cash', 'ASSET'),
+    ('LOAN_RECEIVABLE', 'Loan Receivable', 'ASSET'),
+    ('UNEARNED_INTEREST', 'Unearned Interest', 'LIABILITY'),
+    ('TAX_PAYABLE', 'Tax Payable', 'LIABILITY'),
+    ('OPENING_BALANCE', 'Opening Balance', 'EQUITY'),
+    ('INTEREST_INCOME', 'Interest Income', 'INCOME'),
+    ('FEE_INCOME', 'Fee Income', 'INCOME'),
+    ('PENALTY_INCOME', 'Penalty Income', 'INCOME'),
+    ('RECOVERY_INCOME', 'Recovery Income', 'INCOME'),
+    ('WRITE_OFF_EXPENSE', 'Write-off Expense', 'EXPENSE');
+
+-- Create journal_entries table (one balanced entry per money movement, never updated)
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    entry_type VARCHAR(50) NOT NULL,
+    loan_id VARCHAR(50) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    reference VARCHAR(255),
+    description VARCHAR(500),
+    reversal_of_id INT NULL,
+    entry_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    INDEX idx_entry_type (entry_type),
+    INDEX idx_loan_id (loan_id),
+    INDEX idx_reversal_of_id (reversal_of_id),
+    INDEX idx_entry_date (entry_date),
+    FOREIGN KEY (reversal_of_id) REFERENCES journal_entries(id)
+);
+
+-- Create journal_postings table (debits and credits of the entries on the ledger accounts)
+CREATE TABLE IF NOT EXISTS journal_postings (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    journal_entry_id INT NOT NULL,
+    account_code VARCHAR(50) NOT NULL,
+    loan_id VARCHAR(50) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    debit DECIMAL(15,2) NOT NULL DEFAULT 0,
+    credit DECIMAL(15,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_journal_entry_id (journal_entry_id),
+    INDEX idx_loan_account (loan_id, account_code),
+    INDEX idx_account_code (account_code),
+    FOREIGN KEY (journal_entry_id) REFERENCES journal_entries(id),
+    FOREIGN KEY (account_code) REFERENCES ledger_accounts(code)
+);
+
+-- Loans booked before the ledger existed open with their outstanding amount on the loan receivable
+INSERT INTO journal_entries (entry_type, loan_id, currency, description, entry_date, created_by)
+SELECT 'OPENING_BALANCE', loan_id, currency, 'Opening balance', CURRENT_TIMESTAMP, 'system'
+FROM loan_summaries
+WHERE status IN ('PENDING', 'DELINQUENT') AND outstanding_amount > 0 AND deleted_at IS NULL;
+
+INSERT INTO journal_postings (journal_entry_id, account_code, loan_id, currency, debit, credit)
+SELECT je.id, 'LOAN_RECEIVABLE', ls.loan_id, ls.currency, ls.outstanding_amount, 0
+FROM journal_entries je
+JOIN loan_summaries ls ON ls.loan_id = je.loan_id
+WHERE je.entry_type = 'OPENING_BALANCE';
+
+INSERT INTO journal_postings (journal_entry_id, account_code, loan_id, currency, debit, credit)
+SELECT je.id, 'OPENING_BALANCE', ls.loan_id, ls.currency, 0, ls.outstanding_amount
+FROM journal_entries je
+JOIN loan_summaries ls ON ls.loan_id = je.loan_id
+WHERE je.entry_type = 'OPENING_BALANCE';
+
+COMMIT;
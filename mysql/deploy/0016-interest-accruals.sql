@@ -0,0 +1,30 @@
+-- Deploy billing_engine:0016-interest-accruals to mysql
+-- requires: 0015-general-ledger
+BEGIN;
+
+-- Create interest_accruals table (interest recognised per loan and day, or suspended while non-performing)
+CREATE TABLE IF NOT EXISTS interest_accruals (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id VARCHAR(50) NOT NULL,
+    accrual_date DATE NOT NULL,
+    method VARCHAR(50) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    UNIQUE INDEX idx_loan_accrual_date (loan_id, accrual_date),
+    INDEX idx_status (status)
+);
+
+-- Loans disbursed before the ledger existed have no unearned interest to accrue from: their
+-- interest is marked as accrued up to yesterday so the accrual job leaves them alone
+INSERT INTO interest_accruals (loan_id, accrual_date, method, amount, currency, status, created_by)
+SELECT ls.loan_id, CURRENT_DATE - INTERVAL 1 DAY, 'OPENING_BALANCE', ls.interest_amount, ls.currency, 'ACCRUED', 'system'
+FROM loan_summaries ls
+WHERE ls.status IN ('PENDING', 'DELINQUENT', 'PAID') AND ls.interest_amount > 0 AND ls.deleted_at IS NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM journal_entries je WHERE je.loan_id = ls.loan_id AND je.entry_type = 'DISBURSEMENT'
+    );
+
+COMMIT;
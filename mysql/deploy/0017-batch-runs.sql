@@ -0,0 +1,38 @@
+-- Deploy billing_engine:0017-batch-runs to mysql
+-- requires: 0016-interest-accruals
+BEGIN;
+
+-- Create batch_runs table (one end-of-day run per business date)
+CREATE TABLE IF NOT EXISTS batch_runs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    business_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    triggered_by VARCHAR(255),
+    error_message VARCHAR(1000),
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_business_date (business_date),
+    INDEX idx_status (status)
+);
+
+-- Create batch_step_runs table (progress and checkpoint of each step of a run)
+CREATE TABLE IF NOT EXISTS batch_step_runs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    batch_run_id INT NOT NULL,
+    step_name VARCHAR(50) NOT NULL,
+    sequence INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    checkpoint_loan_id INT NOT NULL DEFAULT 0,
+    processed_loans INT NOT NULL DEFAULT 0,
+    failed_loans INT NOT NULL DEFAULT 0,
+    error_message VARCHAR(1000),
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_batch_run_step (batch_run_id, step_name),
+    FOREIGN KEY (batch_run_id) REFERENCES batch_runs(id)
+);
+
+COMMIT;
@@ -0,0 +1,57 @@
+-- Deploy billing_engine:0018-loan-provisions to mysql
+-- requires: 0017-batch-runs
+BEGIN;
+
+-- Create provision_parameters table (IFRS 9 PD and LGD per product and stage)
+CREATE TABLE IF NOT EXISTS provision_parameters (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    product_code VARCHAR(50) NOT NULL,
+    stage INT NOT NULL,
+    probability_of_default DECIMAL(7,6) NOT NULL,
+    loss_given_default DECIMAL(7,6) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by VARCHAR(255),
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_product_code (product_code),
+    INDEX idx_deleted_at (deleted_at)
+);
+
+-- Default parameters match the built-in ones
+INSERT INTO provision_parameters (product_code, stage, probability_of_default, loss_given_default, created_by, updated_by)
+VALUES ('DEFAULT', 1, 0.010000, 0.450000, 'system', 'system'),
+       ('DEFAULT', 2, 0.200000, 0.450000, 'system', 'system'),
+       ('DEFAULT', 3, 1.000000, 0.450000, 'system', 'system');
+
+-- Create provision_runs table (one expected credit loss snapshot per month)
+CREATE TABLE IF NOT EXISTS provision_runs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    period CHAR(7) NOT NULL,
+    period_end DATE NOT NULL,
+    loan_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    UNIQUE INDEX idx_period (period)
+);
+
+-- Create loan_provisions table (stage and expected credit loss of each loan in a run)
+CREATE TABLE IF NOT EXISTS loan_provisions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    provision_run_id INT NOT NULL,
+    loan_id VARCHAR(50) NOT NULL,
+    product_code VARCHAR(50) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    dpd INT NOT NULL,
+    stage INT NOT NULL,
+    exposure_amount DECIMAL(15,2) NOT NULL,
+    probability_of_default DECIMAL(7,6) NOT NULL,
+    loss_given_default DECIMAL(7,6) NOT NULL,
+    provision_amount DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_run_loan (provision_run_id, loan_id),
+    INDEX idx_loan_id (loan_id),
+    FOREIGN KEY (provision_run_id) REFERENCES provision_runs(id)
+);
+
+COMMIT;
@@ -0,0 +1,47 @@
+-- Deploy billing_engine:0019-bank-statements to mysql
+-- requires: 0018-loan-provisions
+BEGIN;
+
+-- Create bank_statements table (imported MT940 and CSV statement files)
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    file_name VARCHAR(255),
+    format VARCHAR(10) NOT NULL,
+    account_number VARCHAR(50),
+    statement_reference VARCHAR(50),
+    total_lines INT NOT NULL DEFAULT 0,
+    credit_lines INT NOT NULL DEFAULT 0,
+    duplicate_lines INT NOT NULL DEFAULT 0,
+    matched_lines INT NOT NULL DEFAULT 0,
+    unmatched_lines INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255)
+);
+
+-- Create bank_statement_lines table (incoming transfers and the unmatched queue)
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    bank_statement_id INT NOT NULL,
+    line_key CHAR(64) NOT NULL,
+    account_number VARCHAR(50),
+    value_date DATE NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    reference VARCHAR(255),
+    description VARCHAR(1000),
+    status VARCHAR(20) NOT NULL,
+    match_method VARCHAR(20),
+    loan_id VARCHAR(50),
+    reason VARCHAR(1000),
+    matched_at TIMESTAMP NULL,
+    matched_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_line_key (line_key),
+    INDEX idx_bank_statement_id (bank_statement_id),
+    INDEX idx_status (status),
+    INDEX idx_loan_id (loan_id),
+    FOREIGN KEY (bank_statement_id) REFERENCES bank_statements(id)
+);
+
+COMMIT;
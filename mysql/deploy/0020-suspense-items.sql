@@ -0,0 +1,36 @@
+-- Deploy billing_engine:0020-suspense-items to mysql
+-- requires: 0019-bank-statements
+BEGIN;
+
+-- Add the suspense account holding payments that could not be applied to a loan
+INSERT INTO ledger_accounts (code, name, type) VALUES
+    ('SUSPENSE', 'Suspense', 'LIABILITY');
+
+-- Create suspense_items table (rejected payments waiting to be applied, refunded or moved)
+CREATE TABLE IF NOT EXISTS suspense_items (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    source VARCHAR(30) NOT NULL,
+    source_reference VARCHAR(255),
+    loan_id VARCHAR(50),
+    amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    reason_code VARCHAR(30) NOT NULL,
+    reason VARCHAR(1000),
+    status VARCHAR(20) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolution VARCHAR(20),
+    resolved_loan_id VARCHAR(50),
+    account_code VARCHAR(50),
+    refund_reference VARCHAR(255),
+    note VARCHAR(500),
+    resolved_at TIMESTAMP NULL,
+    resolved_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_loan_id (loan_id),
+    INDEX idx_status (status),
+    INDEX idx_reason_code (reason_code),
+    INDEX idx_received_at (received_at)
+);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Deploy billing_engine:0021-loan-virtual-accounts to mysql
+-- requires: 0020-suspense-items
+BEGIN;
+
+-- Add the virtual account the borrower repays the loan into; loans booked before have none
+ALTER TABLE loan_summaries
+    ADD COLUMN virtual_account_number VARCHAR(30) NULL AFTER effective_interest_rate,
+    ADD COLUMN virtual_account_bank VARCHAR(20) NULL AFTER virtual_account_number,
+    ADD INDEX idx_virtual_account_number (virtual_account_number);
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- Deploy billing_engine:0022-payment-notifications to mysql
+-- requires: 0021-loan-virtual-accounts
+BEGIN;
+
+-- Create payment_notifications table (payment callbacks of the payment providers, one per provider transaction)
+CREATE TABLE IF NOT EXISTS payment_notifications (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    provider VARCHAR(30) NOT NULL,
+    transaction_id VARCHAR(100) NOT NULL,
+    payment_method VARCHAR(30) NOT NULL,
+    account_number VARCHAR(30),
+    loan_id VARCHAR(50),
+    amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3),
+    status VARCHAR(20) NOT NULL,
+    suspense_item_id INT,
+    message VARCHAR(1000),
+    payload TEXT,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_provider_transaction (provider, transaction_id),
+    INDEX idx_loan_id (loan_id),
+    FOREIGN KEY (suspense_item_id) REFERENCES suspense_items(id)
+);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Deploy billing_engine:0023-cancellation-receivable to mysql
+-- requires: 0022-payment-notifications
+BEGIN;
+
+-- Add the account holding the payouts of cancelled loans until the borrower returns them
+INSERT INTO ledger_accounts (code, name, type) VALUES
+    ('CANCELLATION_RECEIVABLE', 'Cancellation Receivable', 'ASSET');
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Deploy billing_engine:0024-void-loan-fees to mysql
+-- requires: 0023-cancellation-receivable
+BEGIN;
+
+-- Fees of a loan whose payout failed are voided, never charged
+ALTER TABLE loan_fees
+    ADD COLUMN deleted_at TIMESTAMP NULL AFTER created_by,
+    ADD COLUMN deleted_by VARCHAR(255) AFTER deleted_at,
+    ADD INDEX idx_deleted_at (deleted_at);
+
+COMMIT;
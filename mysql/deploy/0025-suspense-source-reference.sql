@@ -0,0 +1,9 @@
+-- Deploy billing_engine:0025-suspense-source-reference to mysql
+-- requires: 0024-void-loan-fees
+BEGIN;
+
+-- A payment held twice under the same source reference is found and not booked again
+ALTER TABLE suspense_items
+    ADD INDEX idx_source_reference (source, source_reference);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Deploy billing_engine:0026-bank-statement-line-virtual-account to mysql
+-- requires: 0025-suspense-source-reference
+BEGIN;
+
+-- Keep the virtual account a transfer was paid into, so the line is matched to the loan behind it
+ALTER TABLE bank_statement_lines
+    ADD COLUMN virtual_account VARCHAR(30) NULL AFTER account_number;
+
+COMMIT;
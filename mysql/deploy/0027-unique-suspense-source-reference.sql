@@ -0,0 +1,13 @@
+-- Deploy billing_engine:0027-unique-suspense-source-reference to mysql
+-- requires: 0026-bank-statement-line-virtual-account
+BEGIN;
+
+-- Items without a reference are stored as NULL so they never collide
+UPDATE suspense_items SET source_reference = NULL WHERE source_reference = '';
+
+-- A payment is held once per source reference; a concurrent second hold hits the key and finds the first
+ALTER TABLE suspense_items
+    DROP INDEX idx_source_reference,
+    ADD UNIQUE INDEX idx_source_reference (source, source_reference);
+
+COMMIT;
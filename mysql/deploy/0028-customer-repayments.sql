@@ -0,0 +1,34 @@
+-- Deploy billing_engine:0028-customer-repayments to mysql
+-- requires: 0027-unique-suspense-source-reference
+BEGIN;
+
+-- Create customer_repayments table (customer-level repayments made with a reference, applied once each)
+CREATE TABLE IF NOT EXISTS customer_repayments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    customer_id VARCHAR(36) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    payment_amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    policy VARCHAR(30) NOT NULL,
+    allocated_amount DECIMAL(15,2) NOT NULL,
+    unallocated_amount DECIMAL(15,2) NOT NULL,
+    payment_date TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_customer_reference (customer_id, reference)
+);
+
+-- Create customer_repayment_loans table (what each loan received from a customer repayment)
+CREATE TABLE IF NOT EXISTS customer_repayment_loans (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    customer_repayment_id INT NOT NULL,
+    loan_id VARCHAR(50) NOT NULL,
+    allocated_amount DECIMAL(15,2) NOT NULL,
+    penalty_paid DECIMAL(15,2) NOT NULL,
+    tax_paid DECIMAL(15,2) NOT NULL,
+    installments_paid INT NOT NULL,
+    INDEX idx_customer_repayment_id (customer_repayment_id),
+    INDEX idx_loan_id (loan_id),
+    FOREIGN KEY (customer_repayment_id) REFERENCES customer_repayments(id)
+);
+
+COMMIT;
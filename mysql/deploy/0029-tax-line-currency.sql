@@ -0,0 +1,13 @@
+-- Deploy billing_engine:0029-tax-line-currency to mysql
+-- requires: 0028-customer-repayments
+BEGIN;
+
+-- Currency of the tax line, the currency of its loan, so tax is only added up within a currency
+ALTER TABLE tax_lines
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER tax_amount;
+
+UPDATE tax_lines tl
+    JOIN loan_summaries ls ON ls.loan_id = tl.loan_id
+    SET tl.currency = ls.currency;
+
+COMMIT;
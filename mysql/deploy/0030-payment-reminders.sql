@@ -0,0 +1,27 @@
+-- Deploy billing_engine:0030-payment-reminders to mysql
+-- requires: 0029-tax-line-currency
+BEGIN;
+
+-- Create payment_reminders table (reminder of an installment sent by the end-of-day run of a business date)
+CREATE TABLE IF NOT EXISTS payment_reminders (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    schedule_id INT NOT NULL,
+    business_date DATE NOT NULL,
+    loan_id VARCHAR(50) NOT NULL,
+    customer_id VARCHAR(36) NOT NULL,
+    reminder_type VARCHAR(20) NOT NULL,
+    installment_number INT NOT NULL,
+    installment_due_date DATE NOT NULL,
+    days_past_due INT NOT NULL DEFAULT 0,
+    amount_due DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    status VARCHAR(20) NOT NULL,
+    error_message VARCHAR(1000),
+    sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_schedule_business_date (schedule_id, business_date),
+    INDEX idx_loan_id (loan_id)
+);
+
+COMMIT;
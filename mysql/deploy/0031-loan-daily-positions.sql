@@ -0,0 +1,24 @@
+-- Deploy billing_engine:0031-loan-daily-positions to mysql
+-- requires: 0030-payment-reminders
+BEGIN;
+
+-- Create loan_daily_positions table (position of each active loan at the end of a business date)
+CREATE TABLE IF NOT EXISTS loan_daily_positions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    business_date DATE NOT NULL,
+    loan_id VARCHAR(50) NOT NULL,
+    customer_id VARCHAR(36) NOT NULL,
+    product_code VARCHAR(50) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    status VARCHAR(100) NOT NULL,
+    outstanding_amount DECIMAL(15,2) NOT NULL,
+    overdue_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    dpd INT NOT NULL DEFAULT 0,
+    collectibility INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_business_date_loan (business_date, loan_id),
+    INDEX idx_loan_id (loan_id)
+);
+
+COMMIT;
@@ -0,0 +1,3 @@
+#!/bin/sh
+# Deploy the sqitch changes that come after the initial schema
+sh /billing-engine-mysql/migrate.sh
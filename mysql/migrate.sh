@@ -0,0 +1,26 @@
+#!/bin/sh
+# Deploys every change listed in sqitch.plan that has not been deployed yet, in plan order.
+# Deployed changes are recorded in the schema_changes table so the script can be re-run safely.
+# Uses the MYSQL_USER, MYSQL_PASSWORD and MYSQL_DATABASE variables of the MySQL container.
+set -e
+
+MYSQL_DIR=$(cd "$(dirname "$0")" && pwd)
+
+mysql_exec() {
+	mysql -u"$MYSQL_USER" -p"$MYSQL_PASSWORD" "$MYSQL_DATABASE" "$@"
+}
+
+mysql_exec -e "CREATE TABLE IF NOT EXISTS schema_changes (
+	change_name VARCHAR(255) PRIMARY KEY,
+	deployed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)"
+
+for change in $(grep -v '^%' "$MYSQL_DIR/sqitch.plan" | awk 'NF {print $1}'); do
+	deployed=$(mysql_exec -N -e "SELECT COUNT(*) FROM schema_changes WHERE change_name = '$change'")
+	if [ "$deployed" -ne 0 ]; then
+		continue
+	fi
+	echo "Deploying $change"
+	mysql_exec < "$MYSQL_DIR/deploy/$change.sql"
+	mysql_exec -e "INSERT INTO schema_changes (change_name) VALUES ('$change')"
+done
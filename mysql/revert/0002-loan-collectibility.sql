@@ -0,0 +1,13 @@
+-- Revert billing_engine:0002-loan-collectibility from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_collectibilities;
+
+ALTER TABLE loan_summaries
+    DROP INDEX idx_collectibility,
+    DROP INDEX idx_dpd,
+    DROP COLUMN collectibility_date,
+    DROP COLUMN collectibility,
+    DROP COLUMN dpd;
+
+COMMIT;
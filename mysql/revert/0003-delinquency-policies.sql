@@ -0,0 +1,10 @@
+-- Revert billing_engine:0003-delinquency-policies from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS delinquency_rules;
+
+ALTER TABLE loan_summaries
+    DROP INDEX idx_product_code,
+    DROP COLUMN product_code;
+
+COMMIT;
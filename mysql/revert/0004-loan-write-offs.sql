@@ -0,0 +1,7 @@
+-- Revert billing_engine:0004-loan-write-offs from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_recoveries;
+DROP TABLE IF EXISTS loan_write_offs;
+
+COMMIT;
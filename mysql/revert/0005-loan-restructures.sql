@@ -0,0 +1,9 @@
+-- Revert billing_engine:0005-loan-restructures from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_restructures;
+
+ALTER TABLE payment_schedules
+    DROP COLUMN penalty_amount;
+
+COMMIT;
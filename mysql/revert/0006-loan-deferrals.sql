@@ -0,0 +1,6 @@
+-- Revert billing_engine:0006-loan-deferrals from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_deferrals;
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Revert billing_engine:0007-loan-cancellations from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_cancellations;
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Revert billing_engine:0008-disbursement-lifecycle from mysql
+BEGIN;
+
+UPDATE disbursement_details
+SET status = 'PENDING'
+WHERE status IN ('REQUESTED', 'PROCESSING', 'DISBURSED');
+
+ALTER TABLE disbursement_details
+    DROP COLUMN failure_reason,
+    DROP COLUMN reference,
+    DROP COLUMN disbursed_at;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert billing_engine:0009-disbursement-payout from mysql
+BEGIN;
+
+ALTER TABLE disbursement_details
+    DROP COLUMN account_number,
+    DROP COLUMN bank_code;
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Revert billing_engine:0010-loan-fees from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_fees;
+DROP TABLE IF EXISTS fee_rules;
+
+ALTER TABLE loan_summaries
+    DROP COLUMN fee_amount;
+
+COMMIT;
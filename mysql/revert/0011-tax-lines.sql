@@ -0,0 +1,12 @@
+-- Revert billing_engine:0011-tax-lines from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS tax_lines;
+
+ALTER TABLE payment_schedules
+    DROP COLUMN tax_amount;
+
+ALTER TABLE loan_summaries
+    DROP COLUMN tax_amount;
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Revert billing_engine:0012-loan-currency from mysql
+BEGIN;
+
+ALTER TABLE loan_summaries
+    DROP COLUMN currency;
+
+COMMIT;
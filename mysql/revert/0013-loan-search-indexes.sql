@@ -0,0 +1,11 @@
+-- Revert billing_engine:0013-loan-search-indexes from mysql
+BEGIN;
+
+DROP INDEX idx_loan_summaries_status_id ON loan_summaries;
+DROP INDEX idx_loan_summaries_status_start_date ON loan_summaries;
+DROP INDEX idx_loan_summaries_status_outstanding ON loan_summaries;
+DROP INDEX idx_loan_summaries_status_dpd ON loan_summaries;
+DROP INDEX idx_loan_summaries_product_status ON loan_summaries;
+DROP INDEX idx_loan_summaries_unit_status ON loan_summaries;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert billing_engine:0014-loan-apr from mysql
+BEGIN;
+
+ALTER TABLE loan_summaries
+    DROP COLUMN annual_percentage_rate,
+    MODIFY effective_interest_rate DECIMAL(5,4) NOT NULL;
+
+COMMIT;
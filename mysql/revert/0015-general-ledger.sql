@@ -0,0 +1,8 @@
+-- Revert billing_engine:0015-general-ledger from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS journal_postings;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
+
+COMMIT;
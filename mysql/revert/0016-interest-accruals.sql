@@ -0,0 +1,6 @@
+-- Revert billing_engine:0016-interest-accruals from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS interest_accruals;
+
+COMMIT;
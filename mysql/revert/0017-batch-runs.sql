@@ -0,0 +1,7 @@
+-- Revert billing_engine:0017-batch-runs from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS batch_step_runs;
+DROP TABLE IF EXISTS batch_runs;
+
+COMMIT;
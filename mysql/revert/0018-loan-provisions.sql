@@ -0,0 +1,8 @@
+-- Revert billing_engine:0018-loan-provisions from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_provisions;
+DROP TABLE IF EXISTS provision_runs;
+DROP TABLE IF EXISTS provision_parameters;
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Revert billing_engine:0019-bank-statements from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS bank_statement_lines;
+DROP TABLE IF EXISTS bank_statements;
+
+COMMIT;
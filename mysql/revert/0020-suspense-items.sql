@@ -0,0 +1,7 @@
+-- Revert billing_engine:0020-suspense-items from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS suspense_items;
+DELETE FROM ledger_accounts WHERE code = 'SUSPENSE';
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Revert billing_engine:0021-loan-virtual-accounts from mysql
+BEGIN;
+
+ALTER TABLE loan_summaries
+    DROP INDEX idx_virtual_account_number,
+    DROP COLUMN virtual_account_bank,
+    DROP COLUMN virtual_account_number;
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Revert billing_engine:0022-payment-notifications from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS payment_notifications;
+
+COMMIT;
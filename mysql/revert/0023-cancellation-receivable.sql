@@ -0,0 +1,6 @@
+-- Revert billing_engine:0023-cancellation-receivable from mysql
+BEGIN;
+
+DELETE FROM ledger_accounts WHERE code = 'CANCELLATION_RECEIVABLE';
+
+COMMIT;
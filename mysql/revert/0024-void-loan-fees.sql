@@ -0,0 +1,9 @@
+-- Revert billing_engine:0024-void-loan-fees from mysql
+BEGIN;
+
+ALTER TABLE loan_fees
+    DROP INDEX idx_deleted_at,
+    DROP COLUMN deleted_by,
+    DROP COLUMN deleted_at;
+
+COMMIT;
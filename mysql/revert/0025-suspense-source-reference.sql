@@ -0,0 +1,7 @@
+-- Revert billing_engine:0025-suspense-source-reference from mysql
+BEGIN;
+
+ALTER TABLE suspense_items
+    DROP INDEX idx_source_reference;
+
+COMMIT;
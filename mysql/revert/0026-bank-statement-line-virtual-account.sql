@@ -0,0 +1,7 @@
+-- Revert billing_engine:0026-bank-statement-line-virtual-account from mysql
+BEGIN;
+
+ALTER TABLE bank_statement_lines
+    DROP COLUMN virtual_account;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert billing_engine:0027-unique-suspense-source-reference from mysql
+BEGIN;
+
+ALTER TABLE suspense_items
+    DROP INDEX idx_source_reference,
+    ADD INDEX idx_source_reference (source, source_reference);
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Revert billing_engine:0028-customer-repayments from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS customer_repayment_loans;
+DROP TABLE IF EXISTS customer_repayments;
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Revert billing_engine:0029-tax-line-currency from mysql
+BEGIN;
+
+ALTER TABLE tax_lines
+    DROP COLUMN currency;
+
+COMMIT;
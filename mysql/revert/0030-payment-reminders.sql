@@ -0,0 +1,6 @@
+-- Revert billing_engine:0030-payment-reminders from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS payment_reminders;
+
+COMMIT;
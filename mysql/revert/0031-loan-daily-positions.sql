@@ -0,0 +1,6 @@
+-- Revert billing_engine:0031-loan-daily-positions from mysql
+BEGIN;
+
+DROP TABLE IF EXISTS loan_daily_positions;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Verify billing_engine:0002-loan-collectibility on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'loan_collectibilities';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'dpd';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'collectibility';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'collectibility_date';
+
+ROLLBACK;
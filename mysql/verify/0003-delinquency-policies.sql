@@ -0,0 +1,7 @@
+-- Verify billing_engine:0003-delinquency-policies on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'delinquency_rules';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'product_code';
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify billing_engine:0004-loan-write-offs on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'loan_write_offs';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'loan_recoveries';
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify billing_engine:0005-loan-restructures on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'loan_restructures';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'payment_schedules' AND column_name = 'penalty_amount';
+
+ROLLBACK;
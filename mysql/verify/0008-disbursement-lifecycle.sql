@@ -0,0 +1,8 @@
+-- Verify billing_engine:0008-disbursement-lifecycle on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'disbursement_details' AND column_name = 'disbursed_at';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'disbursement_details' AND column_name = 'reference';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'disbursement_details' AND column_name = 'failure_reason';
+
+ROLLBACK;
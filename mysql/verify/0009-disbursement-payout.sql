@@ -0,0 +1,7 @@
+-- Verify billing_engine:0009-disbursement-payout on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'disbursement_details' AND column_name = 'bank_code';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'disbursement_details' AND column_name = 'account_number';
+
+ROLLBACK;
@@ -0,0 +1,8 @@
+-- Verify billing_engine:0010-loan-fees on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'fee_rules';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'loan_fees';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'fee_amount';
+
+ROLLBACK;
@@ -0,0 +1,8 @@
+-- Verify billing_engine:0011-tax-lines on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'tax_lines';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'tax_amount';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'payment_schedules' AND column_name = 'tax_amount';
+
+ROLLBACK;
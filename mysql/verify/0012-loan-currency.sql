@@ -0,0 +1,6 @@
+-- Verify billing_engine:0012-loan-currency on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'currency';
+
+ROLLBACK;
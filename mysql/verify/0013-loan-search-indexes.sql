@@ -0,0 +1,11 @@
+-- Verify billing_engine:0013-loan-search-indexes on mysql
+BEGIN;
+
+SELECT 1/COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND index_name = 'idx_loan_summaries_status_id';
+SELECT 1/COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND index_name = 'idx_loan_summaries_status_start_date';
+SELECT 1/COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND index_name = 'idx_loan_summaries_status_outstanding';
+SELECT 1/COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND index_name = 'idx_loan_summaries_status_dpd';
+SELECT 1/COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND index_name = 'idx_loan_summaries_product_status';
+SELECT 1/COUNT(DISTINCT index_name) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND index_name = 'idx_loan_summaries_unit_status';
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify billing_engine:0014-loan-apr on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'annual_percentage_rate';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'effective_interest_rate' AND numeric_precision = 16 AND numeric_scale = 6;
+
+ROLLBACK;
@@ -0,0 +1,8 @@
+-- Verify billing_engine:0015-general-ledger on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'ledger_accounts';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'journal_entries';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'journal_postings';
+
+ROLLBACK;
@@ -0,0 +1,6 @@
+-- Verify billing_engine:0016-interest-accruals on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'interest_accruals';
+
+ROLLBACK;
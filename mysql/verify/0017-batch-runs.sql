@@ -0,0 +1,7 @@
+-- Verify billing_engine:0017-batch-runs on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'batch_runs';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'batch_step_runs';
+
+ROLLBACK;
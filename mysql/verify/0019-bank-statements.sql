@@ -0,0 +1,7 @@
+-- Verify billing_engine:0019-bank-statements on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'bank_statements';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'bank_statement_lines';
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify billing_engine:0020-suspense-items on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'suspense_items';
+SELECT 1/COUNT(*) FROM ledger_accounts WHERE code = 'SUSPENSE';
+
+ROLLBACK;
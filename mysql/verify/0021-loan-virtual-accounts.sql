@@ -0,0 +1,7 @@
+-- Verify billing_engine:0021-loan-virtual-accounts on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'virtual_account_number';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_summaries' AND column_name = 'virtual_account_bank';
+
+ROLLBACK;
@@ -0,0 +1,6 @@
+-- Verify billing_engine:0022-payment-notifications on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'payment_notifications';
+
+ROLLBACK;
@@ -0,0 +1,6 @@
+-- Verify billing_engine:0023-cancellation-receivable on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM ledger_accounts WHERE code = 'CANCELLATION_RECEIVABLE';
+
+ROLLBACK;
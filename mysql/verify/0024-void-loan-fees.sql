@@ -0,0 +1,7 @@
+-- Verify billing_engine:0024-void-loan-fees on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_fees' AND column_name = 'deleted_at';
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'loan_fees' AND column_name = 'deleted_by';
+
+ROLLBACK;
@@ -0,0 +1,6 @@
+-- Verify billing_engine:0026-bank-statement-line-virtual-account on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'bank_statement_lines' AND column_name = 'virtual_account';
+
+ROLLBACK;
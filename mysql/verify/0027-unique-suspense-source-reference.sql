@@ -0,0 +1,6 @@
+-- Verify billing_engine:0027-unique-suspense-source-reference on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'suspense_items' AND index_name = 'idx_source_reference' AND non_unique = 0;
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify billing_engine:0028-customer-repayments on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'customer_repayments';
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'customer_repayment_loans';
+
+ROLLBACK;
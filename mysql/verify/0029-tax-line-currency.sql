@@ -0,0 +1,6 @@
+-- Verify billing_engine:0029-tax-line-currency on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'tax_lines' AND column_name = 'currency';
+
+ROLLBACK;
@@ -0,0 +1,6 @@
+-- Verify billing_engine:0031-loan-daily-positions on mysql
+BEGIN;
+
+SELECT 1/COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'loan_daily_positions';
+
+ROLLBACK;
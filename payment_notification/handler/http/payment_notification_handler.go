@@ -0,0 +1,77 @@
+package http
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/payment_notification"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PaymentNotificationHandler struct {
+	paymentNotificationService payment_notification.PaymentNotificationServiceInterface
+	middleware                 middlewares.GoMiddlewareInterface
+	providers                  map[string]payment_notification.PaymentProviderInterface // by provider code
+}
+
+// NewPaymentNotificationHandler creates a new payment notification handler instance accepting
+// callbacks of the given providers
+func NewPaymentNotificationHandler(e *echo.Echo, paymentNotificationService payment_notification.PaymentNotificationServiceInterface, middleware middlewares.GoMiddlewareInterface, providers []payment_notification.PaymentProviderInterface) {
+	handler := &PaymentNotificationHandler{
+		paymentNotificationService: paymentNotificationService,
+		middleware:                 middleware,
+		providers:                  make(map[string]payment_notification.PaymentProviderInterface, len(providers)),
+	}
+	for _, provider := range providers {
+		handler.providers[provider.Code()] = provider
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/payments/notifications", handler.HandleNotification)
+}
+
+// HandleNotification receives payment callbacks; the provider query parameter names the provider,
+// whose signature, format and acknowledgement the callback follows
+func (h *PaymentNotificationHandler) HandleNotification(c echo.Context) error {
+	provider, ok := h.providers[strings.ToUpper(c.QueryParam("provider"))]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Unknown payment provider",
+		})
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, provider.Reject(http.StatusBadRequest, "Invalid request body"))
+	}
+	if err := provider.VerifySignature(c.Request(), body); err != nil {
+		return c.JSON(http.StatusUnauthorized, provider.Reject(http.StatusUnauthorized, "Invalid signature"))
+	}
+
+	req, err := provider.ParseNotification(body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, provider.Reject(http.StatusBadRequest, "Invalid request body"))
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, provider.Reject(http.StatusBadRequest, err.Error()))
+	}
+
+	response, err := h.paymentNotificationService.ProcessNotification(c.Request().Context(), req)
+	if err != nil {
+		// The cause stays in the log; the provider only learns the callback was not processed
+		log.Printf("payment notification %s from %s failed: %v", req.TransactionID, provider.Code(), err)
+		return c.JSON(http.StatusInternalServerError, provider.Reject(http.StatusInternalServerError, "Failed to process payment notification"))
+	}
+
+	return c.JSON(http.StatusOK, provider.Acknowledge(response))
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"billing-engine/models"
+	"billing-engine/payment_notification"
+	mocks "billing-engine/payment_notification/_mock"
+	"billing-engine/payment_notification/provider"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+const callbackSecret = "callback-secret"
+
+func newHandler(mockService *mocks.PaymentNotificationServiceInterface) *PaymentNotificationHandler {
+	return &PaymentNotificationHandler{
+		paymentNotificationService: mockService,
+		middleware:                 new(MockMiddleware),
+		providers: map[string]payment_notification.PaymentProviderInterface{
+			models.PaymentProviderAggregator: provider.NewAggregatorProvider(callbackSecret),
+		},
+	}
+}
+
+func newNotificationRequest(body string, signature string) *http.Request {
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications?provider=aggregator", strings.NewReader(body))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("X-Signature", signature)
+	return httpReq
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(callbackSecret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestPaymentNotificationHandler_HandleNotification_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewPaymentNotificationServiceInterface(t)
+	handler := newHandler(mockService)
+	body := `{"transaction_id":"trx_001","payment_method":"VIRTUAL_ACCOUNT","account_number":"8808812345678901","amount":110000,"currency":"IDR","status":"PAID"}`
+
+	mockService.On("ProcessNotification", mock.Anything, mock.MatchedBy(func(req *models.PaymentNotificationRequest) bool {
+		return req.Provider == models.PaymentProviderAggregator && req.TransactionID == "trx_001" && req.AccountNumber == "8808812345678901" && req.Paid
+	})).Return(&models.PaymentNotificationResponse{TransactionID: "trx_001", LoanID: "loan_123", Status: models.PaymentNotificationApplied}, nil)
+
+	// Create request
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newNotificationRequest(body, sign(body)), rec)
+
+	// Execute
+	err := handler.HandleNotification(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"OK"}`, rec.Body.String())
+}
+
+func TestPaymentNotificationHandler_HandleNotification_InvalidSignature(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewPaymentNotificationServiceInterface(t)
+	handler := newHandler(mockService)
+	body := `{"transaction_id":"trx_001","payment_method":"VIRTUAL_ACCOUNT","account_number":"8808812345678901","amount":110000,"status":"PAID"}`
+
+	// Create request
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newNotificationRequest(body, sign(`{"transaction_id":"trx_000"}`)), rec)
+
+	// Execute
+	err := handler.HandleNotification(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"status":"ERROR","message":"Invalid signature"}`, rec.Body.String())
+	mockService.AssertNotCalled(t, "ProcessNotification", mock.Anything, mock.Anything)
+}
+
+func TestPaymentNotificationHandler_HandleNotification_UnknownProvider(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewPaymentNotificationServiceInterface(t)
+	handler := newHandler(mockService)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications?provider=SNAP", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.HandleNotification(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Unknown payment provider")
+}
+
+func TestPaymentNotificationHandler_HandleNotification_ValidationError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewPaymentNotificationServiceInterface(t)
+	handler := newHandler(mockService)
+	body := `{"transaction_id":"trx_001","payment_method":"VIRTUAL_ACCOUNT","amount":110000,"status":"PAID"}`
+
+	// Create request
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newNotificationRequest(body, sign(body)), rec)
+
+	// Execute
+	err := handler.HandleNotification(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"ERROR"`)
+	mockService.AssertNotCalled(t, "ProcessNotification", mock.Anything, mock.Anything)
+}
+
+func TestPaymentNotificationHandler_HandleNotification_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewPaymentNotificationServiceInterface(t)
+	handler := newHandler(mockService)
+	body := `{"transaction_id":"qr_001","payment_method":"QRIS","reference_id":"loan_123","amount":110000,"status":"PAID"}`
+
+	mockService.On("ProcessNotification", mock.Anything, mock.AnythingOfType("*models.PaymentNotificationRequest")).Return(nil, errors.New("failed to create payment notification: deadlock"))
+
+	// Create request
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newNotificationRequest(body, sign(body)), rec)
+
+	// Execute
+	err := handler.HandleNotification(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"status":"ERROR","message":"Failed to process payment notification"}`, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "deadlock")
+}
@@ -0,0 +1,29 @@
+package payment_notification
+
+import (
+	"billing-engine/models"
+	"context"
+	"net/http"
+)
+
+// PaymentNotificationMySQLRepositoryInterface defines the interface for payment notification repository
+type PaymentNotificationMySQLRepositoryInterface interface {
+	CreateNotification(ctx context.Context, notification *models.PaymentNotification) (bool, error)
+	GetNotification(ctx context.Context, provider string, transactionID string) (*models.PaymentNotification, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// PaymentProviderInterface defines the interface for a payment provider pushing payment
+// notifications: how its callbacks are signed, what they look like and how they are acknowledged
+type PaymentProviderInterface interface {
+	Code() string
+	VerifySignature(req *http.Request, body []byte) error
+	ParseNotification(body []byte) (*models.PaymentNotificationRequest, error)
+	Acknowledge(response *models.PaymentNotificationResponse) interface{}
+	Reject(statusCode int, message string) interface{}
+}
+
+// PaymentNotificationServiceInterface defines the interface for payment notification service
+type PaymentNotificationServiceInterface interface {
+	ProcessNotification(ctx context.Context, req *models.PaymentNotificationRequest) (*models.PaymentNotificationResponse, error)
+}
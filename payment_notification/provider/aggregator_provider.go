@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"billing-engine/models"
+	"billing-engine/payment_notification"
+)
+
+const aggregatorStatusPaid = "PAID"
+
+// aggregatorNotification is the callback body of the payment aggregator. ReferenceID carries the
+// loan ID of a QRIS payment.
+type aggregatorNotification struct {
+	TransactionID string  `json:"transaction_id"`
+	PaymentMethod string  `json:"payment_method"`
+	AccountNumber string  `json:"account_number"`
+	ReferenceID   string  `json:"reference_id"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+	Status        string  `json:"status"`
+}
+
+// aggregatorAcknowledgement is the answer the payment aggregator expects; anything but OK is retried
+type aggregatorAcknowledgement struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type aggregatorProvider struct {
+	secret []byte
+}
+
+// NewAggregatorProvider creates the provider for payment aggregator callbacks. Each callback is signed
+// in the X-Signature header with the hex HMAC-SHA256 of the raw body under the shared secret.
+func NewAggregatorProvider(secret string) payment_notification.PaymentProviderInterface {
+	return &aggregatorProvider{secret: []byte(secret)}
+}
+
+func (p *aggregatorProvider) Code() string {
+	return models.PaymentProviderAggregator
+}
+
+func (p *aggregatorProvider) VerifySignature(req *http.Request, body []byte) error {
+	signature, err := hex.DecodeString(strings.TrimSpace(req.Header.Get("X-Signature")))
+	if err != nil || len(signature) == 0 {
+		return fmt.Errorf("missing or malformed signature")
+	}
+
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+func (p *aggregatorProvider) ParseNotification(body []byte) (*models.PaymentNotificationRequest, error) {
+	var notification aggregatorNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, err
+	}
+
+	return &models.PaymentNotificationRequest{
+		Provider:      p.Code(),
+		TransactionID: notification.TransactionID,
+		PaymentMethod: strings.ToUpper(notification.PaymentMethod),
+		AccountNumber: notification.AccountNumber,
+		LoanID:        notification.ReferenceID,
+		Amount:        notification.Amount,
+		Currency:      notification.Currency,
+		Paid:          strings.EqualFold(notification.Status, aggregatorStatusPaid),
+		Payload:       string(body),
+	}, nil
+}
+
+func (p *aggregatorProvider) Acknowledge(response *models.PaymentNotificationResponse) interface{} {
+	return aggregatorAcknowledgement{Status: "OK"}
+}
+
+func (p *aggregatorProvider) Reject(statusCode int, message string) interface{} {
+	return aggregatorAcknowledgement{Status: "ERROR", Message: message}
+}
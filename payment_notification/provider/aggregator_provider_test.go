@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"billing-engine/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signAggregatorBody(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestAggregatorProvider_VerifySignature(t *testing.T) {
+	provider := NewAggregatorProvider("callback-secret")
+	body := `{"transaction_id":"trx_001","amount":110000}`
+
+	valid := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications", strings.NewReader(body))
+	valid.Header.Set("X-Signature", signAggregatorBody("callback-secret", body))
+	wrongSecret := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications", strings.NewReader(body))
+	wrongSecret.Header.Set("X-Signature", signAggregatorBody("other-secret", body))
+	missing := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications", strings.NewReader(body))
+
+	assert.NoError(t, provider.VerifySignature(valid, []byte(body)))
+	assert.Error(t, provider.VerifySignature(wrongSecret, []byte(body)))
+	assert.Error(t, provider.VerifySignature(missing, []byte(body)))
+	// A body changed after signing is rejected
+	assert.Error(t, provider.VerifySignature(valid, []byte(`{"transaction_id":"trx_001","amount":990000}`)))
+}
+
+func TestAggregatorProvider_ParseNotification(t *testing.T) {
+	provider := NewAggregatorProvider("callback-secret")
+	body := `{"transaction_id":"qr_001","payment_method":"qris","reference_id":"loan_123","amount":110000,"currency":"IDR","status":"PAID"}`
+
+	req, err := provider.ParseNotification([]byte(body))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentProviderAggregator, req.Provider)
+	assert.Equal(t, "qr_001", req.TransactionID)
+	assert.Equal(t, models.PaymentMethodQRIS, req.PaymentMethod)
+	assert.Equal(t, "loan_123", req.LoanID)
+	assert.Equal(t, 110000.00, req.Amount)
+	assert.True(t, req.Paid)
+	assert.Equal(t, body, req.Payload)
+
+	expired, err := provider.ParseNotification([]byte(`{"transaction_id":"qr_002","payment_method":"QRIS","status":"EXPIRED"}`))
+	assert.NoError(t, err)
+	assert.False(t, expired.Paid)
+}
+
+func TestAggregatorProvider_Acknowledgement(t *testing.T) {
+	provider := NewAggregatorProvider("callback-secret")
+
+	assert.Equal(t, aggregatorAcknowledgement{Status: "OK"}, provider.Acknowledge(&models.PaymentNotificationResponse{Status: models.PaymentNotificationApplied}))
+	assert.Equal(t, aggregatorAcknowledgement{Status: "ERROR", Message: "Invalid signature"}, provider.Reject(http.StatusUnauthorized, "Invalid signature"))
+}
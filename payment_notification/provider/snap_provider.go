@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"billing-engine/models"
+	"billing-engine/payment_notification"
+)
+
+// snapServiceCode is the SNAP service code of virtual account payment notifications, part of every
+// response code
+const snapServiceCode = "25"
+
+type snapAmount struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+// snapPaymentNotification is the SNAP virtual account payment notification. The virtual account
+// number is the partner service ID, left padded with spaces, followed by the customer number.
+type snapPaymentNotification struct {
+	PartnerServiceID string     `json:"partnerServiceId"`
+	CustomerNo       string     `json:"customerNo"`
+	VirtualAccountNo string     `json:"virtualAccountNo"`
+	PaymentRequestID string     `json:"paymentRequestId"`
+	PaidAmount       snapAmount `json:"paidAmount"`
+}
+
+// snapResponse is the SNAP response body; the response code is the HTTP status, the service code
+// and a case code
+type snapResponse struct {
+	ResponseCode    string `json:"responseCode"`
+	ResponseMessage string `json:"responseMessage"`
+}
+
+type snapProvider struct {
+	publicKey *rsa.PublicKey
+}
+
+// NewSNAPProvider creates the provider for Bank Indonesia SNAP virtual account payment notifications.
+// The X-SIGNATURE header is the base64 SHA256withRSA signature, verified with the bank's public key,
+// of "<method>:<path>:<lowercase hex SHA-256 of the body>:<X-TIMESTAMP>".
+func NewSNAPProvider(publicKey *rsa.PublicKey) payment_notification.PaymentProviderInterface {
+	return &snapProvider{publicKey: publicKey}
+}
+
+// ParseRSAPublicKey reads a PEM encoded RSA public key
+func ParseRSAPublicKey(pemBytes []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("the public key is not an RSA key")
+	}
+	return publicKey, nil
+}
+
+func (p *snapProvider) Code() string {
+	return models.PaymentProviderSNAP
+}
+
+func (p *snapProvider) VerifySignature(req *http.Request, body []byte) error {
+	timestamp := req.Header.Get("X-TIMESTAMP")
+	signature, err := base64.StdEncoding.DecodeString(req.Header.Get("X-SIGNATURE"))
+	if timestamp == "" || err != nil || len(signature) == 0 {
+		return fmt.Errorf("missing or malformed signature")
+	}
+
+	bodyHash := sha256.Sum256(body)
+	stringToSign := fmt.Sprintf("%s:%s:%s:%s", req.Method, req.URL.Path, hex.EncodeToString(bodyHash[:]), timestamp)
+	digest := sha256.Sum256([]byte(stringToSign))
+	if err := rsa.VerifyPKCS1v15(p.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+func (p *snapProvider) ParseNotification(body []byte) (*models.PaymentNotificationRequest, error) {
+	var notification snapPaymentNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, err
+	}
+	amount, err := strconv.ParseFloat(notification.PaidAmount.Value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid paid amount %q", notification.PaidAmount.Value)
+	}
+
+	return &models.PaymentNotificationRequest{
+		Provider:      p.Code(),
+		TransactionID: notification.PaymentRequestID,
+		PaymentMethod: models.PaymentMethodVirtualAccount,
+		AccountNumber: strings.TrimSpace(notification.VirtualAccountNo),
+		Amount:        amount,
+		Currency:      notification.PaidAmount.Currency,
+		Paid:          true, // SNAP only notifies completed payments
+		Payload:       string(body),
+	}, nil
+}
+
+func (p *snapProvider) Acknowledge(response *models.PaymentNotificationResponse) interface{} {
+	return snapResponse{
+		ResponseCode:    fmt.Sprintf("%d%s00", http.StatusOK, snapServiceCode),
+		ResponseMessage: "Successful",
+	}
+}
+
+func (p *snapProvider) Reject(statusCode int, message string) interface{} {
+	return snapResponse{
+		ResponseCode:    fmt.Sprintf("%d%s00", statusCode, snapServiceCode),
+		ResponseMessage: message,
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"billing-engine/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const snapBody = `{"partnerServiceId":"   88088","customerNo":"12345678901","virtualAccountNo":"   8808812345678901","paymentRequestId":"pr_001","paidAmount":{"value":"110000.00","currency":"IDR"}}`
+
+func newSNAPRequest(t *testing.T, key *rsa.PrivateKey, body string) *http.Request {
+	timestamp := "2026-10-19T09:15:00+07:00"
+	bodyHash := sha256.Sum256([]byte(body))
+	digest := sha256.Sum256([]byte(fmt.Sprintf("POST:/v1/payments/notifications:%s:%s", hex.EncodeToString(bodyHash[:]), timestamp)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications?provider=SNAP", strings.NewReader(body))
+	req.Header.Set("X-TIMESTAMP", timestamp)
+	req.Header.Set("X-SIGNATURE", base64.StdEncoding.EncodeToString(signature))
+	return req
+}
+
+func TestSNAPProvider_VerifySignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	provider := NewSNAPProvider(&key.PublicKey)
+
+	assert.NoError(t, provider.VerifySignature(newSNAPRequest(t, key, snapBody), []byte(snapBody)))
+	assert.Error(t, provider.VerifySignature(newSNAPRequest(t, otherKey, snapBody), []byte(snapBody)))
+	assert.Error(t, provider.VerifySignature(newSNAPRequest(t, key, snapBody), []byte(strings.Replace(snapBody, "110000.00", "990000.00", 1))))
+
+	unsigned := httptest.NewRequest(http.MethodPost, "/v1/payments/notifications?provider=SNAP", strings.NewReader(snapBody))
+	assert.Error(t, provider.VerifySignature(unsigned, []byte(snapBody)))
+}
+
+func TestSNAPProvider_ParseNotification(t *testing.T) {
+	provider := NewSNAPProvider(nil)
+
+	req, err := provider.ParseNotification([]byte(snapBody))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentProviderSNAP, req.Provider)
+	assert.Equal(t, "pr_001", req.TransactionID)
+	assert.Equal(t, models.PaymentMethodVirtualAccount, req.PaymentMethod)
+	assert.Equal(t, "8808812345678901", req.AccountNumber)
+	assert.Equal(t, 110000.00, req.Amount)
+	assert.Equal(t, "IDR", req.Currency)
+	assert.True(t, req.Paid)
+
+	_, err = provider.ParseNotification([]byte(`{"paymentRequestId":"pr_002","paidAmount":{"value":"abc","currency":"IDR"}}`))
+	assert.Error(t, err)
+}
+
+func TestSNAPProvider_Acknowledgement(t *testing.T) {
+	provider := NewSNAPProvider(nil)
+
+	assert.Equal(t, snapResponse{ResponseCode: "2002500", ResponseMessage: "Successful"}, provider.Acknowledge(&models.PaymentNotificationResponse{}))
+	assert.Equal(t, snapResponse{ResponseCode: "4012500", ResponseMessage: "Invalid signature"}, provider.Reject(http.StatusUnauthorized, "Invalid signature"))
+}
+
+func TestParseRSAPublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	publicKey, err := ParseRSAPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	assert.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+
+	_, err = ParseRSAPublicKey([]byte("not a key"))
+	assert.Error(t, err)
+}
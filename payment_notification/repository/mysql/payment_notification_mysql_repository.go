@@ -0,0 +1,49 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"billing-engine/models"
+	"billing-engine/payment_notification"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type paymentNotificationMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentNotificationMySQLRepository creates a new payment notification repository instance
+func NewPaymentNotificationMySQLRepository(db *gorm.DB) payment_notification.PaymentNotificationMySQLRepositoryInterface {
+	return &paymentNotificationMySQLRepository{db: db}
+}
+
+// CreateNotification stores the notification unless the provider transaction is stored already, in
+// which case it reports false
+func (r *paymentNotificationMySQLRepository) CreateNotification(ctx context.Context, notification *models.PaymentNotification) (bool, error) {
+	result := transaction.DB(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *paymentNotificationMySQLRepository) GetNotification(ctx context.Context, provider string, transactionID string) (*models.PaymentNotification, error) {
+	var notification models.PaymentNotification
+	err := transaction.DB(ctx, r.db).Where("provider = ? AND transaction_id = ?", provider, transactionID).First(&notification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *paymentNotificationMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/payment_notification"
+	"billing-engine/repayment"
+	"billing-engine/suspense"
+	"billing-engine/virtual_account"
+)
+
+type paymentNotificationService struct {
+	notificationRepo      payment_notification.PaymentNotificationMySQLRepositoryInterface
+	virtualAccountService virtual_account.VirtualAccountServiceInterface
+	repaymentService      repayment.RepaymentServiceInterface
+	suspenseService       suspense.SuspenseServiceInterface
+}
+
+// NewPaymentNotificationService creates a new payment notification service instance
+func NewPaymentNotificationService(notificationRepo payment_notification.PaymentNotificationMySQLRepositoryInterface, virtualAccountService virtual_account.VirtualAccountServiceInterface, repaymentService repayment.RepaymentServiceInterface, suspenseService suspense.SuspenseServiceInterface) payment_notification.PaymentNotificationServiceInterface {
+	return &paymentNotificationService{
+		notificationRepo:      notificationRepo,
+		virtualAccountService: virtualAccountService,
+		repaymentService:      repaymentService,
+		suspenseService:       suspenseService,
+	}
+}
+
+// errDuplicateNotification aborts the transaction of a notification another request stored first
+var errDuplicateNotification = errors.New("payment notification is processed already")
+
+// ProcessNotification applies the payment of a provider callback once. The notification is stored
+// with its outcome in the transaction that applies or holds the payment, so a failed callback is
+// retried by the provider from scratch and a repeated one gets the stored outcome back. When the
+// same callback is processed concurrently, the one storing its notification second is rolled back.
+func (s *paymentNotificationService) ProcessNotification(ctx context.Context, req *models.PaymentNotificationRequest) (*models.PaymentNotificationResponse, error) {
+	var response *models.PaymentNotificationResponse
+	err := s.notificationRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.notificationRepo.GetNotification(ctx, req.Provider, req.TransactionID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment notification: %v", err)
+		}
+		if existing != nil {
+			response = toNotificationResponse(existing)
+			response.Duplicate = true
+			return nil
+		}
+
+		notification := &models.PaymentNotification{
+			Provider:      req.Provider,
+			TransactionID: req.TransactionID,
+			PaymentMethod: req.PaymentMethod,
+			AccountNumber: req.AccountNumber,
+			LoanID:        req.LoanID,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			Payload:       req.Payload,
+			ReceivedAt:    time.Now(),
+		}
+		if err := s.routePayment(ctx, req, notification); err != nil {
+			return err
+		}
+		created, err := s.notificationRepo.CreateNotification(ctx, notification)
+		if err != nil {
+			return fmt.Errorf("failed to create payment notification: %v", err)
+		}
+		if !created {
+			return errDuplicateNotification
+		}
+		response = toNotificationResponse(notification)
+		return nil
+	})
+	if errors.Is(err, errDuplicateNotification) {
+		return s.storedOutcome(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// storedOutcome returns the outcome of a notification stored by a concurrent request
+func (s *paymentNotificationService) storedOutcome(ctx context.Context, req *models.PaymentNotificationRequest) (*models.PaymentNotificationResponse, error) {
+	existing, err := s.notificationRepo.GetNotification(ctx, req.Provider, req.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment notification: %v", err)
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("payment notification %s of %s was not found", req.TransactionID, req.Provider)
+	}
+	response := toNotificationResponse(existing)
+	response.Duplicate = true
+	return response, nil
+}
+
+// routePayment applies the payment to its loan, or holds it in suspense when the loan cannot take
+// it, and records the outcome on the notification. A virtual account payment goes to the loan the
+// account was issued for, a QRIS payment to the loan in its reference.
+func (s *paymentNotificationService) routePayment(ctx context.Context, req *models.PaymentNotificationRequest, notification *models.PaymentNotification) error {
+	if !req.Paid {
+		notification.Status = models.PaymentNotificationIgnored
+		notification.Message = "the payment was not completed"
+		return nil
+	}
+
+	if req.PaymentMethod == models.PaymentMethodVirtualAccount {
+		result, err := s.virtualAccountService.ProcessPayment(ctx, &models.VirtualAccountPaymentRequest{
+			AccountNumber: req.AccountNumber,
+			TransactionID: req.TransactionID,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+		})
+		if err != nil {
+			return err
+		}
+		notification.LoanID = result.LoanID
+		notification.Status = models.PaymentNotificationApplied
+		if result.Status == models.VirtualAccountPaymentHeld {
+			notification.Status = models.PaymentNotificationHeld
+			notification.SuspenseItemID = &result.SuspenseItemID
+			notification.Message = result.Message
+		}
+		return nil
+	}
+
+	repaymentReq := &models.RepaymentRequest{
+		LoanID:        req.LoanID,
+		PaymentAmount: req.Amount,
+		Currency:      req.Currency,
+		Reference:     req.TransactionID,
+	}
+	_, err := s.repaymentService.ProcessRepayment(ctx, repaymentReq)
+	if err == nil {
+		notification.Status = models.PaymentNotificationApplied
+		return nil
+	}
+	var rejection *models.PaymentRejectionError
+	if !errors.As(err, &rejection) {
+		return err
+	}
+
+	item, err := s.suspenseService.HoldPayment(ctx, repaymentReq, models.SuspenseSourceQRIS, rejection)
+	if err != nil {
+		return fmt.Errorf("failed to hold the payment in suspense: %v", err)
+	}
+	notification.Status = models.PaymentNotificationHeld
+	notification.SuspenseItemID = &item.ID
+	notification.Message = rejection.Error()
+	return nil
+}
+
+func toNotificationResponse(notification *models.PaymentNotification) *models.PaymentNotificationResponse {
+	response := &models.PaymentNotificationResponse{
+		Provider:      notification.Provider,
+		TransactionID: notification.TransactionID,
+		LoanID:        notification.LoanID,
+		Status:        notification.Status,
+		Message:       notification.Message,
+	}
+	if notification.SuspenseItemID != nil {
+		response.SuspenseItemID = *notification.SuspenseItemID
+	}
+	return response
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"billing-engine/models"
+	mocks "billing-engine/payment_notification/_mock"
+	repaymentMocks "billing-engine/repayment/_mock"
+	suspenseMocks "billing-engine/suspense/_mock"
+	virtualAccountMocks "billing-engine/virtual_account/_mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestPaymentNotificationService_ProcessNotification_VirtualAccountApplied(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "trx_001",
+		PaymentMethod: models.PaymentMethodVirtualAccount,
+		AccountNumber: "8808812345678901",
+		Amount:        110000.00,
+		Paid:          true,
+		Payload:       `{"transaction_id":"trx_001"}`,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "trx_001").Return(nil, nil)
+	mockRepo.On("CreateNotification", ctx, mock.MatchedBy(func(notification *models.PaymentNotification) bool {
+		return notification.Status == models.PaymentNotificationApplied && notification.LoanID == "loan_123" &&
+			notification.SuspenseItemID == nil && notification.Payload == `{"transaction_id":"trx_001"}`
+	})).Return(true, nil)
+
+	// Mock service calls
+	mockVirtualAccount.On("ProcessPayment", ctx, &models.VirtualAccountPaymentRequest{AccountNumber: "8808812345678901", TransactionID: "trx_001", Amount: 110000.00}).
+		Return(&models.VirtualAccountPaymentResponse{LoanID: "loan_123", Status: models.VirtualAccountPaymentApplied}, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentNotificationApplied, response.Status)
+	assert.Equal(t, "loan_123", response.LoanID)
+	assert.False(t, response.Duplicate)
+}
+
+func TestPaymentNotificationService_ProcessNotification_VirtualAccountHeld(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderSNAP,
+		TransactionID: "trx_002",
+		PaymentMethod: models.PaymentMethodVirtualAccount,
+		AccountNumber: "8808800000000000",
+		Amount:        50000.00,
+		Paid:          true,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderSNAP, "trx_002").Return(nil, nil)
+	mockRepo.On("CreateNotification", ctx, mock.MatchedBy(func(notification *models.PaymentNotification) bool {
+		return notification.Status == models.PaymentNotificationHeld && *notification.SuspenseItemID == 8
+	})).Return(true, nil)
+
+	// Mock service calls
+	mockVirtualAccount.On("ProcessPayment", ctx, mock.AnythingOfType("*models.VirtualAccountPaymentRequest")).Return(&models.VirtualAccountPaymentResponse{
+		Status:         models.VirtualAccountPaymentHeld,
+		SuspenseItemID: 8,
+		Message:        "virtual account 8808800000000000 is not issued to any loan",
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentNotificationHeld, response.Status)
+	assert.Equal(t, uint(8), response.SuspenseItemID)
+	assert.Equal(t, "virtual account 8808800000000000 is not issued to any loan", response.Message)
+}
+
+func TestPaymentNotificationService_ProcessNotification_QRISApplied(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "qr_001",
+		PaymentMethod: models.PaymentMethodQRIS,
+		LoanID:        "loan_123",
+		Amount:        110000.00,
+		Currency:      "IDR",
+		Paid:          true,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "qr_001").Return(nil, nil)
+	mockRepo.On("CreateNotification", ctx, mock.MatchedBy(func(notification *models.PaymentNotification) bool {
+		return notification.Status == models.PaymentNotificationApplied && notification.LoanID == "loan_123"
+	})).Return(true, nil)
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00, Currency: "IDR", Reference: "qr_001"}).
+		Return(&models.RepaymentResponse{LoanID: "loan_123"}, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentNotificationApplied, response.Status)
+	assert.Equal(t, "loan_123", response.LoanID)
+	mockVirtualAccount.AssertNotCalled(t, "ProcessPayment", mock.Anything, mock.Anything)
+}
+
+func TestPaymentNotificationService_ProcessNotification_QRISHeld(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "qr_002",
+		PaymentMethod: models.PaymentMethodQRIS,
+		LoanID:        "loan_123",
+		Amount:        100000.00,
+		Paid:          true,
+	}
+	repaymentReq := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100000.00, Reference: "qr_002"}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100000.00 does not match required amount 110000.00"}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "qr_002").Return(nil, nil)
+	mockRepo.On("CreateNotification", ctx, mock.MatchedBy(func(notification *models.PaymentNotification) bool {
+		return notification.Status == models.PaymentNotificationHeld && *notification.SuspenseItemID == 9 && notification.LoanID == "loan_123"
+	})).Return(true, nil)
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, repaymentReq).Return(nil, rejection)
+	mockSuspense.On("HoldPayment", ctx, repaymentReq, models.SuspenseSourceQRIS, rejection).Return(&models.SuspenseItemResponse{ID: 9}, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentNotificationHeld, response.Status)
+	assert.Equal(t, uint(9), response.SuspenseItemID)
+	assert.Equal(t, rejection.Message, response.Message)
+}
+
+func TestPaymentNotificationService_ProcessNotification_NotPaidIgnored(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "qr_003",
+		PaymentMethod: models.PaymentMethodQRIS,
+		LoanID:        "loan_123",
+		Amount:        110000.00,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "qr_003").Return(nil, nil)
+	mockRepo.On("CreateNotification", ctx, mock.MatchedBy(func(notification *models.PaymentNotification) bool {
+		return notification.Status == models.PaymentNotificationIgnored
+	})).Return(true, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentNotificationIgnored, response.Status)
+	mockRepayment.AssertNotCalled(t, "ProcessRepayment", mock.Anything, mock.Anything)
+}
+
+func TestPaymentNotificationService_ProcessNotification_Duplicate(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "trx_001",
+		PaymentMethod: models.PaymentMethodVirtualAccount,
+		AccountNumber: "8808812345678901",
+		Amount:        110000.00,
+		Paid:          true,
+	}
+
+	// Mock repository calls; the transaction was processed before
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "trx_001").Return(&models.PaymentNotification{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "trx_001",
+		LoanID:        "loan_123",
+		Status:        models.PaymentNotificationApplied,
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Duplicate)
+	assert.Equal(t, models.PaymentNotificationApplied, response.Status)
+	assert.Equal(t, "loan_123", response.LoanID)
+	mockVirtualAccount.AssertNotCalled(t, "ProcessPayment", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+}
+
+func TestPaymentNotificationService_ProcessNotification_ConcurrentDuplicate(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "trx_001",
+		PaymentMethod: models.PaymentMethodVirtualAccount,
+		AccountNumber: "8808812345678901",
+		Amount:        110000.00,
+		Paid:          true,
+	}
+
+	// Mock repository calls; another request stores the same transaction first, so this one is
+	// rolled back and answers with the stored outcome
+	var transactionErr error
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		transactionErr = fn(ctx)
+		return transactionErr
+	})
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "trx_001").Return(nil, nil).Once()
+	mockRepo.On("CreateNotification", ctx, mock.AnythingOfType("*models.PaymentNotification")).Return(false, nil)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "trx_001").Return(&models.PaymentNotification{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "trx_001",
+		LoanID:        "loan_123",
+		Status:        models.PaymentNotificationApplied,
+	}, nil).Once()
+
+	// Mock service calls
+	mockVirtualAccount.On("ProcessPayment", ctx, mock.AnythingOfType("*models.VirtualAccountPaymentRequest")).
+		Return(&models.VirtualAccountPaymentResponse{LoanID: "loan_123", Status: models.VirtualAccountPaymentApplied}, nil)
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Error(t, transactionErr) // the payment applied by this request is rolled back
+	assert.True(t, response.Duplicate)
+	assert.Equal(t, models.PaymentNotificationApplied, response.Status)
+	assert.Equal(t, "loan_123", response.LoanID)
+}
+
+func TestPaymentNotificationService_ProcessNotification_PaymentError(t *testing.T) {
+	mockRepo := mocks.NewPaymentNotificationMySQLRepositoryInterface(t)
+	mockVirtualAccount := virtualAccountMocks.NewVirtualAccountServiceInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewPaymentNotificationService(mockRepo, mockVirtualAccount, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.PaymentNotificationRequest{
+		Provider:      models.PaymentProviderAggregator,
+		TransactionID: "trx_001",
+		PaymentMethod: models.PaymentMethodVirtualAccount,
+		AccountNumber: "8808812345678901",
+		Amount:        110000.00,
+		Paid:          true,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetNotification", ctx, models.PaymentProviderAggregator, "trx_001").Return(nil, nil)
+
+	// Mock service calls; the notification is not stored so the provider's retry is processed again
+	mockVirtualAccount.On("ProcessPayment", ctx, mock.AnythingOfType("*models.VirtualAccountPaymentRequest")).Return(nil, errors.New("failed to get loan by virtual account: connection refused"))
+
+	// Execute
+	response, err := service.ProcessNotification(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "connection refused")
+	mockRepo.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+}
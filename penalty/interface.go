@@ -0,0 +1,20 @@
+package penalty
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// PenaltyMySQLRepositoryInterface defines the interface for late payment penalty repository
+type PenaltyMySQLRepositoryInterface interface {
+	GetPenalisableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetOverduePaymentSchedules(ctx context.Context, loanID string, asOf time.Time) ([]*models.PaymentSchedule, error)
+	UpdatePenaltyAmounts(ctx context.Context, schedules []*models.PaymentSchedule) error
+}
+
+// PenaltyServiceInterface defines the interface for late payment penalty service
+type PenaltyServiceInterface interface {
+	GetPenalisableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	AssessLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) error
+}
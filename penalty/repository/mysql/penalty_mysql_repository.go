@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/penalty"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type penaltyMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewPenaltyMySQLRepository creates a new late payment penalty repository instance
+func NewPenaltyMySQLRepository(db *gorm.DB) penalty.PenaltyMySQLRepositoryInterface {
+	return &penaltyMySQLRepository{db: db}
+}
+
+// GetPenalisableLoanSummaries returns the disbursed loans that are not settled yet
+func (r *penaltyMySQLRepository) GetPenalisableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status IN ? AND deleted_at IS NULL", afterID, []string{models.StatusPending, models.StatusDelinquent}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+func (r *penaltyMySQLRepository) GetOverduePaymentSchedules(ctx context.Context, loanID string, asOf time.Time) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND installment_due_date < ? AND deleted_at IS NULL", loanID, models.StatusPending, asOf).
+		Order("installment_due_date ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// UpdatePenaltyAmounts stores the penalty of each schedule. A schedule paid in the meantime is left
+// untouched, so a repayment never settles a smaller penalty than the one it was charged.
+func (r *penaltyMySQLRepository) UpdatePenaltyAmounts(ctx context.Context, schedules []*models.PaymentSchedule) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, schedule := range schedules {
+			if err := tx.Model(&models.PaymentSchedule{}).
+				Where("id = ? AND status = ?", schedule.ID, models.StatusPending).
+				Updates(map[string]interface{}{
+					"penalty_amount": schedule.PenaltyAmount,
+					"updated_by":     schedule.UpdatedBy,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
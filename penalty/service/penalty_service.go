@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/penalty"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+type penaltyService struct {
+	penaltyRepo penalty.PenaltyMySQLRepositoryInterface
+	dailyRate   decimal.Decimal // share of the installment charged for every day it is overdue
+}
+
+// NewPenaltyService creates a new late payment penalty service instance
+func NewPenaltyService(penaltyRepo penalty.PenaltyMySQLRepositoryInterface, dailyRate float64) penalty.PenaltyServiceInterface {
+	return &penaltyService{
+		penaltyRepo: penaltyRepo,
+		dailyRate:   decimal.NewFromFloat(dailyRate),
+	}
+}
+
+// GetPenalisableLoans returns the loans the penalty assessment covers, in ID order after afterID
+func (s *penaltyService) GetPenalisableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	loanSummaries, err := s.penaltyRepo.GetPenalisableLoanSummaries(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get penalisable loans: %v", err)
+	}
+	return loanSummaries, nil
+}
+
+// AssessLoan sets the penalty of every installment of the loan overdue as of asOf to the daily
+// rate of its unpaid amount for each day past due. The penalty is recomputed from the due date
+// rather than added to, so assessing a date twice charges nothing more and a missed day is
+// caught up on the next run. A penalty never goes down.
+func (s *penaltyService) AssessLoan(ctx context.Context, loanSummary *models.LoanSummary, asOf time.Time) error {
+	schedules, err := s.penaltyRepo.GetOverduePaymentSchedules(ctx, loanSummary.LoanID, asOf)
+	if err != nil {
+		return fmt.Errorf("failed to get overdue payment schedules: %v", err)
+	}
+
+	var assessed []*models.PaymentSchedule
+	for _, schedule := range schedules {
+		unpaid := decimal.NewFromFloat(schedule.InstallmentAmount).Sub(decimal.NewFromFloat(schedule.InstallmentPaid))
+		penaltyAmount := currency.Round(unpaid.Mul(s.dailyRate).Mul(decimal.NewFromInt(int64(schedule.DaysPastDue(asOf)))), loanSummary.Currency)
+		if !penaltyAmount.GreaterThan(decimal.NewFromFloat(schedule.PenaltyAmount)) {
+			continue
+		}
+		schedule.PenaltyAmount = penaltyAmount.InexactFloat64()
+		schedule.UpdatedBy = "system"
+		assessed = append(assessed, schedule)
+	}
+	if len(assessed) == 0 {
+		return nil
+	}
+
+	if err := s.penaltyRepo.UpdatePenaltyAmounts(ctx, assessed); err != nil {
+		return fmt.Errorf("failed to update penalty amounts: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"billing-engine/models"
+	mocks "billing-engine/penalty/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPenaltyService_AssessLoan(t *testing.T) {
+	mockRepo := mocks.NewPenaltyMySQLRepositoryInterface(t)
+	service := NewPenaltyService(mockRepo, 0.001)
+	ctx := context.Background()
+
+	asOf := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Currency: models.CurrencyIDR, Status: models.StatusDelinquent}
+	schedules := []*models.PaymentSchedule{
+		// 38 days overdue
+		{ID: 1, LoanID: "loan_123", InstallmentAmount: 1100000.00, InstallmentDueDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local), PenaltyAmount: 40700.00, Status: models.StatusPending},
+		// 10 days overdue, already assessed for the date
+		{ID: 2, LoanID: "loan_123", InstallmentAmount: 1100000.00, InstallmentDueDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), PenaltyAmount: 11000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", asOf).Return(schedules, nil)
+	mockRepo.On("UpdatePenaltyAmounts", ctx, mock.MatchedBy(func(assessed []*models.PaymentSchedule) bool {
+		return len(assessed) == 1 && assessed[0].ID == 1 && assessed[0].PenaltyAmount == 41800.00
+	})).Return(nil)
+
+	// Execute
+	err := service.AssessLoan(ctx, loanSummary, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 11000.00, schedules[1].PenaltyAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPenaltyService_AssessLoan_NothingOverdue(t *testing.T) {
+	mockRepo := mocks.NewPenaltyMySQLRepositoryInterface(t)
+	service := NewPenaltyService(mockRepo, 0.001)
+	ctx := context.Background()
+
+	asOf := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Currency: models.CurrencyIDR, Status: models.StatusPending}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", asOf).Return([]*models.PaymentSchedule{}, nil)
+
+	// Execute
+	err := service.AssessLoan(ctx, loanSummary, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPenaltyService_AssessLoan_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewPenaltyMySQLRepositoryInterface(t)
+	service := NewPenaltyService(mockRepo, 0.001)
+	ctx := context.Background()
+
+	asOf := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", Currency: models.CurrencyIDR, Status: models.StatusPending}
+
+	// Mock repository calls
+	mockRepo.On("GetOverduePaymentSchedules", ctx, "loan_123", asOf).Return(nil, errors.New("database error"))
+
+	// Execute
+	err := service.AssessLoan(ctx, loanSummary, asOf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get overdue payment schedules")
+
+	mockRepo.AssertExpectations(t)
+}
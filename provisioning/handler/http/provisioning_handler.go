@@ -0,0 +1,195 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/provisioning"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProvisioningHandler struct {
+	provisioningService provisioning.ProvisioningServiceInterface
+	middleware          middlewares.GoMiddlewareInterface
+}
+
+// NewProvisioningHandler creates a new expected credit loss provisioning handler instance
+func NewProvisioningHandler(e *echo.Echo, provisioningService provisioning.ProvisioningServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &ProvisioningHandler{
+		provisioningService: provisioningService,
+		middleware:          middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/provisions/runs", handler.RunProvisioning)
+	v1.GET("/provisions/runs/:period", handler.GetRun)
+	v1.GET("/provisions/movements", handler.GetMovements)
+	v1.GET("/products/:product_code/provision-parameters", handler.GetParameters)
+	v1.PUT("/products/:product_code/provision-parameters", handler.UpdateParameters)
+}
+
+func (h *ProvisioningHandler) RunProvisioning(c echo.Context) error {
+	var req models.ProvisionRunRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	// Without a period the run covers the month of yesterday, like the month-end job
+	periodEnd := time.Now().AddDate(0, 0, -1)
+	if req.Period != "" {
+		period, err := time.ParseInLocation("2006-01", req.Period, time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, global.BadResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid period",
+			})
+		}
+		periodEnd = period
+	}
+
+	response, err := h.provisioningService.RunProvisioning(c.Request().Context(), periodEnd, "api")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.ProvisionRunSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *ProvisioningHandler) GetRun(c echo.Context) error {
+	period := c.Param("period")
+	if _, err := time.Parse("2006-01", period); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid period",
+		})
+	}
+
+	response, err := h.provisioningService.GetRun(c.Request().Context(), period)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.ProvisionRunSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *ProvisioningHandler) GetMovements(c echo.Context) error {
+	var req models.ProvisionMovementRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.provisioningService.GetMovements(c.Request().Context(), req.From, req.To)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.ProvisionMovementSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *ProvisioningHandler) GetParameters(c echo.Context) error {
+	productCode := c.Param("product_code")
+	if productCode == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Product code is required",
+		})
+	}
+
+	response, err := h.provisioningService.GetParameters(c.Request().Context(), productCode)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.ProvisionParametersSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *ProvisioningHandler) UpdateParameters(c echo.Context) error {
+	productCode := c.Param("product_code")
+	if productCode == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Product code is required",
+		})
+	}
+
+	var req models.ProvisionParametersRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.provisioningService.UpdateParameters(c.Request().Context(), productCode, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.ProvisionParametersSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
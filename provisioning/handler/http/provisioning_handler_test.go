@@ -0,0 +1,241 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"billing-engine/global"
+	"billing-engine/models"
+	mocks "billing-engine/provisioning/_mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestProvisioningHandler_RunProvisioning_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	expectedResponse := &models.ProvisionRunResponse{
+		ID:        9,
+		Period:    "2026-01",
+		PeriodEnd: time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local),
+		LoanCount: 1,
+		Stages: []models.ProvisionStageSummary{
+			{Currency: models.CurrencyIDR, Stage: models.ProvisionStage1, LoanCount: 1, ExposureAmount: 1000000.00, ProvisionAmount: 4500.00},
+		},
+	}
+
+	mockService.On("RunProvisioning", mock.Anything, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), "api").Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/provisions/runs", strings.NewReader(`{"period":"2026-01"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.RunProvisioning(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.ProvisionRunSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, "2026-01", response.Data.Period)
+	assert.Equal(t, 4500.00, response.Data.Stages[0].ProvisionAmount)
+}
+
+func TestProvisioningHandler_RunProvisioning_InvalidPeriod(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/provisions/runs", strings.NewReader(`{"period":"2026-13"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.RunProvisioning(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestProvisioningHandler_GetRun_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("GetRun", mock.Anything, "2025-11").Return(nil, errors.New("provision run for 2025-11 not found"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/provisions/runs/2025-11", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("period")
+	c.SetParamValues("2025-11")
+
+	// Execute
+	err := handler.GetRun(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestProvisioningHandler_GetRun_InvalidPeriod(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/provisions/runs/november", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("period")
+	c.SetParamValues("november")
+
+	// Execute
+	err := handler.GetRun(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestProvisioningHandler_GetMovements_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("GetMovements", mock.Anything, "2025-12", "2026-01").Return(&models.ProvisionMovementResponse{
+		FromPeriod: "2025-12",
+		ToPeriod:   "2026-01",
+		Movements: []models.ProvisionStageMovement{
+			{Currency: models.CurrencyIDR, Stage: models.ProvisionStage2, TransfersIn: 2000.00, Remeasurement: 28000.00, ClosingProvision: 30000.00},
+		},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/provisions/movements?from=2025-12&to=2026-01", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetMovements(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.ProvisionMovementSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, 30000.00, response.Data.Movements[0].ClosingProvision)
+}
+
+func TestProvisioningHandler_GetMovements_MissingPeriod(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/provisions/movements?from=2025-12", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetMovements(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestProvisioningHandler_GetParameters_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("GetParameters", mock.Anything, "PAYLATER").Return(&models.ProvisionParametersResponse{
+		ProductCode: "PAYLATER",
+		Source:      models.ProvisionParameterSourceBuiltIn,
+		Stages: []models.ProvisionParameterResponse{
+			{Stage: models.ProvisionStage1, ProbabilityOfDefault: 0.01, LossGivenDefault: 0.45},
+		},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/products/PAYLATER/provision-parameters", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("PAYLATER")
+
+	// Execute
+	err := handler.GetParameters(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.ProvisionParametersSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, models.ProvisionParameterSourceBuiltIn, response.Data.Source)
+}
+
+func TestProvisioningHandler_UpdateParameters_InvalidProbability(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewProvisioningServiceInterface(t)
+	handler := &ProvisioningHandler{provisioningService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	body := `{"stages":[{"stage":1,"probability_of_default":1.5,"loss_given_default":0.45}]}`
+	httpReq := httptest.NewRequest(http.MethodPut, "/v1/products/PAYLATER/provision-parameters", strings.NewReader(body))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("product_code")
+	c.SetParamValues("PAYLATER")
+
+	// Execute
+	err := handler.UpdateParameters(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "UpdateParameters", mock.Anything, mock.Anything, mock.Anything)
+}
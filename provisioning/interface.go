@@ -0,0 +1,27 @@
+package provisioning
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// ProvisioningMySQLRepositoryInterface defines the interface for expected credit loss provisioning repository
+type ProvisioningMySQLRepositoryInterface interface {
+	GetActiveLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetParametersByProductCode(ctx context.Context, productCode string) ([]*models.ProvisionParameter, error)
+	ReplaceParameters(ctx context.Context, productCode string, parameters []*models.ProvisionParameter) error
+	GetRunByPeriod(ctx context.Context, period string) (*models.ProvisionRun, error)
+	SaveRun(ctx context.Context, run *models.ProvisionRun, provisions []*models.LoanProvision) error
+	GetStageSummaries(ctx context.Context, runID uint) ([]models.ProvisionStageSummary, error)
+	GetLoanProvisions(ctx context.Context, runID uint) ([]*models.LoanProvision, error)
+}
+
+// ProvisioningServiceInterface defines the interface for expected credit loss provisioning service
+type ProvisioningServiceInterface interface {
+	RunProvisioning(ctx context.Context, periodEnd time.Time, createdBy string) (*models.ProvisionRunResponse, error)
+	GetRun(ctx context.Context, period string) (*models.ProvisionRunResponse, error)
+	GetMovements(ctx context.Context, fromPeriod, toPeriod string) (*models.ProvisionMovementResponse, error)
+	GetParameters(ctx context.Context, productCode string) (*models.ProvisionParametersResponse, error)
+	UpdateParameters(ctx context.Context, productCode string, req *models.ProvisionParametersRequest) (*models.ProvisionParametersResponse, error)
+}
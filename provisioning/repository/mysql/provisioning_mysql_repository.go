@@ -0,0 +1,133 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/provisioning"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+// provisionInsertBatchSize is the number of loan provisions inserted per statement
+const provisionInsertBatchSize = 500
+
+type provisioningMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewProvisioningMySQLRepository creates a new expected credit loss provisioning repository instance
+func NewProvisioningMySQLRepository(db *gorm.DB) provisioning.ProvisioningMySQLRepositoryInterface {
+	return &provisioningMySQLRepository{db: db}
+}
+
+func (r *provisioningMySQLRepository) GetActiveLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status NOT IN ? AND deleted_at IS NULL", afterID, []string{models.StatusPaid, models.StatusWrittenOff, models.StatusCancelled, models.StatusInactive}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+func (r *provisioningMySQLRepository) GetParametersByProductCode(ctx context.Context, productCode string) ([]*models.ProvisionParameter, error) {
+	var parameters []*models.ProvisionParameter
+	err := transaction.DB(ctx, r.db).
+		Where("product_code = ? AND deleted_at IS NULL", productCode).
+		Order("stage ASC").
+		Find(&parameters).Error
+	if err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
+// ReplaceParameters soft-deletes the current parameters of the product and stores the new set in one transaction
+func (r *provisioningMySQLRepository) ReplaceParameters(ctx context.Context, productCode string, parameters []*models.ProvisionParameter) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ProvisionParameter{}).
+			Where("product_code = ? AND deleted_at IS NULL", productCode).
+			Updates(map[string]interface{}{
+				"deleted_at": time.Now(),
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&parameters).Error
+	})
+}
+
+func (r *provisioningMySQLRepository) GetRunByPeriod(ctx context.Context, period string) (*models.ProvisionRun, error) {
+	var run models.ProvisionRun
+	err := transaction.DB(ctx, r.db).Where("period = ?", period).First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// SaveRun stores the run and its loan provisions in one transaction, replacing an earlier run of the same period
+func (r *provisioningMySQLRepository) SaveRun(ctx context.Context, run *models.ProvisionRun, provisions []*models.LoanProvision) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var previous models.ProvisionRun
+		err := tx.Where("period = ?", run.Period).First(&previous).Error
+		if err == nil {
+			if err := tx.Where("provision_run_id = ?", previous.ID).Delete(&models.LoanProvision{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&previous).Error; err != nil {
+				return err
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		if len(provisions) == 0 {
+			return nil
+		}
+		for _, provision := range provisions {
+			provision.ProvisionRunID = run.ID
+		}
+		return tx.CreateInBatches(provisions, provisionInsertBatchSize).Error
+	})
+}
+
+func (r *provisioningMySQLRepository) GetStageSummaries(ctx context.Context, runID uint) ([]models.ProvisionStageSummary, error) {
+	var summaries []models.ProvisionStageSummary
+	err := transaction.DB(ctx, r.db).
+		Model(&models.LoanProvision{}).
+		Select("currency, stage, COUNT(*) AS loan_count, SUM(exposure_amount) AS exposure_amount, SUM(provision_amount) AS provision_amount").
+		Where("provision_run_id = ?", runID).
+		Group("currency, stage").
+		Order("currency ASC, stage ASC").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *provisioningMySQLRepository) GetLoanProvisions(ctx context.Context, runID uint) ([]*models.LoanProvision, error) {
+	var provisions []*models.LoanProvision
+	err := transaction.DB(ctx, r.db).
+		Where("provision_run_id = ?", runID).
+		Order("id ASC").
+		Find(&provisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return provisions, nil
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/provisioning"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+// provisioningBatchSize is the number of loans loaded per page by a provision run
+const provisioningBatchSize = 500
+
+// builtInParameters are used when neither the loan's product nor the DEFAULT product has
+// parameters configured
+var builtInParameters = []*models.ProvisionParameter{
+	{ProductCode: models.DefaultProductCode, Stage: models.ProvisionStage1, ProbabilityOfDefault: 0.01, LossGivenDefault: 0.45},
+	{ProductCode: models.DefaultProductCode, Stage: models.ProvisionStage2, ProbabilityOfDefault: 0.2, LossGivenDefault: 0.45},
+	{ProductCode: models.DefaultProductCode, Stage: models.ProvisionStage3, ProbabilityOfDefault: 1, LossGivenDefault: 0.45},
+}
+
+type provisioningService struct {
+	provisioningRepo provisioning.ProvisioningMySQLRepositoryInterface
+	stager           *Stager
+}
+
+// NewProvisioningService creates a new expected credit loss provisioning service instance
+func NewProvisioningService(provisioningRepo provisioning.ProvisioningMySQLRepositoryInterface, stager *Stager) provisioning.ProvisioningServiceInterface {
+	return &provisioningService{
+		provisioningRepo: provisioningRepo,
+		stager:           stager,
+	}
+}
+
+// RunProvisioning stages every active loan from the DPD of its last classification and stores
+// its expected credit loss, exposure x PD x LGD of its stage, as the snapshot of the month
+// periodEnd falls in
+func (s *provisioningService) RunProvisioning(ctx context.Context, periodEnd time.Time, createdBy string) (*models.ProvisionRunResponse, error) {
+	periodStart := time.Date(periodEnd.Year(), periodEnd.Month(), 1, 0, 0, 0, 0, time.Local)
+	run := &models.ProvisionRun{
+		Period:    periodStart.Format("2006-01"),
+		PeriodEnd: periodStart.AddDate(0, 1, -1),
+		CreatedBy: createdBy,
+	}
+
+	// Parameters are resolved once per product for the whole run
+	parametersByProduct := make(map[string]map[int]*models.ProvisionParameter)
+	provisions := make([]*models.LoanProvision, 0)
+	var afterID uint
+	for {
+		loanSummaries, err := s.provisioningRepo.GetActiveLoanSummaries(ctx, afterID, provisioningBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active loans: %v", err)
+		}
+		if len(loanSummaries) == 0 {
+			break
+		}
+
+		for _, loanSummary := range loanSummaries {
+			afterID = loanSummary.ID
+
+			parameters, ok := parametersByProduct[loanSummary.ProductCode]
+			if !ok {
+				resolved, _, err := s.resolveParameters(ctx, loanSummary.ProductCode)
+				if err != nil {
+					return nil, err
+				}
+				parameters = make(map[int]*models.ProvisionParameter, len(resolved))
+				for _, parameter := range resolved {
+					parameters[parameter.Stage] = parameter
+				}
+				parametersByProduct[loanSummary.ProductCode] = parameters
+			}
+
+			stage := s.stager.Stage(loanSummary.Dpd)
+			parameter, ok := parameters[stage]
+			if !ok {
+				return nil, fmt.Errorf("product %s has no provision parameters for stage %d", loanSummary.ProductCode, stage)
+			}
+
+			exposure := decimal.NewFromFloat(loanSummary.OutstandingAmount)
+			provision := currency.Round(exposure.
+				Mul(decimal.NewFromFloat(parameter.ProbabilityOfDefault)).
+				Mul(decimal.NewFromFloat(parameter.LossGivenDefault)), loanSummary.Currency)
+
+			provisions = append(provisions, &models.LoanProvision{
+				LoanID:               loanSummary.LoanID,
+				ProductCode:          loanSummary.ProductCode,
+				Currency:             loanSummary.Currency,
+				Dpd:                  loanSummary.Dpd,
+				Stage:                stage,
+				ExposureAmount:       loanSummary.OutstandingAmount,
+				ProbabilityOfDefault: parameter.ProbabilityOfDefault,
+				LossGivenDefault:     parameter.LossGivenDefault,
+				ProvisionAmount:      provision.InexactFloat64(),
+			})
+		}
+	}
+	run.LoanCount = len(provisions)
+
+	if err := s.provisioningRepo.SaveRun(ctx, run, provisions); err != nil {
+		return nil, fmt.Errorf("failed to save provision run: %v", err)
+	}
+
+	return s.buildRunResponse(ctx, run)
+}
+
+func (s *provisioningService) GetRun(ctx context.Context, period string) (*models.ProvisionRunResponse, error) {
+	run, err := s.getRun(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildRunResponse(ctx, run)
+}
+
+// GetMovements reconciles the provision of each currency and stage from the run of fromPeriod to
+// the run of toPeriod. A loan only in the closing run is new and a loan only in the opening run
+// was derecognised (repaid, written off or cancelled). A loan that changed stage moves its opening
+// provision from the old stage to the new one; the change in its provision is remeasurement in
+// the stage it ends in.
+func (s *provisioningService) GetMovements(ctx context.Context, fromPeriod, toPeriod string) (*models.ProvisionMovementResponse, error) {
+	if fromPeriod >= toPeriod {
+		return nil, fmt.Errorf("from period must be before to period")
+	}
+
+	fromRun, err := s.getRun(ctx, fromPeriod)
+	if err != nil {
+		return nil, err
+	}
+	toRun, err := s.getRun(ctx, toPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	openingProvisions, err := s.provisioningRepo.GetLoanProvisions(ctx, fromRun.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan provisions: %v", err)
+	}
+	closingProvisions, err := s.provisioningRepo.GetLoanProvisions(ctx, toRun.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan provisions: %v", err)
+	}
+
+	type stageKey struct {
+		currency string
+		stage    int
+	}
+	type movement struct {
+		opening, newLoans, derecognised, transfersIn, transfersOut, remeasurement, closing decimal.Decimal
+	}
+	movements := make(map[stageKey]*movement)
+	movementOf := func(currencyCode string, stage int) *movement {
+		key := stageKey{currency: currencyCode, stage: stage}
+		if _, ok := movements[key]; !ok {
+			// Every stage of a currency is reported, including the empty ones
+			for reportedStage := models.ProvisionStage1; reportedStage <= models.ProvisionStage3; reportedStage++ {
+				movements[stageKey{currency: currencyCode, stage: reportedStage}] = &movement{}
+			}
+		}
+		return movements[key]
+	}
+
+	closingByLoan := make(map[string]*models.LoanProvision, len(closingProvisions))
+	for _, closing := range closingProvisions {
+		closingByLoan[closing.LoanID] = closing
+		m := movementOf(closing.Currency, closing.Stage)
+		m.closing = m.closing.Add(decimal.NewFromFloat(closing.ProvisionAmount))
+	}
+
+	openingLoans := make(map[string]bool, len(openingProvisions))
+	for _, opening := range openingProvisions {
+		openingLoans[opening.LoanID] = true
+		openingAmount := decimal.NewFromFloat(opening.ProvisionAmount)
+		from := movementOf(opening.Currency, opening.Stage)
+		from.opening = from.opening.Add(openingAmount)
+
+		closing, ok := closingByLoan[opening.LoanID]
+		if !ok {
+			from.derecognised = from.derecognised.Add(openingAmount)
+			continue
+		}
+		to := movementOf(closing.Currency, closing.Stage)
+		if closing.Stage != opening.Stage {
+			from.transfersOut = from.transfersOut.Add(openingAmount)
+			to.transfersIn = to.transfersIn.Add(openingAmount)
+		}
+		to.remeasurement = to.remeasurement.Add(decimal.NewFromFloat(closing.ProvisionAmount).Sub(openingAmount))
+	}
+
+	for _, closing := range closingProvisions {
+		if !openingLoans[closing.LoanID] {
+			m := movementOf(closing.Currency, closing.Stage)
+			m.newLoans = m.newLoans.Add(decimal.NewFromFloat(closing.ProvisionAmount))
+		}
+	}
+
+	keys := make([]stageKey, 0, len(movements))
+	for key := range movements {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].currency != keys[j].currency {
+			return keys[i].currency < keys[j].currency
+		}
+		return keys[i].stage < keys[j].stage
+	})
+
+	response := &models.ProvisionMovementResponse{
+		FromPeriod:  fromRun.Period,
+		ToPeriod:    toRun.Period,
+		GeneratedAt: time.Now(),
+		Movements:   make([]models.ProvisionStageMovement, 0, len(keys)),
+	}
+	for _, key := range keys {
+		m := movements[key]
+		response.Movements = append(response.Movements, models.ProvisionStageMovement{
+			Currency:         key.currency,
+			Stage:            key.stage,
+			OpeningProvision: m.opening.InexactFloat64(),
+			NewLoans:         m.newLoans.InexactFloat64(),
+			Derecognised:     m.derecognised.InexactFloat64(),
+			TransfersIn:      m.transfersIn.InexactFloat64(),
+			TransfersOut:     m.transfersOut.InexactFloat64(),
+			Remeasurement:    m.remeasurement.InexactFloat64(),
+			ClosingProvision: m.closing.InexactFloat64(),
+		})
+	}
+	return response, nil
+}
+
+func (s *provisioningService) GetParameters(ctx context.Context, productCode string) (*models.ProvisionParametersResponse, error) {
+	parameters, source, err := s.resolveParameters(ctx, productCode)
+	if err != nil {
+		return nil, err
+	}
+	return buildParametersResponse(productCode, source, parameters), nil
+}
+
+func (s *provisioningService) UpdateParameters(ctx context.Context, productCode string, req *models.ProvisionParametersRequest) (*models.ProvisionParametersResponse, error) {
+	if len(req.Stages) != models.ProvisionStage3 {
+		return nil, fmt.Errorf("provision parameters must cover stages 1, 2 and 3")
+	}
+
+	seen := make(map[int]bool, len(req.Stages))
+	parameters := make([]*models.ProvisionParameter, 0, len(req.Stages))
+	for _, stageReq := range req.Stages {
+		if seen[stageReq.Stage] {
+			return nil, fmt.Errorf("stage %d is configured more than once", stageReq.Stage)
+		}
+		seen[stageReq.Stage] = true
+		parameters = append(parameters, &models.ProvisionParameter{
+			ProductCode:          productCode,
+			Stage:                stageReq.Stage,
+			ProbabilityOfDefault: stageReq.ProbabilityOfDefault,
+			LossGivenDefault:     stageReq.LossGivenDefault,
+			CreatedBy:            "system",
+			UpdatedBy:            "system",
+		})
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].Stage < parameters[j].Stage })
+
+	if err := s.provisioningRepo.ReplaceParameters(ctx, productCode, parameters); err != nil {
+		return nil, fmt.Errorf("failed to update provision parameters: %v", err)
+	}
+
+	return buildParametersResponse(productCode, models.ProvisionParameterSourceProduct, parameters), nil
+}
+
+func (s *provisioningService) getRun(ctx context.Context, period string) (*models.ProvisionRun, error) {
+	run, err := s.provisioningRepo.GetRunByPeriod(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provision run: %v", err)
+	}
+	if run == nil {
+		return nil, fmt.Errorf("provision run for %s not found", period)
+	}
+	return run, nil
+}
+
+// resolveParameters returns the parameters of the product, falling back to the DEFAULT product
+// and finally to the built-in parameters when nothing is configured
+func (s *provisioningService) resolveParameters(ctx context.Context, productCode string) ([]*models.ProvisionParameter, string, error) {
+	if productCode == "" {
+		productCode = models.DefaultProductCode
+	}
+
+	parameters, err := s.provisioningRepo.GetParametersByProductCode(ctx, productCode)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get provision parameters: %v", err)
+	}
+	if len(parameters) > 0 {
+		return parameters, models.ProvisionParameterSourceProduct, nil
+	}
+
+	if productCode != models.DefaultProductCode {
+		parameters, err = s.provisioningRepo.GetParametersByProductCode(ctx, models.DefaultProductCode)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get default provision parameters: %v", err)
+		}
+		if len(parameters) > 0 {
+			return parameters, models.ProvisionParameterSourceDefaultProduct, nil
+		}
+	}
+
+	return builtInParameters, models.ProvisionParameterSourceBuiltIn, nil
+}
+
+func (s *provisioningService) buildRunResponse(ctx context.Context, run *models.ProvisionRun) (*models.ProvisionRunResponse, error) {
+	summaries, err := s.provisioningRepo.GetStageSummaries(ctx, run.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provision stage summaries: %v", err)
+	}
+
+	return &models.ProvisionRunResponse{
+		ID:        run.ID,
+		Period:    run.Period,
+		PeriodEnd: run.PeriodEnd,
+		LoanCount: run.LoanCount,
+		CreatedAt: run.CreatedAt,
+		Stages:    summaries,
+	}, nil
+}
+
+// buildParametersResponse converts provision parameters into the API response
+func buildParametersResponse(productCode, source string, parameters []*models.ProvisionParameter) *models.ProvisionParametersResponse {
+	stageResponses := make([]models.ProvisionParameterResponse, 0, len(parameters))
+	for _, parameter := range parameters {
+		stageResponses = append(stageResponses, models.ProvisionParameterResponse{
+			Stage:                parameter.Stage,
+			ProbabilityOfDefault: parameter.ProbabilityOfDefault,
+			LossGivenDefault:     parameter.LossGivenDefault,
+		})
+	}
+
+	return &models.ProvisionParametersResponse{
+		ProductCode: productCode,
+		Source:      source,
+		Stages:      stageResponses,
+	}
+}
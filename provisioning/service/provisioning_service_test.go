@@ -0,0 +1,302 @@
+package service
+
+import (
+	"billing-engine/models"
+	mocks "billing-engine/provisioning/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestStager(t *testing.T) *Stager {
+	stager, err := NewStager(DefaultStageDpdThresholds)
+	assert.NoError(t, err)
+	return stager
+}
+
+func TestStager_Stage(t *testing.T) {
+	stager := newTestStager(t)
+
+	tests := []struct {
+		dpd      int
+		expected int
+	}{
+		{dpd: 0, expected: models.ProvisionStage1},
+		{dpd: 30, expected: models.ProvisionStage1},
+		{dpd: 31, expected: models.ProvisionStage2},
+		{dpd: 90, expected: models.ProvisionStage2},
+		{dpd: 91, expected: models.ProvisionStage3},
+		{dpd: 400, expected: models.ProvisionStage3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, stager.Stage(tt.dpd), "dpd %d", tt.dpd)
+	}
+}
+
+func TestNewStager_InvalidThresholds(t *testing.T) {
+	_, err := NewStager([]int{30})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expected 2 dpd thresholds")
+
+	_, err = NewStager([]int{90, 30})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "strictly increasing")
+}
+
+func TestParseStageDpdThresholds(t *testing.T) {
+	thresholds, err := ParseStageDpdThresholds(" 15, 60")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{15, 60}, thresholds)
+
+	thresholds, err = ParseStageDpdThresholds("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultStageDpdThresholds, thresholds)
+
+	_, err = ParseStageDpdThresholds("30,abc")
+	assert.Error(t, err)
+}
+
+func TestProvisioningService_RunProvisioning(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	loanSummaries := []*models.LoanSummary{
+		{ID: 1, LoanID: "loan_1", ProductCode: models.DefaultProductCode, Currency: models.CurrencyIDR, Dpd: 0, OutstandingAmount: 1000000.00},
+		{ID: 2, LoanID: "loan_2", ProductCode: "PAYLATER", Currency: models.CurrencyIDR, Dpd: 45, OutstandingAmount: 500000.00},
+		{ID: 3, LoanID: "loan_3", ProductCode: "PAYLATER", Currency: models.CurrencyIDR, Dpd: 120, OutstandingAmount: 200000.00},
+	}
+	paylaterParameters := []*models.ProvisionParameter{
+		{ProductCode: "PAYLATER", Stage: models.ProvisionStage1, ProbabilityOfDefault: 0.02, LossGivenDefault: 0.5},
+		{ProductCode: "PAYLATER", Stage: models.ProvisionStage2, ProbabilityOfDefault: 0.3, LossGivenDefault: 0.5},
+		{ProductCode: "PAYLATER", Stage: models.ProvisionStage3, ProbabilityOfDefault: 1, LossGivenDefault: 0.5},
+	}
+	stageSummaries := []models.ProvisionStageSummary{
+		{Currency: models.CurrencyIDR, Stage: models.ProvisionStage1, LoanCount: 1, ExposureAmount: 1000000.00, ProvisionAmount: 4500.00},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetActiveLoanSummaries", ctx, uint(0), provisioningBatchSize).Return(loanSummaries, nil)
+	mockRepo.On("GetActiveLoanSummaries", ctx, uint(3), provisioningBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("GetParametersByProductCode", ctx, models.DefaultProductCode).Return([]*models.ProvisionParameter{}, nil).Once()
+	mockRepo.On("GetParametersByProductCode", ctx, "PAYLATER").Return(paylaterParameters, nil).Once()
+
+	var savedRun *models.ProvisionRun
+	var savedProvisions []*models.LoanProvision
+	mockRepo.On("SaveRun", ctx, mock.AnythingOfType("*models.ProvisionRun"), mock.AnythingOfType("[]*models.LoanProvision")).
+		Run(func(args mock.Arguments) {
+			savedRun = args.Get(1).(*models.ProvisionRun)
+			savedRun.ID = 9
+			savedProvisions = args.Get(2).([]*models.LoanProvision)
+		}).Return(nil)
+	mockRepo.On("GetStageSummaries", ctx, uint(9)).Return(stageSummaries, nil)
+
+	// Execute
+	result, err := service.RunProvisioning(ctx, time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local), "system")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01", savedRun.Period)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local), savedRun.PeriodEnd)
+	assert.Equal(t, 3, savedRun.LoanCount)
+
+	assert.Len(t, savedProvisions, 3)
+	// DEFAULT has nothing configured, so the built-in parameters apply
+	assert.Equal(t, models.ProvisionStage1, savedProvisions[0].Stage)
+	assert.Equal(t, 4500.00, savedProvisions[0].ProvisionAmount)
+	assert.Equal(t, models.ProvisionStage2, savedProvisions[1].Stage)
+	assert.Equal(t, 75000.00, savedProvisions[1].ProvisionAmount)
+	assert.Equal(t, models.ProvisionStage3, savedProvisions[2].Stage)
+	assert.Equal(t, 100000.00, savedProvisions[2].ProvisionAmount)
+	assert.Equal(t, 0.5, savedProvisions[2].LossGivenDefault)
+
+	assert.Equal(t, uint(9), result.ID)
+	assert.Equal(t, "2026-01", result.Period)
+	assert.Equal(t, stageSummaries, result.Stages)
+}
+
+func TestProvisioningService_RunProvisioning_SaveError(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetActiveLoanSummaries", ctx, uint(0), provisioningBatchSize).Return([]*models.LoanSummary{}, nil)
+	mockRepo.On("SaveRun", ctx, mock.AnythingOfType("*models.ProvisionRun"), mock.AnythingOfType("[]*models.LoanProvision")).Return(errors.New("database error"))
+
+	// Execute
+	result, err := service.RunProvisioning(ctx, time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local), "system")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to save provision run")
+}
+
+func TestProvisioningService_GetMovements(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	openingProvisions := []*models.LoanProvision{
+		{LoanID: "loan_a", Currency: models.CurrencyIDR, Stage: models.ProvisionStage1, ProvisionAmount: 4500.00},
+		{LoanID: "loan_b", Currency: models.CurrencyIDR, Stage: models.ProvisionStage1, ProvisionAmount: 2000.00},
+		{LoanID: "loan_c", Currency: models.CurrencyIDR, Stage: models.ProvisionStage3, ProvisionAmount: 100000.00},
+	}
+	closingProvisions := []*models.LoanProvision{
+		{LoanID: "loan_a", Currency: models.CurrencyIDR, Stage: models.ProvisionStage1, ProvisionAmount: 4000.00},
+		{LoanID: "loan_b", Currency: models.CurrencyIDR, Stage: models.ProvisionStage2, ProvisionAmount: 30000.00},
+		{LoanID: "loan_d", Currency: models.CurrencyIDR, Stage: models.ProvisionStage1, ProvisionAmount: 1000.00},
+		{LoanID: "loan_e", Currency: "USD", Stage: models.ProvisionStage1, ProvisionAmount: 10.00},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetRunByPeriod", ctx, "2025-12").Return(&models.ProvisionRun{ID: 1, Period: "2025-12"}, nil)
+	mockRepo.On("GetRunByPeriod", ctx, "2026-01").Return(&models.ProvisionRun{ID: 2, Period: "2026-01"}, nil)
+	mockRepo.On("GetLoanProvisions", ctx, uint(1)).Return(openingProvisions, nil)
+	mockRepo.On("GetLoanProvisions", ctx, uint(2)).Return(closingProvisions, nil)
+
+	// Execute
+	result, err := service.GetMovements(ctx, "2025-12", "2026-01")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-12", result.FromPeriod)
+	assert.Equal(t, "2026-01", result.ToPeriod)
+	assert.Len(t, result.Movements, 6) // three stages for IDR and USD
+
+	// loan_b moves its opening 2000 to stage 2, loan_a is remeasured by -500 and loan_d is new
+	assert.Equal(t, models.ProvisionStageMovement{
+		Currency: models.CurrencyIDR, Stage: models.ProvisionStage1,
+		OpeningProvision: 6500.00, NewLoans: 1000.00, TransfersOut: 2000.00, Remeasurement: -500.00, ClosingProvision: 5000.00,
+	}, result.Movements[0])
+	assert.Equal(t, models.ProvisionStageMovement{
+		Currency: models.CurrencyIDR, Stage: models.ProvisionStage2,
+		TransfersIn: 2000.00, Remeasurement: 28000.00, ClosingProvision: 30000.00,
+	}, result.Movements[1])
+	assert.Equal(t, models.ProvisionStageMovement{
+		Currency: models.CurrencyIDR, Stage: models.ProvisionStage3,
+		OpeningProvision: 100000.00, Derecognised: 100000.00,
+	}, result.Movements[2])
+	assert.Equal(t, "USD", result.Movements[3].Currency)
+	assert.Equal(t, 10.00, result.Movements[3].NewLoans)
+	assert.Equal(t, 10.00, result.Movements[3].ClosingProvision)
+}
+
+func TestProvisioningService_GetMovements_InvalidPeriods(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	// Execute
+	result, err := service.GetMovements(ctx, "2026-01", "2026-01")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "from period must be before to period", err.Error())
+}
+
+func TestProvisioningService_GetMovements_RunNotFound(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetRunByPeriod", ctx, "2025-12").Return(nil, nil)
+
+	// Execute
+	result, err := service.GetMovements(ctx, "2025-12", "2026-01")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "provision run for 2025-12 not found", err.Error())
+}
+
+func TestProvisioningService_GetParameters_FallsBackToDefaultProduct(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	defaultParameters := []*models.ProvisionParameter{
+		{ProductCode: models.DefaultProductCode, Stage: models.ProvisionStage1, ProbabilityOfDefault: 0.015, LossGivenDefault: 0.4},
+		{ProductCode: models.DefaultProductCode, Stage: models.ProvisionStage2, ProbabilityOfDefault: 0.25, LossGivenDefault: 0.4},
+		{ProductCode: models.DefaultProductCode, Stage: models.ProvisionStage3, ProbabilityOfDefault: 1, LossGivenDefault: 0.4},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetParametersByProductCode", ctx, "PAYLATER").Return([]*models.ProvisionParameter{}, nil)
+	mockRepo.On("GetParametersByProductCode", ctx, models.DefaultProductCode).Return(defaultParameters, nil)
+
+	// Execute
+	result, err := service.GetParameters(ctx, "PAYLATER")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "PAYLATER", result.ProductCode)
+	assert.Equal(t, models.ProvisionParameterSourceDefaultProduct, result.Source)
+	assert.Len(t, result.Stages, 3)
+	assert.Equal(t, 0.015, result.Stages[0].ProbabilityOfDefault)
+}
+
+func TestProvisioningService_UpdateParameters(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	req := &models.ProvisionParametersRequest{
+		Stages: []models.ProvisionParameterRequest{
+			{Stage: 3, ProbabilityOfDefault: 1, LossGivenDefault: 0.6},
+			{Stage: 1, ProbabilityOfDefault: 0.02, LossGivenDefault: 0.6},
+			{Stage: 2, ProbabilityOfDefault: 0.3, LossGivenDefault: 0.6},
+		},
+	}
+
+	// Mock repository calls
+	mockRepo.On("ReplaceParameters", ctx, "PAYLATER", mock.MatchedBy(func(parameters []*models.ProvisionParameter) bool {
+		return len(parameters) == 3 && parameters[0].Stage == models.ProvisionStage1 && parameters[2].Stage == models.ProvisionStage3
+	})).Return(nil)
+
+	// Execute
+	result, err := service.UpdateParameters(ctx, "PAYLATER", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.ProvisionParameterSourceProduct, result.Source)
+	assert.Equal(t, models.ProvisionStage1, result.Stages[0].Stage)
+	assert.Equal(t, 0.02, result.Stages[0].ProbabilityOfDefault)
+}
+
+func TestProvisioningService_UpdateParameters_InvalidStages(t *testing.T) {
+	mockRepo := mocks.NewProvisioningMySQLRepositoryInterface(t)
+	service := NewProvisioningService(mockRepo, newTestStager(t))
+	ctx := context.Background()
+
+	// Execute
+	_, err := service.UpdateParameters(ctx, "PAYLATER", &models.ProvisionParametersRequest{
+		Stages: []models.ProvisionParameterRequest{{Stage: 1, ProbabilityOfDefault: 0.02, LossGivenDefault: 0.6}},
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "provision parameters must cover stages 1, 2 and 3", err.Error())
+
+	// Execute
+	_, err = service.UpdateParameters(ctx, "PAYLATER", &models.ProvisionParametersRequest{
+		Stages: []models.ProvisionParameterRequest{
+			{Stage: 1, ProbabilityOfDefault: 0.02, LossGivenDefault: 0.6},
+			{Stage: 1, ProbabilityOfDefault: 0.03, LossGivenDefault: 0.6},
+			{Stage: 3, ProbabilityOfDefault: 1, LossGivenDefault: 0.6},
+		},
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "stage 1 is configured more than once", err.Error())
+}
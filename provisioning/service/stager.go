@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"billing-engine/models"
+)
+
+// DefaultStageDpdThresholds are the upper DPD bounds of Stage 1 and Stage 2: more than 30 days
+// past due is a significant increase in credit risk and more than 90 is credit-impaired
+var DefaultStageDpdThresholds = []int{30, 90}
+
+// Stager maps days past due to an IFRS 9 impairment stage
+type Stager struct {
+	upperBounds []int
+}
+
+// NewStager creates a stager from the inclusive upper DPD bounds of Stage 1 and Stage 2
+func NewStager(upperBounds []int) (*Stager, error) {
+	if len(upperBounds) != models.ProvisionStage3-1 {
+		return nil, fmt.Errorf("expected %d dpd thresholds, got %d", models.ProvisionStage3-1, len(upperBounds))
+	}
+	for i, bound := range upperBounds {
+		if bound < 0 {
+			return nil, fmt.Errorf("dpd threshold %d cannot be negative", bound)
+		}
+		if i > 0 && bound <= upperBounds[i-1] {
+			return nil, fmt.Errorf("dpd thresholds must be strictly increasing")
+		}
+	}
+	return &Stager{upperBounds: upperBounds}, nil
+}
+
+// ParseStageDpdThresholds parses a comma separated threshold list such as "30,90".
+// An empty value yields the defaults.
+func ParseStageDpdThresholds(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultStageDpdThresholds, nil
+	}
+	parts := strings.Split(value, ",")
+	thresholds := make([]int, 0, len(parts))
+	for _, part := range parts {
+		threshold, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid dpd threshold %q: %v", part, err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// Stage returns the impairment stage for the given days past due
+func (s *Stager) Stage(dpd int) int {
+	for i, bound := range s.upperBounds {
+		if dpd <= bound {
+			return i + 1
+		}
+	}
+	return models.ProvisionStage3
+}
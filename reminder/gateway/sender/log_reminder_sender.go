@@ -0,0 +1,29 @@
+// Package sender implements the channels payment reminders are delivered through.
+package sender
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"billing-engine/models"
+	"billing-engine/reminder"
+)
+
+type logReminderSender struct{}
+
+// NewLogReminderSender creates a reminder sender that writes each reminder to the service log
+// instead of messaging the borrower, until a notification provider is connected
+func NewLogReminderSender() reminder.ReminderSenderInterface {
+	return &logReminderSender{}
+}
+
+func (s *logReminderSender) SendReminder(ctx context.Context, paymentReminder *models.PaymentReminder) error {
+	if paymentReminder.CustomerID == "" {
+		return fmt.Errorf("customer ID is required")
+	}
+	log.Printf("%s reminder for installment %d of loan %s to customer %s: %s %.2f due %s",
+		paymentReminder.ReminderType, paymentReminder.InstallmentNumber, paymentReminder.LoanID, paymentReminder.CustomerID,
+		paymentReminder.Currency, paymentReminder.AmountDue, paymentReminder.InstallmentDueDate.Format("2006-01-02"))
+	return nil
+}
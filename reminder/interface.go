@@ -0,0 +1,27 @@
+package reminder
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// ReminderMySQLRepositoryInterface defines the interface for payment reminder repository
+type ReminderMySQLRepositoryInterface interface {
+	GetRemindableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	GetPendingPaymentSchedules(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	GetReminder(ctx context.Context, scheduleID uint, businessDate time.Time) (*models.PaymentReminder, error)
+	SaveReminder(ctx context.Context, paymentReminder *models.PaymentReminder) error
+}
+
+// ReminderSenderInterface defines the interface for the channel that delivers a payment reminder to
+// the borrower
+type ReminderSenderInterface interface {
+	SendReminder(ctx context.Context, paymentReminder *models.PaymentReminder) error
+}
+
+// ReminderServiceInterface defines the interface for payment reminder service
+type ReminderServiceInterface interface {
+	GetRemindableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error)
+	RemindLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error
+}
@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/reminder"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+)
+
+type reminderMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderMySQLRepository creates a new payment reminder repository instance
+func NewReminderMySQLRepository(db *gorm.DB) reminder.ReminderMySQLRepositoryInterface {
+	return &reminderMySQLRepository{db: db}
+}
+
+// GetRemindableLoanSummaries returns the disbursed loans that are not settled yet
+func (r *reminderMySQLRepository) GetRemindableLoanSummaries(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("id > ? AND status IN ? AND deleted_at IS NULL", afterID, []string{models.StatusPending, models.StatusDelinquent}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+func (r *reminderMySQLRepository) GetPendingPaymentSchedules(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanID, models.StatusPending).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *reminderMySQLRepository) GetReminder(ctx context.Context, scheduleID uint, businessDate time.Time) (*models.PaymentReminder, error) {
+	var paymentReminder models.PaymentReminder
+	err := transaction.DB(ctx, r.db).
+		Where("schedule_id = ? AND business_date = ?", scheduleID, businessDate.Format("2006-01-02")).
+		First(&paymentReminder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &paymentReminder, nil
+}
+
+// SaveReminder stores a new reminder or the outcome of sending one again
+func (r *reminderMySQLRepository) SaveReminder(ctx context.Context, paymentReminder *models.PaymentReminder) error {
+	return transaction.DB(ctx, r.db).Save(paymentReminder).Error
+}
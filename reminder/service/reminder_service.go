@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/reminder"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultOverdueDays are the days past due an overdue installment is reminded of
+var DefaultOverdueDays = []int{1, 7, 30}
+
+// ParseOverdueDays parses a comma separated list of days past due such as "1,7,30".
+// An empty value yields the defaults.
+func ParseOverdueDays(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultOverdueDays, nil
+	}
+	parts := strings.Split(value, ",")
+	overdueDays := make([]int, 0, len(parts))
+	for i, part := range parts {
+		days, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid days past due %q: %v", part, err)
+		}
+		if days < 1 {
+			return nil, fmt.Errorf("days past due must be at least 1")
+		}
+		if i > 0 && days <= overdueDays[i-1] {
+			return nil, fmt.Errorf("days past due must be strictly increasing")
+		}
+		overdueDays = append(overdueDays, days)
+	}
+	return overdueDays, nil
+}
+
+type reminderService struct {
+	reminderRepo   reminder.ReminderMySQLRepositoryInterface
+	reminderSender reminder.ReminderSenderInterface
+	taxService     tax.TaxServiceInterface
+	daysBeforeDue  int   // days ahead of the due date an upcoming installment is reminded of
+	overdueDays    []int // days past due an overdue installment is reminded of
+}
+
+// NewReminderService creates a new payment reminder service instance
+func NewReminderService(reminderRepo reminder.ReminderMySQLRepositoryInterface, reminderSender reminder.ReminderSenderInterface, taxService tax.TaxServiceInterface, daysBeforeDue int, overdueDays []int) reminder.ReminderServiceInterface {
+	return &reminderService{
+		reminderRepo:   reminderRepo,
+		reminderSender: reminderSender,
+		taxService:     taxService,
+		daysBeforeDue:  daysBeforeDue,
+		overdueDays:    overdueDays,
+	}
+}
+
+// GetRemindableLoans returns the loans the reminders cover, in ID order after afterID
+func (s *reminderService) GetRemindableLoans(ctx context.Context, afterID uint, limit int) ([]*models.LoanSummary, error) {
+	loanSummaries, err := s.reminderRepo.GetRemindableLoanSummaries(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remindable loans: %v", err)
+	}
+	return loanSummaries, nil
+}
+
+// RemindLoan sends the reminders of the loan's pending installments once the business date is
+// closed: an UPCOMING reminder daysBeforeDue days ahead of the due date, and an OVERDUE reminder
+// on each of the overdue days. Each reminder is stored with its outcome. A reminder already sent
+// for the business date is not sent again, while one that failed is retried, so the loan fails
+// the step until every reminder is out.
+func (s *reminderService) RemindLoan(ctx context.Context, loanSummary *models.LoanSummary, businessDate time.Time) error {
+	schedules, err := s.reminderRepo.GetPendingPaymentSchedules(ctx, loanSummary.LoanID)
+	if err != nil {
+		return fmt.Errorf("failed to get pending payment schedules: %v", err)
+	}
+
+	// The reminders reach the borrower on the day after the business date
+	asOf := businessDate.AddDate(0, 0, 1)
+	var failed []string
+	for _, schedule := range schedules {
+		reminderType, daysPastDue := s.reminderTypeOf(schedule, asOf)
+		if reminderType == "" {
+			continue
+		}
+
+		paymentReminder, err := s.reminderRepo.GetReminder(ctx, schedule.ID, businessDate)
+		if err != nil {
+			return fmt.Errorf("failed to get payment reminder: %v", err)
+		}
+		if paymentReminder != nil && paymentReminder.Status == models.ReminderStatusSent {
+			continue
+		}
+		if paymentReminder == nil {
+			paymentReminder = &models.PaymentReminder{ScheduleID: schedule.ID, BusinessDate: businessDate}
+		}
+
+		loanCurrency := currency.Normalize(loanSummary.Currency)
+		paymentReminder.LoanID = loanSummary.LoanID
+		paymentReminder.CustomerID = loanSummary.CustomerID
+		paymentReminder.ReminderType = reminderType
+		paymentReminder.InstallmentNumber = schedule.InstallmentNumber
+		paymentReminder.InstallmentDueDate = schedule.InstallmentDueDate
+		paymentReminder.DaysPastDue = daysPastDue
+		paymentReminder.AmountDue = s.amountDue(schedule, loanCurrency).InexactFloat64()
+		paymentReminder.Currency = loanCurrency
+
+		if err := s.reminderSender.SendReminder(ctx, paymentReminder); err != nil {
+			paymentReminder.Status = models.ReminderStatusFailed
+			paymentReminder.ErrorMessage = err.Error()
+			failed = append(failed, fmt.Sprintf("installment %d: %v", schedule.InstallmentNumber, err))
+		} else {
+			sentAt := time.Now()
+			paymentReminder.Status = models.ReminderStatusSent
+			paymentReminder.ErrorMessage = ""
+			paymentReminder.SentAt = &sentAt
+		}
+		if err := s.reminderRepo.SaveReminder(ctx, paymentReminder); err != nil {
+			return fmt.Errorf("failed to save payment reminder: %v", err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send payment reminders: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// reminderTypeOf returns the reminder the installment gets on asOf with its days past due, or an
+// empty type when it gets none that day
+func (s *reminderService) reminderTypeOf(schedule *models.PaymentSchedule, asOf time.Time) (string, int) {
+	daysPastDue := schedule.DaysPastDue(asOf)
+	if daysPastDue == 0 {
+		dueDate := time.Date(schedule.InstallmentDueDate.Year(), schedule.InstallmentDueDate.Month(), schedule.InstallmentDueDate.Day(), 0, 0, 0, 0, time.Local)
+		remindOn := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, s.daysBeforeDue)
+		if dueDate.Equal(remindOn) {
+			return models.ReminderTypeUpcoming, 0
+		}
+		return "", 0
+	}
+	for _, days := range s.overdueDays {
+		if daysPastDue == days {
+			return models.ReminderTypeOverdue, daysPastDue
+		}
+	}
+	return "", 0
+}
+
+// amountDue is what paying the installment takes, as the repayment requires it: the installment,
+// its penalty and the tax on that penalty
+func (s *reminderService) amountDue(schedule *models.PaymentSchedule, currencyCode string) decimal.Decimal {
+	amount := decimal.NewFromFloat(schedule.InstallmentAmount)
+	if schedule.PenaltyAmount <= 0 {
+		return amount
+	}
+
+	amount = amount.Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+	if taxLine := s.taxService.TaxCharge(models.ChargeTypePenalty, schedule.PenaltyAmount, currencyCode); taxLine != nil {
+		amount = amount.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+	}
+	return amount
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"billing-engine/models"
+	mocks "billing-engine/reminder/_mock"
+	taxMocks "billing-engine/tax/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseOverdueDays(t *testing.T) {
+	overdueDays, err := ParseOverdueDays("1, 7,30")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 7, 30}, overdueDays)
+
+	overdueDays, err = ParseOverdueDays("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultOverdueDays, overdueDays)
+
+	_, err = ParseOverdueDays("7,1")
+	assert.Error(t, err)
+	_, err = ParseOverdueDays("0,7")
+	assert.Error(t, err)
+	_, err = ParseOverdueDays("1,x")
+	assert.Error(t, err)
+}
+
+func TestReminderService_RemindLoan(t *testing.T) {
+	mockRepo := mocks.NewReminderMySQLRepositoryInterface(t)
+	mockSender := mocks.NewReminderSenderInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	service := NewReminderService(mockRepo, mockSender, mockTax, 3, []int{1, 7, 30})
+	ctx := context.Background()
+
+	// Reminders of the business date reach the borrower on 11 March
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", CustomerID: "customer_123", Currency: models.CurrencyIDR, Status: models.StatusDelinquent}
+	schedules := []*models.PaymentSchedule{
+		// 7 days overdue with a penalty
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 110000.00, PenaltyAmount: 770.00, InstallmentDueDate: time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+		// 2 days overdue, no reminder that day
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00, PenaltyAmount: 220.00, InstallmentDueDate: time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+		// Due in 3 days
+		{ID: 3, LoanID: "loan_123", InstallmentNumber: 3, InstallmentAmount: 110000.00, InstallmentDueDate: time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+		// Due in 10 days
+		{ID: 4, LoanID: "loan_123", InstallmentNumber: 4, InstallmentAmount: 110000.00, InstallmentDueDate: time.Date(2026, 3, 21, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetPendingPaymentSchedules", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("GetReminder", ctx, uint(1), businessDate).Return(nil, nil)
+	mockRepo.On("GetReminder", ctx, uint(3), businessDate).Return(nil, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 770.00, models.CurrencyIDR).Return(&models.TaxLine{TaxAmount: 85.00})
+	mockSender.On("SendReminder", ctx, mock.Anything).Return(nil).Twice()
+	mockRepo.On("SaveReminder", ctx, mock.MatchedBy(func(paymentReminder *models.PaymentReminder) bool {
+		return paymentReminder.ScheduleID == 1 && paymentReminder.ReminderType == models.ReminderTypeOverdue &&
+			paymentReminder.DaysPastDue == 7 && paymentReminder.AmountDue == 110855.00 &&
+			paymentReminder.Status == models.ReminderStatusSent && paymentReminder.SentAt != nil
+	})).Return(nil).Once()
+	mockRepo.On("SaveReminder", ctx, mock.MatchedBy(func(paymentReminder *models.PaymentReminder) bool {
+		return paymentReminder.ScheduleID == 3 && paymentReminder.ReminderType == models.ReminderTypeUpcoming &&
+			paymentReminder.AmountDue == 110000.00 && paymentReminder.CustomerID == "customer_123"
+	})).Return(nil).Once()
+
+	// Execute
+	err := service.RemindLoan(ctx, loanSummary, businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReminderService_RemindLoan_ResumedRun(t *testing.T) {
+	mockRepo := mocks.NewReminderMySQLRepositoryInterface(t)
+	mockSender := mocks.NewReminderSenderInterface(t)
+	service := NewReminderService(mockRepo, mockSender, taxMocks.NewTaxServiceInterface(t), 3, []int{1, 7, 30})
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", CustomerID: "customer_123", Currency: models.CurrencyIDR, Status: models.StatusPending}
+	schedules := []*models.PaymentSchedule{
+		{ID: 3, LoanID: "loan_123", InstallmentNumber: 3, InstallmentAmount: 110000.00, InstallmentDueDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+		{ID: 5, LoanID: "loan_123", InstallmentNumber: 4, InstallmentAmount: 110000.00, InstallmentDueDate: time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+	}
+	failedReminder := &models.PaymentReminder{ID: 9, ScheduleID: 5, BusinessDate: businessDate, Status: models.ReminderStatusFailed, ErrorMessage: "provider unavailable"}
+
+	// Mock repository calls: the first reminder went out before the run failed, the second did not
+	mockRepo.On("GetPendingPaymentSchedules", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("GetReminder", ctx, uint(3), businessDate).Return(&models.PaymentReminder{ID: 8, ScheduleID: 3, Status: models.ReminderStatusSent}, nil)
+	mockRepo.On("GetReminder", ctx, uint(5), businessDate).Return(failedReminder, nil)
+	mockSender.On("SendReminder", ctx, failedReminder).Return(nil).Once()
+	mockRepo.On("SaveReminder", ctx, failedReminder).Return(nil).Once()
+
+	// Execute
+	err := service.RemindLoan(ctx, loanSummary, businessDate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReminderStatusSent, failedReminder.Status)
+	assert.Empty(t, failedReminder.ErrorMessage)
+	assert.Equal(t, uint(9), failedReminder.ID)
+}
+
+func TestReminderService_RemindLoan_SendFails(t *testing.T) {
+	mockRepo := mocks.NewReminderMySQLRepositoryInterface(t)
+	mockSender := mocks.NewReminderSenderInterface(t)
+	service := NewReminderService(mockRepo, mockSender, taxMocks.NewTaxServiceInterface(t), 3, []int{1, 7, 30})
+	ctx := context.Background()
+
+	businessDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	loanSummary := &models.LoanSummary{ID: 1, LoanID: "loan_123", CustomerID: "customer_123", Currency: models.CurrencyIDR, Status: models.StatusDelinquent}
+	schedules := []*models.PaymentSchedule{
+		// 1 day overdue
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00, InstallmentDueDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetPendingPaymentSchedules", ctx, "loan_123").Return(schedules, nil)
+	mockRepo.On("GetReminder", ctx, uint(2), businessDate).Return(nil, nil)
+	mockSender.On("SendReminder", ctx, mock.Anything).Return(errors.New("provider unavailable"))
+	mockRepo.On("SaveReminder", ctx, mock.MatchedBy(func(paymentReminder *models.PaymentReminder) bool {
+		return paymentReminder.Status == models.ReminderStatusFailed && paymentReminder.ErrorMessage == "provider unavailable" &&
+			paymentReminder.DaysPastDue == 1 && paymentReminder.SentAt == nil
+	})).Return(nil)
+
+	// Execute
+	err := service.RemindLoan(ctx, loanSummary, businessDate)
+
+	// Assert: the loan fails the step so the reminder is retried on resume
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "installment 2: provider unavailable")
+}
@@ -16,6 +16,52 @@ type RepaymentMySQLRepositoryInterface struct {
 	mock.Mock
 }
 
+// CollectTaxLines provides a mock function with given fields: ctx, loanID, installmentNumbers, penaltyTaxLines, collectedAt
+func (_m *RepaymentMySQLRepositoryInterface) CollectTaxLines(ctx context.Context, loanID string, installmentNumbers []int, penaltyTaxLines []*models.TaxLine, collectedAt time.Time) error {
+	ret := _m.Called(ctx, loanID, installmentNumbers, penaltyTaxLines, collectedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CollectTaxLines")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, []*models.TaxLine, time.Time) error); ok {
+		r0 = rf(ctx, loanID, installmentNumbers, penaltyTaxLines, collectedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCustomerRepayment provides a mock function with given fields: ctx, customerRepayment
+func (_m *RepaymentMySQLRepositoryInterface) CreateCustomerRepayment(ctx context.Context, customerRepayment *models.CustomerRepayment) (bool, error) {
+	ret := _m.Called(ctx, customerRepayment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCustomerRepayment")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CustomerRepayment) (bool, error)); ok {
+		return rf(ctx, customerRepayment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CustomerRepayment) bool); ok {
+		r0 = rf(ctx, customerRepayment)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CustomerRepayment) error); ok {
+		r1 = rf(ctx, customerRepayment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePaymentHistory provides a mock function with given fields: ctx, histories
 func (_m *RepaymentMySQLRepositoryInterface) CreatePaymentHistory(ctx context.Context, histories []*models.PaymentScheduleHistory) error {
 	ret := _m.Called(ctx, histories)
@@ -34,6 +80,36 @@ func (_m *RepaymentMySQLRepositoryInterface) CreatePaymentHistory(ctx context.Co
 	return r0
 }
 
+// GetActiveLoanSummariesByCustomerID provides a mock function with given fields: ctx, customerID, currencyCode
+func (_m *RepaymentMySQLRepositoryInterface) GetActiveLoanSummariesByCustomerID(ctx context.Context, customerID string, currencyCode string) ([]*models.LoanSummary, error) {
+	ret := _m.Called(ctx, customerID, currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveLoanSummariesByCustomerID")
+	}
+
+	var r0 []*models.LoanSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.LoanSummary, error)); ok {
+		return rf(ctx, customerID, currencyCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.LoanSummary); ok {
+		r0 = rf(ctx, customerID, currencyCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoanSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, customerID, currencyCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLoanSummaryByLoanID provides a mock function with given fields: ctx, loanID
 func (_m *RepaymentMySQLRepositoryInterface) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
 	ret := _m.Called(ctx, loanID)
@@ -154,6 +230,24 @@ func (_m *RepaymentMySQLRepositoryInterface) GetPendingPaymentSchedulesByLoanID(
 	return r0, r1
 }
 
+// UpdateCustomerRepayment provides a mock function with given fields: ctx, customerRepayment
+func (_m *RepaymentMySQLRepositoryInterface) UpdateCustomerRepayment(ctx context.Context, customerRepayment *models.CustomerRepayment) error {
+	ret := _m.Called(ctx, customerRepayment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCustomerRepayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CustomerRepayment) error); ok {
+		r0 = rf(ctx, customerRepayment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateLoanSummary provides a mock function with given fields: ctx, loanSummary
 func (_m *RepaymentMySQLRepositoryInterface) UpdateLoanSummary(ctx context.Context, loanSummary *models.LoanSummary) error {
 	ret := _m.Called(ctx, loanSummary)
@@ -190,6 +284,24 @@ func (_m *RepaymentMySQLRepositoryInterface) UpdatePaymentSchedules(ctx context.
 	return r0
 }
 
+// WithinTransaction provides a mock function with given fields: ctx, fn
+func (_m *RepaymentMySQLRepositoryInterface) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepaymentMySQLRepositoryInterface creates a new instance of RepaymentMySQLRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepaymentMySQLRepositoryInterface(t interface {
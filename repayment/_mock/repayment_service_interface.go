@@ -14,6 +14,36 @@ type RepaymentServiceInterface struct {
 	mock.Mock
 }
 
+// ProcessCustomerRepayment provides a mock function with given fields: ctx, customerID, req
+func (_m *RepaymentServiceInterface) ProcessCustomerRepayment(ctx context.Context, customerID string, req *models.CustomerRepaymentRequest) (*models.CustomerRepaymentResponse, error) {
+	ret := _m.Called(ctx, customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessCustomerRepayment")
+	}
+
+	var r0 *models.CustomerRepaymentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CustomerRepaymentRequest) (*models.CustomerRepaymentResponse, error)); ok {
+		return rf(ctx, customerID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CustomerRepaymentRequest) *models.CustomerRepaymentResponse); ok {
+		r0 = rf(ctx, customerID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CustomerRepaymentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.CustomerRepaymentRequest) error); ok {
+		r1 = rf(ctx, customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProcessRepayment provides a mock function with given fields: ctx, req
 func (_m *RepaymentServiceInterface) ProcessRepayment(ctx context.Context, req *models.RepaymentRequest) (*models.RepaymentResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -27,6 +27,7 @@ func NewRepaymentHandler(e *echo.Echo, repaymentService repayment.RepaymentServi
 	// Register routes
 	v1 := e.Group("/v1")
 	v1.POST("/repayment", handler.ProcessRepayment)
+	v1.POST("/customers/:customer_id/repayment", handler.ProcessCustomerRepayment)
 }
 
 func (h *RepaymentHandler) ProcessRepayment(c echo.Context) error {
@@ -61,3 +62,42 @@ func (h *RepaymentHandler) ProcessRepayment(c echo.Context) error {
 		Data:   response,
 	})
 }
+
+func (h *RepaymentHandler) ProcessCustomerRepayment(c echo.Context) error {
+	customerID := c.Param("customer_id")
+	if customerID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Customer ID is required",
+		})
+	}
+
+	var req models.CustomerRepaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.repaymentService.ProcessCustomerRepayment(c.Request().Context(), customerID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.CustomerRepaymentSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
@@ -257,3 +257,123 @@ func TestRepaymentHandler_ProcessRepayment_IncorrectPaymentAmount(t *testing.T)
 
 	mockService.AssertExpectations(t)
 }
+
+func TestRepaymentHandler_ProcessCustomerRepayment_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewRepaymentServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &RepaymentHandler{
+		repaymentService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	req := models.CustomerRepaymentRequest{
+		PaymentAmount: 330000.00,
+		Policy:        models.AllocationPolicyProRata,
+	}
+
+	expectedResponse := &models.CustomerRepaymentResponse{
+		CustomerID:      "12312312",
+		PaymentAmount:   330000.00,
+		Currency:        models.CurrencyIDR,
+		Policy:          models.AllocationPolicyProRata,
+		AllocatedAmount: 330000.00,
+		PaymentDate:     time.Now(),
+		Allocations: []models.CustomerRepaymentAllocation{
+			{LoanID: "loan_1", AllocatedAmount: 220000.00, InstallmentsPaid: 2},
+			{LoanID: "loan_2", AllocatedAmount: 110000.00, InstallmentsPaid: 1},
+		},
+	}
+
+	mockService.On("ProcessCustomerRepayment", mock.Anything, "12312312", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/customers/12312312/repayment", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("12312312")
+
+	// Execute
+	err := handler.ProcessCustomerRepayment(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.CustomerRepaymentSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Allocations, 2)
+	assert.Equal(t, 330000.00, response.Data.AllocatedAmount)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRepaymentHandler_ProcessCustomerRepayment_InvalidPolicy(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewRepaymentServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &RepaymentHandler{
+		repaymentService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/customers/12312312/repayment", bytes.NewBufferString(`{"payment_amount":110000,"policy":"LARGEST_FIRST"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("12312312")
+
+	// Execute
+	err := handler.ProcessCustomerRepayment(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Contains(t, response.Message, "policy must be one of")
+}
+
+func TestRepaymentHandler_ProcessCustomerRepayment_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewRepaymentServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &RepaymentHandler{
+		repaymentService: mockService,
+		middleware:       mockMiddleware,
+	}
+
+	req := models.CustomerRepaymentRequest{PaymentAmount: 50000.00}
+	mockService.On("ProcessCustomerRepayment", mock.Anything, "12312312", &req).Return(nil, errors.New("payment amount 50000.00 does not cover a full payment on any loan"))
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/customers/12312312/repayment", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("12312312")
+
+	// Execute
+	err := handler.ProcessCustomerRepayment(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
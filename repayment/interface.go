@@ -14,10 +14,16 @@ type RepaymentMySQLRepositoryInterface interface {
 	UpdatePaymentSchedules(ctx context.Context, schedules []*models.PaymentSchedule) error
 	UpdateLoanSummary(ctx context.Context, loanSummary *models.LoanSummary) error
 	CreatePaymentHistory(ctx context.Context, histories []*models.PaymentScheduleHistory) error
+	CollectTaxLines(ctx context.Context, loanID string, installmentNumbers []int, penaltyTaxLines []*models.TaxLine, collectedAt time.Time) error
 	GetNextDueDate(ctx context.Context, loanID string) (*time.Time, error)
+	GetActiveLoanSummariesByCustomerID(ctx context.Context, customerID string, currencyCode string) ([]*models.LoanSummary, error)
+	CreateCustomerRepayment(ctx context.Context, customerRepayment *models.CustomerRepayment) (bool, error)
+	UpdateCustomerRepayment(ctx context.Context, customerRepayment *models.CustomerRepayment) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // RepaymentServiceInterface defines the interface for repayment service
 type RepaymentServiceInterface interface {
 	ProcessRepayment(ctx context.Context, req *models.RepaymentRequest) (*models.RepaymentResponse, error)
+	ProcessCustomerRepayment(ctx context.Context, customerID string, req *models.CustomerRepaymentRequest) (*models.CustomerRepaymentResponse, error)
 }
@@ -3,14 +3,20 @@ package mysql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"billing-engine/models"
 	"billing-engine/repayment"
+	"billing-engine/utils/transaction"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// repayableLoanStatuses are the statuses of a loan whose installments are being paid
+var repayableLoanStatuses = []string{models.StatusPending, models.StatusDelinquent}
+
 type repaymentMySQLRepository struct {
 	db *gorm.DB
 }
@@ -20,9 +26,11 @@ func NewRepaymentMySQLRepository(db *gorm.DB) repayment.RepaymentMySQLRepository
 	return &repaymentMySQLRepository{db: db}
 }
 
+// GetLoanSummaryByLoanID returns the loan summary. Within a transaction the row stays locked until
+// it ends, so concurrent payments on the loan are planned one after the other.
 func (r *repaymentMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
 	var loanSummary models.LoanSummary
-	err := r.db.WithContext(ctx).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	err := transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -34,7 +42,7 @@ func (r *repaymentMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, l
 
 func (r *repaymentMySQLRepository) GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
 	var schedules []*models.PaymentSchedule
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanID, models.StatusPending).
 		Order("installment_number ASC").
 		Find(&schedules).Error
@@ -47,7 +55,7 @@ func (r *repaymentMySQLRepository) GetPendingPaymentSchedulesByLoanID(ctx contex
 func (r *repaymentMySQLRepository) GetOverduePaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
 	var schedules []*models.PaymentSchedule
 	now := time.Now()
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("loan_id = ? AND status = ? AND installment_due_date < ? AND deleted_at IS NULL", loanID, models.StatusPending, now).
 		Order("installment_number ASC").
 		Find(&schedules).Error
@@ -57,26 +65,81 @@ func (r *repaymentMySQLRepository) GetOverduePaymentSchedulesByLoanID(ctx contex
 	return schedules, nil
 }
 
+// UpdatePaymentSchedules marks the installments as paid. It fails when one of them is no longer
+// PENDING, so an installment is never paid twice.
 func (r *repaymentMySQLRepository) UpdatePaymentSchedules(ctx context.Context, schedules []*models.PaymentSchedule) error {
 	for _, schedule := range schedules {
-		if err := r.db.WithContext(ctx).Save(schedule).Error; err != nil {
-			return err
+		result := transaction.DB(ctx, r.db).
+			Model(schedule).
+			Where("status = ?", models.StatusPending).
+			Updates(map[string]interface{}{
+				"status":           schedule.Status,
+				"installment_paid": schedule.InstallmentPaid,
+				"updated_by":       schedule.UpdatedBy,
+				"updated_at":       schedule.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("installment %d is no longer pending", schedule.InstallmentNumber)
 		}
 	}
 	return nil
 }
 
+// UpdateLoanSummary writes the outstanding amount and status left by a payment. It fails when the
+// loan is no longer PENDING or DELINQUENT, so a loan written off or cancelled in the meantime is
+// not brought back.
 func (r *repaymentMySQLRepository) UpdateLoanSummary(ctx context.Context, loanSummary *models.LoanSummary) error {
-	return r.db.WithContext(ctx).Save(loanSummary).Error
+	result := transaction.DB(ctx, r.db).
+		Model(loanSummary).
+		Where("status IN ?", repayableLoanStatuses).
+		Updates(map[string]interface{}{
+			"outstanding_amount": loanSummary.OutstandingAmount,
+			"status":             loanSummary.Status,
+			"updated_by":         loanSummary.UpdatedBy,
+			"updated_at":         loanSummary.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("loan %s is no longer repayable", loanSummary.LoanID)
+	}
+	return nil
 }
 
 func (r *repaymentMySQLRepository) CreatePaymentHistory(ctx context.Context, histories []*models.PaymentScheduleHistory) error {
-	return r.db.WithContext(ctx).Create(&histories).Error
+	return transaction.DB(ctx, r.db).Create(&histories).Error
+}
+
+// CollectTaxLines marks the tax due with the paid installments as collected and stores the tax on
+// the penalties paid with them, in one transaction
+func (r *repaymentMySQLRepository) CollectTaxLines(ctx context.Context, loanID string, installmentNumbers []int, penaltyTaxLines []*models.TaxLine, collectedAt time.Time) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if len(installmentNumbers) > 0 {
+			if err := tx.Model(&models.TaxLine{}).
+				Where("loan_id = ? AND installment_number IN ? AND status = ?", loanID, installmentNumbers, models.StatusPending).
+				Updates(map[string]interface{}{
+					"status":       models.TaxStatusCollected,
+					"collected_at": collectedAt,
+					"updated_by":   "system",
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(penaltyTaxLines) == 0 {
+			return nil
+		}
+		return tx.Create(&penaltyTaxLines).Error
+	})
 }
 
 func (r *repaymentMySQLRepository) GetNextDueDate(ctx context.Context, loanID string) (*time.Time, error) {
 	var schedule models.PaymentSchedule
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanID, models.StatusPending).
 		Order("installment_number ASC").
 		First(&schedule).Error
@@ -88,3 +151,71 @@ func (r *repaymentMySQLRepository) GetNextDueDate(ctx context.Context, loanID st
 	}
 	return &schedule.InstallmentDueDate, nil
 }
+
+// GetActiveLoanSummariesByCustomerID returns the customer's pending and delinquent loans in the currency, oldest first
+func (r *repaymentMySQLRepository) GetActiveLoanSummariesByCustomerID(ctx context.Context, customerID string, currencyCode string) ([]*models.LoanSummary, error) {
+	var loanSummaries []*models.LoanSummary
+	err := transaction.DB(ctx, r.db).
+		Where("customer_id = ? AND currency = ? AND status IN ? AND deleted_at IS NULL",
+			customerID, currencyCode, repayableLoanStatuses).
+		Order("loan_start_date ASC, id ASC").
+		Find(&loanSummaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return loanSummaries, nil
+}
+
+// CreateCustomerRepayment claims the customer's reference for the repayment before any loan is
+// paid. When the reference is stored already it reports false and loads the stored repayment into
+// customerRepayment; a concurrent claim waits on the unique key until the other one commits.
+func (r *repaymentMySQLRepository) CreateCustomerRepayment(ctx context.Context, customerRepayment *models.CustomerRepayment) (bool, error) {
+	db := transaction.DB(ctx, r.db)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(customerRepayment)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	// The row exists, so the locking read takes a record lock and sees the committed repayment
+	var stored models.CustomerRepayment
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Loans", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Where("customer_id = ? AND reference = ?", customerRepayment.CustomerID, customerRepayment.Reference).
+		First(&stored).Error
+	if err != nil {
+		return false, err
+	}
+	*customerRepayment = stored
+	return false, nil
+}
+
+// UpdateCustomerRepayment stores how the claimed customer repayment was allocated and what each
+// loan received
+func (r *repaymentMySQLRepository) UpdateCustomerRepayment(ctx context.Context, customerRepayment *models.CustomerRepayment) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(customerRepayment).Updates(map[string]interface{}{
+			"allocated_amount":   customerRepayment.AllocatedAmount,
+			"unallocated_amount": customerRepayment.UnallocatedAmount,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if len(customerRepayment.Loans) == 0 {
+			return nil
+		}
+		for _, loan := range customerRepayment.Loans {
+			loan.CustomerRepaymentID = customerRepayment.ID
+		}
+		return tx.Create(&customerRepayment.Loans).Error
+	})
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *repaymentMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+// customerLoan is an active loan of the customer with the overdue position used to rank it
+type customerLoan struct {
+	loanSummary   *models.LoanSummary
+	oldestOverdue *time.Time
+	overdueAmount decimal.Decimal // overdue installments and their penalties
+	penaltyAmount decimal.Decimal
+}
+
+// customerAllocator spreads a customer-level repayment across loans, keeping track of what is left
+// and of what each loan received
+type customerAllocator struct {
+	service     *repaymentService
+	currency    string
+	remaining   decimal.Decimal
+	allocations []*models.CustomerRepaymentAllocation
+}
+
+// ParseAllocationPolicy parses the configured customer repayment allocation policy
+func ParseAllocationPolicy(value string) (string, error) {
+	policy := strings.ToUpper(strings.TrimSpace(value))
+	switch policy {
+	case models.AllocationPolicyOldestOverdueFirst, models.AllocationPolicyHighestPenaltyFirst, models.AllocationPolicyProRata:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid allocation policy %q", value)
+	}
+}
+
+// rankCustomerLoans loads the overdue position of each loan and orders the loans by the policy.
+// Pro-rata falls back to the oldest overdue order for what its shares leave over.
+func (s *repaymentService) rankCustomerLoans(ctx context.Context, loanSummaries []*models.LoanSummary, policy string) ([]*customerLoan, error) {
+	loans := make([]*customerLoan, 0, len(loanSummaries))
+	for _, loanSummary := range loanSummaries {
+		overdueSchedules, err := s.repaymentRepo.GetOverduePaymentSchedulesByLoanID(ctx, loanSummary.LoanID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get overdue schedules: %v", err)
+		}
+
+		loan := &customerLoan{loanSummary: loanSummary}
+		for _, schedule := range overdueSchedules {
+			if loan.oldestOverdue == nil || schedule.InstallmentDueDate.Before(*loan.oldestOverdue) {
+				dueDate := schedule.InstallmentDueDate
+				loan.oldestOverdue = &dueDate
+			}
+			loan.overdueAmount = loan.overdueAmount.Add(decimal.NewFromFloat(schedule.InstallmentAmount)).Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+			loan.penaltyAmount = loan.penaltyAmount.Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+		}
+		loans = append(loans, loan)
+	}
+
+	// Loans come oldest first, a stable sort keeps that order between equal loans
+	sort.SliceStable(loans, func(i, j int) bool {
+		if policy == models.AllocationPolicyHighestPenaltyFirst && !loans[i].penaltyAmount.Equal(loans[j].penaltyAmount) {
+			return loans[i].penaltyAmount.GreaterThan(loans[j].penaltyAmount)
+		}
+		return overdueBefore(loans[i], loans[j])
+	})
+	return loans, nil
+}
+
+// overdueBefore reports whether loan a has been overdue longer than loan b; loans without overdue
+// installments come last
+func overdueBefore(a, b *customerLoan) bool {
+	if a.oldestOverdue == nil || b.oldestOverdue == nil {
+		return a.oldestOverdue != nil && b.oldestOverdue == nil
+	}
+	return a.oldestOverdue.Before(*b.oldestOverdue)
+}
+
+// allocate runs the allocation passes: pro-rata shares of the overdue amounts when the policy asks
+// for it, then overdue payments loan by loan in rank order, then upcoming installments one per
+// loan in turn until nothing more fits
+func (a *customerAllocator) allocate(ctx context.Context, loans []*customerLoan, policy string) error {
+	if policy == models.AllocationPolicyProRata {
+		if err := a.allocateProRata(ctx, loans); err != nil {
+			return err
+		}
+	}
+
+	for _, loan := range loans {
+		for {
+			paid, err := a.payNext(ctx, loan, a.remaining, true)
+			if err != nil {
+				return err
+			}
+			if paid.IsZero() {
+				break
+			}
+		}
+	}
+
+	for {
+		progress := false
+		for _, loan := range loans {
+			paid, err := a.payNext(ctx, loan, a.remaining, false)
+			if err != nil {
+				return err
+			}
+			if !paid.IsZero() {
+				progress = true
+			}
+		}
+		if !progress {
+			return nil
+		}
+	}
+}
+
+// allocateProRata gives every loan a share of the payment in proportion to its overdue amount and
+// pays its overdue installments out of that share
+func (a *customerAllocator) allocateProRata(ctx context.Context, loans []*customerLoan) error {
+	totalOverdue := decimal.Zero
+	for _, loan := range loans {
+		totalOverdue = totalOverdue.Add(loan.overdueAmount)
+	}
+	if totalOverdue.IsZero() {
+		return nil
+	}
+
+	pool := a.remaining
+	for _, loan := range loans {
+		share := currency.RoundDown(pool.Mul(loan.overdueAmount).Div(totalOverdue), a.currency)
+		for share.IsPositive() {
+			paid, err := a.payNext(ctx, loan, share, true)
+			if err != nil {
+				return err
+			}
+			if paid.IsZero() {
+				break
+			}
+			share = share.Sub(paid)
+		}
+	}
+	return nil
+}
+
+// payNext applies the next payment due on the loan through ProcessRepayment when it fits in the
+// budget, and returns the amount paid. Nothing is paid once the loan is settled, when the payment
+// does not fit, or when overdueOnly is set and the next installment is not overdue yet.
+func (a *customerAllocator) payNext(ctx context.Context, loan *customerLoan, budget decimal.Decimal, overdueOnly bool) (decimal.Decimal, error) {
+	if !budget.IsPositive() {
+		return decimal.Zero, nil
+	}
+
+	s := a.service
+	loanSummary, err := s.validateLoanExists(ctx, loan.loanSummary.LoanID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if loanSummary.Status != models.StatusPending && loanSummary.Status != models.StatusDelinquent {
+		return decimal.Zero, nil
+	}
+
+	schedulesToPay, allocation, err := s.planPayment(ctx, loanSummary)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if overdueOnly && !schedulesToPay[0].InstallmentDueDate.Before(time.Now()) {
+		return decimal.Zero, nil
+	}
+	requiredAmount := allocation.requiredAmount()
+	if decimal.NewFromFloat(requiredAmount).GreaterThan(budget) {
+		return decimal.Zero, nil
+	}
+
+	response, err := s.ProcessRepayment(ctx, &models.RepaymentRequest{
+		LoanID:        loanSummary.LoanID,
+		PaymentAmount: requiredAmount,
+		Currency:      a.currency,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to repay loan %s: %v", loanSummary.LoanID, err)
+	}
+
+	paid := decimal.NewFromFloat(requiredAmount)
+	a.remaining = a.remaining.Sub(paid)
+	a.record(response)
+	return paid, nil
+}
+
+// record adds a repayment to the loan's allocation; balances are taken from the latest repayment
+func (a *customerAllocator) record(response *models.RepaymentResponse) {
+	var allocation *models.CustomerRepaymentAllocation
+	for _, existing := range a.allocations {
+		if existing.LoanID == response.LoanID {
+			allocation = existing
+			break
+		}
+	}
+	if allocation == nil {
+		allocation = &models.CustomerRepaymentAllocation{LoanID: response.LoanID}
+		a.allocations = append(a.allocations, allocation)
+	}
+
+	allocation.AllocatedAmount = decimal.NewFromFloat(allocation.AllocatedAmount).Add(decimal.NewFromFloat(response.PaymentAmount)).InexactFloat64()
+	allocation.PenaltyPaid = decimal.NewFromFloat(allocation.PenaltyPaid).Add(decimal.NewFromFloat(response.PenaltyPaid)).InexactFloat64()
+	allocation.TaxPaid = decimal.NewFromFloat(allocation.TaxPaid).Add(decimal.NewFromFloat(response.TaxPaid)).InexactFloat64()
+	allocation.InstallmentsPaid += response.InstallmentsPaid
+	allocation.RemainingInstallments = response.RemainingInstallments
+	allocation.OutstandingAmount = response.OutstandingAmount
+	allocation.NextDueDate = response.NextDueDate
+	allocation.Collectibility = response.Collectibility
+}
@@ -5,59 +5,288 @@ import (
 	"fmt"
 	"time"
 
+	"billing-engine/collectibility"
+	"billing-engine/delinquency"
+	"billing-engine/ledger"
 	"billing-engine/models"
 	"billing-engine/repayment"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+	"billing-engine/write_off"
+
+	"github.com/shopspring/decimal"
 )
 
 type repaymentService struct {
-	repaymentRepo repayment.RepaymentMySQLRepositoryInterface
+	repaymentRepo         repayment.RepaymentMySQLRepositoryInterface
+	collectibilityService collectibility.CollectibilityServiceInterface
+	delinquencyService    delinquency.DelinquencyServiceInterface
+	writeOffService       write_off.WriteOffServiceInterface
+	taxService            tax.TaxServiceInterface
+	ledgerService         ledger.LedgerServiceInterface
+	allocationPolicy      string
 }
 
-// NewRepaymentService creates a new repayment service instance
-func NewRepaymentService(repaymentRepo repayment.RepaymentMySQLRepositoryInterface) repayment.RepaymentServiceInterface {
+// NewRepaymentService creates a new repayment service instance. allocationPolicy is the default
+// policy spreading customer-level repayments across the customer's loans.
+func NewRepaymentService(repaymentRepo repayment.RepaymentMySQLRepositoryInterface, collectibilityService collectibility.CollectibilityServiceInterface, delinquencyService delinquency.DelinquencyServiceInterface, writeOffService write_off.WriteOffServiceInterface, taxService tax.TaxServiceInterface, ledgerService ledger.LedgerServiceInterface, allocationPolicy string) repayment.RepaymentServiceInterface {
 	return &repaymentService{
-		repaymentRepo: repaymentRepo,
+		repaymentRepo:         repaymentRepo,
+		collectibilityService: collectibilityService,
+		delinquencyService:    delinquencyService,
+		writeOffService:       writeOffService,
+		taxService:            taxService,
+		ledgerService:         ledgerService,
+		allocationPolicy:      allocationPolicy,
 	}
 }
 
+// paymentAllocation splits the amount due on the installments being paid
+type paymentAllocation struct {
+	installmentAmount decimal.Decimal // principal, interest, installment fees and their tax
+	penaltyAmount     decimal.Decimal
+	penaltyTaxAmount  decimal.Decimal
+	feeTaxAmount      decimal.Decimal // tax on installment fees, part of installmentAmount
+	penaltyTaxLines   []*models.TaxLine
+}
+
+// requiredAmount is the exact amount the borrower has to pay
+func (a *paymentAllocation) requiredAmount() float64 {
+	return a.installmentAmount.Add(a.penaltyAmount).Add(a.penaltyTaxAmount).InexactFloat64()
+}
+
+// ProcessRepayment applies an exact payment to the loan. The loan is read and locked, and the
+// payment planned and booked, in one transaction, so concurrent payments on the same loan are
+// applied one after the other and each pays what the previous one left due. The response is read
+// in the same transaction, so a booked payment is never reported as failed.
 func (s *repaymentService) ProcessRepayment(ctx context.Context, req *models.RepaymentRequest) (*models.RepaymentResponse, error) {
-	// 1. Validate loan exists
-	loanSummary, err := s.validateLoanExists(ctx, req.LoanID)
+	paymentDate := time.Now()
+	var response *models.RepaymentResponse
+	err := s.repaymentRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 1. Validate loan exists, locking it until the payment is booked
+		loanSummary, err := s.validateLoanExists(ctx, req.LoanID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.validatePaymentCurrency(req, loanSummary); err != nil {
+			return err
+		}
+
+		switch loanSummary.Status {
+		case models.StatusCancelled:
+			return paymentRejection(models.SuspenseReasonClosedLoan, currency.Normalize(loanSummary.Currency), "loan is cancelled")
+		case models.StatusInactive:
+			return paymentRejection(models.SuspenseReasonLoanNotDisbursed, currency.Normalize(loanSummary.Currency), "loan is not disbursed yet")
+		}
+
+		// Payments on written-off loans are booked as recoveries instead of installment payments
+		if loanSummary.Status == models.StatusWrittenOff {
+			response, err = s.processRecovery(ctx, req, loanSummary)
+			return err
+		}
+
+		// 2-3. Get payment schedules and calculate the payment plan
+		schedulesToPay, allocation, err := s.planPayment(ctx, loanSummary)
+		if err != nil {
+			return err
+		}
+
+		// 4. Validate payment amount
+		if err := s.validatePaymentAmount(req.PaymentAmount, allocation.requiredAmount(), currency.Normalize(loanSummary.Currency)); err != nil {
+			return err
+		}
+
+		// 5-6. Process payment, update loan summary and post the payment to the ledger;
+		// penalties are not part of the outstanding amount
+		if err := s.processPaymentSchedules(ctx, schedulesToPay, paymentDate); err != nil {
+			return err
+		}
+		if err := s.collectTaxes(ctx, req.LoanID, schedulesToPay, allocation, paymentDate); err != nil {
+			return err
+		}
+
+		remainingSchedules, err := s.updateLoanSummary(ctx, loanSummary, allocation.installmentAmount.InexactFloat64(), paymentDate)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ledgerService.PostRepayment(ctx, loanSummary, allocation.installmentAmount.InexactFloat64(), allocation.penaltyAmount.InexactFloat64(), allocation.penaltyTaxAmount.InexactFloat64(), paymentDate); err != nil {
+			return fmt.Errorf("failed to post repayment: %v", err)
+		}
+
+		// 7. Re-evaluate collectibility now that the overdue position changed, in the same
+		// transaction so a failure cannot leave a booked payment reported as failed
+		collectibilityResult, err := s.collectibilityService.EvaluateLoan(ctx, req.LoanID, paymentDate)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate collectibility: %v", err)
+		}
+
+		// 8. Build response
+		response, err = s.buildRepaymentResponse(ctx, req, loanSummary, schedulesToPay, remainingSchedules, paymentDate)
+		if err != nil {
+			return err
+		}
+		response.PenaltyPaid = allocation.penaltyAmount.InexactFloat64()
+		response.TaxPaid = allocation.feeTaxAmount.Add(allocation.penaltyTaxAmount).InexactFloat64()
+		response.Collectibility = *collectibilityResult
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return response, nil
+}
 
-	// 2. Get payment schedules
-	overdueSchedules, pendingSchedules, err := s.getPaymentSchedules(ctx, req.LoanID)
-	if err != nil {
-		return nil, err
+// ProcessCustomerRepayment spreads a single transfer across the customer's active loans in its
+// currency by the allocation policy. Every loan is paid through ProcessRepayment, one exact
+// payment at a time, and all of them are committed in one transaction. A transfer carrying a
+// reference is applied once: the reference is claimed before any loan is paid, and a repeated
+// transfer gets the stored allocations back, or its rejection again when it allocated nothing.
+func (s *repaymentService) ProcessCustomerRepayment(ctx context.Context, customerID string, req *models.CustomerRepaymentRequest) (*models.CustomerRepaymentResponse, error) {
+	paymentCurrency := currency.Normalize(req.Currency)
+	paymentAmount := decimal.NewFromFloat(req.PaymentAmount)
+	if !currency.IsRounded(paymentAmount, paymentCurrency) {
+		return nil, fmt.Errorf("payment amount has more decimal places than %s allows", paymentCurrency)
+	}
+	policy := req.Policy
+	if policy == "" {
+		policy = s.allocationPolicy
 	}
 
-	// 3. Calculate payment plan
-	schedulesToPay, requiredAmount, err := s.calculatePaymentPlan(overdueSchedules, pendingSchedules, req.PaymentAmount)
+	var response *models.CustomerRepaymentResponse
+	paymentDate := time.Now()
+	allocator := &customerAllocator{service: s, currency: paymentCurrency, remaining: paymentAmount}
+	err := s.repaymentRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var customerRepayment *models.CustomerRepayment
+		if req.Reference != "" {
+			customerRepayment = &models.CustomerRepayment{
+				CustomerID:        customerID,
+				Reference:         req.Reference,
+				PaymentAmount:     req.PaymentAmount,
+				Currency:          paymentCurrency,
+				Policy:            policy,
+				UnallocatedAmount: req.PaymentAmount,
+				PaymentDate:       paymentDate,
+			}
+			created, err := s.repaymentRepo.CreateCustomerRepayment(ctx, customerRepayment)
+			if err != nil {
+				return fmt.Errorf("failed to store customer repayment: %v", err)
+			}
+			if !created {
+				if len(customerRepayment.Loans) == 0 {
+					return paymentRejection(models.SuspenseReasonUnallocated, customerRepayment.Currency, fmt.Sprintf("customer repayment %s allocated nothing when it was received", req.Reference))
+				}
+				response = toCustomerRepaymentResponse(customerRepayment)
+				return nil
+			}
+		}
+
+		loanSummaries, err := s.repaymentRepo.GetActiveLoanSummariesByCustomerID(ctx, customerID, paymentCurrency)
+		if err != nil {
+			return fmt.Errorf("failed to get customer loans: %v", err)
+		}
+		if len(loanSummaries) == 0 {
+			return paymentRejection(models.SuspenseReasonUnallocated, paymentCurrency, fmt.Sprintf("customer has no active loans in %s", paymentCurrency))
+		}
+
+		loans, err := s.rankCustomerLoans(ctx, loanSummaries, policy)
+		if err != nil {
+			return err
+		}
+		if err := allocator.allocate(ctx, loans, policy); err != nil {
+			return err
+		}
+		if len(allocator.allocations) == 0 {
+			return paymentRejection(models.SuspenseReasonUnallocated, paymentCurrency, fmt.Sprintf("payment amount %.2f does not cover a full payment on any loan", req.PaymentAmount))
+		}
+
+		response = &models.CustomerRepaymentResponse{
+			CustomerID:        customerID,
+			PaymentAmount:     req.PaymentAmount,
+			Currency:          paymentCurrency,
+			Policy:            policy,
+			AllocatedAmount:   paymentAmount.Sub(allocator.remaining).InexactFloat64(),
+			UnallocatedAmount: allocator.remaining.InexactFloat64(),
+			PaymentDate:       paymentDate,
+			Allocations:       make([]models.CustomerRepaymentAllocation, 0, len(allocator.allocations)),
+		}
+		for _, allocation := range allocator.allocations {
+			response.Allocations = append(response.Allocations, *allocation)
+		}
+		if customerRepayment == nil {
+			return nil
+		}
+
+		customerRepayment.AllocatedAmount = response.AllocatedAmount
+		customerRepayment.UnallocatedAmount = response.UnallocatedAmount
+		for _, allocation := range allocator.allocations {
+			customerRepayment.Loans = append(customerRepayment.Loans, &models.CustomerRepaymentLoan{
+				LoanID:           allocation.LoanID,
+				AllocatedAmount:  allocation.AllocatedAmount,
+				PenaltyPaid:      allocation.PenaltyPaid,
+				TaxPaid:          allocation.TaxPaid,
+				InstallmentsPaid: allocation.InstallmentsPaid,
+			})
+		}
+		if err := s.repaymentRepo.UpdateCustomerRepayment(ctx, customerRepayment); err != nil {
+			return fmt.Errorf("failed to store customer repayment allocations: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return response, nil
+}
 
-	// 4. Validate payment amount
-	if err := s.validatePaymentAmount(req.PaymentAmount, requiredAmount); err != nil {
-		return nil, err
+// toCustomerRepaymentResponse returns the stored outcome of a customer repayment applied before
+func toCustomerRepaymentResponse(customerRepayment *models.CustomerRepayment) *models.CustomerRepaymentResponse {
+	response := &models.CustomerRepaymentResponse{
+		CustomerID:        customerRepayment.CustomerID,
+		PaymentAmount:     customerRepayment.PaymentAmount,
+		Currency:          customerRepayment.Currency,
+		Policy:            customerRepayment.Policy,
+		AllocatedAmount:   customerRepayment.AllocatedAmount,
+		UnallocatedAmount: customerRepayment.UnallocatedAmount,
+		PaymentDate:       customerRepayment.PaymentDate,
+		Duplicate:         true,
+		Allocations:       make([]models.CustomerRepaymentAllocation, 0, len(customerRepayment.Loans)),
 	}
-
-	// 5. Process payment
-	paymentDate := time.Now()
-	if err := s.processPaymentSchedules(ctx, schedulesToPay, paymentDate); err != nil {
-		return nil, err
+	for _, loan := range customerRepayment.Loans {
+		response.Allocations = append(response.Allocations, models.CustomerRepaymentAllocation{
+			LoanID:           loan.LoanID,
+			AllocatedAmount:  loan.AllocatedAmount,
+			PenaltyPaid:      loan.PenaltyPaid,
+			TaxPaid:          loan.TaxPaid,
+			InstallmentsPaid: loan.InstallmentsPaid,
+		})
 	}
+	return response
+}
 
-	// 6. Update loan summary
-	remainingSchedules, err := s.updateLoanSummary(ctx, loanSummary, req.PaymentAmount, paymentDate)
+// processRecovery records a payment on a written-off loan as a recovery
+func (s *repaymentService) processRecovery(ctx context.Context, req *models.RepaymentRequest, loanSummary *models.LoanSummary) (*models.RepaymentResponse, error) {
+	recovery, err := s.writeOffService.RecordRecovery(ctx, loanSummary, req.PaymentAmount, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	// 7. Build response
-	return s.buildRepaymentResponse(ctx, req, loanSummary, schedulesToPay, remainingSchedules, paymentDate)
+	return &models.RepaymentResponse{
+		LoanID:            req.LoanID,
+		PaymentAmount:     recovery.Amount,
+		Currency:          currency.Normalize(loanSummary.Currency),
+		InstallmentAmount: loanSummary.InstallmentAmount,
+		OutstandingAmount: loanSummary.OutstandingAmount,
+		PaymentDate:       recovery.RecoveredAt,
+		PaymentType:       models.PaymentTypeRecovery,
+		Collectibility: models.CollectibilityResponse{
+			Collectibility: loanSummary.Collectibility,
+			Label:          models.CollectibilityLabels[loanSummary.Collectibility],
+			Dpd:            loanSummary.Dpd,
+			EffectiveDate:  loanSummary.CollectibilityDate,
+		},
+	}, nil
 }
 
 // validateLoanExists checks if the loan exists and returns the loan summary
@@ -67,67 +296,117 @@ func (s *repaymentService) validateLoanExists(ctx context.Context, loanID string
 		return nil, fmt.Errorf("failed to get loan summary: %v", err)
 	}
 	if loanSummary == nil {
-		return nil, fmt.Errorf("loan not found")
+		return nil, paymentRejection(models.SuspenseReasonUnknownLoan, "", "loan not found")
 	}
 	return loanSummary, nil
 }
 
+// validatePaymentCurrency ensures the payment is made in the loan's currency and fits its minor unit.
+// Payments without a currency are taken to be in the loan's currency.
+func (s *repaymentService) validatePaymentCurrency(req *models.RepaymentRequest, loanSummary *models.LoanSummary) error {
+	loanCurrency := currency.Normalize(loanSummary.Currency)
+	if req.Currency != "" && currency.Normalize(req.Currency) != loanCurrency {
+		return paymentRejection(models.SuspenseReasonCurrencyMismatch, loanCurrency, fmt.Sprintf("payment currency %s does not match loan currency %s", currency.Normalize(req.Currency), loanCurrency))
+	}
+	if !currency.IsRounded(decimal.NewFromFloat(req.PaymentAmount), loanCurrency) {
+		return fmt.Errorf("payment amount has more decimal places than %s allows", loanCurrency)
+	}
+	return nil
+}
+
 // getPaymentSchedules retrieves overdue and pending payment schedules
-func (s *repaymentService) getPaymentSchedules(ctx context.Context, loanID string) ([]*models.PaymentSchedule, []*models.PaymentSchedule, error) {
+func (s *repaymentService) getPaymentSchedules(ctx context.Context, loanSummary *models.LoanSummary) ([]*models.PaymentSchedule, []*models.PaymentSchedule, error) {
 	// Get overdue payment schedules first (must be paid first)
-	overdueSchedules, err := s.repaymentRepo.GetOverduePaymentSchedulesByLoanID(ctx, loanID)
+	overdueSchedules, err := s.repaymentRepo.GetOverduePaymentSchedulesByLoanID(ctx, loanSummary.LoanID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get overdue schedules: %v", err)
 	}
 
 	// Get all pending payment schedules
-	pendingSchedules, err := s.repaymentRepo.GetPendingPaymentSchedulesByLoanID(ctx, loanID)
+	pendingSchedules, err := s.repaymentRepo.GetPendingPaymentSchedulesByLoanID(ctx, loanSummary.LoanID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get pending schedules: %v", err)
 	}
 
+	// A loan without pending installments is paid off
 	if len(pendingSchedules) == 0 {
-		return nil, nil, fmt.Errorf("no pending installments found")
+		return nil, nil, paymentRejection(models.SuspenseReasonClosedLoan, currency.Normalize(loanSummary.Currency), "no pending installments found")
 	}
 
 	return overdueSchedules, pendingSchedules, nil
 }
 
-// calculatePaymentPlan determines which schedules to pay and the required amount
-func (s *repaymentService) calculatePaymentPlan(overdueSchedules, pendingSchedules []*models.PaymentSchedule, paymentAmount float64) ([]*models.PaymentSchedule, float64, error) {
-	var requiredAmount float64
-	var schedulesToPay []*models.PaymentSchedule
+// planPayment picks the installments the next payment on the loan has to cover, based on the
+// product's delinquency policy, and adds up what is due on them
+func (s *repaymentService) planPayment(ctx context.Context, loanSummary *models.LoanSummary) ([]*models.PaymentSchedule, *paymentAllocation, error) {
+	overdueSchedules, pendingSchedules, err := s.getPaymentSchedules(ctx, loanSummary)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// If there are overdue installments, customer MUST pay ALL overdue installments at once
-	if len(overdueSchedules) > 0 {
-		for _, schedule := range overdueSchedules {
-			requiredAmount += schedule.InstallmentAmount
-			schedulesToPay = append(schedulesToPay, schedule)
-		}
-		// Customer must pay the exact total amount of all overdue installments
-		return schedulesToPay, requiredAmount, nil
+	evaluation, err := s.delinquencyService.EvaluateLoan(ctx, loanSummary, overdueSchedules, time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to evaluate delinquency: %v", err)
+	}
+	schedulesToPay, err := s.calculatePaymentPlan(overdueSchedules, pendingSchedules, evaluation.IsDelinquent)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedulesToPay, s.allocatePayment(schedulesToPay, loanSummary.Currency), nil
+}
+
+// calculatePaymentPlan determines which schedules to pay
+func (s *repaymentService) calculatePaymentPlan(overdueSchedules, pendingSchedules []*models.PaymentSchedule, isDelinquent bool) ([]*models.PaymentSchedule, error) {
+	// If the delinquency policy matches, customer MUST pay ALL overdue installments at once
+	if isDelinquent && len(overdueSchedules) > 0 {
+		return overdueSchedules, nil
 	}
 
-	// If no overdue installments, customer can pay exactly one pending installment
+	// Otherwise customer can pay exactly one pending installment, the oldest overdue one first
 	if len(pendingSchedules) > 0 {
-		nextInstallment := pendingSchedules[0]
-		requiredAmount = nextInstallment.InstallmentAmount
-		schedulesToPay = append(schedulesToPay, nextInstallment)
-		return schedulesToPay, requiredAmount, nil
+		return pendingSchedules[:1], nil
 	}
 
 	// No installments to pay
-	return nil, 0, fmt.Errorf("no pending installments found")
+	return nil, fmt.Errorf("no pending installments found")
+}
+
+// allocatePayment adds up what is due on the schedules: the installments, their penalties and
+// the tax on those penalties
+func (s *repaymentService) allocatePayment(schedulesToPay []*models.PaymentSchedule, currencyCode string) *paymentAllocation {
+	allocation := &paymentAllocation{}
+	for _, schedule := range schedulesToPay {
+		allocation.installmentAmount = allocation.installmentAmount.Add(decimal.NewFromFloat(schedule.InstallmentAmount))
+		allocation.feeTaxAmount = allocation.feeTaxAmount.Add(decimal.NewFromFloat(schedule.TaxAmount))
+		if schedule.PenaltyAmount <= 0 {
+			continue
+		}
+
+		allocation.penaltyAmount = allocation.penaltyAmount.Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+		if taxLine := s.taxService.TaxCharge(models.ChargeTypePenalty, schedule.PenaltyAmount, currency.Normalize(currencyCode)); taxLine != nil {
+			taxLine.LoanID = schedule.LoanID
+			taxLine.InstallmentNumber = schedule.InstallmentNumber
+			allocation.penaltyTaxAmount = allocation.penaltyTaxAmount.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+			allocation.penaltyTaxLines = append(allocation.penaltyTaxLines, taxLine)
+		}
+	}
+	return allocation
 }
 
 // validatePaymentAmount ensures the payment amount matches the required amount exactly
-func (s *repaymentService) validatePaymentAmount(paymentAmount, requiredAmount float64) error {
+func (s *repaymentService) validatePaymentAmount(paymentAmount, requiredAmount float64, currencyCode string) error {
 	if paymentAmount != requiredAmount {
-		return fmt.Errorf("payment amount %.2f does not match required amount %.2f. You must pay the exact amount for all overdue installments or the next pending installment", paymentAmount, requiredAmount)
+		return paymentRejection(models.SuspenseReasonWrongAmount, currencyCode, fmt.Sprintf("payment amount %.2f does not match required amount %.2f. You must pay the exact amount for all overdue installments or the next pending installment", paymentAmount, requiredAmount))
 	}
 	return nil
 }
 
+// paymentRejection returns the error for a payment that cannot be applied to the loan as it is.
+// Callers that received the funds hold them in suspense under the reason code.
+func paymentRejection(reasonCode, currencyCode, message string) error {
+	return &models.PaymentRejectionError{ReasonCode: reasonCode, Currency: currencyCode, Message: message}
+}
+
 // processPaymentSchedules updates payment schedules and creates history records
 func (s *repaymentService) processPaymentSchedules(ctx context.Context, schedulesToPay []*models.PaymentSchedule, paymentDate time.Time) error {
 	var histories []*models.PaymentScheduleHistory
@@ -155,6 +434,29 @@ func (s *repaymentService) processPaymentSchedules(ctx context.Context, schedule
 	return nil
 }
 
+// collectTaxes records the tax paid with the installments as collected
+func (s *repaymentService) collectTaxes(ctx context.Context, loanID string, paidSchedules []*models.PaymentSchedule, allocation *paymentAllocation, paymentDate time.Time) error {
+	if allocation.feeTaxAmount.IsZero() && len(allocation.penaltyTaxLines) == 0 {
+		return nil
+	}
+
+	var installmentNumbers []int
+	for _, schedule := range paidSchedules {
+		if schedule.TaxAmount > 0 {
+			installmentNumbers = append(installmentNumbers, schedule.InstallmentNumber)
+		}
+	}
+	for _, taxLine := range allocation.penaltyTaxLines {
+		taxLine.Status = models.TaxStatusCollected
+		taxLine.CollectedAt = &paymentDate
+	}
+
+	if err := s.repaymentRepo.CollectTaxLines(ctx, loanID, installmentNumbers, allocation.penaltyTaxLines, paymentDate); err != nil {
+		return fmt.Errorf("failed to collect tax: %v", err)
+	}
+	return nil
+}
+
 // createPaymentHistory creates a payment history record for a schedule
 func (s *repaymentService) createPaymentHistory(schedule *models.PaymentSchedule) *models.PaymentScheduleHistory {
 	return &models.PaymentScheduleHistory{
@@ -218,11 +520,13 @@ func (s *repaymentService) buildRepaymentResponse(ctx context.Context, req *mode
 	return &models.RepaymentResponse{
 		LoanID:                req.LoanID,
 		PaymentAmount:         req.PaymentAmount,
+		Currency:              currency.Normalize(loanSummary.Currency),
 		InstallmentsPaid:      len(schedulesToPay),
 		InstallmentAmount:     loanSummary.InstallmentAmount,
 		RemainingInstallments: len(remainingSchedules),
 		OutstandingAmount:     loanSummary.OutstandingAmount,
 		NextDueDate:           nextDue,
 		PaymentDate:           paymentDate,
+		PaymentType:           models.PaymentTypeInstallment,
 	}, nil
 }
@@ -1,8 +1,13 @@
 package service
 
 import (
+	collectibilityMocks "billing-engine/collectibility/_mock"
+	delinquencyMocks "billing-engine/delinquency/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
 	"billing-engine/models"
 	mocks "billing-engine/repayment/_mock"
+	taxMocks "billing-engine/tax/_mock"
+	writeOffMocks "billing-engine/write_off/_mock"
 	"context"
 	"errors"
 	"testing"
@@ -14,7 +19,12 @@ import (
 
 func TestRepaymentService_ProcessRepayment_Success(t *testing.T) {
 	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
-	service := NewRepaymentService(mockRepo)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
 	ctx := context.Background()
 
 	req := &models.RepaymentRequest{
@@ -80,12 +90,21 @@ func TestRepaymentService_ProcessRepayment_Success(t *testing.T) {
 	// Mock repository calls
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
 	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: true}, nil)
 	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil).Once()
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 220000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
 	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
 	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
 	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(remainingSchedules, nil).Once()
 	mockRepo.On("UpdateLoanSummary", ctx, mock.AnythingOfType("*models.LoanSummary")).Return(nil)
 	mockRepo.On("GetNextDueDate", ctx, "loan_123").Return(&nextDueDate, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilityCurrent,
+		Label:          "Lancar",
+	}, nil)
 
 	// Execute
 	response, err := service.ProcessRepayment(ctx, req)
@@ -99,13 +118,20 @@ func TestRepaymentService_ProcessRepayment_Success(t *testing.T) {
 	assert.Equal(t, 110000.00, response.InstallmentAmount)
 	assert.Equal(t, 1, response.RemainingInstallments)
 	assert.Equal(t, nextDueDate, response.NextDueDate)
+	assert.Equal(t, models.PaymentTypeInstallment, response.PaymentType)
+	assert.Equal(t, models.CollectibilityCurrent, response.Collectibility.Collectibility)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestRepaymentService_ProcessRepayment_LoanNotFound(t *testing.T) {
 	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
-	service := NewRepaymentService(mockRepo)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
 	ctx := context.Background()
 
 	req := &models.RepaymentRequest{
@@ -114,6 +140,9 @@ func TestRepaymentService_ProcessRepayment_LoanNotFound(t *testing.T) {
 	}
 
 	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(nil, nil)
 
 	// Execute
@@ -124,12 +153,155 @@ func TestRepaymentService_ProcessRepayment_LoanNotFound(t *testing.T) {
 	assert.Nil(t, response)
 	assert.Contains(t, err.Error(), "loan not found")
 
+	var rejection *models.PaymentRejectionError
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, models.SuspenseReasonUnknownLoan, rejection.ReasonCode)
+
 	mockRepo.AssertExpectations(t)
 }
 
+func TestRepaymentService_ProcessRepayment_CurrencyMismatch(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 110000.00,
+		Currency:      "USD",
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+		LoanID:   "loan_123",
+		Currency: models.CurrencyIDR,
+		Status:   models.StatusPending,
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "payment currency USD does not match loan currency IDR")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessRepayment_AmountExceedsMinorUnit(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 366666.50,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+		LoanID:   "loan_123",
+		Currency: "JPY",
+		Status:   models.StatusPending,
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "payment amount has more decimal places than JPY allows")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessRepayment_CancelledLoan(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 110000.00,
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+		LoanID: "loan_123",
+		Status: models.StatusCancelled,
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "loan is cancelled")
+}
+
+func TestRepaymentService_ProcessRepayment_LoanNotDisbursed(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+		LoanID: "loan_123",
+		Status: models.StatusInactive,
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "loan is not disbursed yet")
+}
+
 func TestRepaymentService_ProcessRepayment_IncorrectPaymentAmount(t *testing.T) {
 	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
-	service := NewRepaymentService(mockRepo)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
 	ctx := context.Background()
 
 	req := &models.RepaymentRequest{
@@ -167,8 +339,12 @@ func TestRepaymentService_ProcessRepayment_IncorrectPaymentAmount(t *testing.T)
 	}
 
 	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
 	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
 	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil)
 
 	// Execute
@@ -179,12 +355,22 @@ func TestRepaymentService_ProcessRepayment_IncorrectPaymentAmount(t *testing.T)
 	assert.Nil(t, response)
 	assert.Contains(t, err.Error(), "payment amount 100000.00 does not match required amount 110000.00")
 
+	var rejection *models.PaymentRejectionError
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, models.SuspenseReasonWrongAmount, rejection.ReasonCode)
+	assert.Equal(t, "IDR", rejection.Currency)
+
 	mockRepo.AssertExpectations(t)
 }
 
 func TestRepaymentService_ProcessRepayment_NoPendingInstallments(t *testing.T) {
 	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
-	service := NewRepaymentService(mockRepo)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
 	ctx := context.Background()
 
 	req := &models.RepaymentRequest{
@@ -205,6 +391,9 @@ func TestRepaymentService_ProcessRepayment_NoPendingInstallments(t *testing.T) {
 	pendingSchedules := []*models.PaymentSchedule{}
 
 	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
 	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
 	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil)
@@ -217,12 +406,21 @@ func TestRepaymentService_ProcessRepayment_NoPendingInstallments(t *testing.T) {
 	assert.Nil(t, response)
 	assert.Contains(t, err.Error(), "no pending installments found")
 
+	var rejection *models.PaymentRejectionError
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, models.SuspenseReasonClosedLoan, rejection.ReasonCode)
+
 	mockRepo.AssertExpectations(t)
 }
 
 func TestRepaymentService_ProcessRepayment_AllInstallmentsPaid(t *testing.T) {
 	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
-	service := NewRepaymentService(mockRepo)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
 	ctx := context.Background()
 
 	req := &models.RepaymentRequest{
@@ -255,7 +453,12 @@ func TestRepaymentService_ProcessRepayment_AllInstallmentsPaid(t *testing.T) {
 	// Mock repository calls
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
 	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
 	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil).Once()
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 110000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
 	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
 	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
 	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(remainingSchedules, nil).Once()
@@ -263,6 +466,10 @@ func TestRepaymentService_ProcessRepayment_AllInstallmentsPaid(t *testing.T) {
 		return ls.Status == models.StatusPaid // Should be marked as PAID
 	})).Return(nil)
 	mockRepo.On("GetNextDueDate", ctx, "loan_123").Return(nil, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilityCurrent,
+		Label:          "Lancar",
+	}, nil)
 
 	// Execute
 	response, err := service.ProcessRepayment(ctx, req)
@@ -278,9 +485,342 @@ func TestRepaymentService_ProcessRepayment_AllInstallmentsPaid(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestRepaymentService_ProcessRepayment_CollectibilityFailureRollsBack(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 110000.00,
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		OutstandingAmount: 220000.00,
+		InstallmentAmount: 110000.00,
+		NoOfInstallment:   2,
+		Status:            models.StatusPending,
+	}
+	overdueSchedules := []*models.PaymentSchedule{}
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 110000.00, Status: models.StatusPending},
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil).Once()
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 110000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules[1:], nil).Once()
+	mockRepo.On("UpdateLoanSummary", ctx, mock.AnythingOfType("*models.LoanSummary")).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert: the error is returned from inside the transaction, so the payment is rolled back
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to evaluate collectibility")
+	mockRepo.AssertNotCalled(t, "GetNextDueDate", mock.Anything, mock.Anything)
+}
+
+func TestRepaymentService_ProcessRepayment_NextDueDateFailureRollsBack(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 110000.00,
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		OutstandingAmount: 220000.00,
+		InstallmentAmount: 110000.00,
+		NoOfInstallment:   2,
+		Status:            models.StatusPending,
+	}
+	overdueSchedules := []*models.PaymentSchedule{}
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_123", InstallmentNumber: 1, InstallmentAmount: 110000.00, Status: models.StatusPending},
+		{ID: 2, LoanID: "loan_123", InstallmentNumber: 2, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	var txErr error
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil).Once()
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		txErr = fn(ctx)
+		return txErr
+	})
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 110000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules[1:], nil).Once()
+	mockRepo.On("UpdateLoanSummary", ctx, mock.AnythingOfType("*models.LoanSummary")).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{Collectibility: models.CollectibilityCurrent}, nil)
+	mockRepo.On("GetNextDueDate", ctx, "loan_123").Return(nil, errors.New("connection reset"))
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert: the next due date is read before the commit, so its failure rolls the payment back
+	// instead of reporting a booked payment as failed
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to get next due date")
+	assert.Equal(t, txErr, err)
+}
+
+func TestRepaymentService_ProcessRepayment_OverdueButNotDelinquent(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 110000.00, // Only the oldest overdue installment
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		CustomerID:        "customer_123",
+		ProductCode:       "WEEKLY_50",
+		OutstandingAmount: 5500000.00,
+		InstallmentAmount: 110000.00,
+		NoOfInstallment:   50,
+		Status:            models.StatusPending,
+	}
+
+	overdueSchedules := []*models.PaymentSchedule{
+		{
+			ID:                1,
+			LoanID:            "loan_123",
+			InstallmentNumber: 1,
+			InstallmentAmount: 110000.00,
+			Status:            models.StatusPending,
+		},
+		{
+			ID:                2,
+			LoanID:            "loan_123",
+			InstallmentNumber: 2,
+			InstallmentAmount: 110000.00,
+			Status:            models.StatusPending,
+		},
+	}
+
+	remainingSchedules := []*models.PaymentSchedule{overdueSchedules[1]}
+	nextDueDate := time.Now().AddDate(0, 0, -7)
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil).Once()
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 110000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+		return len(schedules) == 1 && schedules[0].InstallmentNumber == 1
+	})).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(remainingSchedules, nil).Once()
+	mockRepo.On("UpdateLoanSummary", ctx, mock.AnythingOfType("*models.LoanSummary")).Return(nil)
+	mockRepo.On("GetNextDueDate", ctx, "loan_123").Return(&nextDueDate, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilitySpecialMention,
+		Label:          "Dalam Perhatian Khusus",
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 1, response.InstallmentsPaid)
+	assert.Equal(t, 1, response.RemainingInstallments)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessRepayment_PenaltyAndTax(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 116660.00, // installment with fee tax + penalty + PPN on the penalty
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		CustomerID:        "customer_123",
+		OutstandingAmount: 5555500.00,
+		InstallmentAmount: 111110.00,
+		NoOfInstallment:   50,
+		Status:            models.StatusPending,
+	}
+
+	overdueSchedules := []*models.PaymentSchedule{
+		{
+			ID:                1,
+			LoanID:            "loan_123",
+			InstallmentNumber: 1,
+			InstallmentAmount: 111110.00,
+			TaxAmount:         110.00,
+			PenaltyAmount:     5000.00,
+			Status:            models.StatusPending,
+		},
+	}
+	remainingSchedules := []*models.PaymentSchedule{
+		{
+			ID:                2,
+			LoanID:            "loan_123",
+			InstallmentNumber: 2,
+			InstallmentAmount: 111110.00,
+			TaxAmount:         110.00,
+			Status:            models.StatusPending,
+		},
+	}
+	nextDueDate := time.Now().AddDate(0, 0, 7)
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(overdueSchedules, nil).Once()
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 5000.00, models.CurrencyIDR).Return(&models.TaxLine{
+		TaxType: models.TaxTypePPN, ChargeType: models.ChargeTypePenalty, TaxableAmount: 5000.00, TaxRate: 0.11, TaxAmount: 550.00, Status: models.StatusPending,
+	})
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 111110.00, 5000.00, 550.00, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockRepo.On("CollectTaxLines", ctx, "loan_123", []int{1}, mock.MatchedBy(func(taxLines []*models.TaxLine) bool {
+		return len(taxLines) == 1 && taxLines[0].LoanID == "loan_123" && taxLines[0].InstallmentNumber == 1 &&
+			taxLines[0].Status == models.TaxStatusCollected && taxLines[0].CollectedAt != nil
+	}), mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(remainingSchedules, nil).Once()
+	mockRepo.On("UpdateLoanSummary", ctx, mock.MatchedBy(func(loanSummary *models.LoanSummary) bool {
+		return loanSummary.OutstandingAmount == 5444390.00 // penalties are not part of the outstanding amount
+	})).Return(nil)
+	mockRepo.On("GetNextDueDate", ctx, "loan_123").Return(&nextDueDate, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilityCurrent,
+		Label:          "Lancar",
+	}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.InstallmentsPaid)
+	assert.Equal(t, 5000.00, response.PenaltyPaid)
+	assert.Equal(t, 660.00, response.TaxPaid) // 110 PPN on the provision fee + 550 PPN on the penalty
+	assert.Equal(t, 5444390.00, response.OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessRepayment_WrittenOffLoanRecordsRecovery(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{
+		LoanID:        "loan_123",
+		PaymentAmount: 75000.00, // Recoveries are not bound to the installment amount
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		CustomerID:        "customer_123",
+		OutstandingAmount: 3300000.00,
+		InstallmentAmount: 110000.00,
+		Collectibility:    models.CollectibilityLoss,
+		Status:            models.StatusWrittenOff,
+	}
+	recoveredAt := time.Now()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockWriteOff.On("RecordRecovery", ctx, loanSummary, 75000.00, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*models.LoanSummary).OutstandingAmount = 3225000.00
+		}).
+		Return(&models.RecoveryResponse{Amount: 75000.00, RecoveredAt: recoveredAt}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, models.PaymentTypeRecovery, response.PaymentType)
+	assert.Equal(t, 75000.00, response.PaymentAmount)
+	assert.Equal(t, 0, response.InstallmentsPaid)
+	assert.Equal(t, 3225000.00, response.OutstandingAmount)
+	assert.Equal(t, models.CollectibilityLoss, response.Collectibility.Collectibility)
+
+	mockRepo.AssertExpectations(t)
+	mockWriteOff.AssertExpectations(t)
+}
+
 func TestRepaymentService_ProcessRepayment_RepositoryError(t *testing.T) {
 	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
-	service := NewRepaymentService(mockRepo)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
 	ctx := context.Background()
 
 	req := &models.RepaymentRequest{
@@ -289,6 +829,9 @@ func TestRepaymentService_ProcessRepayment_RepositoryError(t *testing.T) {
 	}
 
 	// Mock repository error
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(nil, errors.New("database error"))
 
 	// Execute
@@ -301,3 +844,420 @@ func TestRepaymentService_ProcessRepayment_RepositoryError(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+// mockLoanRepayment sets up the repository and collectibility calls made while repaying one
+// installment of the loan during a customer-level repayment
+func mockLoanRepayment(ctx context.Context, mockRepo *mocks.RepaymentMySQLRepositoryInterface, mockCollectibility *collectibilityMocks.CollectibilityServiceInterface, loanSummary *models.LoanSummary, overdueSchedules, pendingSchedules []*models.PaymentSchedule) {
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, loanSummary.LoanID).Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, loanSummary.LoanID).Return(overdueSchedules, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, loanSummary.LoanID).Return(pendingSchedules, nil)
+	mockRepo.On("UpdateLoanSummary", ctx, loanSummary).Return(nil)
+	mockRepo.On("GetNextDueDate", ctx, loanSummary.LoanID).Return(&pendingSchedules[len(pendingSchedules)-1].InstallmentDueDate, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, loanSummary.LoanID, mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilityCurrent,
+		Label:          "Lancar",
+	}, nil)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_OldestOverdueFirst(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	now := time.Now()
+	currentLoan := &models.LoanSummary{LoanID: "loan_a", CustomerID: "customer_123", OutstandingAmount: 1100000.00, Status: models.StatusPending}
+	overdueLoan := &models.LoanSummary{LoanID: "loan_b", CustomerID: "customer_123", OutstandingAmount: 550000.00, Status: models.StatusPending}
+	overdueSchedules := []*models.PaymentSchedule{
+		{ID: 11, LoanID: "loan_b", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentDueDate: now.AddDate(0, 0, -10), Status: models.StatusPending},
+	}
+	pendingSchedules := []*models.PaymentSchedule{
+		overdueSchedules[0],
+		{ID: 12, LoanID: "loan_b", InstallmentNumber: 2, InstallmentAmount: 110000.00, InstallmentDueDate: now.AddDate(0, 0, 4), Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", models.CurrencyIDR).Return([]*models.LoanSummary{currentLoan, overdueLoan}, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_a").Return([]*models.PaymentSchedule{}, nil)
+	mockLoanRepayment(ctx, mockRepo, mockCollectibility, overdueLoan, overdueSchedules, pendingSchedules)
+	mockDelinquency.On("EvaluateLoan", ctx, overdueLoan, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 110000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 110000.00})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AllocationPolicyOldestOverdueFirst, response.Policy)
+	assert.Equal(t, models.CurrencyIDR, response.Currency)
+	assert.Equal(t, 110000.00, response.AllocatedAmount)
+	assert.Equal(t, 0.00, response.UnallocatedAmount)
+	assert.Len(t, response.Allocations, 1)
+	assert.Equal(t, "loan_b", response.Allocations[0].LoanID)
+	assert.Equal(t, 110000.00, response.Allocations[0].AllocatedAmount)
+	assert.Equal(t, 1, response.Allocations[0].InstallmentsPaid)
+	assert.Equal(t, 440000.00, response.Allocations[0].OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+	mockDelinquency.AssertExpectations(t)
+	mockCollectibility.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_HighestPenaltyFirst(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	now := time.Now()
+	olderLoan := &models.LoanSummary{LoanID: "loan_a", CustomerID: "customer_123", OutstandingAmount: 1100000.00, Status: models.StatusPending}
+	penaltyLoan := &models.LoanSummary{LoanID: "loan_b", CustomerID: "customer_123", OutstandingAmount: 550000.00, Status: models.StatusPending}
+	olderOverdue := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_a", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentDueDate: now.AddDate(0, 0, -20), Status: models.StatusPending},
+	}
+	penaltyOverdue := []*models.PaymentSchedule{
+		{ID: 11, LoanID: "loan_b", InstallmentNumber: 1, InstallmentAmount: 110000.00, PenaltyAmount: 5000.00, InstallmentDueDate: now.AddDate(0, 0, -5), Status: models.StatusPending},
+	}
+	penaltyPending := []*models.PaymentSchedule{
+		penaltyOverdue[0],
+		{ID: 12, LoanID: "loan_b", InstallmentNumber: 2, InstallmentAmount: 110000.00, InstallmentDueDate: now.AddDate(0, 0, 2), Status: models.StatusPending},
+	}
+
+	// Mock repository calls; what is left after the loan carrying the penalty does not cover the older loan
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", models.CurrencyIDR).Return([]*models.LoanSummary{olderLoan, penaltyLoan}, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_a").Return(olderOverdue, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_a").Return(olderLoan, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_a").Return(olderOverdue, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, olderLoan, olderOverdue, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockLoanRepayment(ctx, mockRepo, mockCollectibility, penaltyLoan, penaltyOverdue, penaltyPending)
+	mockDelinquency.On("EvaluateLoan", ctx, penaltyLoan, penaltyOverdue, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 5000.00, models.CurrencyIDR).Return(nil)
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{
+		PaymentAmount: 150000.00,
+		Policy:        models.AllocationPolicyHighestPenaltyFirst,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AllocationPolicyHighestPenaltyFirst, response.Policy)
+	assert.Equal(t, 115000.00, response.AllocatedAmount)
+	assert.Equal(t, 35000.00, response.UnallocatedAmount)
+	assert.Len(t, response.Allocations, 1)
+	assert.Equal(t, "loan_b", response.Allocations[0].LoanID)
+	assert.Equal(t, 5000.00, response.Allocations[0].PenaltyPaid)
+
+	mockRepo.AssertExpectations(t)
+	mockTax.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_ProRata(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyProRata)
+	ctx := context.Background()
+
+	now := time.Now()
+	firstLoan := &models.LoanSummary{LoanID: "loan_a", CustomerID: "customer_123", OutstandingAmount: 1100000.00, Status: models.StatusPending}
+	secondLoan := &models.LoanSummary{LoanID: "loan_b", CustomerID: "customer_123", OutstandingAmount: 550000.00, Status: models.StatusPending}
+	firstOverdue := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_a", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentDueDate: now.AddDate(0, 0, -3), Status: models.StatusPending},
+	}
+	secondOverdue := []*models.PaymentSchedule{
+		{ID: 11, LoanID: "loan_b", InstallmentNumber: 1, InstallmentAmount: 55000.00, InstallmentDueDate: now.AddDate(0, 0, -8), Status: models.StatusPending},
+	}
+
+	// Mock repository calls; the same installments stay listed so later passes find nothing that fits
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", models.CurrencyIDR).Return([]*models.LoanSummary{firstLoan, secondLoan}, nil)
+	mockLoanRepayment(ctx, mockRepo, mockCollectibility, firstLoan, firstOverdue, firstOverdue)
+	mockLoanRepayment(ctx, mockRepo, mockCollectibility, secondLoan, secondOverdue, secondOverdue)
+	mockDelinquency.On("EvaluateLoan", ctx, mock.AnythingOfType("*models.LoanSummary"), mock.AnythingOfType("[]*models.PaymentSchedule"), mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 180000.00})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AllocationPolicyProRata, response.Policy)
+	assert.Equal(t, 165000.00, response.AllocatedAmount)
+	assert.Equal(t, 15000.00, response.UnallocatedAmount)
+	assert.Len(t, response.Allocations, 2)
+	assert.Equal(t, "loan_b", response.Allocations[0].LoanID)
+	assert.Equal(t, 55000.00, response.Allocations[0].AllocatedAmount)
+	assert.Equal(t, "loan_a", response.Allocations[1].LoanID)
+	assert.Equal(t, 110000.00, response.Allocations[1].AllocatedAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_NothingCovered(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_a", CustomerID: "customer_123", OutstandingAmount: 1100000.00, Status: models.StatusPending}
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_a", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentDueDate: time.Now().AddDate(0, 0, 7), Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", models.CurrencyIDR).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_a").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_a").Return([]*models.PaymentSchedule{}, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_a").Return(pendingSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, []*models.PaymentSchedule{}, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 50000.00})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "does not cover a full payment on any loan")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_NoActiveLoans(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", "USD").Return([]*models.LoanSummary{}, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 100.00, Currency: "usd"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "customer has no active loans in USD", err.Error())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_RepaymentErrorRollsBack(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_a", CustomerID: "customer_123", OutstandingAmount: 1100000.00, Status: models.StatusPending}
+	overdueSchedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_a", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentDueDate: time.Now().AddDate(0, 0, -3), Status: models.StatusPending},
+	}
+
+	// Mock repository calls; the transaction returns the error of the failed repayment
+	var transactionErr error
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		transactionErr = fn(ctx)
+		return transactionErr
+	})
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", models.CurrencyIDR).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_a").Return(loanSummary, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_a").Return(overdueSchedules, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_a").Return(overdueSchedules, nil)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(errors.New("database error"))
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 110000.00})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, transactionErr, err)
+	assert.Contains(t, err.Error(), "failed to repay loan loan_a")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_StoresReference(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_a", CustomerID: "customer_123", OutstandingAmount: 550000.00, Status: models.StatusPending}
+	overdueSchedules := []*models.PaymentSchedule{
+		{ID: 1, LoanID: "loan_a", InstallmentNumber: 1, InstallmentAmount: 110000.00, InstallmentDueDate: time.Now().AddDate(0, 0, -3), Status: models.StatusPending},
+	}
+
+	// Mock repository calls; the reference is claimed before the loan is paid
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateCustomerRepayment", ctx, mock.MatchedBy(func(customerRepayment *models.CustomerRepayment) bool {
+		return customerRepayment.Reference == "TRF-001" && customerRepayment.CustomerID == "customer_123" && len(customerRepayment.Loans) == 0
+	})).Return(true, nil).Once()
+	mockRepo.On("GetActiveLoanSummariesByCustomerID", ctx, "customer_123", models.CurrencyIDR).Return([]*models.LoanSummary{loanSummary}, nil)
+	mockRepo.On("GetOverduePaymentSchedulesByLoanID", ctx, "loan_a").Return(overdueSchedules, nil)
+	mockLoanRepayment(ctx, mockRepo, mockCollectibility, loanSummary, overdueSchedules, overdueSchedules)
+	mockDelinquency.On("EvaluateLoan", ctx, loanSummary, overdueSchedules, mock.AnythingOfType("time.Time")).Return(&models.DelinquencyEvaluation{IsDelinquent: false}, nil)
+	mockLedger.On("PostRepayment", ctx, mock.AnythingOfType("*models.LoanSummary"), 110000.00, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UpdatePaymentSchedules", ctx, mock.AnythingOfType("[]*models.PaymentSchedule")).Return(nil)
+	mockRepo.On("CreatePaymentHistory", ctx, mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockRepo.On("UpdateCustomerRepayment", ctx, mock.MatchedBy(func(customerRepayment *models.CustomerRepayment) bool {
+		return customerRepayment.AllocatedAmount == 110000.00 && customerRepayment.UnallocatedAmount == 5000.00 &&
+			len(customerRepayment.Loans) == 1 && customerRepayment.Loans[0].LoanID == "loan_a" && customerRepayment.Loans[0].InstallmentsPaid == 1
+	})).Return(nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 115000.00, Reference: "TRF-001"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, response.Duplicate)
+	assert.Equal(t, 110000.00, response.AllocatedAmount)
+	assert.Equal(t, 5000.00, response.UnallocatedAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_Duplicate(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	paymentDate := time.Now().AddDate(0, 0, -1)
+	stored := models.CustomerRepayment{
+		ID:                7,
+		CustomerID:        "customer_123",
+		Reference:         "TRF-001",
+		PaymentAmount:     115000.00,
+		Currency:          models.CurrencyIDR,
+		Policy:            models.AllocationPolicyOldestOverdueFirst,
+		AllocatedAmount:   110000.00,
+		UnallocatedAmount: 5000.00,
+		PaymentDate:       paymentDate,
+		Loans: []*models.CustomerRepaymentLoan{
+			{ID: 1, CustomerRepaymentID: 7, LoanID: "loan_a", AllocatedAmount: 110000.00, InstallmentsPaid: 1},
+		},
+	}
+
+	// Mock repository calls; the stored repayment is returned and no loan is paid again
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateCustomerRepayment", ctx, mock.AnythingOfType("*models.CustomerRepayment")).Run(func(args mock.Arguments) {
+		*args.Get(1).(*models.CustomerRepayment) = stored
+	}).Return(false, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 115000.00, Reference: "TRF-001"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Duplicate)
+	assert.Equal(t, 110000.00, response.AllocatedAmount)
+	assert.Equal(t, 5000.00, response.UnallocatedAmount)
+	assert.Equal(t, paymentDate, response.PaymentDate)
+	assert.Len(t, response.Allocations, 1)
+	assert.Equal(t, "loan_a", response.Allocations[0].LoanID)
+	assert.Equal(t, 1, response.Allocations[0].InstallmentsPaid)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRepaymentService_ProcessCustomerRepayment_DuplicateOfRejected(t *testing.T) {
+	mockRepo := mocks.NewRepaymentMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockDelinquency := delinquencyMocks.NewDelinquencyServiceInterface(t)
+	mockWriteOff := writeOffMocks.NewWriteOffServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRepaymentService(mockRepo, mockCollectibility, mockDelinquency, mockWriteOff, mockTax, mockLedger, models.AllocationPolicyOldestOverdueFirst)
+	ctx := context.Background()
+
+	// Mock repository calls; the stored repayment allocated nothing, its funds were held in suspense
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("CreateCustomerRepayment", ctx, mock.AnythingOfType("*models.CustomerRepayment")).Run(func(args mock.Arguments) {
+		customerRepayment := args.Get(1).(*models.CustomerRepayment)
+		customerRepayment.ID = 7
+		customerRepayment.UnallocatedAmount = customerRepayment.PaymentAmount
+	}).Return(false, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "customer_123", &models.CustomerRepaymentRequest{PaymentAmount: 5000.00, Reference: "TRF-001"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	var rejection *models.PaymentRejectionError
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, models.SuspenseReasonUnallocated, rejection.ReasonCode)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestParseAllocationPolicy(t *testing.T) {
+	policy, err := ParseAllocationPolicy(" pro_rata ")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AllocationPolicyProRata, policy)
+
+	_, err = ParseAllocationPolicy("LARGEST_FIRST")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid allocation policy")
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"net/http"
+
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/restructure"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RestructureHandler struct {
+	restructureService restructure.RestructureServiceInterface
+	middleware         middlewares.GoMiddlewareInterface
+}
+
+// NewRestructureHandler creates a new loan restructure handler instance
+func NewRestructureHandler(e *echo.Echo, restructureService restructure.RestructureServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &RestructureHandler{
+		restructureService: restructureService,
+		middleware:         middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/loans/:loan_id/restructure", handler.RestructureLoan)
+	v1.GET("/loans/:loan_id/restructures", handler.GetRestructureHistory)
+}
+
+func (h *RestructureHandler) RestructureLoan(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	var req models.RestructureRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.restructureService.RestructureLoan(c.Request().Context(), loanID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.RestructureSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *RestructureHandler) GetRestructureHistory(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	response, err := h.restructureService.GetRestructureHistory(c.Request().Context(), loanID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.LoanRestructureHistorySuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
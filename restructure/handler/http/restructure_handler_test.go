@@ -0,0 +1,149 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"billing-engine/global"
+	"billing-engine/models"
+	mocks "billing-engine/restructure/_mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestRestructureHandler_RestructureLoan_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewRestructureServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &RestructureHandler{
+		restructureService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	req := models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitMonth,
+		NumberOfInstallment: 12,
+		Reason:              "Hardship - job loss",
+	}
+	expectedResponse := &models.RestructureResponse{
+		LoanID:              "loan_123",
+		RestructureID:       1,
+		OutstandingAmount:   3300000.00,
+		InstallmentAmount:   275000.00,
+		NumberOfInstallment: 12,
+	}
+
+	mockService.On("RestructureLoan", mock.Anything, "loan_123", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/restructure", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.RestructureLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.RestructureSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 275000.00, response.Data.InstallmentAmount)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRestructureHandler_RestructureLoan_InvalidInstallmentUnit(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewRestructureServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &RestructureHandler{
+		restructureService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(models.RestructureRequest{
+		InstallmentUnit:     "day",
+		NumberOfInstallment: 12,
+		Reason:              "Hardship",
+	})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/restructure", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.RestructureLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRestructureHandler_GetRestructureHistory_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewRestructureServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &RestructureHandler{
+		restructureService: mockService,
+		middleware:         mockMiddleware,
+	}
+
+	mockService.On("GetRestructureHistory", mock.Anything, "loan_123").Return(&models.LoanRestructureHistoryResponse{
+		LoanID:       "loan_123",
+		Restructures: []models.RestructureHistoryResponse{{RestructureID: 1}},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/loans/loan_123/restructures", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.GetRestructureHistory(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.LoanRestructureHistorySuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Len(t, response.Data.Restructures, 1)
+
+	mockService.AssertExpectations(t)
+}
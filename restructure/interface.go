@@ -0,0 +1,26 @@
+package restructure
+
+import (
+	"billing-engine/models"
+	"context"
+)
+
+// RestructureMySQLRepositoryInterface defines the interface for loan restructure repository
+type RestructureMySQLRepositoryInterface interface {
+	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	GetLastInstallmentNumber(ctx context.Context, loanID string) (int, error)
+	RestructureLoan(ctx context.Context, loanSummary *models.LoanSummary, restructure *models.LoanRestructure, oldSchedules, newSchedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) error
+	GetRestructuresByLoanID(ctx context.Context, loanID string) ([]*models.LoanRestructure, error)
+	GetPaymentSchedulesByInstallmentRange(ctx context.Context, loanID string, fromInstallment, toInstallment int) ([]*models.PaymentSchedule, error)
+	GetPendingTaxLinesByLoanID(ctx context.Context, loanID string) ([]*models.TaxLine, error)
+	CancelTaxLines(ctx context.Context, taxLines []*models.TaxLine) error
+	CreateTaxLines(ctx context.Context, taxLines []*models.TaxLine) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// RestructureServiceInterface defines the interface for loan restructure service
+type RestructureServiceInterface interface {
+	RestructureLoan(ctx context.Context, loanID string, req *models.RestructureRequest) (*models.RestructureResponse, error)
+	GetRestructureHistory(ctx context.Context, loanID string) (*models.LoanRestructureHistoryResponse, error)
+}
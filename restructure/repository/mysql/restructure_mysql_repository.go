@@ -0,0 +1,184 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/restructure"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type restructureMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewRestructureMySQLRepository creates a new loan restructure repository instance
+func NewRestructureMySQLRepository(db *gorm.DB) restructure.RestructureMySQLRepositoryInterface {
+	return &restructureMySQLRepository{db: db}
+}
+
+// GetLoanSummaryByLoanID reads the loan and locks it until the transaction ends
+func (r *restructureMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
+
+func (r *restructureMySQLRepository) GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanID, models.StatusPending).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetLastInstallmentNumber returns the highest installment number of the loan, including
+// soft-deleted installments, since installment numbers stay unique per loan
+func (r *restructureMySQLRepository) GetLastInstallmentNumber(ctx context.Context, loanID string) (int, error) {
+	var lastNumber int
+	err := transaction.DB(ctx, r.db).
+		Model(&models.PaymentSchedule{}).
+		Select("COALESCE(MAX(installment_number), 0)").
+		Where("loan_id = ?", loanID).
+		Scan(&lastNumber).Error
+	if err != nil {
+		return 0, err
+	}
+	return lastNumber, nil
+}
+
+// RestructureLoan soft-deletes the replaced installments, stores the restructure record,
+// the new installments and their history rows, and updates the loan summary in one transaction.
+// It fails when a replaced installment is no longer pending or the loan is no longer pending or
+// delinquent.
+func (r *restructureMySQLRepository) RestructureLoan(ctx context.Context, loanSummary *models.LoanSummary, restructure *models.LoanRestructure, oldSchedules, newSchedules []*models.PaymentSchedule, histories []*models.PaymentScheduleHistory) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		oldIDs := make([]uint, 0, len(oldSchedules))
+		for _, schedule := range oldSchedules {
+			oldIDs = append(oldIDs, schedule.ID)
+		}
+
+		result := tx.Model(&models.PaymentSchedule{}).
+			Where("id IN ? AND status = ? AND deleted_at IS NULL", oldIDs, models.StatusPending).
+			Updates(map[string]interface{}{
+				"status":     models.StatusRestructured,
+				"deleted_at": restructure.RestructuredAt,
+				"deleted_by": "system",
+				"updated_by": "system",
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(oldIDs)) {
+			return fmt.Errorf("installments of loan %s changed while it was restructured", loanSummary.LoanID)
+		}
+
+		if err := tx.Create(restructure).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&newSchedules).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&histories).Error; err != nil {
+			return err
+		}
+
+		result = tx.Model(loanSummary).
+			Where("status IN ?", []string{models.StatusPending, models.StatusDelinquent}).
+			Updates(map[string]interface{}{
+				"outstanding_amount": loanSummary.OutstandingAmount,
+				"no_of_installment":  loanSummary.NoOfInstallment,
+				"installment_unit":   loanSummary.InstallmentUnit,
+				"installment_amount": loanSummary.InstallmentAmount,
+				"updated_by":         loanSummary.UpdatedBy,
+				"updated_at":         time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("loan %s can no longer be restructured", loanSummary.LoanID)
+		}
+		return nil
+	})
+}
+
+func (r *restructureMySQLRepository) GetRestructuresByLoanID(ctx context.Context, loanID string) ([]*models.LoanRestructure, error) {
+	var restructures []*models.LoanRestructure
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ?", loanID).
+		Order("restructured_at DESC, id DESC").
+		Find(&restructures).Error
+	if err != nil {
+		return nil, err
+	}
+	return restructures, nil
+}
+
+// GetPaymentSchedulesByInstallmentRange returns the installments in the range, including soft-deleted ones
+func (r *restructureMySQLRepository) GetPaymentSchedulesByInstallmentRange(ctx context.Context, loanID string, fromInstallment, toInstallment int) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND installment_number BETWEEN ? AND ?", loanID, fromInstallment, toInstallment).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetPendingTaxLinesByLoanID returns the tax lines due with installments that are not collected yet
+func (r *restructureMySQLRepository) GetPendingTaxLinesByLoanID(ctx context.Context, loanID string) ([]*models.TaxLine, error) {
+	var taxLines []*models.TaxLine
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND installment_number > 0 AND status = ?", loanID, models.StatusPending).
+		Order("installment_number ASC, id ASC").
+		Find(&taxLines).Error
+	if err != nil {
+		return nil, err
+	}
+	return taxLines, nil
+}
+
+// CancelTaxLines cancels the pending tax lines of installments replaced by a restructure
+func (r *restructureMySQLRepository) CancelTaxLines(ctx context.Context, taxLines []*models.TaxLine) error {
+	ids := make([]uint, 0, len(taxLines))
+	for _, taxLine := range taxLines {
+		ids = append(ids, taxLine.ID)
+	}
+	return transaction.DB(ctx, r.db).
+		Model(&models.TaxLine{}).
+		Where("id IN ? AND status = ?", ids, models.StatusPending).
+		Updates(map[string]interface{}{
+			"status":     models.StatusCancelled,
+			"updated_by": "system",
+		}).Error
+}
+
+func (r *restructureMySQLRepository) CreateTaxLines(ctx context.Context, taxLines []*models.TaxLine) error {
+	return transaction.DB(ctx, r.db).Create(&taxLines).Error
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *restructureMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
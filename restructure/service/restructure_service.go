@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"billing-engine/collectibility"
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/restructure"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+type restructureService struct {
+	restructureRepo       restructure.RestructureMySQLRepositoryInterface
+	collectibilityService collectibility.CollectibilityServiceInterface
+	taxService            tax.TaxServiceInterface
+	ledgerService         ledger.LedgerServiceInterface
+}
+
+// NewRestructureService creates a new loan restructure service instance
+func NewRestructureService(restructureRepo restructure.RestructureMySQLRepositoryInterface, collectibilityService collectibility.CollectibilityServiceInterface, taxService tax.TaxServiceInterface, ledgerService ledger.LedgerServiceInterface) restructure.RestructureServiceInterface {
+	return &restructureService{
+		restructureRepo:       restructureRepo,
+		collectibilityService: collectibilityService,
+		taxService:            taxService,
+		ledgerService:         ledgerService,
+	}
+}
+
+// RestructureLoan replaces the pending installments of a loan with a new schedule. The loan is read
+// and locked, and restructured, in one transaction, so the new schedule spreads the balance left by
+// any repayment committed before and a concurrent repayment or write-off cannot slip in between.
+func (s *restructureService) RestructureLoan(ctx context.Context, loanID string, req *models.RestructureRequest) (*models.RestructureResponse, error) {
+	var (
+		loanSummary       *models.LoanSummary
+		oldSchedules      []*models.PaymentSchedule
+		newSchedules      []*models.PaymentSchedule
+		restructureRecord *models.LoanRestructure
+		installmentAmount float64
+	)
+	restructuredAt := time.Now()
+	err := s.restructureRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		loanSummary, err = s.restructureRepo.GetLoanSummaryByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get loan summary: %v", err)
+		}
+		if loanSummary == nil {
+			return fmt.Errorf("loan not found")
+		}
+		switch loanSummary.Status {
+		case models.StatusPaid, models.StatusWrittenOff, models.StatusCancelled, models.StatusInactive:
+			return fmt.Errorf("loan with status %s cannot be restructured", loanSummary.Status)
+		}
+
+		oldSchedules, err = s.restructureRepo.GetPendingPaymentSchedulesByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get pending schedules: %v", err)
+		}
+		if len(oldSchedules) == 0 {
+			return fmt.Errorf("no pending installments found")
+		}
+
+		lastInstallmentNumber, err := s.restructureRepo.GetLastInstallmentNumber(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get last installment number: %v", err)
+		}
+
+		// The tax still due with the replaced installments is part of the outstanding amount; it moves
+		// to the new installments together with its tax lines
+		pendingTaxLines, err := s.restructureRepo.GetPendingTaxLinesByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get pending tax lines: %v", err)
+		}
+		replacedNumbers := make(map[int]bool, len(oldSchedules))
+		for _, schedule := range oldSchedules {
+			replacedNumbers[schedule.InstallmentNumber] = true
+		}
+		var replacedTaxLines []*models.TaxLine
+		for _, taxLine := range pendingTaxLines {
+			if replacedNumbers[taxLine.InstallmentNumber] {
+				replacedTaxLines = append(replacedTaxLines, taxLine)
+			}
+		}
+
+		// New balance is the current outstanding amount, plus accrued penalties and their tax when
+		// capitalised. The penalty tax is charged per installment like on a repayment and becomes due
+		// with the new installments. Penalties that are not capitalised are waived together with the
+		// replaced installments.
+		penaltyAmount := decimal.Zero
+		penaltyTaxAmount := decimal.Zero
+		var penaltyTaxLines []*models.TaxLine
+		if req.CapitalisePenalties {
+			for _, schedule := range oldSchedules {
+				penaltyAmount = penaltyAmount.Add(decimal.NewFromFloat(schedule.PenaltyAmount))
+				if taxLine := s.taxService.TaxCharge(models.ChargeTypePenalty, schedule.PenaltyAmount, currency.Normalize(loanSummary.Currency)); taxLine != nil {
+					taxLine.LoanID = loanID
+					taxLine.InstallmentNumber = schedule.InstallmentNumber
+					penaltyTaxAmount = penaltyTaxAmount.Add(decimal.NewFromFloat(taxLine.TaxAmount))
+					penaltyTaxLines = append(penaltyTaxLines, taxLine)
+				}
+			}
+		}
+		previousOutstanding := decimal.NewFromFloat(loanSummary.OutstandingAmount)
+		newOutstanding := previousOutstanding.Add(penaltyAmount).Add(penaltyTaxAmount)
+
+		startDate := req.StartDate
+		if startDate.IsZero() {
+			startDate = restructuredAt
+		}
+
+		dueTaxLines := append(append([]*models.TaxLine{}, replacedTaxLines...), penaltyTaxLines...)
+		newTaxLines, installmentTaxes := reissueTaxLines(dueTaxLines, lastInstallmentNumber+1, req.NumberOfInstallment, loanSummary.Currency)
+		carriedTax := decimal.Zero
+		for _, installmentTax := range installmentTaxes {
+			carriedTax = carriedTax.Add(installmentTax)
+		}
+
+		newSchedules = generateSchedules(loanID, lastInstallmentNumber+1, req.NumberOfInstallment, req.InstallmentUnit, newOutstanding.Sub(carriedTax), loanSummary.Currency, startDate)
+		for i, schedule := range newSchedules {
+			if installmentTaxes[i].IsZero() {
+				continue
+			}
+			schedule.TaxAmount = installmentTaxes[i].InexactFloat64()
+			schedule.InstallmentAmount = decimal.NewFromFloat(schedule.InstallmentAmount).Add(installmentTaxes[i]).InexactFloat64()
+		}
+		installmentAmount = newSchedules[0].InstallmentAmount
+
+		restructureRecord = &models.LoanRestructure{
+			LoanID:                    loanID,
+			PreviousOutstandingAmount: loanSummary.OutstandingAmount,
+			CapitalisedPenaltyAmount:  penaltyAmount.InexactFloat64(),
+			NewOutstandingAmount:      newOutstanding.InexactFloat64(),
+			PreviousInstallmentUnit:   loanSummary.InstallmentUnit,
+			PreviousInstallmentAmount: loanSummary.InstallmentAmount,
+			OldFromInstallment:        oldSchedules[0].InstallmentNumber,
+			OldToInstallment:          oldSchedules[len(oldSchedules)-1].InstallmentNumber,
+			NewInstallmentUnit:        req.InstallmentUnit,
+			NewInstallmentAmount:      installmentAmount,
+			NewFromInstallment:        newSchedules[0].InstallmentNumber,
+			NewToInstallment:          newSchedules[len(newSchedules)-1].InstallmentNumber,
+			Reason:                    req.Reason,
+			RestructuredAt:            restructuredAt,
+			CreatedBy:                 "system",
+		}
+
+		// History rows keep the replaced installments visible next to the new ones
+		histories := make([]*models.PaymentScheduleHistory, 0, len(oldSchedules))
+		for _, schedule := range oldSchedules {
+			histories = append(histories, &models.PaymentScheduleHistory{
+				ScheduleID:         schedule.ID,
+				LoanID:             schedule.LoanID,
+				Action:             models.ActionRestructure,
+				InstallmentNumber:  schedule.InstallmentNumber,
+				InstallmentAmount:  schedule.InstallmentAmount,
+				InstallmentDueDate: schedule.InstallmentDueDate,
+				Status:             models.StatusRestructured,
+				Currency:           schedule.Currency,
+				CreatedBy:          "system",
+			})
+		}
+
+		paidInstallments := loanSummary.NoOfInstallment - len(oldSchedules)
+		loanSummary.OutstandingAmount = newOutstanding.InexactFloat64()
+		loanSummary.NoOfInstallment = paidInstallments + req.NumberOfInstallment
+		loanSummary.InstallmentUnit = req.InstallmentUnit
+		loanSummary.InstallmentAmount = installmentAmount
+		loanSummary.UpdatedBy = "system"
+
+		if err := s.restructureRepo.RestructureLoan(ctx, loanSummary, restructureRecord, oldSchedules, newSchedules, histories); err != nil {
+			return fmt.Errorf("failed to restructure loan: %v", err)
+		}
+		if len(replacedTaxLines) > 0 {
+			if err := s.restructureRepo.CancelTaxLines(ctx, replacedTaxLines); err != nil {
+				return fmt.Errorf("failed to cancel replaced tax lines: %v", err)
+			}
+		}
+		if len(newTaxLines) > 0 {
+			if err := s.restructureRepo.CreateTaxLines(ctx, newTaxLines); err != nil {
+				return fmt.Errorf("failed to create tax lines: %v", err)
+			}
+		}
+		if err := s.ledgerService.PostCapitalisedPenalties(ctx, loanSummary, penaltyAmount.InexactFloat64(), penaltyTaxAmount.InexactFloat64(), restructuredAt); err != nil {
+			return fmt.Errorf("failed to post capitalised penalties: %v", err)
+		}
+
+		// Overdue installments were replaced, so the overdue position must be re-evaluated
+		if _, err := s.collectibilityService.EvaluateLoan(ctx, loanID, restructuredAt); err != nil {
+			return fmt.Errorf("failed to evaluate collectibility: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RestructureResponse{
+		LoanID:                    loanID,
+		RestructureID:             restructureRecord.ID,
+		PreviousOutstandingAmount: restructureRecord.PreviousOutstandingAmount,
+		CapitalisedPenaltyAmount:  restructureRecord.CapitalisedPenaltyAmount,
+		OutstandingAmount:         restructureRecord.NewOutstandingAmount,
+		ReplacedInstallments:      len(oldSchedules),
+		InstallmentUnit:           req.InstallmentUnit,
+		InstallmentAmount:         installmentAmount,
+		NumberOfInstallment:       req.NumberOfInstallment,
+		FirstDueDate:              newSchedules[0].InstallmentDueDate,
+		FinalDueDate:              newSchedules[len(newSchedules)-1].InstallmentDueDate,
+		RestructuredAt:            restructuredAt,
+	}, nil
+}
+
+func (s *restructureService) GetRestructureHistory(ctx context.Context, loanID string) (*models.LoanRestructureHistoryResponse, error) {
+	loanSummary, err := s.restructureRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	restructures, err := s.restructureRepo.GetRestructuresByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restructures: %v", err)
+	}
+
+	history := make([]models.RestructureHistoryResponse, 0, len(restructures))
+	for _, restructureRecord := range restructures {
+		oldSchedules, err := s.restructureRepo.GetPaymentSchedulesByInstallmentRange(ctx, loanID, restructureRecord.OldFromInstallment, restructureRecord.OldToInstallment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replaced schedules: %v", err)
+		}
+		newSchedules, err := s.restructureRepo.GetPaymentSchedulesByInstallmentRange(ctx, loanID, restructureRecord.NewFromInstallment, restructureRecord.NewToInstallment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get new schedules: %v", err)
+		}
+
+		history = append(history, models.RestructureHistoryResponse{
+			RestructureID:             restructureRecord.ID,
+			Reason:                    restructureRecord.Reason,
+			PreviousOutstandingAmount: restructureRecord.PreviousOutstandingAmount,
+			CapitalisedPenaltyAmount:  restructureRecord.CapitalisedPenaltyAmount,
+			NewOutstandingAmount:      restructureRecord.NewOutstandingAmount,
+			RestructuredAt:            restructureRecord.RestructuredAt,
+			OldSchedule:               toScheduleResponses(oldSchedules),
+			NewSchedule:               toScheduleResponses(newSchedules),
+		})
+	}
+
+	return &models.LoanRestructureHistoryResponse{
+		LoanID:       loanID,
+		Restructures: history,
+	}, nil
+}
+
+// generateSchedules spreads the total amount over the given number of installments, numbered
+// from firstNumber. Installments are rounded down to the currency's minor unit and the last one
+// absorbs the remainder.
+func generateSchedules(loanID string, firstNumber, count int, installmentUnit string, total decimal.Decimal, currencyCode string, startDate time.Time) []*models.PaymentSchedule {
+	currencyCode = currency.Normalize(currencyCode)
+	installmentAmount := currency.RoundDown(total.Div(decimal.NewFromInt(int64(count))), currencyCode)
+	lastInstallmentAmount := total.Sub(installmentAmount.Mul(decimal.NewFromInt(int64(count - 1))))
+
+	schedules := make([]*models.PaymentSchedule, 0, count)
+	for i := 1; i <= count; i++ {
+		var dueDate time.Time
+		if installmentUnit == models.InstallmentUnitWeek {
+			dueDate = startDate.AddDate(0, 0, 7*i)
+		} else { // month
+			dueDate = startDate.AddDate(0, i, 0)
+		}
+
+		amount := installmentAmount
+		if i == count {
+			amount = lastInstallmentAmount
+		}
+
+		schedules = append(schedules, &models.PaymentSchedule{
+			LoanID:             loanID,
+			InstallmentNumber:  firstNumber + i - 1,
+			InstallmentAmount:  amount.InexactFloat64(),
+			InstallmentDueDate: dueDate,
+			InstallmentPaid:    0,
+			Status:             models.StatusPending,
+			Currency:           currencyCode,
+			CreatedBy:          "system",
+			UpdatedBy:          "system",
+		})
+	}
+
+	return schedules
+}
+
+// reissueTaxLines spreads the tax due with the replaced installments over the new installments,
+// numbered from firstNumber. The lines of each charge are added up and split like the installments,
+// the remainder due with the last one. It returns the new lines and the tax due with each installment.
+func reissueTaxLines(replacedTaxLines []*models.TaxLine, firstNumber, count int, currencyCode string) ([]*models.TaxLine, []decimal.Decimal) {
+	currencyCode = currency.Normalize(currencyCode)
+	installmentTaxes := make([]decimal.Decimal, count)
+
+	// One total per charge, in the order the charges first appear
+	type chargeKey struct {
+		taxType    string
+		chargeType string
+		taxRate    float64
+	}
+	var charges []*models.TaxLine
+	chargeTotals := make(map[chargeKey]*models.TaxLine)
+	for _, taxLine := range replacedTaxLines {
+		key := chargeKey{taxType: taxLine.TaxType, chargeType: taxLine.ChargeType, taxRate: taxLine.TaxRate}
+		total, ok := chargeTotals[key]
+		if !ok {
+			total = &models.TaxLine{
+				LoanID:     taxLine.LoanID,
+				TaxType:    taxLine.TaxType,
+				ChargeType: taxLine.ChargeType,
+				TaxRate:    taxLine.TaxRate,
+			}
+			chargeTotals[key] = total
+			charges = append(charges, total)
+		}
+		total.TaxableAmount = decimal.NewFromFloat(total.TaxableAmount).Add(decimal.NewFromFloat(taxLine.TaxableAmount)).InexactFloat64()
+		total.TaxAmount = decimal.NewFromFloat(total.TaxAmount).Add(decimal.NewFromFloat(taxLine.TaxAmount)).InexactFloat64()
+	}
+
+	newTaxLines := make([]*models.TaxLine, 0, len(charges)*count)
+	for _, charge := range charges {
+		taxableParts := currency.Split(decimal.NewFromFloat(charge.TaxableAmount), count, currencyCode)
+		taxParts := currency.Split(decimal.NewFromFloat(charge.TaxAmount), count, currencyCode)
+		for i := 0; i < count; i++ {
+			installmentTaxes[i] = installmentTaxes[i].Add(taxParts[i])
+			newTaxLines = append(newTaxLines, &models.TaxLine{
+				LoanID:            charge.LoanID,
+				TaxType:           charge.TaxType,
+				ChargeType:        charge.ChargeType,
+				InstallmentNumber: firstNumber + i,
+				TaxableAmount:     taxableParts[i].InexactFloat64(),
+				TaxRate:           charge.TaxRate,
+				TaxAmount:         taxParts[i].InexactFloat64(),
+				Currency:          currencyCode,
+				Status:            models.StatusPending,
+				CreatedBy:         "system",
+				UpdatedBy:         "system",
+			})
+		}
+	}
+	return newTaxLines, installmentTaxes
+}
+
+// toScheduleResponses converts payment schedules into API schedule items
+func toScheduleResponses(schedules []*models.PaymentSchedule) []models.PaymentScheduleResponse {
+	responses := make([]models.PaymentScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		var paidDate *time.Time
+		if schedule.Status == models.StatusPaid {
+			paidDate = &schedule.UpdatedAt
+		}
+
+		responses = append(responses, models.PaymentScheduleResponse{
+			InstallmentNumber: schedule.InstallmentNumber,
+			DueDate:           schedule.InstallmentDueDate,
+			InstallmentAmount: schedule.InstallmentAmount,
+			InstallmentPaid:   schedule.InstallmentPaid,
+			PenaltyAmount:     schedule.PenaltyAmount,
+			TaxAmount:         schedule.TaxAmount,
+			Currency:          schedule.Currency,
+			Status:            schedule.Status,
+			PaidDate:          paidDate,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,434 @@
+package service
+
+import (
+	collectibilityMocks "billing-engine/collectibility/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	mocks "billing-engine/restructure/_mock"
+	taxMocks "billing-engine/tax/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateSchedules_LastInstallmentAbsorbsRemainder(t *testing.T) {
+	startDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	schedules := generateSchedules("loan_123", 51, 3, models.InstallmentUnitMonth, decimal.NewFromInt(1000000), models.CurrencyIDR, startDate)
+
+	assert.Len(t, schedules, 3)
+	assert.Equal(t, 51, schedules[0].InstallmentNumber)
+	assert.Equal(t, 53, schedules[2].InstallmentNumber)
+	assert.Equal(t, 333333.33, schedules[0].InstallmentAmount)
+	assert.Equal(t, 333333.34, schedules[2].InstallmentAmount)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), schedules[0].InstallmentDueDate)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), schedules[2].InstallmentDueDate)
+}
+
+func TestRestructureService_RestructureLoan_Success(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitMonth,
+		NumberOfInstallment: 4,
+		StartDate:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		CapitalisePenalties: true,
+		Reason:              "Hardship - job loss",
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		OutstandingAmount: 330000.00,
+		NoOfInstallment:   50,
+		InstallmentUnit:   models.InstallmentUnitWeek,
+		InstallmentAmount: 110000.00,
+		Status:            models.StatusPending,
+	}
+
+	oldSchedules := []*models.PaymentSchedule{
+		{ID: 48, LoanID: "loan_123", InstallmentNumber: 48, InstallmentAmount: 110000.00, PenaltyAmount: 5000.00, Status: models.StatusPending},
+		{ID: 49, LoanID: "loan_123", InstallmentNumber: 49, InstallmentAmount: 110000.00, PenaltyAmount: 5000.00, Status: models.StatusPending},
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(oldSchedules, nil)
+	mockRepo.On("GetLastInstallmentNumber", ctx, "loan_123").Return(50, nil)
+	mockRepo.On("GetPendingTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, mock.AnythingOfType("float64"), models.CurrencyIDR).Return(nil)
+	mockLedger.On("PostCapitalisedPenalties", ctx, loanSummary, 10000.00, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("RestructureLoan", ctx, loanSummary,
+		mock.MatchedBy(func(restructure *models.LoanRestructure) bool {
+			return restructure.OldFromInstallment == 48 && restructure.OldToInstallment == 50 &&
+				restructure.NewFromInstallment == 51 && restructure.NewToInstallment == 54 &&
+				restructure.CapitalisedPenaltyAmount == 10000.00
+		}),
+		oldSchedules,
+		mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+			return len(schedules) == 4 && schedules[0].InstallmentAmount == 85000.00
+		}),
+		mock.MatchedBy(func(histories []*models.PaymentScheduleHistory) bool {
+			return len(histories) == 3 && histories[0].Action == models.ActionRestructure
+		}),
+	).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{}, nil)
+
+	// Execute
+	response, err := service.RestructureLoan(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 330000.00, response.PreviousOutstandingAmount)
+	assert.Equal(t, 10000.00, response.CapitalisedPenaltyAmount)
+	assert.Equal(t, 340000.00, response.OutstandingAmount)
+	assert.Equal(t, 3, response.ReplacedInstallments)
+	assert.Equal(t, 85000.00, response.InstallmentAmount)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), response.FirstDueDate)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), response.FinalDueDate)
+
+	// Loan summary now reflects the new schedule
+	assert.Equal(t, 51, loanSummary.NoOfInstallment)
+	assert.Equal(t, models.InstallmentUnitMonth, loanSummary.InstallmentUnit)
+	assert.Equal(t, 340000.00, loanSummary.OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+	mockCollectibility.AssertExpectations(t)
+}
+
+func TestRestructureService_RestructureLoan_CapitalisedPenaltyTax(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitMonth,
+		NumberOfInstallment: 4,
+		StartDate:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		CapitalisePenalties: true,
+		Reason:              "Hardship - job loss",
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		OutstandingAmount: 330000.00,
+		NoOfInstallment:   50,
+		InstallmentUnit:   models.InstallmentUnitWeek,
+		InstallmentAmount: 110000.00,
+		Currency:          models.CurrencyIDR,
+		Status:            models.StatusPending,
+	}
+
+	oldSchedules := []*models.PaymentSchedule{
+		{ID: 48, LoanID: "loan_123", InstallmentNumber: 48, InstallmentAmount: 110000.00, PenaltyAmount: 5000.00, Status: models.StatusPending},
+		{ID: 49, LoanID: "loan_123", InstallmentNumber: 49, InstallmentAmount: 110000.00, PenaltyAmount: 5000.00, Status: models.StatusPending},
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls; PPN of 11% is charged on each capitalised penalty
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(oldSchedules, nil)
+	mockRepo.On("GetLastInstallmentNumber", ctx, "loan_123").Return(50, nil)
+	mockRepo.On("GetPendingTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 5000.00, models.CurrencyIDR).Return(func(chargeType string, taxableAmount float64, currencyCode string) *models.TaxLine {
+		return &models.TaxLine{TaxType: models.TaxTypePPN, ChargeType: chargeType, TaxableAmount: taxableAmount, TaxRate: 0.11, TaxAmount: 550.00, Status: models.StatusPending}
+	})
+	mockTax.On("TaxCharge", models.ChargeTypePenalty, 0.0, models.CurrencyIDR).Return(nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("RestructureLoan", ctx, loanSummary, mock.AnythingOfType("*models.LoanRestructure"), oldSchedules,
+		mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+			return len(schedules) == 4 && schedules[0].InstallmentAmount == 85275.00 && schedules[0].TaxAmount == 275.00
+		}),
+		mock.AnythingOfType("[]*models.PaymentScheduleHistory"),
+	).Return(nil)
+	mockRepo.On("CreateTaxLines", ctx, mock.MatchedBy(func(taxLines []*models.TaxLine) bool {
+		return len(taxLines) == 4 && taxLines[0].ChargeType == models.ChargeTypePenalty && taxLines[0].InstallmentNumber == 51 &&
+			taxLines[0].TaxableAmount == 2500.00 && taxLines[0].TaxAmount == 275.00 && taxLines[0].Status == models.StatusPending
+	})).Return(nil)
+	mockLedger.On("PostCapitalisedPenalties", ctx, loanSummary, 10000.00, 1100.00, mock.AnythingOfType("time.Time")).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{}, nil)
+
+	// Execute
+	response, err := service.RestructureLoan(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10000.00, response.CapitalisedPenaltyAmount)
+	assert.Equal(t, 341100.00, response.OutstandingAmount)
+	assert.Equal(t, 85275.00, response.InstallmentAmount)
+
+	mockRepo.AssertExpectations(t)
+	mockTax.AssertExpectations(t)
+	mockLedger.AssertExpectations(t)
+}
+
+func TestRestructureService_RestructureLoan_PenaltiesWaived(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitWeek,
+		NumberOfInstallment: 2,
+		Reason:              "Hardship",
+	}
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 110000.00, NoOfInstallment: 50, Status: models.StatusPending}
+	oldSchedules := []*models.PaymentSchedule{
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, PenaltyAmount: 5000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(oldSchedules, nil)
+	mockRepo.On("GetLastInstallmentNumber", ctx, "loan_123").Return(50, nil)
+	mockRepo.On("GetPendingTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostCapitalisedPenalties", ctx, loanSummary, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("RestructureLoan", ctx, loanSummary, mock.AnythingOfType("*models.LoanRestructure"), oldSchedules,
+		mock.AnythingOfType("[]*models.PaymentSchedule"), mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{}, nil)
+
+	// Execute
+	response, err := service.RestructureLoan(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, response.CapitalisedPenaltyAmount)
+	assert.Equal(t, 110000.00, response.OutstandingAmount)
+	assert.Equal(t, 55000.00, response.InstallmentAmount)
+}
+
+func TestRestructureService_RestructureLoan_CarriesPendingTax(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitMonth,
+		NumberOfInstallment: 3,
+		StartDate:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Reason:              "Hardship",
+	}
+
+	// Each installment carries 110 tax on a 1000 admin fee part
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 220220.00, NoOfInstallment: 50, Currency: models.CurrencyIDR, Status: models.StatusPending}
+	oldSchedules := []*models.PaymentSchedule{
+		{ID: 49, LoanID: "loan_123", InstallmentNumber: 49, InstallmentAmount: 110110.00, TaxAmount: 110.00, Status: models.StatusPending},
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110110.00, TaxAmount: 110.00, Status: models.StatusPending},
+	}
+	replacedTaxLines := []*models.TaxLine{
+		{ID: 7, LoanID: "loan_123", TaxType: models.TaxTypePPN, ChargeType: "ADMIN", InstallmentNumber: 49, TaxableAmount: 1000.00, TaxRate: 0.11, TaxAmount: 110.00, Status: models.StatusPending},
+		{ID: 8, LoanID: "loan_123", TaxType: models.TaxTypePPN, ChargeType: "ADMIN", InstallmentNumber: 50, TaxableAmount: 1000.00, TaxRate: 0.11, TaxAmount: 110.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(oldSchedules, nil)
+	mockRepo.On("GetLastInstallmentNumber", ctx, "loan_123").Return(50, nil)
+	mockRepo.On("GetPendingTaxLinesByLoanID", ctx, "loan_123").Return(replacedTaxLines, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostCapitalisedPenalties", ctx, loanSummary, 0.0, 0.0, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("RestructureLoan", ctx, loanSummary, mock.AnythingOfType("*models.LoanRestructure"), oldSchedules,
+		mock.MatchedBy(func(schedules []*models.PaymentSchedule) bool {
+			// 220000 spread over 3 plus the 220 tax, the remainders on the last installment
+			return len(schedules) == 3 &&
+				schedules[0].TaxAmount == 73.33 && schedules[0].InstallmentAmount == 73406.66 &&
+				schedules[2].TaxAmount == 73.34 && schedules[2].InstallmentAmount == 73406.68
+		}),
+		mock.AnythingOfType("[]*models.PaymentScheduleHistory")).Return(nil)
+	mockRepo.On("CancelTaxLines", ctx, replacedTaxLines).Return(nil)
+	mockRepo.On("CreateTaxLines", ctx, mock.MatchedBy(func(taxLines []*models.TaxLine) bool {
+		return len(taxLines) == 3 &&
+			taxLines[0].InstallmentNumber == 51 && taxLines[0].TaxAmount == 73.33 && taxLines[0].TaxableAmount == 666.66 &&
+			taxLines[2].InstallmentNumber == 53 && taxLines[2].TaxAmount == 73.34 && taxLines[2].TaxableAmount == 666.68 &&
+			taxLines[0].ChargeType == "ADMIN" && taxLines[0].Status == models.StatusPending
+	})).Return(nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{}, nil)
+
+	// Execute
+	response, err := service.RestructureLoan(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 220220.00, response.OutstandingAmount)
+	assert.Equal(t, 73406.66, response.InstallmentAmount)
+}
+
+func TestRestructureService_RestructureLoan_WrittenOffLoan(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+		LoanID: "loan_123",
+		Status: models.StatusWrittenOff,
+	}, nil)
+
+	// Execute
+	response, err := service.RestructureLoan(ctx, "loan_123", &models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitWeek,
+		NumberOfInstallment: 10,
+		Reason:              "Hardship",
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "cannot be restructured")
+}
+
+func TestRestructureService_RestructureLoan_NotDisbursedLoan(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+
+	for _, status := range []string{models.StatusInactive, models.StatusCancelled} {
+		// Mock repository calls
+		mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+			LoanID: "loan_123",
+			Status: status,
+		}, nil).Once()
+
+		// Execute
+		response, err := service.RestructureLoan(ctx, "loan_123", &models.RestructureRequest{
+			InstallmentUnit:     models.InstallmentUnitWeek,
+			NumberOfInstallment: 10,
+			Reason:              "Hardship",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "loan with status "+status+" cannot be restructured")
+	}
+}
+
+func TestRestructureService_RestructureLoan_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 110000.00, NoOfInstallment: 50, Status: models.StatusPending}
+	oldSchedules := []*models.PaymentSchedule{
+		{ID: 50, LoanID: "loan_123", InstallmentNumber: 50, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(oldSchedules, nil)
+	mockRepo.On("GetLastInstallmentNumber", ctx, "loan_123").Return(50, nil)
+	mockRepo.On("GetPendingTaxLinesByLoanID", ctx, "loan_123").Return([]*models.TaxLine{}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("RestructureLoan", ctx, loanSummary, mock.Anything, oldSchedules, mock.Anything, mock.Anything).
+		Return(errors.New("database error"))
+
+	// Execute
+	response, err := service.RestructureLoan(ctx, "loan_123", &models.RestructureRequest{
+		InstallmentUnit:     models.InstallmentUnitWeek,
+		NumberOfInstallment: 2,
+		Reason:              "Hardship",
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to restructure loan")
+}
+
+func TestRestructureService_GetRestructureHistory_Success(t *testing.T) {
+	mockRepo := mocks.NewRestructureMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockTax := taxMocks.NewTaxServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewRestructureService(mockRepo, mockCollectibility, mockTax, mockLedger)
+	ctx := context.Background()
+
+	restructures := []*models.LoanRestructure{
+		{
+			ID:                 3,
+			LoanID:             "loan_123",
+			OldFromInstallment: 48,
+			OldToInstallment:   50,
+			NewFromInstallment: 51,
+			NewToInstallment:   54,
+			Reason:             "Hardship - job loss",
+		},
+	}
+	oldSchedules := []*models.PaymentSchedule{
+		{InstallmentNumber: 48, Status: models.StatusRestructured},
+		{InstallmentNumber: 49, Status: models.StatusRestructured},
+		{InstallmentNumber: 50, Status: models.StatusRestructured},
+	}
+	newSchedules := []*models.PaymentSchedule{
+		{InstallmentNumber: 51, Status: models.StatusPending},
+		{InstallmentNumber: 52, Status: models.StatusPending},
+		{InstallmentNumber: 53, Status: models.StatusPending},
+		{InstallmentNumber: 54, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{LoanID: "loan_123"}, nil)
+	mockRepo.On("GetRestructuresByLoanID", ctx, "loan_123").Return(restructures, nil)
+	mockRepo.On("GetPaymentSchedulesByInstallmentRange", ctx, "loan_123", 48, 50).Return(oldSchedules, nil)
+	mockRepo.On("GetPaymentSchedulesByInstallmentRange", ctx, "loan_123", 51, 54).Return(newSchedules, nil)
+
+	// Execute
+	response, err := service.GetRestructureHistory(ctx, "loan_123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Restructures, 1)
+	assert.Len(t, response.Restructures[0].OldSchedule, 3)
+	assert.Len(t, response.Restructures[0].NewSchedule, 4)
+	assert.Equal(t, models.StatusRestructured, response.Restructures[0].OldSchedule[0].Status)
+
+	mockRepo.AssertExpectations(t)
+}
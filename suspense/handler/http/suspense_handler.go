@@ -0,0 +1,133 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/suspense"
+	"billing-engine/utils/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SuspenseHandler struct {
+	suspenseService suspense.SuspenseServiceInterface
+	middleware      middlewares.GoMiddlewareInterface
+}
+
+// NewSuspenseHandler creates a new suspense handler instance
+func NewSuspenseHandler(e *echo.Echo, suspenseService suspense.SuspenseServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &SuspenseHandler{
+		suspenseService: suspenseService,
+		middleware:      middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/suspense/items", handler.ListItems)
+	v1.POST("/suspense/items/:item_id/resolve", handler.ResolveItem)
+	v1.GET("/suspense/aging", handler.GetAgingReport)
+}
+
+func (h *SuspenseHandler) ListItems(c echo.Context) error {
+	var req models.SuspenseItemListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query parameters",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.suspenseService.ListItems(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.SuspenseItemListSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *SuspenseHandler) ResolveItem(c echo.Context) error {
+	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 64)
+	if err != nil || itemID == 0 {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid item ID",
+		})
+	}
+
+	var req models.SuspenseResolveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.suspenseService.ResolveItem(c.Request().Context(), uint(itemID), &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.SuspenseItemSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// GetAgingReport ages the items open at the end of as_of (YYYY-MM-DD, default today)
+func (h *SuspenseHandler) GetAgingReport(c echo.Context) error {
+	now := time.Now()
+	asOf := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if c.QueryParam("as_of") != "" {
+		date, err := time.ParseInLocation("2006-01-02", c.QueryParam("as_of"), time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, global.BadResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Dates must use the YYYY-MM-DD format",
+			})
+		}
+		asOf = date
+	}
+
+	response, err := h.suspenseService.GetAgingReport(c.Request().Context(), asOf)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.SuspenseAgingSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
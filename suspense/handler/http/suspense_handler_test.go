@@ -0,0 +1,218 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"billing-engine/models"
+	mocks "billing-engine/suspense/_mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestSuspenseHandler_ListItems_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ListItems", mock.Anything, &models.SuspenseItemListRequest{Status: "OPEN", ReasonCode: "UNKNOWN_LOAN"}).Return(&models.SuspenseItemListResponse{
+		Items: []models.SuspenseItemResponse{{ID: 7, ReasonCode: models.SuspenseReasonUnknownLoan, Status: models.SuspenseStatusOpen}},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/suspense/items?status=OPEN&reason_code=UNKNOWN_LOAN", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ListItems(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"reason_code":"UNKNOWN_LOAN"`)
+}
+
+func TestSuspenseHandler_ListItems_InvalidStatus(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/suspense/items?status=CLOSED", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.ListItems(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "status must be one of: OPEN, RESOLVED")
+	mockService.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+}
+
+func TestSuspenseHandler_ResolveItem_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ResolveItem", mock.Anything, uint(7), &models.SuspenseResolveRequest{
+		Action:          models.SuspenseResolutionRefund,
+		RefundReference: "RFD-001",
+		ResolvedBy:      "ops@example.com",
+	}).Return(&models.SuspenseItemResponse{ID: 7, Status: models.SuspenseStatusResolved, Resolution: models.SuspenseResolutionRefund}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/suspense/items/7/resolve", strings.NewReader(`{"action":"REFUND","refund_reference":"RFD-001","resolved_by":"ops@example.com"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("item_id")
+	c.SetParamValues("7")
+
+	// Execute
+	err := handler.ResolveItem(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"resolution":"REFUND"`)
+}
+
+func TestSuspenseHandler_ResolveItem_MissingRefundReference(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/suspense/items/7/resolve", strings.NewReader(`{"action":"REFUND","resolved_by":"ops@example.com"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("item_id")
+	c.SetParamValues("7")
+
+	// Execute
+	err := handler.ResolveItem(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "refundreference is required")
+	mockService.AssertNotCalled(t, "ResolveItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSuspenseHandler_ResolveItem_InvalidItemID(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/suspense/items/abc/resolve", strings.NewReader(`{"action":"REFUND"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("item_id")
+	c.SetParamValues("abc")
+
+	// Execute
+	err := handler.ResolveItem(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid item ID")
+}
+
+func TestSuspenseHandler_ResolveItem_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	mockService.On("ResolveItem", mock.Anything, uint(7), mock.AnythingOfType("*models.SuspenseResolveRequest")).Return(nil, errors.New("suspense item 7 is already resolved"))
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/suspense/items/7/resolve", strings.NewReader(`{"action":"APPLY","loan_id":"loan_123","resolved_by":"ops@example.com"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("item_id")
+	c.SetParamValues("7")
+
+	// Execute
+	err := handler.ResolveItem(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "already resolved")
+}
+
+func TestSuspenseHandler_GetAgingReport_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+	mockService.On("GetAgingReport", mock.Anything, asOf).Return(&models.SuspenseAgingResponse{AsOf: asOf}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/suspense/aging?as_of=2026-03-31", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetAgingReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSuspenseHandler_GetAgingReport_InvalidDate(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewSuspenseServiceInterface(t)
+	handler := &SuspenseHandler{suspenseService: mockService, middleware: new(MockMiddleware)}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/suspense/aging?as_of=31-03-2026", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetAgingReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "GetAgingReport", mock.Anything, mock.Anything)
+}
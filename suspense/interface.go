@@ -0,0 +1,27 @@
+package suspense
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// SuspenseMySQLRepositoryInterface defines the interface for suspense repository
+type SuspenseMySQLRepositoryInterface interface {
+	CreateItem(ctx context.Context, item *models.SuspenseItem) (bool, error)
+	GetItemByID(ctx context.Context, itemID uint) (*models.SuspenseItem, error)
+	GetItemBySourceReference(ctx context.Context, source string, sourceReference string) (*models.SuspenseItem, error)
+	GetItems(ctx context.Context, status string, reasonCode string, afterID uint, limit int) ([]*models.SuspenseItem, error)
+	GetItemsOpenAt(ctx context.Context, before time.Time) ([]*models.SuspenseItem, error)
+	ResolveItem(ctx context.Context, item *models.SuspenseItem) (bool, error)
+	LedgerAccountExists(ctx context.Context, accountCode string) (bool, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// SuspenseServiceInterface defines the interface for suspense service
+type SuspenseServiceInterface interface {
+	HoldPayment(ctx context.Context, req *models.RepaymentRequest, source string, rejection *models.PaymentRejectionError) (*models.SuspenseItemResponse, error)
+	ListItems(ctx context.Context, req *models.SuspenseItemListRequest) (*models.SuspenseItemListResponse, error)
+	ResolveItem(ctx context.Context, itemID uint, req *models.SuspenseResolveRequest) (*models.SuspenseItemResponse, error)
+	GetAgingReport(ctx context.Context, asOf time.Time) (*models.SuspenseAgingResponse, error)
+}
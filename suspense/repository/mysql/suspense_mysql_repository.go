@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/suspense"
+	"billing-engine/utils/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type suspenseMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSuspenseMySQLRepository creates a new suspense repository instance
+func NewSuspenseMySQLRepository(db *gorm.DB) suspense.SuspenseMySQLRepositoryInterface {
+	return &suspenseMySQLRepository{db: db}
+}
+
+// CreateItem stores the item unless an item is held under the same source reference already. It
+// then reports false and loads the held item into item. The held item is read with a lock, so an
+// item committed by a concurrent hold after this transaction started is seen too.
+func (r *suspenseMySQLRepository) CreateItem(ctx context.Context, item *models.SuspenseItem) (bool, error) {
+	db := transaction.DB(ctx, r.db)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(item)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	var held models.SuspenseItem
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("source = ? AND source_reference = ?", item.Source, item.SourceReference).
+		First(&held).Error
+	if err != nil {
+		return false, err
+	}
+	*item = held
+	return false, nil
+}
+
+func (r *suspenseMySQLRepository) GetItemByID(ctx context.Context, itemID uint) (*models.SuspenseItem, error) {
+	var item models.SuspenseItem
+	err := transaction.DB(ctx, r.db).Where("id = ?", itemID).First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItemBySourceReference returns the item held under the source reference. The read takes no
+// lock; the unique source reference keeps concurrent holds of the same payment apart in CreateItem.
+func (r *suspenseMySQLRepository) GetItemBySourceReference(ctx context.Context, source string, sourceReference string) (*models.SuspenseItem, error) {
+	var item models.SuspenseItem
+	err := transaction.DB(ctx, r.db).
+		Where("source = ? AND source_reference = ?", source, sourceReference).
+		First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItems returns the items oldest first, continuing after afterID. Empty filters match every item.
+func (r *suspenseMySQLRepository) GetItems(ctx context.Context, status string, reasonCode string, afterID uint, limit int) ([]*models.SuspenseItem, error) {
+	query := transaction.DB(ctx, r.db).Where("id > ?", afterID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if reasonCode != "" {
+		query = query.Where("reason_code = ?", reasonCode)
+	}
+
+	var items []*models.SuspenseItem
+	err := query.Order("id ASC").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetItemsOpenAt returns the items received before the given time and not resolved by then
+func (r *suspenseMySQLRepository) GetItemsOpenAt(ctx context.Context, before time.Time) ([]*models.SuspenseItem, error) {
+	var items []*models.SuspenseItem
+	err := transaction.DB(ctx, r.db).
+		Where("received_at < ? AND (resolved_at IS NULL OR resolved_at >= ?)", before, before).
+		Order("currency ASC, received_at ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ResolveItem stores the resolution of an item that is still open. It reports false when the item
+// was resolved in the meantime, so the funds are never released twice.
+func (r *suspenseMySQLRepository) ResolveItem(ctx context.Context, item *models.SuspenseItem) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&models.SuspenseItem{}).
+		Where("id = ? AND status = ?", item.ID, models.SuspenseStatusOpen).
+		Updates(map[string]interface{}{
+			"status":           item.Status,
+			"resolution":       item.Resolution,
+			"resolved_loan_id": item.ResolvedLoanID,
+			"account_code":     item.AccountCode,
+			"refund_reference": item.RefundReference,
+			"note":             item.Note,
+			"resolved_at":      item.ResolvedAt,
+			"resolved_by":      item.ResolvedBy,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *suspenseMySQLRepository) LedgerAccountExists(ctx context.Context, accountCode string) (bool, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).
+		Model(&models.LedgerAccount{}).
+		Where("code = ?", accountCode).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *suspenseMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
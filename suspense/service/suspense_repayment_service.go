@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"billing-engine/models"
+	"billing-engine/repayment"
+	"billing-engine/suspense"
+)
+
+type suspenseRepaymentService struct {
+	repayment.RepaymentServiceInterface
+	suspenseService suspense.SuspenseServiceInterface
+	suspenseRepo    suspense.SuspenseMySQLRepositoryInterface
+	source          string
+}
+
+// NewSuspenseRepaymentService wraps the repayment service for callers that receive the funds of a
+// payment, such as the repayment API. A payment the repayment rejects is held in suspense under
+// the given source instead of being lost; the caller still gets the rejection. Only payments that
+// carry a reference are held, so a retried call finds the held item instead of booking the funds
+// again, and a call without one is just rejected. What a referenced customer repayment leaves
+// unallocated is held too, in the transaction of its loan payments; a repeated one finds its stored
+// allocations and the held item instead of paying the loans again.
+func NewSuspenseRepaymentService(repaymentService repayment.RepaymentServiceInterface, suspenseService suspense.SuspenseServiceInterface, suspenseRepo suspense.SuspenseMySQLRepositoryInterface, source string) repayment.RepaymentServiceInterface {
+	return &suspenseRepaymentService{
+		RepaymentServiceInterface: repaymentService,
+		suspenseService:           suspenseService,
+		suspenseRepo:              suspenseRepo,
+		source:                    source,
+	}
+}
+
+func (s *suspenseRepaymentService) ProcessRepayment(ctx context.Context, req *models.RepaymentRequest) (*models.RepaymentResponse, error) {
+	response, err := s.RepaymentServiceInterface.ProcessRepayment(ctx, req)
+	var rejection *models.PaymentRejectionError
+	if err == nil || !errors.As(err, &rejection) || req.Reference == "" {
+		return response, err
+	}
+
+	item, holdErr := s.suspenseService.HoldPayment(ctx, req, s.source, rejection)
+	if holdErr != nil {
+		return nil, fmt.Errorf("%v; failed to hold the payment in suspense: %v", err, holdErr)
+	}
+	return nil, fmt.Errorf("%v; the payment is held in suspense item %d", err, item.ID)
+}
+
+// ProcessCustomerRepayment holds the unallocated remainder of the transfer in suspense, or the whole
+// transfer when it covers no loan payment at all
+func (s *suspenseRepaymentService) ProcessCustomerRepayment(ctx context.Context, customerID string, req *models.CustomerRepaymentRequest) (*models.CustomerRepaymentResponse, error) {
+	if req.Reference == "" {
+		return s.RepaymentServiceInterface.ProcessCustomerRepayment(ctx, customerID, req)
+	}
+
+	var (
+		response  *models.CustomerRepaymentResponse
+		rejection *models.PaymentRejectionError
+		item      *models.SuspenseItemResponse
+	)
+	err := s.suspenseRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = s.RepaymentServiceInterface.ProcessCustomerRepayment(ctx, customerID, req)
+		if err != nil {
+			if !errors.As(err, &rejection) {
+				return err
+			}
+			item, err = s.suspenseService.HoldPayment(ctx, &models.RepaymentRequest{
+				PaymentAmount: req.PaymentAmount,
+				Currency:      req.Currency,
+				Reference:     req.Reference,
+			}, s.source, rejection)
+			if err != nil {
+				return fmt.Errorf("%v; failed to hold the payment in suspense: %v", rejection, err)
+			}
+			return nil
+		}
+		if response.UnallocatedAmount <= 0 {
+			return nil
+		}
+
+		item, err = s.suspenseService.HoldPayment(ctx, &models.RepaymentRequest{
+			PaymentAmount: response.UnallocatedAmount,
+			Currency:      response.Currency,
+			Reference:     req.Reference,
+		}, s.source, &models.PaymentRejectionError{
+			ReasonCode: models.SuspenseReasonUnallocated,
+			Currency:   response.Currency,
+			Message:    fmt.Sprintf("%.2f of the customer %s repayment fits no loan payment", response.UnallocatedAmount, customerID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to hold the unallocated amount in suspense: %v", err)
+		}
+		response.SuspenseItemID = &item.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejection != nil {
+		return nil, fmt.Errorf("%v; the payment is held in suspense item %d", rejection, item.ID)
+	}
+	return response, nil
+}
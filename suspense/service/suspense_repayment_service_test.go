@@ -0,0 +1,173 @@
+package service
+
+import (
+	"billing-engine/models"
+	repaymentMocks "billing-engine/repayment/_mock"
+	mocks "billing-engine/suspense/_mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuspenseRepaymentService_ProcessRepayment_HoldsRejectedPayment(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mocks.NewSuspenseMySQLRepositoryInterface(t), models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100000.00, Reference: "TRF-001"}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100000.00 does not match required amount 110000.00"}
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, req).Return(nil, rejection)
+	mockSuspense.On("HoldPayment", ctx, req, models.SuspenseSourceRepaymentAPI, rejection).Return(&models.SuspenseItemResponse{ID: 7}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "payment amount 100000.00 does not match required amount 110000.00; the payment is held in suspense item 7", err.Error())
+}
+
+func TestSuspenseRepaymentService_ProcessRepayment_WithoutReference(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mocks.NewSuspenseMySQLRepositoryInterface(t), models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100000.00}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100000.00 does not match required amount 110000.00"}
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, req).Return(nil, rejection)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, rejection.Message, err.Error())
+	mockSuspense.AssertNotCalled(t, "HoldPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSuspenseRepaymentService_ProcessRepayment_OtherErrors(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mocks.NewSuspenseMySQLRepositoryInterface(t), models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00}
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, req).Return(nil, errors.New("failed to get loan summary: connection refused"))
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "failed to get loan summary: connection refused", err.Error())
+	mockSuspense.AssertNotCalled(t, "HoldPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSuspenseRepaymentService_ProcessRepayment_Success(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mocks.NewSuspenseMySQLRepositoryInterface(t), models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00}
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, req).Return(&models.RepaymentResponse{LoanID: "loan_123"}, nil)
+
+	// Execute
+	response, err := service.ProcessRepayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "loan_123", response.LoanID)
+}
+
+func TestSuspenseRepaymentService_ProcessCustomerRepayment_HoldsUnallocatedAmount(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mockRepo, models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.CustomerRepaymentRequest{PaymentAmount: 250000.00, Reference: "TRF-002"}
+
+	// Mock repository and service calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepayment.On("ProcessCustomerRepayment", ctx, "12312312", req).Return(&models.CustomerRepaymentResponse{
+		CustomerID:        "12312312",
+		Currency:          models.CurrencyIDR,
+		AllocatedAmount:   220000.00,
+		UnallocatedAmount: 30000.00,
+	}, nil)
+	mockSuspense.On("HoldPayment", ctx, &models.RepaymentRequest{PaymentAmount: 30000.00, Currency: models.CurrencyIDR, Reference: "TRF-002"},
+		models.SuspenseSourceRepaymentAPI, mock.MatchedBy(func(rejection *models.PaymentRejectionError) bool {
+			return rejection.ReasonCode == models.SuspenseReasonUnallocated
+		})).Return(&models.SuspenseItemResponse{ID: 9}, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "12312312", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 220000.00, response.AllocatedAmount)
+	assert.Equal(t, uint(9), *response.SuspenseItemID)
+}
+
+func TestSuspenseRepaymentService_ProcessCustomerRepayment_HoldsRejectedPayment(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mockRepo, models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.CustomerRepaymentRequest{PaymentAmount: 50000.00, Reference: "TRF-003"}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonUnallocated, Currency: models.CurrencyIDR, Message: "payment amount 50000.00 does not cover a full payment on any loan"}
+
+	// Mock repository and service calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepayment.On("ProcessCustomerRepayment", ctx, "12312312", req).Return(nil, rejection)
+	mockSuspense.On("HoldPayment", ctx, &models.RepaymentRequest{PaymentAmount: 50000.00, Reference: "TRF-003"}, models.SuspenseSourceRepaymentAPI, rejection).
+		Return(&models.SuspenseItemResponse{ID: 10}, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "12312312", req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "payment amount 50000.00 does not cover a full payment on any loan; the payment is held in suspense item 10", err.Error())
+}
+
+func TestSuspenseRepaymentService_ProcessCustomerRepayment_WithoutReference(t *testing.T) {
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := mocks.NewSuspenseServiceInterface(t)
+	service := NewSuspenseRepaymentService(mockRepayment, mockSuspense, mocks.NewSuspenseMySQLRepositoryInterface(t), models.SuspenseSourceRepaymentAPI)
+	ctx := context.Background()
+
+	req := &models.CustomerRepaymentRequest{PaymentAmount: 250000.00}
+
+	// Mock service calls
+	mockRepayment.On("ProcessCustomerRepayment", ctx, "12312312", req).Return(&models.CustomerRepaymentResponse{UnallocatedAmount: 30000.00}, nil)
+
+	// Execute
+	response, err := service.ProcessCustomerRepayment(ctx, "12312312", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, response.SuspenseItemID)
+	mockSuspense.AssertNotCalled(t, "HoldPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/repayment"
+	"billing-engine/suspense"
+	"billing-engine/utils/currency"
+	"billing-engine/utils/pagination"
+
+	"github.com/shopspring/decimal"
+)
+
+// agingBucket is an age range of the aging report; maxDays is -1 for the last, open-ended bucket
+type agingBucket struct {
+	label   string
+	minDays int
+	maxDays int
+}
+
+var agingBuckets = []agingBucket{
+	{label: "0-7", minDays: 0, maxDays: 7},
+	{label: "8-30", minDays: 8, maxDays: 30},
+	{label: "31-60", minDays: 31, maxDays: 60},
+	{label: "61-90", minDays: 61, maxDays: 90},
+	{label: "91+", minDays: 91, maxDays: -1},
+}
+
+type suspenseService struct {
+	suspenseRepo     suspense.SuspenseMySQLRepositoryInterface
+	repaymentService repayment.RepaymentServiceInterface
+	ledgerService    ledger.LedgerServiceInterface
+}
+
+// NewSuspenseService creates a new suspense service instance. repaymentService applies resolved
+// items to loans and must not hold rejected payments in suspense itself.
+func NewSuspenseService(suspenseRepo suspense.SuspenseMySQLRepositoryInterface, repaymentService repayment.RepaymentServiceInterface, ledgerService ledger.LedgerServiceInterface) suspense.SuspenseServiceInterface {
+	return &suspenseService{
+		suspenseRepo:     suspenseRepo,
+		repaymentService: repaymentService,
+		ledgerService:    ledgerService,
+	}
+}
+
+// HoldPayment records a payment the repayment rejected as an open suspense item and books it on
+// the suspense account. The payment currency falls back to the loan's, then to the default one.
+// A payment whose source reference is already held, even by a concurrent request, returns that item
+// without booking it again.
+func (s *suspenseService) HoldPayment(ctx context.Context, req *models.RepaymentRequest, source string, rejection *models.PaymentRejectionError) (*models.SuspenseItemResponse, error) {
+	paymentCurrency := rejection.Currency
+	if req.Currency != "" || paymentCurrency == "" {
+		paymentCurrency = currency.Normalize(req.Currency)
+	}
+
+	item := &models.SuspenseItem{
+		Source:          source,
+		SourceReference: req.Reference,
+		LoanID:          req.LoanID,
+		Amount:          req.PaymentAmount,
+		Currency:        paymentCurrency,
+		ReasonCode:      rejection.ReasonCode,
+		Reason:          rejection.Message,
+		Status:          models.SuspenseStatusOpen,
+		ReceivedAt:      time.Now(),
+	}
+	err := s.suspenseRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if item.SourceReference != "" {
+			held, err := s.suspenseRepo.GetItemBySourceReference(ctx, source, item.SourceReference)
+			if err != nil {
+				return fmt.Errorf("failed to get suspense item: %v", err)
+			}
+			if held != nil {
+				item = held
+				return nil
+			}
+		}
+		created, err := s.suspenseRepo.CreateItem(ctx, item)
+		if err != nil {
+			return fmt.Errorf("failed to create suspense item: %v", err)
+		}
+		if !created {
+			return nil // held by a concurrent request; item is the held one
+		}
+		if err := s.ledgerService.PostSuspenseReceipt(ctx, item); err != nil {
+			return fmt.Errorf("failed to post suspense receipt: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toItemResponse(item, item.ReceivedAt)
+	return &response, nil
+}
+
+// ListItems returns the suspense items oldest first, filtered by status and reason code
+func (s *suspenseService) ListItems(ctx context.Context, req *models.SuspenseItemListRequest) (*models.SuspenseItemListResponse, error) {
+	cursor, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	var afterID uint
+	if cursor != nil {
+		afterID = cursor.ID
+	}
+
+	// Fetch one item more than the page size to know whether another page follows
+	limit := pagination.Limit(req.Limit)
+	items, err := s.suspenseRepo.GetItems(ctx, req.Status, req.ReasonCode, afterID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suspense items: %v", err)
+	}
+
+	response := &models.SuspenseItemListResponse{}
+	if len(items) > limit {
+		items = items[:limit]
+		response.NextCursor = pagination.EncodeCursor(pagination.Cursor{ID: items[limit-1].ID})
+	}
+	now := time.Now()
+	response.Items = make([]models.SuspenseItemResponse, 0, len(items))
+	for _, item := range items {
+		response.Items = append(response.Items, toItemResponse(item, now))
+	}
+	return response, nil
+}
+
+// ResolveItem takes an open item off the suspense account. APPLY pays the loan with the funds,
+// REFUND records that they were paid back and MOVE moves them to another ledger account. The
+// item stays open when the repayment is rejected.
+func (s *suspenseService) ResolveItem(ctx context.Context, itemID uint, req *models.SuspenseResolveRequest) (*models.SuspenseItemResponse, error) {
+	item, err := s.suspenseRepo.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suspense item: %v", err)
+	}
+	if item == nil {
+		return nil, fmt.Errorf("suspense item not found")
+	}
+	if item.Status != models.SuspenseStatusOpen {
+		return nil, fmt.Errorf("suspense item %d is already resolved", item.ID)
+	}
+
+	resolvedAt := time.Now()
+	resolved := *item
+	resolved.Status = models.SuspenseStatusResolved
+	resolved.Resolution = req.Action
+	resolved.Note = req.Note
+	resolved.ResolvedAt = &resolvedAt
+	resolved.ResolvedBy = req.ResolvedBy
+
+	// The account the funds leave the suspense account to
+	releaseAccount := models.AccountCash
+	switch req.Action {
+	case models.SuspenseResolutionApply:
+		resolved.ResolvedLoanID = req.LoanID
+		if resolved.ResolvedLoanID == "" {
+			resolved.ResolvedLoanID = item.LoanID
+		}
+		if resolved.ResolvedLoanID == "" {
+			return nil, fmt.Errorf("loan_id is required to apply suspense item %d", item.ID)
+		}
+	case models.SuspenseResolutionRefund:
+		resolved.RefundReference = req.RefundReference
+	case models.SuspenseResolutionMove:
+		releaseAccount = strings.ToUpper(req.AccountCode)
+		if err := s.validateMoveAccount(ctx, releaseAccount); err != nil {
+			return nil, err
+		}
+		resolved.AccountCode = releaseAccount
+	default:
+		return nil, fmt.Errorf("invalid resolution action %q", req.Action)
+	}
+
+	err = s.suspenseRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.suspenseRepo.ResolveItem(ctx, &resolved)
+		if err != nil {
+			return fmt.Errorf("failed to resolve suspense item: %v", err)
+		}
+		if !claimed {
+			return fmt.Errorf("suspense item %d is already resolved", item.ID)
+		}
+
+		if req.Action == models.SuspenseResolutionApply {
+			_, err := s.repaymentService.ProcessRepayment(ctx, &models.RepaymentRequest{
+				LoanID:        resolved.ResolvedLoanID,
+				PaymentAmount: item.Amount,
+				Currency:      item.Currency,
+				Reference:     item.SourceReference,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.ledgerService.PostSuspenseRelease(ctx, &resolved, releaseAccount, resolvedAt); err != nil {
+			return fmt.Errorf("failed to post suspense release: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toItemResponse(&resolved, resolvedAt)
+	return &response, nil
+}
+
+// GetAgingReport totals the items open at the end of asOf per currency and age bucket. The age
+// is the number of days from the day the payment was received to asOf.
+func (s *suspenseService) GetAgingReport(ctx context.Context, asOf time.Time) (*models.SuspenseAgingResponse, error) {
+	asOfDate := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.Local)
+	items, err := s.suspenseRepo.GetItemsOpenAt(ctx, asOfDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open suspense items: %v", err)
+	}
+
+	type currencyTotals struct {
+		summary models.SuspenseAgingCurrency
+		amounts []decimal.Decimal
+		total   decimal.Decimal
+	}
+	totals := make(map[string]*currencyTotals)
+	for _, item := range items {
+		itemCurrency := currency.Normalize(item.Currency)
+		group, ok := totals[itemCurrency]
+		if !ok {
+			group = &currencyTotals{
+				summary: models.SuspenseAgingCurrency{Currency: itemCurrency, Buckets: make([]models.SuspenseAgingBucket, len(agingBuckets))},
+				amounts: make([]decimal.Decimal, len(agingBuckets)),
+			}
+			for i, bucket := range agingBuckets {
+				group.summary.Buckets[i] = models.SuspenseAgingBucket{Bucket: bucket.label, MinDays: bucket.minDays}
+				if bucket.maxDays >= 0 {
+					maxDays := bucket.maxDays
+					group.summary.Buckets[i].MaxDays = &maxDays
+				}
+			}
+			totals[itemCurrency] = group
+		}
+
+		age := ageInDays(item.ReceivedAt, asOfDate)
+		index := bucketIndex(age)
+		amount := decimal.NewFromFloat(item.Amount)
+		group.amounts[index] = group.amounts[index].Add(amount)
+		group.total = group.total.Add(amount)
+		group.summary.Buckets[index].ItemCount++
+		group.summary.Buckets[index].Amount = group.amounts[index].InexactFloat64()
+		group.summary.ItemCount++
+		group.summary.Amount = group.total.InexactFloat64()
+		if age > group.summary.OldestAgeDays {
+			group.summary.OldestAgeDays = age
+		}
+	}
+
+	response := &models.SuspenseAgingResponse{
+		AsOf:        asOfDate,
+		GeneratedAt: time.Now(),
+		Currencies:  make([]models.SuspenseAgingCurrency, 0, len(totals)),
+	}
+	for _, group := range totals {
+		response.Currencies = append(response.Currencies, group.summary)
+	}
+	sort.Slice(response.Currencies, func(i, j int) bool {
+		return response.Currencies[i].Currency < response.Currencies[j].Currency
+	})
+	return response, nil
+}
+
+// validateMoveAccount checks that funds can be moved to the account. The suspense account itself,
+// cash (use a refund) and the loan receivable (use apply) are not allowed.
+func (s *suspenseService) validateMoveAccount(ctx context.Context, accountCode string) error {
+	switch accountCode {
+	case models.AccountSuspense, models.AccountCash, models.AccountLoanReceivable:
+		return fmt.Errorf("suspense items cannot be moved to %s", accountCode)
+	}
+	exists, err := s.suspenseRepo.LedgerAccountExists(ctx, accountCode)
+	if err != nil {
+		return fmt.Errorf("failed to get ledger account: %v", err)
+	}
+	if !exists {
+		return fmt.Errorf("ledger account %s not found", accountCode)
+	}
+	return nil
+}
+
+// ageInDays returns the number of calendar days from the day of receivedAt to the day of asOf
+func ageInDays(receivedAt, asOf time.Time) int {
+	received := time.Date(receivedAt.Year(), receivedAt.Month(), receivedAt.Day(), 0, 0, 0, 0, time.Local)
+	day := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.Local)
+	days := int(day.Sub(received).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// bucketIndex returns the aging bucket an age in days falls in
+func bucketIndex(age int) int {
+	for i, bucket := range agingBuckets {
+		if bucket.maxDays < 0 || age <= bucket.maxDays {
+			return i
+		}
+	}
+	return len(agingBuckets) - 1
+}
+
+// toItemResponse builds the response of an item; open items age up to now, resolved ones up to
+// their resolution
+func toItemResponse(item *models.SuspenseItem, now time.Time) models.SuspenseItemResponse {
+	agedUntil := now
+	if item.ResolvedAt != nil {
+		agedUntil = *item.ResolvedAt
+	}
+	return models.SuspenseItemResponse{
+		ID:              item.ID,
+		Source:          item.Source,
+		SourceReference: item.SourceReference,
+		LoanID:          item.LoanID,
+		Amount:          item.Amount,
+		Currency:        item.Currency,
+		ReasonCode:      item.ReasonCode,
+		Reason:          item.Reason,
+		Status:          item.Status,
+		ReceivedAt:      item.ReceivedAt,
+		AgeDays:         ageInDays(item.ReceivedAt, agedUntil),
+		Resolution:      item.Resolution,
+		ResolvedLoanID:  item.ResolvedLoanID,
+		AccountCode:     item.AccountCode,
+		RefundReference: item.RefundReference,
+		Note:            item.Note,
+		ResolvedAt:      item.ResolvedAt,
+		ResolvedBy:      item.ResolvedBy,
+	}
+}
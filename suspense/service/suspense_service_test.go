@@ -0,0 +1,442 @@
+package service
+
+import (
+	ledgerMocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	repaymentMocks "billing-engine/repayment/_mock"
+	mocks "billing-engine/suspense/_mock"
+	"billing-engine/utils/pagination"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestSuspenseService_HoldPayment(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100.00, Reference: "TRF-001"}
+	rejection := &models.PaymentRejectionError{
+		ReasonCode: models.SuspenseReasonWrongAmount,
+		Currency:   "USD",
+		Message:    "payment amount 100.00 does not match required amount 110.00",
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetItemBySourceReference", ctx, models.SuspenseSourceRepaymentAPI, "TRF-001").Return(nil, nil)
+	mockRepo.On("CreateItem", ctx, mock.MatchedBy(func(item *models.SuspenseItem) bool {
+		return item.Source == models.SuspenseSourceRepaymentAPI && item.SourceReference == "TRF-001" &&
+			item.LoanID == "loan_123" && item.Amount == 100.00 && item.Currency == "USD" &&
+			item.ReasonCode == models.SuspenseReasonWrongAmount && item.Status == models.SuspenseStatusOpen
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.SuspenseItem).ID = 7
+	}).Return(true, nil)
+	mockLedger.On("PostSuspenseReceipt", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(nil)
+
+	// Execute
+	result, err := service.HoldPayment(ctx, req, models.SuspenseSourceRepaymentAPI, rejection)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, "USD", result.Currency)
+	assert.Equal(t, rejection.Message, result.Reason)
+	assert.Equal(t, 0, result.AgeDays)
+}
+
+func TestSuspenseService_HoldPayment_AlreadyHeld(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100.00, Reference: "TRF-001"}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100.00 does not match required amount 110.00"}
+	held := &models.SuspenseItem{
+		ID:              7,
+		Source:          models.SuspenseSourceRepaymentAPI,
+		SourceReference: "TRF-001",
+		LoanID:          "loan_123",
+		Amount:          100.00,
+		Currency:        models.CurrencyIDR,
+		ReasonCode:      models.SuspenseReasonWrongAmount,
+		Status:          models.SuspenseStatusOpen,
+		ReceivedAt:      time.Now(),
+	}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetItemBySourceReference", ctx, models.SuspenseSourceRepaymentAPI, "TRF-001").Return(held, nil)
+
+	// Execute
+	result, err := service.HoldPayment(ctx, req, models.SuspenseSourceRepaymentAPI, rejection)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	mockRepo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	mockLedger.AssertNotCalled(t, "PostSuspenseReceipt", mock.Anything, mock.Anything)
+}
+
+func TestSuspenseService_HoldPayment_HeldConcurrently(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	req := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100.00, Reference: "TRF-001"}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100.00 does not match required amount 110.00"}
+
+	// Mock repository calls; another request holds the payment between the lookup and the insert
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("GetItemBySourceReference", ctx, models.SuspenseSourceRepaymentAPI, "TRF-001").Return(nil, nil)
+	mockRepo.On("CreateItem", ctx, mock.AnythingOfType("*models.SuspenseItem")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.SuspenseItem).ID = 8
+	}).Return(false, nil)
+
+	// Execute
+	result, err := service.HoldPayment(ctx, req, models.SuspenseSourceRepaymentAPI, rejection)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(8), result.ID)
+	mockLedger.AssertNotCalled(t, "PostSuspenseReceipt", mock.Anything, mock.Anything)
+}
+
+func TestSuspenseService_HoldPayment_PaymentCurrency(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("CreateItem", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(true, nil)
+	mockLedger.On("PostSuspenseReceipt", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(nil)
+
+	// Execute
+	mismatch, err := service.HoldPayment(ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100.00, Currency: "usd"}, models.SuspenseSourceRepaymentAPI,
+		&models.PaymentRejectionError{ReasonCode: models.SuspenseReasonCurrencyMismatch, Currency: "IDR", Message: "payment currency USD does not match loan currency IDR"})
+	assert.NoError(t, err)
+	unknown, err := service.HoldPayment(ctx, &models.RepaymentRequest{LoanID: "loan_404", PaymentAmount: 110000.00}, models.SuspenseSourceRepaymentAPI,
+		&models.PaymentRejectionError{ReasonCode: models.SuspenseReasonUnknownLoan, Message: "loan not found"})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "USD", mismatch.Currency)
+	assert.Equal(t, "IDR", unknown.Currency)
+	assert.Equal(t, models.SuspenseReasonUnknownLoan, unknown.ReasonCode)
+}
+
+func TestSuspenseService_HoldPayment_LedgerError(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("CreateItem", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(true, nil)
+	mockLedger.On("PostSuspenseReceipt", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(errors.New("journal entry does not balance"))
+
+	// Execute
+	result, err := service.HoldPayment(ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100.00}, models.SuspenseSourceRepaymentAPI,
+		&models.PaymentRejectionError{ReasonCode: models.SuspenseReasonClosedLoan, Message: "loan is cancelled"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to post suspense receipt")
+}
+
+func TestSuspenseService_ListItems(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	items := []*models.SuspenseItem{
+		{ID: 11, Status: models.SuspenseStatusOpen, ReasonCode: models.SuspenseReasonWrongAmount, ReceivedAt: time.Now().AddDate(0, 0, -3)},
+		{ID: 12, Status: models.SuspenseStatusOpen, ReasonCode: models.SuspenseReasonWrongAmount, ReceivedAt: time.Now()},
+		{ID: 14, Status: models.SuspenseStatusOpen, ReasonCode: models.SuspenseReasonWrongAmount, ReceivedAt: time.Now()},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetItems", ctx, models.SuspenseStatusOpen, models.SuspenseReasonWrongAmount, uint(10), 3).Return(items, nil)
+
+	// Execute
+	result, err := service.ListItems(ctx, &models.SuspenseItemListRequest{
+		Status:     models.SuspenseStatusOpen,
+		ReasonCode: models.SuspenseReasonWrongAmount,
+		Limit:      2,
+		Cursor:     pagination.EncodeCursor(pagination.Cursor{ID: 10}),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, 3, result.Items[0].AgeDays)
+	assert.Equal(t, pagination.EncodeCursor(pagination.Cursor{ID: 12}), result.NextCursor)
+}
+
+func TestSuspenseService_ResolveItem_Apply(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	item := &models.SuspenseItem{ID: 7, LoanID: "loan_404", Amount: 110000.00, Currency: models.CurrencyIDR, SourceReference: "TRF-001", Status: models.SuspenseStatusOpen}
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(item, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("ResolveItem", ctx, mock.MatchedBy(func(resolved *models.SuspenseItem) bool {
+		return resolved.ID == 7 && resolved.Status == models.SuspenseStatusResolved &&
+			resolved.Resolution == models.SuspenseResolutionApply && resolved.ResolvedLoanID == "loan_123" &&
+			resolved.ResolvedBy == "ops@example.com" && resolved.ResolvedAt != nil
+	})).Return(true, nil)
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00, Currency: "IDR", Reference: "TRF-001"}).Return(&models.RepaymentResponse{}, nil)
+	mockLedger.On("PostSuspenseRelease", ctx, mock.AnythingOfType("*models.SuspenseItem"), models.AccountCash, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{
+		Action:     models.SuspenseResolutionApply,
+		LoanID:     "loan_123",
+		ResolvedBy: "ops@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.SuspenseStatusResolved, result.Status)
+	assert.Equal(t, models.SuspenseResolutionApply, result.Resolution)
+	assert.Equal(t, "loan_123", result.ResolvedLoanID)
+	assert.Equal(t, models.SuspenseStatusOpen, item.Status)
+}
+
+func TestSuspenseService_ResolveItem_ApplyRejected(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	item := &models.SuspenseItem{ID: 7, LoanID: "loan_123", Amount: 100000.00, Currency: models.CurrencyIDR, Status: models.SuspenseStatusOpen}
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(item, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("ResolveItem", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(true, nil)
+	mockRepayment.On("ProcessRepayment", ctx, mock.MatchedBy(func(req *models.RepaymentRequest) bool {
+		return req.LoanID == "loan_123"
+	})).Return(nil, &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100000.00 does not match required amount 110000.00"})
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{Action: models.SuspenseResolutionApply, ResolvedBy: "ops@example.com"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "does not match required amount")
+	mockLedger.AssertNotCalled(t, "PostSuspenseRelease", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSuspenseService_ResolveItem_ApplyWithoutLoan(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(&models.SuspenseItem{ID: 7, Status: models.SuspenseStatusOpen}, nil)
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{Action: models.SuspenseResolutionApply, ResolvedBy: "ops@example.com"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "loan_id is required to apply suspense item 7", err.Error())
+}
+
+func TestSuspenseService_ResolveItem_Refund(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(&models.SuspenseItem{ID: 7, Amount: 50000.00, Status: models.SuspenseStatusOpen}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("ResolveItem", ctx, mock.MatchedBy(func(resolved *models.SuspenseItem) bool {
+		return resolved.Resolution == models.SuspenseResolutionRefund && resolved.RefundReference == "RFD-001"
+	})).Return(true, nil)
+	mockLedger.On("PostSuspenseRelease", ctx, mock.AnythingOfType("*models.SuspenseItem"), models.AccountCash, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{
+		Action:          models.SuspenseResolutionRefund,
+		RefundReference: "RFD-001",
+		ResolvedBy:      "ops@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "RFD-001", result.RefundReference)
+	mockRepayment.AssertNotCalled(t, "ProcessRepayment", mock.Anything, mock.Anything)
+}
+
+func TestSuspenseService_ResolveItem_Move(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(&models.SuspenseItem{ID: 7, Amount: 5000.00, Status: models.SuspenseStatusOpen}, nil)
+	mockRepo.On("LedgerAccountExists", ctx, models.AccountPenaltyIncome).Return(true, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("ResolveItem", ctx, mock.MatchedBy(func(resolved *models.SuspenseItem) bool {
+		return resolved.Resolution == models.SuspenseResolutionMove && resolved.AccountCode == models.AccountPenaltyIncome
+	})).Return(true, nil)
+	mockLedger.On("PostSuspenseRelease", ctx, mock.AnythingOfType("*models.SuspenseItem"), models.AccountPenaltyIncome, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{
+		Action:      models.SuspenseResolutionMove,
+		AccountCode: "penalty_income",
+		ResolvedBy:  "ops@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountPenaltyIncome, result.AccountCode)
+}
+
+func TestSuspenseService_ResolveItem_MoveInvalidAccount(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(&models.SuspenseItem{ID: 7, Status: models.SuspenseStatusOpen}, nil)
+	mockRepo.On("LedgerAccountExists", ctx, "OTHER_INCOME").Return(false, nil)
+
+	// Execute
+	_, receivableErr := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{Action: models.SuspenseResolutionMove, AccountCode: models.AccountLoanReceivable, ResolvedBy: "ops@example.com"})
+	_, unknownErr := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{Action: models.SuspenseResolutionMove, AccountCode: "OTHER_INCOME", ResolvedBy: "ops@example.com"})
+
+	// Assert
+	assert.Equal(t, "suspense items cannot be moved to LOAN_RECEIVABLE", receivableErr.Error())
+	assert.Equal(t, "ledger account OTHER_INCOME not found", unknownErr.Error())
+}
+
+func TestSuspenseService_ResolveItem_AlreadyResolved(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(&models.SuspenseItem{ID: 7, Status: models.SuspenseStatusOpen}, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(withinTransaction)
+	mockRepo.On("ResolveItem", ctx, mock.AnythingOfType("*models.SuspenseItem")).Return(false, nil)
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{Action: models.SuspenseResolutionRefund, RefundReference: "RFD-001", ResolvedBy: "ops@example.com"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "suspense item 7 is already resolved", err.Error())
+	mockLedger.AssertNotCalled(t, "PostSuspenseRelease", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSuspenseService_ResolveItem_NotFound(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetItemByID", ctx, uint(7)).Return(nil, nil)
+
+	// Execute
+	result, err := service.ResolveItem(ctx, 7, &models.SuspenseResolveRequest{Action: models.SuspenseResolutionRefund, RefundReference: "RFD-001", ResolvedBy: "ops@example.com"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "suspense item not found", err.Error())
+}
+
+func TestSuspenseService_GetAgingReport(t *testing.T) {
+	mockRepo := mocks.NewSuspenseMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewSuspenseService(mockRepo, mockRepayment, mockLedger)
+	ctx := context.Background()
+
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+	items := []*models.SuspenseItem{
+		{ID: 1, Currency: models.CurrencyIDR, Amount: 110000.00, ReceivedAt: time.Date(2026, 3, 31, 15, 0, 0, 0, time.Local)}, // 0 days
+		{ID: 2, Currency: models.CurrencyIDR, Amount: 55000.00, ReceivedAt: time.Date(2026, 3, 24, 9, 0, 0, 0, time.Local)},   // 7 days
+		{ID: 3, Currency: models.CurrencyIDR, Amount: 220000.00, ReceivedAt: time.Date(2026, 3, 23, 9, 0, 0, 0, time.Local)},  // 8 days
+		{ID: 4, Currency: models.CurrencyIDR, Amount: 1000.00, ReceivedAt: time.Date(2025, 12, 1, 9, 0, 0, 0, time.Local)},    // 120 days
+		{ID: 5, Currency: "USD", Amount: 12.50, ReceivedAt: time.Date(2026, 2, 15, 9, 0, 0, 0, time.Local)},                   // 44 days
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetItemsOpenAt", ctx, time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)).Return(items, nil)
+
+	// Execute
+	result, err := service.GetAgingReport(ctx, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, asOf, result.AsOf)
+	assert.Len(t, result.Currencies, 2)
+
+	idr := result.Currencies[0]
+	assert.Equal(t, models.CurrencyIDR, idr.Currency)
+	assert.Equal(t, 4, idr.ItemCount)
+	assert.Equal(t, 386000.00, idr.Amount)
+	assert.Equal(t, 120, idr.OldestAgeDays)
+	assert.Len(t, idr.Buckets, 5)
+	assert.Equal(t, "0-7", idr.Buckets[0].Bucket)
+	assert.Equal(t, 2, idr.Buckets[0].ItemCount)
+	assert.Equal(t, 165000.00, idr.Buckets[0].Amount)
+	assert.Equal(t, 1, idr.Buckets[1].ItemCount)
+	assert.Equal(t, 0, idr.Buckets[2].ItemCount)
+	assert.Equal(t, 1000.00, idr.Buckets[4].Amount)
+	assert.Nil(t, idr.Buckets[4].MaxDays)
+
+	usd := result.Currencies[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Equal(t, 1, usd.Buckets[2].ItemCount)
+	assert.Equal(t, 12.50, usd.Buckets[2].Amount)
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/tax"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaxHandler struct {
+	taxService tax.TaxServiceInterface
+	middleware middlewares.GoMiddlewareInterface
+}
+
+// NewTaxHandler creates a new tax handler instance
+func NewTaxHandler(e *echo.Echo, taxService tax.TaxServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &TaxHandler{
+		taxService: taxService,
+		middleware: middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.GET("/reports/tax", handler.GetTaxReport)
+}
+
+func (h *TaxHandler) GetTaxReport(c echo.Context) error {
+	if c.QueryParam("from") == "" || c.QueryParam("to") == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "From and to dates are required",
+		})
+	}
+
+	from, fromErr := time.ParseInLocation("2006-01-02", c.QueryParam("from"), time.Local)
+	to, toErr := time.ParseInLocation("2006-01-02", c.QueryParam("to"), time.Local)
+	if fromErr != nil || toErr != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Dates must use the YYYY-MM-DD format",
+		})
+	}
+
+	response, err := h.taxService.GetTaxReport(c.Request().Context(), from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.TaxReportSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
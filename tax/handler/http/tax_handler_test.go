@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"billing-engine/global"
+	"billing-engine/models"
+	mocks "billing-engine/tax/_mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestTaxHandler_GetTaxReport_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewTaxServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &TaxHandler{
+		taxService: mockService,
+		middleware: mockMiddleware,
+	}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+	expectedResponse := &models.TaxReportResponse{
+		TaxType: models.TaxTypePPN,
+		From:    from,
+		To:      to,
+		Currencies: []models.TaxCurrencyResponse{
+			{
+				Currency:      models.CurrencyIDR,
+				TaxableAmount: 150000.00,
+				TaxAmount:     16500.00,
+				Periods: []models.TaxPeriodResponse{
+					{Period: "2026-01", TaxableAmount: 150000.00, TaxAmount: 16500.00},
+				},
+			},
+		},
+	}
+
+	mockService.On("GetTaxReport", mock.Anything, from, to).Return(expectedResponse, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/tax?from=2026-01-01&to=2026-01-31", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetTaxReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.TaxReportSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Len(t, response.Data.Currencies, 1)
+	assert.Equal(t, 16500.00, response.Data.Currencies[0].TaxAmount)
+	assert.Len(t, response.Data.Currencies[0].Periods, 1)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTaxHandler_GetTaxReport_MissingDates(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewTaxServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &TaxHandler{
+		taxService: mockService,
+		middleware: mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/tax?from=2026-01-01", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetTaxReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "From and to dates are required", response.Message)
+}
+
+func TestTaxHandler_GetTaxReport_InvalidDateFormat(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewTaxServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &TaxHandler{
+		taxService: mockService,
+		middleware: mockMiddleware,
+	}
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/tax?from=01-01-2026&to=2026-01-31", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetTaxReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response global.BadResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "Dates must use the YYYY-MM-DD format", response.Message)
+}
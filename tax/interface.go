@@ -0,0 +1,18 @@
+package tax
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// TaxMySQLRepositoryInterface defines the interface for tax repository
+type TaxMySQLRepositoryInterface interface {
+	GetCollectedTaxTotals(ctx context.Context, from, to time.Time) ([]*models.TaxCollectionTotal, error)
+}
+
+// TaxServiceInterface defines the interface for tax service
+type TaxServiceInterface interface {
+	TaxCharge(chargeType string, taxableAmount float64, currencyCode string) *models.TaxLine
+	GetTaxReport(ctx context.Context, from, to time.Time) (*models.TaxReportResponse, error)
+}
@@ -0,0 +1,36 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/tax"
+
+	"gorm.io/gorm"
+)
+
+type taxMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewTaxMySQLRepository creates a new tax repository instance
+func NewTaxMySQLRepository(db *gorm.DB) tax.TaxMySQLRepositoryInterface {
+	return &taxMySQLRepository{db: db}
+}
+
+// GetCollectedTaxTotals sums the tax collected in [from, to) per currency, month and charge type
+func (r *taxMySQLRepository) GetCollectedTaxTotals(ctx context.Context, from, to time.Time) ([]*models.TaxCollectionTotal, error) {
+	var totals []*models.TaxCollectionTotal
+	err := r.db.WithContext(ctx).
+		Model(&models.TaxLine{}).
+		Select("currency, DATE_FORMAT(collected_at, '%Y-%m') AS period, charge_type, COALESCE(SUM(taxable_amount), 0) AS taxable_amount, COALESCE(SUM(tax_amount), 0) AS tax_amount").
+		Where("status = ? AND collected_at >= ? AND collected_at < ?", models.TaxStatusCollected, from, to).
+		Group("currency, period, charge_type").
+		Order("currency ASC, period ASC, charge_type ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"billing-engine/models"
+	"billing-engine/tax"
+	"billing-engine/utils/currency"
+
+	"github.com/shopspring/decimal"
+)
+
+// taxableChargeTypes lists the charge types PPN can be configured on
+var taxableChargeTypes = []string{models.FeeTypeAdmin, models.FeeTypeProvision, models.ChargeTypePenalty}
+
+type taxService struct {
+	taxRepo      tax.TaxMySQLRepositoryInterface
+	rate         decimal.Decimal
+	taxedCharges map[string]bool
+}
+
+// NewTaxService creates a new tax service instance charging PPN at the given rate on the given charge types
+func NewTaxService(taxRepo tax.TaxMySQLRepositoryInterface, rate float64, chargeTypes []string) tax.TaxServiceInterface {
+	taxedCharges := make(map[string]bool, len(chargeTypes))
+	for _, chargeType := range chargeTypes {
+		taxedCharges[chargeType] = true
+	}
+	return &taxService{
+		taxRepo:      taxRepo,
+		rate:         decimal.NewFromFloat(rate),
+		taxedCharges: taxedCharges,
+	}
+}
+
+// ParseTaxableChargeTypes parses a comma separated charge type list such as "ADMIN,PROVISION,PENALTY".
+// An empty value taxes nothing.
+func ParseTaxableChargeTypes(value string) ([]string, error) {
+	chargeTypes := make([]string, 0)
+	for _, part := range strings.Split(value, ",") {
+		chargeType := strings.ToUpper(strings.TrimSpace(part))
+		if chargeType == "" {
+			continue
+		}
+		if !isTaxableChargeType(chargeType) {
+			return nil, fmt.Errorf("invalid taxable charge type %q", part)
+		}
+		chargeTypes = append(chargeTypes, chargeType)
+	}
+	return chargeTypes, nil
+}
+
+func isTaxableChargeType(chargeType string) bool {
+	for _, taxable := range taxableChargeTypes {
+		if taxable == chargeType {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxCharge returns the pending tax line for a charge in the given currency, or nil when the charge
+// type is not taxed. The line is not linked to a loan yet.
+func (s *taxService) TaxCharge(chargeType string, taxableAmount float64, currencyCode string) *models.TaxLine {
+	if !s.taxedCharges[chargeType] || taxableAmount <= 0 || !s.rate.IsPositive() {
+		return nil
+	}
+
+	taxable := currency.Round(decimal.NewFromFloat(taxableAmount), currencyCode)
+	return &models.TaxLine{
+		TaxType:       models.TaxTypePPN,
+		ChargeType:    chargeType,
+		TaxableAmount: taxable.InexactFloat64(),
+		TaxRate:       s.rate.InexactFloat64(),
+		TaxAmount:     currency.Round(taxable.Mul(s.rate), currencyCode).InexactFloat64(),
+		Currency:      currency.Normalize(currencyCode),
+		Status:        models.StatusPending,
+		CreatedBy:     "system",
+		UpdatedBy:     "system",
+	}
+}
+
+// GetTaxReport reports the tax collected per currency and month between the from and to dates,
+// both inclusive
+func (s *taxService) GetTaxReport(ctx context.Context, from, to time.Time) (*models.TaxReportResponse, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+
+	totals, err := s.taxRepo.GetCollectedTaxTotals(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collected tax: %v", err)
+	}
+
+	report := &models.TaxReportResponse{
+		TaxType:     models.TaxTypePPN,
+		From:        from,
+		To:          to,
+		GeneratedAt: time.Now(),
+		Currencies:  make([]models.TaxCurrencyResponse, 0),
+	}
+
+	// Totals are ordered by currency and period, so each currency's periods and each period's
+	// charges are contiguous
+	var currencyTaxable, currencyTax, periodTaxable, periodTax decimal.Decimal
+	for _, total := range totals {
+		if len(report.Currencies) == 0 || report.Currencies[len(report.Currencies)-1].Currency != total.Currency {
+			report.Currencies = append(report.Currencies, models.TaxCurrencyResponse{
+				Currency: total.Currency,
+				Periods:  make([]models.TaxPeriodResponse, 0),
+			})
+			currencyTaxable, currencyTax = decimal.Zero, decimal.Zero
+		}
+		currencyReport := &report.Currencies[len(report.Currencies)-1]
+
+		if len(currencyReport.Periods) == 0 || currencyReport.Periods[len(currencyReport.Periods)-1].Period != total.Period {
+			currencyReport.Periods = append(currencyReport.Periods, models.TaxPeriodResponse{Period: total.Period})
+			periodTaxable, periodTax = decimal.Zero, decimal.Zero
+		}
+		period := &currencyReport.Periods[len(currencyReport.Periods)-1]
+		period.Charges = append(period.Charges, models.TaxChargeResponse{
+			ChargeType:    total.ChargeType,
+			TaxableAmount: total.TaxableAmount,
+			TaxAmount:     total.TaxAmount,
+		})
+
+		periodTaxable = periodTaxable.Add(decimal.NewFromFloat(total.TaxableAmount))
+		periodTax = periodTax.Add(decimal.NewFromFloat(total.TaxAmount))
+		period.TaxableAmount = periodTaxable.InexactFloat64()
+		period.TaxAmount = periodTax.InexactFloat64()
+
+		currencyTaxable = currencyTaxable.Add(decimal.NewFromFloat(total.TaxableAmount))
+		currencyTax = currencyTax.Add(decimal.NewFromFloat(total.TaxAmount))
+		currencyReport.TaxableAmount = currencyTaxable.InexactFloat64()
+		currencyReport.TaxAmount = currencyTax.InexactFloat64()
+	}
+
+	return report, nil
+}
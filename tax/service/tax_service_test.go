@@ -0,0 +1,155 @@
+package service
+
+import (
+	"billing-engine/models"
+	mocks "billing-engine/tax/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxService_TaxCharge_TaxedCharge(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0.11, []string{models.FeeTypeAdmin, models.ChargeTypePenalty})
+
+	// Execute
+	taxLine := service.TaxCharge(models.FeeTypeAdmin, 150000.00, models.CurrencyIDR)
+
+	// Assert
+	assert.NotNil(t, taxLine)
+	assert.Equal(t, models.TaxTypePPN, taxLine.TaxType)
+	assert.Equal(t, models.FeeTypeAdmin, taxLine.ChargeType)
+	assert.Equal(t, 150000.00, taxLine.TaxableAmount)
+	assert.Equal(t, 0.11, taxLine.TaxRate)
+	assert.Equal(t, 16500.00, taxLine.TaxAmount)
+	assert.Equal(t, models.CurrencyIDR, taxLine.Currency)
+	assert.Equal(t, models.StatusPending, taxLine.Status)
+}
+
+func TestTaxService_TaxCharge_RoundsTaxAmount(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0.11, []string{models.FeeTypeProvision})
+
+	// Execute
+	taxLine := service.TaxCharge(models.FeeTypeProvision, 41666.66, models.CurrencyIDR)
+
+	// Assert
+	assert.NotNil(t, taxLine)
+	assert.Equal(t, 4583.33, taxLine.TaxAmount) // 41666.66 * 11% = 4583.3326
+}
+
+func TestTaxService_TaxCharge_UntaxedCharge(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0.11, []string{models.FeeTypeAdmin})
+
+	// Execute & Assert
+	assert.Nil(t, service.TaxCharge(models.FeeTypeProvision, 30000.00, models.CurrencyIDR))
+	assert.Nil(t, service.TaxCharge(models.FeeTypeAdmin, 0, models.CurrencyIDR))
+}
+
+func TestTaxService_TaxCharge_ZeroRate(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0, []string{models.FeeTypeAdmin})
+
+	// Execute & Assert
+	assert.Nil(t, service.TaxCharge(models.FeeTypeAdmin, 150000.00, models.CurrencyIDR))
+}
+
+func TestParseTaxableChargeTypes(t *testing.T) {
+	chargeTypes, err := ParseTaxableChargeTypes(" admin, PENALTY ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.FeeTypeAdmin, models.ChargeTypePenalty}, chargeTypes)
+
+	chargeTypes, err = ParseTaxableChargeTypes("")
+	assert.NoError(t, err)
+	assert.Empty(t, chargeTypes)
+
+	_, err = ParseTaxableChargeTypes("ADMIN,INTEREST")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid taxable charge type")
+}
+
+func TestTaxService_GetTaxReport_Success(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0.11, []string{models.FeeTypeAdmin, models.ChargeTypePenalty})
+	ctx := context.Background()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("GetCollectedTaxTotals", ctx, from, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)).Return([]*models.TaxCollectionTotal{
+		{Currency: models.CurrencyIDR, Period: "2026-01", ChargeType: models.FeeTypeAdmin, TaxableAmount: 300000.00, TaxAmount: 33000.00},
+		{Currency: models.CurrencyIDR, Period: "2026-01", ChargeType: models.ChargeTypePenalty, TaxableAmount: 5000.00, TaxAmount: 550.00},
+		{Currency: models.CurrencyIDR, Period: "2026-02", ChargeType: models.FeeTypeAdmin, TaxableAmount: 150000.00, TaxAmount: 16500.00},
+		{Currency: "USD", Period: "2026-01", ChargeType: models.FeeTypeAdmin, TaxableAmount: 20.00, TaxAmount: 2.20},
+		{Currency: "USD", Period: "2026-02", ChargeType: models.FeeTypeAdmin, TaxableAmount: 10.00, TaxAmount: 1.10},
+	}, nil)
+
+	// Execute
+	report, err := service.GetTaxReport(ctx, from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.TaxTypePPN, report.TaxType)
+	assert.Len(t, report.Currencies, 2)
+
+	// Amounts are only added up within a currency
+	idr := report.Currencies[0]
+	assert.Equal(t, models.CurrencyIDR, idr.Currency)
+	assert.Len(t, idr.Periods, 2)
+	assert.Equal(t, "2026-01", idr.Periods[0].Period)
+	assert.Len(t, idr.Periods[0].Charges, 2)
+	assert.Equal(t, 305000.00, idr.Periods[0].TaxableAmount)
+	assert.Equal(t, 33550.00, idr.Periods[0].TaxAmount)
+	assert.Equal(t, "2026-02", idr.Periods[1].Period)
+	assert.Equal(t, 16500.00, idr.Periods[1].TaxAmount)
+	assert.Equal(t, 455000.00, idr.TaxableAmount)
+	assert.Equal(t, 50050.00, idr.TaxAmount)
+
+	usd := report.Currencies[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Len(t, usd.Periods, 2)
+	assert.Equal(t, 2.20, usd.Periods[0].TaxAmount)
+	assert.Equal(t, 30.00, usd.TaxableAmount)
+	assert.Equal(t, 3.30, usd.TaxAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaxService_GetTaxReport_InvalidRange(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0.11, nil)
+	ctx := context.Background()
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+
+	// Execute
+	report, err := service.GetTaxReport(ctx, from, from.AddDate(0, 0, -1))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	assert.Contains(t, err.Error(), "to date must not be before from date")
+}
+
+func TestTaxService_GetTaxReport_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewTaxMySQLRepositoryInterface(t)
+	service := NewTaxService(mockRepo, 0.11, nil)
+	ctx := context.Background()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+
+	// Mock repository calls
+	mockRepo.On("GetCollectedTaxTotals", ctx, from, from.AddDate(0, 0, 1)).Return(nil, errors.New("database error"))
+
+	// Execute
+	report, err := service.GetTaxReport(ctx, from, from)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	assert.Contains(t, err.Error(), "failed to get collected tax")
+
+	mockRepo.AssertExpectations(t)
+}
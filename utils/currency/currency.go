@@ -0,0 +1,66 @@
+package currency
+
+import (
+	"strings"
+
+	"billing-engine/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// minorUnits lists the ISO 4217 currencies whose minor unit is not 2 decimal places
+var minorUnits = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Normalize upper-cases the currency code; an empty code is the default currency IDR
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return models.CurrencyIDR
+	}
+	return code
+}
+
+// MinorUnits returns the number of decimal places amounts in the currency are kept in
+func MinorUnits(code string) int32 {
+	if places, ok := minorUnits[Normalize(code)]; ok {
+		return places
+	}
+	return 2
+}
+
+// IsSupported reports whether amounts in the currency fit the 2 decimal places amounts are stored with
+func IsSupported(code string) bool {
+	return MinorUnits(code) <= 2
+}
+
+// Round rounds the amount half away from zero to the currency's minor unit
+func Round(amount decimal.Decimal, code string) decimal.Decimal {
+	return amount.Round(MinorUnits(code))
+}
+
+// RoundDown truncates the amount to the currency's minor unit
+func RoundDown(amount decimal.Decimal, code string) decimal.Decimal {
+	return amount.RoundDown(MinorUnits(code))
+}
+
+// IsRounded reports whether the amount has no more decimal places than the currency allows
+func IsRounded(amount decimal.Decimal, code string) bool {
+	return amount.Equal(Round(amount, code))
+}
+
+// Split splits the amount into equal parts rounded down to the currency's minor unit, the
+// remainder on the last part
+func Split(amount decimal.Decimal, parts int, code string) []decimal.Decimal {
+	part := RoundDown(amount.Div(decimal.NewFromInt(int64(parts))), code)
+	result := make([]decimal.Decimal, parts)
+	for i := range result {
+		result[i] = part
+	}
+	result[parts-1] = amount.Sub(part.Mul(decimal.NewFromInt(int64(parts - 1))))
+	return result
+}
@@ -0,0 +1,49 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "IDR", Normalize(""))
+	assert.Equal(t, "USD", Normalize(" usd "))
+}
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, int32(2), MinorUnits("IDR"))
+	assert.Equal(t, int32(2), MinorUnits("USD"))
+	assert.Equal(t, int32(0), MinorUnits("JPY"))
+	assert.Equal(t, int32(3), MinorUnits("kwd"))
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported("IDR"))
+	assert.True(t, IsSupported("JPY"))
+	assert.False(t, IsSupported("KWD"))
+}
+
+func TestRound(t *testing.T) {
+	amount := decimal.RequireFromString("1234.5678")
+
+	assert.Equal(t, "1234.57", Round(amount, "IDR").String())
+	assert.Equal(t, "1235", Round(amount, "JPY").String())
+	assert.Equal(t, "1234.568", Round(amount, "KWD").String())
+	assert.Equal(t, "1234", RoundDown(amount, "JPY").String())
+}
+
+func TestIsRounded(t *testing.T) {
+	assert.True(t, IsRounded(decimal.RequireFromString("100.25"), "USD"))
+	assert.False(t, IsRounded(decimal.RequireFromString("100.25"), "JPY"))
+	assert.True(t, IsRounded(decimal.RequireFromString("100"), "JPY"))
+}
+
+func TestSplit(t *testing.T) {
+	parts := Split(decimal.RequireFromString("100.00"), 3, "IDR")
+	assert.Equal(t, []string{"33.33", "33.33", "33.34"}, []string{parts[0].String(), parts[1].String(), parts[2].String()})
+
+	parts = Split(decimal.RequireFromString("100"), 3, "JPY")
+	assert.Equal(t, []string{"33", "33", "34"}, []string{parts[0].String(), parts[1].String(), parts[2].String()})
+}
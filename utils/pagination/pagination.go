@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Cursor marks the last item of a page. Value holds the sort key of that item when the list is
+// not sorted by ID, so the next page can continue after the (Value, ID) pair. Sort records the
+// ordering the cursor was issued for so it is not replayed against another one.
+type Cursor struct {
+	ID    uint   `json:"id"`
+	Value string `json:"value,omitempty"`
+	Sort  string `json:"sort,omitempty"`
+}
+
+// EncodeCursor returns the opaque cursor string handed out to API clients
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor string; an empty string is the first page and returns nil
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// Limit returns the page size to use for the requested limit
+func Limit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	if limit > MaxLimit {
+		return MaxLimit
+	}
+	return limit
+}
@@ -0,0 +1,38 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	encoded := EncodeCursor(Cursor{ID: 42, Value: "2025-08-31"})
+
+	cursor, err := DecodeCursor(encoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(42), cursor.ID)
+	assert.Equal(t, "2025-08-31", cursor.Value)
+}
+
+func TestDecodeCursor_Empty(t *testing.T) {
+	cursor, err := DecodeCursor("")
+
+	assert.NoError(t, err)
+	assert.Nil(t, cursor)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	_, err := DecodeCursor("not a cursor")
+	assert.Error(t, err)
+
+	_, err = DecodeCursor(EncodeCursor(Cursor{}))
+	assert.Error(t, err)
+}
+
+func TestLimit(t *testing.T) {
+	assert.Equal(t, DefaultLimit, Limit(0))
+	assert.Equal(t, 5, Limit(5))
+	assert.Equal(t, MaxLimit, Limit(500))
+}
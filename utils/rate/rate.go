@@ -0,0 +1,107 @@
+package rate
+
+import (
+	"fmt"
+	"math"
+
+	"billing-engine/models"
+)
+
+const (
+	// tolerance is the precision the periodic rate is solved to
+	tolerance     = 1e-12
+	maxIterations = 200
+)
+
+// PeriodsPerYear returns the number of installment periods in a year for the installment unit
+func PeriodsPerYear(installmentUnit string) int {
+	if installmentUnit == models.InstallmentUnitWeek {
+		return 52
+	}
+	return 12 // month
+}
+
+// PeriodicIRR solves the rate per installment period at which the payments, made at the end of
+// periods 1..n, are worth the amount received at period 0: the internal rate of return of the loan.
+// It uses Newton's method and falls back to bisection when a step leaves the bracket. An error is
+// returned when no rate above -99% solves the cash flows or the rate has not converged within
+// maxIterations.
+func PeriodicIRR(amount float64, payments []float64) (float64, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("amount received must be positive")
+	}
+	totalPayments := 0.0
+	for _, payment := range payments {
+		if payment < 0 {
+			return 0, fmt.Errorf("payments must not be negative")
+		}
+		totalPayments += payment
+	}
+	if totalPayments <= 0 {
+		return 0, fmt.Errorf("payments must add up to a positive amount")
+	}
+
+	// The present value falls as the rate grows: bracket the root between a rate where it is above
+	// the amount and one where it is below
+	low, high := -0.99, 1.0
+	if presentValue(payments, low) < amount {
+		return 0, fmt.Errorf("rate did not converge")
+	}
+	for presentValue(payments, high) > amount {
+		high *= 2
+		if high > 1e6 {
+			return 0, fmt.Errorf("rate did not converge")
+		}
+	}
+
+	r := (low + high) / 2
+	for i := 0; i < maxIterations; i++ {
+		value, derivative := presentValueAndDerivative(payments, r)
+		diff := value - amount
+		if math.Abs(diff) < tolerance*amount {
+			return r, nil
+		}
+		if diff > 0 {
+			low = r
+		} else {
+			high = r
+		}
+
+		next := r - diff/derivative
+		if derivative == 0 || next <= low || next >= high {
+			next = (low + high) / 2
+		}
+		if math.Abs(next-r) < tolerance {
+			return next, nil
+		}
+		r = next
+	}
+	return 0, fmt.Errorf("rate did not converge")
+}
+
+// AnnualPercentageRate is the nominal yearly rate of a periodic rate
+func AnnualPercentageRate(periodicRate float64, periodsPerYear int) float64 {
+	return periodicRate * float64(periodsPerYear)
+}
+
+// EffectiveAnnualRate is the yearly rate of a periodic rate compounded every period
+func EffectiveAnnualRate(periodicRate float64, periodsPerYear int) float64 {
+	return math.Pow(1+periodicRate, float64(periodsPerYear)) - 1
+}
+
+func presentValue(payments []float64, r float64) float64 {
+	value, _ := presentValueAndDerivative(payments, r)
+	return value
+}
+
+// presentValueAndDerivative discounts the payments at rate r and returns the derivative with respect to r
+func presentValueAndDerivative(payments []float64, r float64) (float64, float64) {
+	value, derivative := 0.0, 0.0
+	discount := 1.0
+	for i, payment := range payments {
+		discount /= 1 + r
+		value += payment * discount
+		derivative -= float64(i+1) * payment * discount / (1 + r)
+	}
+	return value, derivative
+}
@@ -0,0 +1,85 @@
+package rate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func repeat(payment float64, count int) []float64 {
+	payments := make([]float64, count)
+	for i := range payments {
+		payments[i] = payment
+	}
+	return payments
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	assert.Equal(t, 52, PeriodsPerYear("week"))
+	assert.Equal(t, 12, PeriodsPerYear("month"))
+}
+
+func TestPeriodicIRR_Annuity(t *testing.T) {
+	// 1,000 repaid in 12 monthly payments of 88.848789 is the 1% a month annuity
+	r, err := PeriodicIRR(1000, repeat(88.84878867834171, 12))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.01, r, 1e-10)
+	assert.InDelta(t, 0.12, AnnualPercentageRate(r, 12), 1e-9)
+	assert.InDelta(t, 0.12682503013196977, EffectiveAnnualRate(r, 12), 1e-9)
+}
+
+func TestPeriodicIRR_SpreadsheetRate(t *testing.T) {
+	// RATE(48, -200, 8000) from the spreadsheet documentation: 0.77% a month, 9.24% a year
+	r, err := PeriodicIRR(8000, repeat(200, 48))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.007701472488202, r, 1e-10)
+	assert.InDelta(t, 0.092417669858425, AnnualPercentageRate(r, 12), 1e-9)
+}
+
+func TestPeriodicIRR_FlatRateLoan(t *testing.T) {
+	// A 10% flat rate over 50 weeks costs almost twice as much once amortisation is accounted for
+	r, err := PeriodicIRR(5000000, repeat(110000, 50))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.003803706726001, r, 1e-10)
+	assert.InDelta(t, 0.197792749752056, AnnualPercentageRate(r, 52), 1e-9)
+	assert.InDelta(t, 0.218252589261505, EffectiveAnnualRate(r, 52), 1e-9)
+
+	// An upfront fee withheld from the payout raises the rate
+	r, err = PeriodicIRR(4850000, repeat(111000, 50))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.281889104826980, AnnualPercentageRate(r, 52), 1e-9)
+}
+
+func TestPeriodicIRR_ShortExpensiveLoan(t *testing.T) {
+	r, err := PeriodicIRR(4833500, []float64{1844433.33, 1844433.33, 1844433.34})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.070778447379944, r, 1e-10)
+	assert.InDelta(t, 34.025173768417217, EffectiveAnnualRate(r, 52), 1e-6)
+}
+
+func TestPeriodicIRR_NoInterest(t *testing.T) {
+	r, err := PeriodicIRR(1200, repeat(100, 12))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, r, 1e-10)
+}
+
+func TestPeriodicIRR_InvalidCashFlows(t *testing.T) {
+	_, err := PeriodicIRR(0, repeat(100, 12))
+	assert.Error(t, err)
+
+	_, err = PeriodicIRR(1000, []float64{})
+	assert.Error(t, err)
+
+	_, err = PeriodicIRR(1000, []float64{-100, 1200})
+	assert.Error(t, err)
+}
+
+func TestPeriodicIRR_DoesNotConverge(t *testing.T) {
+	// Repaying a thousandth of the amount a period later needs a rate below -99%
+	_, err := PeriodicIRR(1000, []float64{1})
+	assert.EqualError(t, err, "rate did not converge")
+
+	// Repaying many times the amount in a single period needs a rate above the search range
+	_, err = PeriodicIRR(1, []float64{1e9})
+	assert.EqualError(t, err, "rate did not converge")
+}
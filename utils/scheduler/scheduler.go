@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ParseTimeOfDay parses a "HH:MM" value into hour and minute
+func ParseTimeOfDay(value string) (int, int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// NextRun returns the first occurrence of hour:minute strictly after now, in now's location
+func NextRun(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// RunDaily calls job once a day at hour:minute local time until ctx is cancelled
+func RunDaily(ctx context.Context, hour, minute int, job func(ctx context.Context, runAt time.Time)) {
+	for {
+		timer := time.NewTimer(time.Until(NextRun(time.Now(), hour, minute)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case runAt := <-timer.C:
+			job(ctx, runAt)
+		}
+	}
+}
+
+// RunEvery calls job every interval until ctx is cancelled
+func RunEvery(ctx context.Context, interval time.Duration, job func(ctx context.Context, runAt time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case runAt := <-ticker.C:
+			job(ctx, runAt)
+		}
+	}
+}
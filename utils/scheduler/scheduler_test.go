@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	hour, minute, err := ParseTimeOfDay("00:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, hour)
+	assert.Equal(t, 30, minute)
+
+	_, _, err = ParseTimeOfDay("25:00")
+	assert.Error(t, err)
+}
+
+func TestNextRun_LaterToday(t *testing.T) {
+	now := time.Date(2025, 9, 15, 0, 10, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 9, 15, 0, 30, 0, 0, time.UTC), NextRun(now, 0, 30))
+}
+
+func TestNextRun_Tomorrow(t *testing.T) {
+	now := time.Date(2025, 9, 15, 0, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 9, 16, 0, 30, 0, 0, time.UTC), NextRun(now, 0, 30))
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"billing-engine/utils/pagination"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -11,6 +13,13 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterValidation("cursor", validateCursor)
+}
+
+// validateCursor checks that a string is a pagination cursor handed out by a list endpoint
+func validateCursor(fl validator.FieldLevel) bool {
+	_, err := pagination.DecodeCursor(fl.Field().String())
+	return err == nil
 }
 
 // ValidateStruct validates a struct and returns formatted error messages
@@ -31,7 +40,7 @@ func formatValidationError(err validator.FieldError) string {
 	field := strings.ToLower(err.Field())
 
 	switch err.Tag() {
-	case "required":
+	case "required", "required_if":
 		return fmt.Sprintf("%s is required", field)
 	case "gt":
 		return fmt.Sprintf("%s must be greater than %s", field, err.Param())
@@ -45,6 +54,12 @@ func formatValidationError(err validator.FieldError) string {
 		return fmt.Sprintf("%s must be at least %s characters long", field, err.Param())
 	case "max":
 		return fmt.Sprintf("%s must be at most %s characters long", field, err.Param())
+	case "gtefield":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, strings.ToLower(err.Param()))
+	case "datetime":
+		return fmt.Sprintf("%s must be a date in %s format", field, err.Param())
+	case "iso4217":
+		return fmt.Sprintf("%s must be an ISO 4217 currency code", field)
 	case "oneof":
 		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(err.Param(), " ", ", "))
 	default:
@@ -12,6 +12,8 @@ type TestStruct struct {
 	Email    string  `validate:"required,email"`
 	Category string  `validate:"oneof=A B C"`
 	Score    float64 `validate:"gte=0,lte=100"`
+	Currency string  `validate:"omitempty,iso4217"`
+	Cursor   string  `validate:"omitempty,cursor"`
 }
 
 func TestValidateStruct_Success(t *testing.T) {
@@ -69,6 +71,36 @@ func TestValidateStruct_OneOfValidation(t *testing.T) {
 	assert.Contains(t, err.Error(), "category must be one of: A, B, C")
 }
 
+func TestValidateStruct_CurrencyValidation(t *testing.T) {
+	invalidStruct := TestStruct{
+		Name:     "John Doe",
+		Age:      25,
+		Email:    "john@example.com",
+		Category: "A",
+		Score:    85.5,
+		Currency: "RUP", // Not an ISO 4217 code
+	}
+
+	err := ValidateStruct(&invalidStruct)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "currency must be an ISO 4217 currency code")
+}
+
+func TestValidateStruct_CursorValidation(t *testing.T) {
+	invalidStruct := TestStruct{
+		Name:     "John Doe",
+		Age:      25,
+		Email:    "john@example.com",
+		Category: "A",
+		Score:    85.5,
+		Cursor:   "not-a-cursor",
+	}
+
+	err := ValidateStruct(&invalidStruct)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cursor is invalid")
+}
+
 func TestValidateStruct_MultipleErrors(t *testing.T) {
 	invalidStruct := TestStruct{
 		Name:     "",              // Required field missing
@@ -0,0 +1,16 @@
+package virtual_account
+
+import (
+	"billing-engine/models"
+	"context"
+)
+
+// VirtualAccountMySQLRepositoryInterface defines the interface for virtual account repository
+type VirtualAccountMySQLRepositoryInterface interface {
+	GetLoanSummaryByVirtualAccountNumber(ctx context.Context, accountNumber string) (*models.LoanSummary, error)
+}
+
+// VirtualAccountServiceInterface defines the interface for virtual account service
+type VirtualAccountServiceInterface interface {
+	ProcessPayment(ctx context.Context, req *models.VirtualAccountPaymentRequest) (*models.VirtualAccountPaymentResponse, error)
+}
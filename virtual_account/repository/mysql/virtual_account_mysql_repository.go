@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+	"billing-engine/virtual_account"
+
+	"gorm.io/gorm"
+)
+
+type virtualAccountMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewVirtualAccountMySQLRepository creates a new virtual account repository instance
+func NewVirtualAccountMySQLRepository(db *gorm.DB) virtual_account.VirtualAccountMySQLRepositoryInterface {
+	return &virtualAccountMySQLRepository{db: db}
+}
+
+func (r *virtualAccountMySQLRepository) GetLoanSummaryByVirtualAccountNumber(ctx context.Context, accountNumber string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Where("virtual_account_number = ? AND deleted_at IS NULL", accountNumber).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
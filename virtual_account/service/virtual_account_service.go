@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"billing-engine/models"
+	"billing-engine/repayment"
+	"billing-engine/suspense"
+	"billing-engine/virtual_account"
+)
+
+type virtualAccountService struct {
+	virtualAccountRepo virtual_account.VirtualAccountMySQLRepositoryInterface
+	repaymentService   repayment.RepaymentServiceInterface
+	suspenseService    suspense.SuspenseServiceInterface
+}
+
+// NewVirtualAccountService creates a new virtual account service instance
+func NewVirtualAccountService(virtualAccountRepo virtual_account.VirtualAccountMySQLRepositoryInterface, repaymentService repayment.RepaymentServiceInterface, suspenseService suspense.SuspenseServiceInterface) virtual_account.VirtualAccountServiceInterface {
+	return &virtualAccountService{
+		virtualAccountRepo: virtualAccountRepo,
+		repaymentService:   repaymentService,
+		suspenseService:    suspenseService,
+	}
+}
+
+// ProcessPayment applies a payment received into a virtual account to the loan the account was
+// issued for. The money has already arrived, so a payment the loan cannot take, or one into an
+// account no loan owns, is held in suspense and reported as HELD rather than failed.
+func (s *virtualAccountService) ProcessPayment(ctx context.Context, req *models.VirtualAccountPaymentRequest) (*models.VirtualAccountPaymentResponse, error) {
+	loanSummary, err := s.virtualAccountRepo.GetLoanSummaryByVirtualAccountNumber(ctx, req.AccountNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan by virtual account: %v", err)
+	}
+
+	response := &models.VirtualAccountPaymentResponse{
+		AccountNumber: req.AccountNumber,
+		TransactionID: req.TransactionID,
+	}
+	repaymentReq := &models.RepaymentRequest{
+		PaymentAmount: req.Amount,
+		Currency:      req.Currency,
+		Reference:     req.TransactionID,
+	}
+
+	var rejection *models.PaymentRejectionError
+	if loanSummary == nil {
+		rejection = &models.PaymentRejectionError{
+			ReasonCode: models.SuspenseReasonUnknownLoan,
+			Message:    fmt.Sprintf("virtual account %s is not issued to any loan", req.AccountNumber),
+		}
+	} else {
+		response.LoanID = loanSummary.LoanID
+		repaymentReq.LoanID = loanSummary.LoanID
+		repaymentResponse, err := s.repaymentService.ProcessRepayment(ctx, repaymentReq)
+		if err == nil {
+			response.Status = models.VirtualAccountPaymentApplied
+			response.Repayment = repaymentResponse
+			return response, nil
+		}
+		if !errors.As(err, &rejection) {
+			return nil, err
+		}
+	}
+
+	item, err := s.suspenseService.HoldPayment(ctx, repaymentReq, models.SuspenseSourceVirtualAccount, rejection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold the payment in suspense: %v", err)
+	}
+	response.Status = models.VirtualAccountPaymentHeld
+	response.SuspenseItemID = item.ID
+	response.Message = rejection.Error()
+	return response, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"billing-engine/models"
+	repaymentMocks "billing-engine/repayment/_mock"
+	suspenseMocks "billing-engine/suspense/_mock"
+	mocks "billing-engine/virtual_account/_mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVirtualAccountService_ProcessPayment_Applied(t *testing.T) {
+	mockRepo := mocks.NewVirtualAccountMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewVirtualAccountService(mockRepo, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.VirtualAccountPaymentRequest{AccountNumber: "8808812345678901", TransactionID: "trx_001", Amount: 110000.00}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByVirtualAccountNumber", ctx, "8808812345678901").Return(&models.LoanSummary{LoanID: "loan_123"}, nil)
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 110000.00, Reference: "trx_001"}).
+		Return(&models.RepaymentResponse{LoanID: "loan_123", PaymentAmount: 110000.00, InstallmentsPaid: 1}, nil)
+
+	// Execute
+	response, err := service.ProcessPayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.VirtualAccountPaymentApplied, response.Status)
+	assert.Equal(t, "loan_123", response.LoanID)
+	assert.Equal(t, "trx_001", response.TransactionID)
+	assert.Equal(t, 1, response.Repayment.InstallmentsPaid)
+	assert.Zero(t, response.SuspenseItemID)
+	mockSuspense.AssertNotCalled(t, "HoldPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVirtualAccountService_ProcessPayment_RejectedPaymentHeld(t *testing.T) {
+	mockRepo := mocks.NewVirtualAccountMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewVirtualAccountService(mockRepo, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.VirtualAccountPaymentRequest{AccountNumber: "8808812345678901", TransactionID: "trx_001", Amount: 100000.00}
+	repaymentReq := &models.RepaymentRequest{LoanID: "loan_123", PaymentAmount: 100000.00, Reference: "trx_001"}
+	rejection := &models.PaymentRejectionError{ReasonCode: models.SuspenseReasonWrongAmount, Message: "payment amount 100000.00 does not match required amount 110000.00"}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByVirtualAccountNumber", ctx, "8808812345678901").Return(&models.LoanSummary{LoanID: "loan_123"}, nil)
+
+	// Mock service calls
+	mockRepayment.On("ProcessRepayment", ctx, repaymentReq).Return(nil, rejection)
+	mockSuspense.On("HoldPayment", ctx, repaymentReq, models.SuspenseSourceVirtualAccount, rejection).Return(&models.SuspenseItemResponse{ID: 7}, nil)
+
+	// Execute
+	response, err := service.ProcessPayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.VirtualAccountPaymentHeld, response.Status)
+	assert.Equal(t, "loan_123", response.LoanID)
+	assert.Equal(t, uint(7), response.SuspenseItemID)
+	assert.Equal(t, rejection.Message, response.Message)
+	assert.Nil(t, response.Repayment)
+}
+
+func TestVirtualAccountService_ProcessPayment_UnknownAccountHeld(t *testing.T) {
+	mockRepo := mocks.NewVirtualAccountMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewVirtualAccountService(mockRepo, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.VirtualAccountPaymentRequest{AccountNumber: "8808800000000000", TransactionID: "trx_002", Amount: 50000.00, Currency: "IDR"}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByVirtualAccountNumber", ctx, "8808800000000000").Return(nil, nil)
+
+	// Mock service calls
+	mockSuspense.On("HoldPayment", ctx, &models.RepaymentRequest{PaymentAmount: 50000.00, Currency: "IDR", Reference: "trx_002"}, models.SuspenseSourceVirtualAccount,
+		mock.MatchedBy(func(rejection *models.PaymentRejectionError) bool {
+			return rejection.ReasonCode == models.SuspenseReasonUnknownLoan
+		})).Return(&models.SuspenseItemResponse{ID: 8}, nil)
+
+	// Execute
+	response, err := service.ProcessPayment(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.VirtualAccountPaymentHeld, response.Status)
+	assert.Empty(t, response.LoanID)
+	assert.Equal(t, uint(8), response.SuspenseItemID)
+	assert.Equal(t, "virtual account 8808800000000000 is not issued to any loan", response.Message)
+	mockRepayment.AssertNotCalled(t, "ProcessRepayment", mock.Anything, mock.Anything)
+}
+
+func TestVirtualAccountService_ProcessPayment_RepaymentError(t *testing.T) {
+	mockRepo := mocks.NewVirtualAccountMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewVirtualAccountService(mockRepo, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.VirtualAccountPaymentRequest{AccountNumber: "8808812345678901", TransactionID: "trx_001", Amount: 110000.00}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByVirtualAccountNumber", ctx, "8808812345678901").Return(&models.LoanSummary{LoanID: "loan_123"}, nil)
+
+	// Mock service calls; an error that is not a rejection leaves the payment for a retry
+	mockRepayment.On("ProcessRepayment", ctx, mock.AnythingOfType("*models.RepaymentRequest")).Return(nil, errors.New("failed to update payment schedules: deadlock"))
+
+	// Execute
+	response, err := service.ProcessPayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "failed to update payment schedules: deadlock", err.Error())
+	mockSuspense.AssertNotCalled(t, "HoldPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVirtualAccountService_ProcessPayment_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewVirtualAccountMySQLRepositoryInterface(t)
+	mockRepayment := repaymentMocks.NewRepaymentServiceInterface(t)
+	mockSuspense := suspenseMocks.NewSuspenseServiceInterface(t)
+	service := NewVirtualAccountService(mockRepo, mockRepayment, mockSuspense)
+	ctx := context.Background()
+
+	req := &models.VirtualAccountPaymentRequest{AccountNumber: "8808812345678901", TransactionID: "trx_001", Amount: 110000.00}
+
+	// Mock repository error
+	mockRepo.On("GetLoanSummaryByVirtualAccountNumber", ctx, "8808812345678901").Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.ProcessPayment(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to get loan by virtual account")
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+
+	"billing-engine/global"
+	"billing-engine/middlewares"
+	"billing-engine/models"
+	"billing-engine/utils/validator"
+	"billing-engine/write_off"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WriteOffHandler struct {
+	writeOffService write_off.WriteOffServiceInterface
+	middleware      middlewares.GoMiddlewareInterface
+}
+
+// NewWriteOffHandler creates a new write-off handler instance
+func NewWriteOffHandler(e *echo.Echo, writeOffService write_off.WriteOffServiceInterface, middleware middlewares.GoMiddlewareInterface) {
+	handler := &WriteOffHandler{
+		writeOffService: writeOffService,
+		middleware:      middleware,
+	}
+
+	// Register routes
+	v1 := e.Group("/v1")
+	v1.POST("/loans/:loan_id/write-off", handler.WriteOffLoan)
+	v1.GET("/loans/:loan_id/write-off", handler.GetWriteOff)
+	v1.GET("/reports/write-offs", handler.GetWriteOffReport)
+}
+
+func (h *WriteOffHandler) WriteOffLoan(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	var req models.WriteOffRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validate request using validator
+	if err := validator.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	response, err := h.writeOffService.WriteOffLoan(c.Request().Context(), loanID, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.WriteOffSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *WriteOffHandler) GetWriteOff(c echo.Context) error {
+	loanID := c.Param("loan_id")
+	if loanID == "" {
+		return c.JSON(http.StatusBadRequest, global.BadResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Loan ID is required",
+		})
+	}
+
+	response, err := h.writeOffService.GetWriteOff(c.Request().Context(), loanID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.WriteOffSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+func (h *WriteOffHandler) GetWriteOffReport(c echo.Context) error {
+	response, err := h.writeOffService.GetWriteOffReport(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, global.BadResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, global.WriteOffReportSuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
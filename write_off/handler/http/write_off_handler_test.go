@@ -0,0 +1,179 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"billing-engine/global"
+	"billing-engine/models"
+	mocks "billing-engine/write_off/_mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiddleware is a mock implementation of GoMiddlewareInterface
+type MockMiddleware struct {
+	mock.Mock
+}
+
+func (m *MockMiddleware) ValidateCORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func (m *MockMiddleware) ValidateToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func TestWriteOffHandler_WriteOffLoan_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewWriteOffServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &WriteOffHandler{
+		writeOffService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	req := models.WriteOffRequest{
+		Reason:     "Customer unreachable for 6 months",
+		ApprovedBy: "risk_manager_01",
+	}
+	expectedResponse := &models.WriteOffResponse{
+		LoanID:           "loan_123",
+		Status:           models.StatusWrittenOff,
+		WrittenOffAmount: 3300000.00,
+		Dpd:              185,
+	}
+
+	mockService.On("WriteOffLoan", mock.Anything, "loan_123", &req).Return(expectedResponse, nil)
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/write-off", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.WriteOffLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.WriteOffSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, models.StatusWrittenOff, response.Data.Status)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestWriteOffHandler_WriteOffLoan_MissingApprover(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewWriteOffServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &WriteOffHandler{
+		writeOffService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	// Create request
+	reqBody, _ := json.Marshal(models.WriteOffRequest{Reason: "Customer unreachable"})
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/write-off", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.WriteOffLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWriteOffHandler_WriteOffLoan_ServiceError(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewWriteOffServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &WriteOffHandler{
+		writeOffService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	req := models.WriteOffRequest{Reason: "Customer unreachable", ApprovedBy: "risk_manager_01"}
+	mockService.On("WriteOffLoan", mock.Anything, "loan_123", &req).
+		Return(nil, errors.New("loan is not eligible for write-off: dpd 30 is below 180"))
+
+	// Create request
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/loans/loan_123/write-off", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("loan_id")
+	c.SetParamValues("loan_123")
+
+	// Execute
+	err := handler.WriteOffLoan(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestWriteOffHandler_GetWriteOffReport_Success(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockService := mocks.NewWriteOffServiceInterface(t)
+	mockMiddleware := new(MockMiddleware)
+
+	handler := &WriteOffHandler{
+		writeOffService: mockService,
+		middleware:      mockMiddleware,
+	}
+
+	mockService.On("GetWriteOffReport", mock.Anything).Return(&models.WriteOffReportResponse{
+		WrittenOffLoans: 3,
+		Currencies: []models.WriteOffCurrencyReport{
+			{Currency: models.CurrencyIDR, WrittenOffLoans: 3, WrittenOffAmount: 9000000.00, RecoveredAmount: 1500000.00},
+		},
+	}, nil)
+
+	// Create request
+	httpReq := httptest.NewRequest(http.MethodGet, "/v1/reports/write-offs", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Execute
+	err := handler.GetWriteOffReport(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response global.WriteOffReportSuccessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, 3, response.Data.WrittenOffLoans)
+	assert.Len(t, response.Data.Currencies, 1)
+
+	mockService.AssertExpectations(t)
+}
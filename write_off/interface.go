@@ -0,0 +1,27 @@
+package write_off
+
+import (
+	"billing-engine/models"
+	"context"
+	"time"
+)
+
+// WriteOffMySQLRepositoryInterface defines the interface for write-off repository
+type WriteOffMySQLRepositoryInterface interface {
+	GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error)
+	GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error)
+	GetWriteOffByLoanID(ctx context.Context, loanID string) (*models.LoanWriteOff, error)
+	GetRecoveriesByLoanID(ctx context.Context, loanID string) ([]*models.LoanRecovery, error)
+	WriteOffLoan(ctx context.Context, loanSummary *models.LoanSummary, writeOff *models.LoanWriteOff, histories []*models.PaymentScheduleHistory) error
+	CreateRecovery(ctx context.Context, loanSummary *models.LoanSummary, writeOff *models.LoanWriteOff, recovery *models.LoanRecovery) error
+	GetWriteOffTotals(ctx context.Context) ([]*models.WriteOffTotals, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WriteOffServiceInterface defines the interface for write-off service
+type WriteOffServiceInterface interface {
+	WriteOffLoan(ctx context.Context, loanID string, req *models.WriteOffRequest) (*models.WriteOffResponse, error)
+	GetWriteOff(ctx context.Context, loanID string) (*models.WriteOffResponse, error)
+	RecordRecovery(ctx context.Context, loanSummary *models.LoanSummary, amount float64, recoveredAt time.Time) (*models.RecoveryResponse, error)
+	GetWriteOffReport(ctx context.Context) (*models.WriteOffReportResponse, error)
+}
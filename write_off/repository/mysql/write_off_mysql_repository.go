@@ -0,0 +1,154 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"billing-engine/models"
+	"billing-engine/utils/transaction"
+	"billing-engine/write_off"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type writeOffMySQLRepository struct {
+	db *gorm.DB
+}
+
+// NewWriteOffMySQLRepository creates a new write-off repository instance
+func NewWriteOffMySQLRepository(db *gorm.DB) write_off.WriteOffMySQLRepositoryInterface {
+	return &writeOffMySQLRepository{db: db}
+}
+
+// GetLoanSummaryByLoanID reads the loan and locks it until the transaction ends
+func (r *writeOffMySQLRepository) GetLoanSummaryByLoanID(ctx context.Context, loanID string) (*models.LoanSummary, error) {
+	var loanSummary models.LoanSummary
+	err := transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("loan_id = ? AND deleted_at IS NULL", loanID).First(&loanSummary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loanSummary, nil
+}
+
+func (r *writeOffMySQLRepository) GetPendingPaymentSchedulesByLoanID(ctx context.Context, loanID string) ([]*models.PaymentSchedule, error) {
+	var schedules []*models.PaymentSchedule
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanID, models.StatusPending).
+		Order("installment_number ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *writeOffMySQLRepository) GetWriteOffByLoanID(ctx context.Context, loanID string) (*models.LoanWriteOff, error) {
+	var writeOff models.LoanWriteOff
+	err := transaction.DB(ctx, r.db).Where("loan_id = ?", loanID).First(&writeOff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &writeOff, nil
+}
+
+func (r *writeOffMySQLRepository) GetRecoveriesByLoanID(ctx context.Context, loanID string) ([]*models.LoanRecovery, error) {
+	var recoveries []*models.LoanRecovery
+	err := transaction.DB(ctx, r.db).
+		Where("loan_id = ?", loanID).
+		Order("recovered_at ASC, id ASC").
+		Find(&recoveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return recoveries, nil
+}
+
+// WriteOffLoan stores the write-off, closes the pending installments with their history
+// rows and moves the loan to WRITTEN_OFF in one transaction. It fails when the loan is no
+// longer pending or delinquent.
+func (r *writeOffMySQLRepository) WriteOffLoan(ctx context.Context, loanSummary *models.LoanSummary, writeOff *models.LoanWriteOff, histories []*models.PaymentScheduleHistory) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(writeOff).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.PaymentSchedule{}).
+			Where("loan_id = ? AND status = ? AND deleted_at IS NULL", loanSummary.LoanID, models.StatusPending).
+			Updates(map[string]interface{}{
+				"status":     models.StatusWrittenOff,
+				"updated_by": "system",
+			}).Error; err != nil {
+			return err
+		}
+
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(loanSummary).
+			Where("status IN ?", []string{models.StatusPending, models.StatusDelinquent}).
+			Updates(map[string]interface{}{
+				"status":     loanSummary.Status,
+				"updated_by": loanSummary.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("loan %s can no longer be written off", loanSummary.LoanID)
+		}
+		return nil
+	})
+}
+
+// CreateRecovery stores the recovery and updates the recovered and outstanding amounts in one transaction
+func (r *writeOffMySQLRepository) CreateRecovery(ctx context.Context, loanSummary *models.LoanSummary, writeOff *models.LoanWriteOff, recovery *models.LoanRecovery) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(recovery).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(writeOff).Updates(map[string]interface{}{
+			"recovered_amount": writeOff.RecoveredAmount,
+			"updated_by":       writeOff.UpdatedBy,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(loanSummary).Updates(map[string]interface{}{
+			"outstanding_amount": loanSummary.OutstandingAmount,
+			"updated_by":         loanSummary.UpdatedBy,
+		}).Error
+	})
+}
+
+// GetWriteOffTotals sums the write-offs and their recoveries per currency of the written-off loans
+func (r *writeOffMySQLRepository) GetWriteOffTotals(ctx context.Context) ([]*models.WriteOffTotals, error) {
+	var totals []*models.WriteOffTotals
+	err := transaction.DB(ctx, r.db).
+		Table("loan_write_offs wo").
+		Joins("JOIN loan_summaries ls ON ls.loan_id = wo.loan_id").
+		Select("ls.currency, COUNT(*) AS written_off_loans, COALESCE(SUM(wo.written_off_amount), 0) AS written_off_amount, COALESCE(SUM(wo.recovered_amount), 0) AS recovered_amount").
+		Group("ls.currency").
+		Order("ls.currency ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// WithinTransaction runs fn in one transaction shared by every repository reached through its context
+func (r *writeOffMySQLRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"billing-engine/collectibility"
+	"billing-engine/ledger"
+	"billing-engine/models"
+	"billing-engine/write_off"
+
+	"github.com/shopspring/decimal"
+)
+
+type writeOffService struct {
+	writeOffRepo          write_off.WriteOffMySQLRepositoryInterface
+	collectibilityService collectibility.CollectibilityServiceInterface
+	ledgerService         ledger.LedgerServiceInterface
+	minDpd                int // minimum days past due before a loan can be written off
+}
+
+// NewWriteOffService creates a new write-off service instance
+func NewWriteOffService(writeOffRepo write_off.WriteOffMySQLRepositoryInterface, collectibilityService collectibility.CollectibilityServiceInterface, ledgerService ledger.LedgerServiceInterface, minDpd int) write_off.WriteOffServiceInterface {
+	return &writeOffService{
+		writeOffRepo:          writeOffRepo,
+		collectibilityService: collectibilityService,
+		ledgerService:         ledgerService,
+		minDpd:                minDpd,
+	}
+}
+
+// WriteOffLoan writes off a loan past the minimum DPD. The loan is read and locked, and written
+// off, in one transaction, so the written-off amount is the balance left by any repayment
+// committed before and a concurrent write-off or repayment cannot slip in between.
+func (s *writeOffService) WriteOffLoan(ctx context.Context, loanID string, req *models.WriteOffRequest) (*models.WriteOffResponse, error) {
+	var (
+		loanSummary *models.LoanSummary
+		writeOff    *models.LoanWriteOff
+	)
+	writeOffDate := time.Now()
+	err := s.writeOffRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		loanSummary, err = s.writeOffRepo.GetLoanSummaryByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get loan summary: %v", err)
+		}
+		if loanSummary == nil {
+			return fmt.Errorf("loan not found")
+		}
+
+		switch loanSummary.Status {
+		case models.StatusWrittenOff:
+			return fmt.Errorf("loan is already written off")
+		case models.StatusPaid:
+			return fmt.Errorf("loan is already paid")
+		case models.StatusCancelled:
+			return fmt.Errorf("loan is cancelled")
+		case models.StatusInactive:
+			return fmt.Errorf("loan is not disbursed yet")
+		}
+
+		// Refresh DPD so eligibility is checked against today's overdue position
+		collectibilityResult, err := s.collectibilityService.EvaluateLoan(ctx, loanID, writeOffDate)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate collectibility: %v", err)
+		}
+		if collectibilityResult.Dpd < s.minDpd {
+			return fmt.Errorf("loan is not eligible for write-off: dpd %d is below %d", collectibilityResult.Dpd, s.minDpd)
+		}
+
+		pendingSchedules, err := s.writeOffRepo.GetPendingPaymentSchedulesByLoanID(ctx, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to get pending schedules: %v", err)
+		}
+
+		histories := make([]*models.PaymentScheduleHistory, 0, len(pendingSchedules))
+		for _, schedule := range pendingSchedules {
+			histories = append(histories, &models.PaymentScheduleHistory{
+				ScheduleID:         schedule.ID,
+				LoanID:             schedule.LoanID,
+				Action:             models.ActionWriteOff,
+				InstallmentNumber:  schedule.InstallmentNumber,
+				InstallmentAmount:  schedule.InstallmentAmount,
+				InstallmentDueDate: schedule.InstallmentDueDate,
+				Status:             models.StatusWrittenOff,
+				Currency:           schedule.Currency,
+				CreatedBy:          "system",
+			})
+		}
+
+		writeOff = &models.LoanWriteOff{
+			LoanID:           loanID,
+			WrittenOffAmount: loanSummary.OutstandingAmount,
+			Dpd:              collectibilityResult.Dpd,
+			Reason:           req.Reason,
+			ApprovedBy:       req.ApprovedBy,
+			WrittenOffAt:     writeOffDate,
+			CreatedBy:        "system",
+			UpdatedBy:        "system",
+		}
+
+		loanSummary.Status = models.StatusWrittenOff
+		loanSummary.UpdatedBy = "system"
+
+		if err := s.writeOffRepo.WriteOffLoan(ctx, loanSummary, writeOff, histories); err != nil {
+			return fmt.Errorf("failed to write off loan: %v", err)
+		}
+		if err := s.ledgerService.PostWriteOff(ctx, loanSummary, writeOffDate); err != nil {
+			return fmt.Errorf("failed to post write-off: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildWriteOffResponse(loanSummary, writeOff, nil), nil
+}
+
+func (s *writeOffService) GetWriteOff(ctx context.Context, loanID string) (*models.WriteOffResponse, error) {
+	loanSummary, err := s.writeOffRepo.GetLoanSummaryByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan summary: %v", err)
+	}
+	if loanSummary == nil {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	writeOff, err := s.writeOffRepo.GetWriteOffByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get write-off: %v", err)
+	}
+	if writeOff == nil {
+		return nil, fmt.Errorf("loan is not written off")
+	}
+
+	recoveries, err := s.writeOffRepo.GetRecoveriesByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recoveries: %v", err)
+	}
+
+	return buildWriteOffResponse(loanSummary, writeOff, recoveries), nil
+}
+
+// RecordRecovery books a payment on a written-off loan as a recovery. Unlike installment
+// payments any amount up to the remaining outstanding balance is accepted.
+func (s *writeOffService) RecordRecovery(ctx context.Context, loanSummary *models.LoanSummary, amount float64, recoveredAt time.Time) (*models.RecoveryResponse, error) {
+	writeOff, err := s.writeOffRepo.GetWriteOffByLoanID(ctx, loanSummary.LoanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get write-off: %v", err)
+	}
+	if writeOff == nil {
+		return nil, fmt.Errorf("loan is not written off")
+	}
+
+	recoveryAmount := decimal.NewFromFloat(amount)
+	outstanding := decimal.NewFromFloat(loanSummary.OutstandingAmount)
+	if recoveryAmount.GreaterThan(outstanding) {
+		return nil, fmt.Errorf("recovery amount %.2f exceeds outstanding amount %.2f", amount, loanSummary.OutstandingAmount)
+	}
+
+	recovery := &models.LoanRecovery{
+		LoanID:      loanSummary.LoanID,
+		WriteOffID:  writeOff.ID,
+		Amount:      amount,
+		RecoveredAt: recoveredAt,
+		CreatedBy:   "system",
+	}
+
+	writeOff.RecoveredAmount = decimal.NewFromFloat(writeOff.RecoveredAmount).Add(recoveryAmount).InexactFloat64()
+	writeOff.UpdatedBy = "system"
+	loanSummary.OutstandingAmount = outstanding.Sub(recoveryAmount).InexactFloat64()
+	loanSummary.UpdatedBy = "system"
+
+	err = s.writeOffRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.writeOffRepo.CreateRecovery(ctx, loanSummary, writeOff, recovery); err != nil {
+			return fmt.Errorf("failed to record recovery: %v", err)
+		}
+		if err := s.ledgerService.PostRecovery(ctx, loanSummary, amount, recoveredAt); err != nil {
+			return fmt.Errorf("failed to post recovery: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RecoveryResponse{
+		Amount:      recovery.Amount,
+		RecoveredAt: recovery.RecoveredAt,
+	}, nil
+}
+
+// GetWriteOffReport reports the written-off and recovered amounts per currency of the loans
+func (s *writeOffService) GetWriteOffReport(ctx context.Context) (*models.WriteOffReportResponse, error) {
+	totals, err := s.writeOffRepo.GetWriteOffTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get write-off totals: %v", err)
+	}
+
+	report := &models.WriteOffReportResponse{
+		GeneratedAt: time.Now(),
+		Currencies:  make([]models.WriteOffCurrencyReport, 0, len(totals)),
+	}
+	for _, total := range totals {
+		writtenOff := decimal.NewFromFloat(total.WrittenOffAmount)
+		recovered := decimal.NewFromFloat(total.RecoveredAmount)
+
+		currencyReport := models.WriteOffCurrencyReport{
+			Currency:          total.Currency,
+			WrittenOffLoans:   total.WrittenOffLoans,
+			WrittenOffAmount:  total.WrittenOffAmount,
+			RecoveredAmount:   total.RecoveredAmount,
+			OutstandingAmount: writtenOff.Sub(recovered).InexactFloat64(),
+		}
+		if writtenOff.IsPositive() {
+			currencyReport.RecoveryRate = recovered.Div(writtenOff).Round(4).InexactFloat64()
+		}
+		report.WrittenOffLoans += total.WrittenOffLoans
+		report.Currencies = append(report.Currencies, currencyReport)
+	}
+
+	return report, nil
+}
+
+// buildWriteOffResponse converts a write-off and its recoveries into the API response
+func buildWriteOffResponse(loanSummary *models.LoanSummary, writeOff *models.LoanWriteOff, recoveries []*models.LoanRecovery) *models.WriteOffResponse {
+	recoveryResponses := make([]models.RecoveryResponse, 0, len(recoveries))
+	for _, recovery := range recoveries {
+		recoveryResponses = append(recoveryResponses, models.RecoveryResponse{
+			Amount:      recovery.Amount,
+			RecoveredAt: recovery.RecoveredAt,
+		})
+	}
+
+	return &models.WriteOffResponse{
+		LoanID:            writeOff.LoanID,
+		Status:            loanSummary.Status,
+		WrittenOffAmount:  writeOff.WrittenOffAmount,
+		RecoveredAmount:   writeOff.RecoveredAmount,
+		OutstandingAmount: loanSummary.OutstandingAmount,
+		Dpd:               writeOff.Dpd,
+		Reason:            writeOff.Reason,
+		ApprovedBy:        writeOff.ApprovedBy,
+		WrittenOffAt:      writeOff.WrittenOffAt,
+		Recoveries:        recoveryResponses,
+	}
+}
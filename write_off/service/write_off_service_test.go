@@ -0,0 +1,277 @@
+package service
+
+import (
+	collectibilityMocks "billing-engine/collectibility/_mock"
+	ledgerMocks "billing-engine/ledger/_mock"
+	"billing-engine/models"
+	mocks "billing-engine/write_off/_mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWriteOffService_WriteOffLoan_Success(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	req := &models.WriteOffRequest{
+		Reason:     "Customer unreachable for 6 months",
+		ApprovedBy: "risk_manager_01",
+	}
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		OutstandingAmount: 3300000.00,
+		Status:            models.StatusPending,
+	}
+
+	pendingSchedules := []*models.PaymentSchedule{
+		{ID: 21, LoanID: "loan_123", InstallmentNumber: 21, InstallmentAmount: 110000.00, Status: models.StatusPending},
+		{ID: 22, LoanID: "loan_123", InstallmentNumber: 22, InstallmentAmount: 110000.00, Status: models.StatusPending},
+	}
+
+	// Mock repository calls
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilityLoss,
+		Dpd:            185,
+	}, nil)
+	mockRepo.On("GetPendingPaymentSchedulesByLoanID", ctx, "loan_123").Return(pendingSchedules, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostWriteOff", ctx, loanSummary, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("WriteOffLoan", ctx, loanSummary,
+		mock.MatchedBy(func(writeOff *models.LoanWriteOff) bool {
+			return writeOff.WrittenOffAmount == 3300000.00 && writeOff.Dpd == 185 && writeOff.ApprovedBy == "risk_manager_01"
+		}),
+		mock.MatchedBy(func(histories []*models.PaymentScheduleHistory) bool {
+			return len(histories) == 2 && histories[0].Action == models.ActionWriteOff && histories[0].Status == models.StatusWrittenOff
+		}),
+	).Return(nil)
+
+	// Execute
+	response, err := service.WriteOffLoan(ctx, "loan_123", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, models.StatusWrittenOff, response.Status)
+	assert.Equal(t, 3300000.00, response.WrittenOffAmount)
+	assert.Equal(t, 185, response.Dpd)
+	assert.Empty(t, response.Recoveries)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWriteOffService_WriteOffLoan_BelowMinimumDpd(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", Status: models.StatusPending}
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(loanSummary, nil)
+	mockCollectibility.On("EvaluateLoan", ctx, "loan_123", mock.AnythingOfType("time.Time")).Return(&models.CollectibilityResponse{
+		Collectibility: models.CollectibilityDoubtful,
+		Dpd:            150,
+	}, nil)
+
+	// Execute
+	response, err := service.WriteOffLoan(ctx, "loan_123", &models.WriteOffRequest{Reason: "hardship", ApprovedBy: "risk"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "dpd 150 is below 180")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWriteOffService_WriteOffLoan_AlreadyWrittenOff(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+		LoanID: "loan_123",
+		Status: models.StatusWrittenOff,
+	}, nil)
+
+	// Execute
+	response, err := service.WriteOffLoan(ctx, "loan_123", &models.WriteOffRequest{Reason: "hardship", ApprovedBy: "risk"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "already written off")
+}
+
+func TestWriteOffService_WriteOffLoan_NotDisbursedLoan(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	tests := []struct {
+		status   string
+		expected string
+	}{
+		{status: models.StatusCancelled, expected: "loan is cancelled"},
+		{status: models.StatusInactive, expected: "loan is not disbursed yet"},
+	}
+
+	for _, tt := range tests {
+		// Mock repository calls
+		mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+		mockRepo.On("GetLoanSummaryByLoanID", ctx, "loan_123").Return(&models.LoanSummary{
+			LoanID: "loan_123",
+			Status: tt.status,
+		}, nil).Once()
+
+		// Execute
+		response, err := service.WriteOffLoan(ctx, "loan_123", &models.WriteOffRequest{Reason: "hardship", ApprovedBy: "risk"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), tt.expected)
+	}
+}
+
+func TestWriteOffService_RecordRecovery_Success(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{
+		LoanID:            "loan_123",
+		OutstandingAmount: 3300000.00,
+		Status:            models.StatusWrittenOff,
+	}
+	writeOff := &models.LoanWriteOff{
+		ID:               7,
+		LoanID:           "loan_123",
+		WrittenOffAmount: 3300000.00,
+		RecoveredAmount:  100000.00,
+	}
+	recoveredAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// Mock repository calls
+	mockRepo.On("GetWriteOffByLoanID", ctx, "loan_123").Return(writeOff, nil)
+	mockRepo.On("WithinTransaction", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mockLedger.On("PostRecovery", ctx, loanSummary, 250000.00, recoveredAt).Return(nil)
+	mockRepo.On("CreateRecovery", ctx, loanSummary, writeOff, mock.MatchedBy(func(recovery *models.LoanRecovery) bool {
+		return recovery.WriteOffID == 7 && recovery.Amount == 250000.00
+	})).Return(nil)
+
+	// Execute
+	response, err := service.RecordRecovery(ctx, loanSummary, 250000.00, recoveredAt)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 250000.00, response.Amount)
+	assert.Equal(t, recoveredAt, response.RecoveredAt)
+	assert.Equal(t, 350000.00, writeOff.RecoveredAmount)
+	assert.Equal(t, 3050000.00, loanSummary.OutstandingAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWriteOffService_RecordRecovery_ExceedsOutstanding(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	loanSummary := &models.LoanSummary{LoanID: "loan_123", OutstandingAmount: 100000.00, Status: models.StatusWrittenOff}
+
+	// Mock repository calls
+	mockRepo.On("GetWriteOffByLoanID", ctx, "loan_123").Return(&models.LoanWriteOff{ID: 7, LoanID: "loan_123"}, nil)
+
+	// Execute
+	response, err := service.RecordRecovery(ctx, loanSummary, 150000.00, time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "exceeds outstanding amount")
+	assert.Equal(t, 100000.00, loanSummary.OutstandingAmount)
+}
+
+func TestWriteOffService_GetWriteOffReport(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	// Mock repository calls
+	mockRepo.On("GetWriteOffTotals", ctx).Return([]*models.WriteOffTotals{
+		{Currency: models.CurrencyIDR, WrittenOffLoans: 3, WrittenOffAmount: 9000000.00, RecoveredAmount: 1500000.00},
+		{Currency: "USD", WrittenOffLoans: 1, WrittenOffAmount: 800.00, RecoveredAmount: 200.00},
+	}, nil)
+
+	// Execute
+	response, err := service.GetWriteOffReport(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, response.WrittenOffLoans)
+	assert.Len(t, response.Currencies, 2)
+
+	// Amounts are only added up within a currency
+	assert.Equal(t, models.CurrencyIDR, response.Currencies[0].Currency)
+	assert.Equal(t, 3, response.Currencies[0].WrittenOffLoans)
+	assert.Equal(t, 7500000.00, response.Currencies[0].OutstandingAmount)
+	assert.Equal(t, 0.1667, response.Currencies[0].RecoveryRate)
+	assert.Equal(t, "USD", response.Currencies[1].Currency)
+	assert.Equal(t, 600.00, response.Currencies[1].OutstandingAmount)
+	assert.Equal(t, 0.25, response.Currencies[1].RecoveryRate)
+}
+
+func TestWriteOffService_GetWriteOffReport_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewWriteOffMySQLRepositoryInterface(t)
+	mockCollectibility := collectibilityMocks.NewCollectibilityServiceInterface(t)
+	mockLedger := ledgerMocks.NewLedgerServiceInterface(t)
+	service := NewWriteOffService(mockRepo, mockCollectibility, mockLedger, 180)
+	ctx := context.Background()
+
+	// Mock repository error
+	mockRepo.On("GetWriteOffTotals", ctx).Return(nil, errors.New("database error"))
+
+	// Execute
+	response, err := service.GetWriteOffReport(ctx)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "failed to get write-off totals")
+}